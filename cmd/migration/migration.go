@@ -9,6 +9,7 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"log"
 	"os"
+	"strconv"
 )
 
 func main() {
@@ -32,11 +33,29 @@ func main() {
 		log.Fatalf("failed to create search service: %v", err)
 	}
 
+	batchSize, _ := strconv.Atoi(os.Getenv("CHATBOT_MIGRATION_BATCH_SIZE"))
+
 	// Create a new migrator
 	migrator := &migration.Migrator{
-		Search:   index,
-		Database: next,
+		Search:         index,
+		Database:       next,
+		CheckpointFile: os.Getenv("CHATBOT_MIGRATION_CHECKPOINT"),
+		BatchSize:      batchSize,
+		SkipPreflight:  os.Getenv("CHATBOT_MIGRATION_SKIP_PREFLIGHT") == "true",
 	}
 
-	migrator.MigrateVectorDB()
+	_, err = migrator.MigrateVectorDB(ctx)
+	if err != nil {
+		log.Fatalf("migration failed: %v", err)
+	}
+
+	_, err = migrator.AdoptEmbeddingModel(ctx)
+	if err != nil {
+		log.Fatalf("failed to update embedding models: %v", err)
+	}
+
+	_, err = migrator.BackfillIndexedAt(ctx)
+	if err != nil {
+		log.Fatalf("failed to backfill indexed_at: %v", err)
+	}
 }
@@ -3,11 +3,14 @@ package main
 import (
 	"cloud.google.com/go/storage"
 	"context"
+	"errors"
+	_ "expvar"
 	firebase "firebase.google.com/go"
 	"github.com/pzierahn/chatbot_services/auth"
 	"github.com/pzierahn/chatbot_services/datastore"
 	"github.com/pzierahn/chatbot_services/llm"
 	"github.com/pzierahn/chatbot_services/llm/anthropic"
+	"github.com/pzierahn/chatbot_services/llm/embedder"
 	"github.com/pzierahn/chatbot_services/llm/openai"
 	"github.com/pzierahn/chatbot_services/llm/vertex"
 	"github.com/pzierahn/chatbot_services/search"
@@ -15,14 +18,24 @@ import (
 	"github.com/pzierahn/chatbot_services/services/account"
 	"github.com/pzierahn/chatbot_services/services/chat"
 	"github.com/pzierahn/chatbot_services/services/collections"
+	"github.com/pzierahn/chatbot_services/services/diagnostics"
 	"github.com/pzierahn/chatbot_services/services/documents"
+	"github.com/pzierahn/chatbot_services/services/embeddings"
+	"github.com/pzierahn/chatbot_services/services/gateway"
 	"github.com/pzierahn/chatbot_services/services/notion"
 	pb "github.com/pzierahn/chatbot_services/services/proto"
 	"google.golang.org/api/option"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/reflection"
 	"log"
 	"net"
+	"net/http"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 )
 
 const credentialsFile = "service_account.json"
@@ -91,16 +104,139 @@ func initModels(ctx context.Context) []llm.Chat {
 		claude,
 	}
 
+	// Azure OpenAI is optional, its models are selected with the prefix "azure."
+	if os.Getenv("AZURE_OPENAI_RESOURCE") != "" {
+		azure, err := openai.AzureFromEnv()
+		if err != nil {
+			log.Fatalf("failed to create azure openai client: %v", err)
+		}
+
+		models = append(models, azure)
+	}
+
 	return models
 }
 
+func initEmbedding() llm.Embedding {
+	engine, err := embedder.FromEnv()
+	if err != nil {
+		log.Fatalf("failed to create embedding client: %v", err)
+	}
+
+	return engine
+}
+
+func initModerator() llm.Moderator {
+	switch os.Getenv("CHATBOT_MODERATION") {
+	case "openai":
+		client, err := openai.New()
+		if err != nil {
+			log.Fatalf("failed to create openai moderation: %v", err)
+		}
+
+		return client
+	default:
+		return llm.NoModeration{}
+	}
+}
+
 func initSearch(engine llm.Embedding) search.Index {
 	searchEngine, err := qdrant.New(engine, "documents_v2")
 	if err != nil {
 		log.Fatalf("failed to create qdrant search: %v", err)
 	}
 
-	return searchEngine
+	ttl, size := search.CacheConfig()
+	if ttl <= 0 || size <= 0 {
+		return searchEngine
+	}
+
+	return search.NewCachedIndex(searchEngine, ttl, size)
+}
+
+// initAdmins reads the admin user ids from the comma separated CHATBOT_ADMIN_USERS.
+func initAdmins() map[string]bool {
+	admins := make(map[string]bool)
+	for _, userId := range strings.Split(os.Getenv("CHATBOT_ADMIN_USERS"), ",") {
+		if userId = strings.TrimSpace(userId); userId != "" {
+			admins[userId] = true
+		}
+	}
+
+	return admins
+}
+
+// initRewriteModel returns the model that rewrites search queries, Claude Haiku by default.
+func initRewriteModel() string {
+	if model := os.Getenv("CHATBOT_REWRITE_MODEL"); model != "" {
+		return model
+	}
+
+	return anthropic.ClaudeHaiku
+}
+
+// initProviders returns the providers checked by the diagnostics with a cheap model each.
+// Each model is checked with the client that serves it, as chat requests select it.
+func initProviders(models []llm.Chat, engine llm.Embedding) []diagnostics.Provider {
+	checks := []diagnostics.Provider{
+		{Name: "openai", Model: "openai.gpt-4o-mini"},
+		{Name: "vertex", Model: "google." + vertex.GeminiFlash},
+		{Name: "anthropic", Model: anthropic.ClaudeHaiku},
+	}
+
+	var providers []diagnostics.Provider
+	for _, provider := range checks {
+		for _, model := range models {
+			if model.ProvidesModel(provider.Model) {
+				provider.Chat = model
+				break
+			}
+		}
+
+		if provider.Chat == nil {
+			log.Printf("no client provides %s, skipping the %s diagnostics", provider.Model, provider.Name)
+			continue
+		}
+
+		providers = append(providers, provider)
+	}
+
+	return append(providers, diagnostics.Provider{Name: "embedding", Embedding: engine})
+}
+
+// DefaultShutdownTimeout leaves time to close the database before Cloud Run kills
+// the instance 10 seconds after SIGTERM.
+const DefaultShutdownTimeout = 8 * time.Second
+
+// shutdownTimeout returns how long in-flight requests are awaited on shutdown,
+// configured by CHATBOT_SHUTDOWN_TIMEOUT or DefaultShutdownTimeout if not set.
+func shutdownTimeout() time.Duration {
+	timeout, err := time.ParseDuration(os.Getenv("CHATBOT_SHUTDOWN_TIMEOUT"))
+	if err != nil || timeout <= 0 {
+		return DefaultShutdownTimeout
+	}
+
+	return timeout
+}
+
+// shutdown stops accepting new requests and waits for the in-flight requests to
+// finish. Requests still running after the timeout are canceled, their handlers
+// return once they saved what they persist without the request context.
+func shutdown(server *grpc.Server, timeout time.Duration) {
+	log.Printf("shutting down, waiting up to %v for in-flight requests", timeout)
+
+	drained := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+	case <-time.After(timeout):
+		log.Printf("shutdown timeout reached, canceling remaining requests")
+		server.Stop()
+	}
 }
 
 func initAuth(ctx context.Context, app *firebase.App) auth.Service {
@@ -112,6 +248,54 @@ func initAuth(ctx context.Context, app *firebase.App) auth.Service {
 	return service
 }
 
+// Timeouts of the gateway. Server streams like Index outlast any write timeout,
+// so only reading the requests and idle connections are bounded.
+const (
+	gatewayReadHeaderTimeout = 10 * time.Second
+	gatewayReadTimeout       = time.Minute
+	gatewayIdleTimeout       = 2 * time.Minute
+)
+
+// initGateway returns the server of the Connect compatible gateway, which forwards
+// the requests to the gRPC server on the local port.
+func initGateway(gatewayPort, grpcPort string) *http.Server {
+	conn, err := grpc.NewClient("localhost:"+grpcPort,
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("failed to connect gateway: %v", err)
+	}
+
+	origins := strings.Split(os.Getenv("CHATBOT_GATEWAY_ORIGINS"), ",")
+	handler, err := gateway.New(conn, origins, pb.Chat_ServiceDesc.ServiceName, pb.Document_ServiceDesc.ServiceName)
+	if err != nil {
+		log.Fatalf("failed to create gateway: %v", err)
+	}
+
+	server := &http.Server{
+		Addr:              ":" + gatewayPort,
+		Handler:           handler,
+		ReadHeaderTimeout: gatewayReadHeaderTimeout,
+		ReadTimeout:       gatewayReadTimeout,
+		IdleTimeout:       gatewayIdleTimeout,
+	}
+	server.RegisterOnShutdown(func() { _ = conn.Close() })
+
+	return server
+}
+
+// shutdownGateway stops accepting gateway requests and waits for the open ones,
+// e.g. server streams, which are closed after the timeout.
+func shutdownGateway(server *http.Server, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	err := server.Shutdown(ctx)
+	if err != nil {
+		log.Printf("gateway shutdown timeout reached, closing remaining requests")
+		_ = server.Close()
+	}
+}
+
 func main() {
 	ctx := context.Background()
 
@@ -120,7 +304,7 @@ func main() {
 	database := initDatastore(ctx)
 	models := initModels(ctx)
 
-	engine := models[0].(llm.Embedding)
+	engine := initEmbedding()
 	searchEngine := initSearch(engine)
 	bucket := initBucket(ctx, app)
 	authService := initAuth(ctx, app)
@@ -128,27 +312,62 @@ func main() {
 	userService := &account.Service{
 		Database: database,
 		Auth:     authService,
+		Admins:   initAdmins(),
 	}
 
+	retrieval := chat.RetrievalDefaultsFromEnv()
+	// Chat and documents share the access log, so that it is written in common batches
+	accessLog := documents.AccessLoggerFromEnv(database.InsertAccessLogs)
+
 	chatService := &chat.Service{
-		Models:   models,
-		Auth:     userService,
-		Database: database,
-		Search:   searchEngine,
+		Models:       models,
+		Auth:         userService,
+		Database:     database,
+		Search:       searchEngine,
+		Moderator:    initModerator(),
+		Limiter:      chat.LimiterFromEnv(),
+		ThreadLimit:  chat.ThreadLimitFromEnv(),
+		Retrieval:    retrieval,
+		AccessLog:    accessLog,
+		ModelAccess:  chat.ModelAccessFromEnv(),
+		DefaultModel: os.Getenv("CHATBOT_DEFAULT_MODEL"),
+		RewriteModel: initRewriteModel(),
 	}
 
 	documentsService := &documents.Service{
-		Auth:        userService,
-		Database:    database,
-		Storage:     bucket,
-		SearchIndex: searchEngine,
+		Auth:          userService,
+		Database:      database,
+		Storage:       bucket,
+		SearchIndex:   searchEngine,
+		Limits:        documents.LimitsFromEnv(),
+		WebhookSecret: os.Getenv("CHATBOT_WEBHOOK_SECRET"),
+		PageCache:     documents.NewPageCache(documents.DefaultPageCacheSize),
+		AccessLog:     accessLog,
+	}
+	if documentsService.WebhookSecret == "" {
+		log.Printf("CHATBOT_WEBHOOK_SECRET not set, index callbacks are disabled")
 	}
 
 	collectionService := &collections.Service{
+		Auth:       userService,
+		Database:   database,
+		Storage:    bucket,
+		Search:     searchEngine,
+		CheckModel: chatService.CheckModel,
+	}
+
+	embeddingService := &embeddings.Service{
 		Auth:     userService,
 		Database: database,
-		Storage:  bucket,
-		Search:   searchEngine,
+		Engine:   engine,
+	}
+
+	diagnosticsService := &diagnostics.Service{
+		Providers: initProviders(models, engine),
+		Database:  database,
+		Search:    searchEngine,
+		Indexing:  documentsService.Indexing,
+		Warmup:    diagnostics.WarmupFromEnv(retrieval.Documents, retrieval.Threshold),
 	}
 
 	notionService := &notion.Client{
@@ -159,12 +378,37 @@ func main() {
 		Cache:     make(map[string]string),
 	}
 
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(userService.UnaryInterceptor),
+		grpc.ChainStreamInterceptor(userService.StreamInterceptor),
+		// Canceled handlers still persist paid completions and embeddings, Stop
+		// waits for them so that the database is closed only afterward
+		grpc.WaitForHandlers(true),
+	)
 	pb.RegisterAccountServer(grpcServer, userService)
 	pb.RegisterChatServer(grpcServer, chatService)
 	pb.RegisterDocumentServer(grpcServer, documentsService)
 	pb.RegisterCollectionsServer(grpcServer, collectionService)
 	pb.RegisterNotionServer(grpcServer, notionService)
+	pb.RegisterEmbeddingServer(grpcServer, embeddingService)
+	pb.RegisterDiagnosticsServer(grpcServer, diagnosticsService)
+
+	// Let tools like grpcurl discover the services, never enable in production
+	if os.Getenv("CHATBOT_GRPC_REFLECTION") == "true" {
+		log.Printf("gRPC reflection enabled")
+		reflection.Register(grpcServer)
+	}
+
+	// Serve the metrics on /debug/vars
+	if metricsPort := os.Getenv("CHATBOT_METRICS_PORT"); metricsPort != "" {
+		go func() {
+			log.Printf("serving metrics on port %s", metricsPort)
+			err := http.ListenAndServe(":"+metricsPort, nil)
+			if err != nil {
+				log.Printf("failed to serve metrics: %v", err)
+			}
+		}()
+	}
 
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -177,8 +421,43 @@ func main() {
 		log.Fatalf("failed to listen: %v", err)
 	}
 
+	// Serve the chat and document services to browsers over HTTP
+	var gatewayServer *http.Server
+	if gatewayPort := os.Getenv("CHATBOT_GATEWAY_PORT"); gatewayPort != "" {
+		gatewayServer = initGateway(gatewayPort, port)
+
+		go func() {
+			log.Printf("serving gateway on port %s", gatewayPort)
+			err := gatewayServer.ListenAndServe()
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Printf("failed to serve gateway: %v", err)
+			}
+		}()
+	}
+
+	// Drain the in-flight requests on SIGTERM or SIGINT, e.g. when Cloud Run replaces the instance
+	stop, cancel := signal.NotifyContext(ctx, syscall.SIGTERM, os.Interrupt)
+	defer cancel()
+
+	go func() {
+		<-stop.Done()
+		timeout := shutdownTimeout()
+
+		// The gateway drains in parallel, its requests are served by the gRPC server
+		if gatewayServer != nil {
+			go shutdownGateway(gatewayServer, timeout)
+		}
+
+		shutdown(grpcServer, timeout)
+	}()
+
 	log.Printf("starting server on %v", listener.Addr().String())
 	if err = grpcServer.Serve(listener); err != nil {
 		log.Fatalf("failed to serve: %v", err)
 	}
+
+	// Write the buffered access log before the database connection is closed
+	accessLog.Close()
+	database.Close()
+	log.Printf("server stopped")
 }
@@ -35,7 +35,7 @@ func main() {
 		Position:     0,
 	}
 
-	usage, err := pc.Upsert(ctx, []*search.Fragment{fragment})
+	usage, err := pc.Upsert(ctx, []*search.Fragment{fragment}, nil)
 	if err != nil {
 		log.Fatalf("failed to upsert fragment: %v", err)
 	}
@@ -3,21 +3,53 @@ package datastore
 import (
 	"context"
 	"errors"
+	"expvar"
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"os"
+	"strconv"
+	"sync"
+	"time"
 )
 
 // Service defines the datastore service
 type Service struct {
-	mongo *mongo.Client
+	mongo   *mongo.Client
+	monitor *poolMonitor
+
+	idempotencyIndex lazyIndex
+
+	statsIndexes lazyIndex
+
+	externalIdIndex lazyIndex
+
+	feedbackIndex lazyIndex
+
+	accessLogIndex lazyIndex
+
+	// topology caches whether the server supports transactions
+	topology struct {
+		mu           sync.Mutex
+		checked      bool
+		transactions bool
+	}
+
+	// retries tracks the background retries of failed usage inserts
+	retries sync.WaitGroup
 }
 
-// Close closes the connection to the database
+// Close waits for pending usage retries and closes the connection to the database
 func (service *Service) Close() {
+	service.retries.Wait()
 	_ = service.mongo.Disconnect(context.Background())
 }
 
+// Ping checks if the database is reachable
+func (service *Service) Ping(ctx context.Context) error {
+	return service.mongo.Ping(ctx, nil)
+}
+
 const (
 	DatabaseName = "chatbot"
 )
@@ -30,17 +62,72 @@ const (
 	CollectionPayments     = "payments"
 	CollectionModelUsages  = "model_usages"
 	CollectionNotionAPIKey = "notion_api_keys"
+	CollectionIdempotency  = "idempotency_keys"
+
+	CollectionDocumentTexts = "document_texts"
+	CollectionAccessGrants  = "access_grants"
+	CollectionFeedback      = "feedback"
+	CollectionAccessLogs    = "access_logs"
+	CollectionFailedUsages  = "model_usages_failed"
+	CollectionReindexRuns   = "reindex_runs"
 )
 
-func NewFrom(ctx context.Context, uri string) (*Service, error) {
-	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+func NewFrom(ctx context.Context, uri string, pool PoolConfig) (*Service, error) {
+	monitor := &poolMonitor{}
+
+	opts := options.Client().
+		ApplyURI(uri).
+		SetPoolMonitor(&event.PoolMonitor{Event: monitor.event})
+
+	if pool.MaxPoolSize > 0 {
+		opts.SetMaxPoolSize(pool.MaxPoolSize)
+	}
+
+	if pool.MinPoolSize > 0 {
+		opts.SetMinPoolSize(pool.MinPoolSize)
+	}
+
+	if pool.MaxConnIdleTime > 0 {
+		opts.SetMaxConnIdleTime(pool.MaxConnIdleTime)
+	}
+
+	client, err := mongo.Connect(ctx, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Service{
-		mongo: client,
-	}, nil
+	service := &Service{
+		mongo:   client,
+		monitor: monitor,
+	}
+
+	// Publish the pool stats on /debug/vars
+	if expvar.Get("datastore_pool") == nil {
+		expvar.Publish("datastore_pool", expvar.Func(func() any {
+			return service.PoolStats()
+		}))
+	}
+
+	return service, nil
+}
+
+// poolConfigFromEnv reads the connection pool configuration from the environment.
+func poolConfigFromEnv() PoolConfig {
+	var pool PoolConfig
+
+	if size, err := strconv.ParseUint(os.Getenv("CHATBOT_MONGODB_MAX_POOL_SIZE"), 10, 64); err == nil {
+		pool.MaxPoolSize = size
+	}
+
+	if size, err := strconv.ParseUint(os.Getenv("CHATBOT_MONGODB_MIN_POOL_SIZE"), 10, 64); err == nil {
+		pool.MinPoolSize = size
+	}
+
+	if idle, err := time.ParseDuration(os.Getenv("CHATBOT_MONGODB_MAX_IDLE_TIME")); err == nil {
+		pool.MaxConnIdleTime = idle
+	}
+
+	return pool
 }
 
 func New(ctx context.Context) (*Service, error) {
@@ -49,5 +136,5 @@ func New(ctx context.Context) (*Service, error) {
 		return nil, errors.New("CHATBOT_MONGODB_URI not set")
 	}
 
-	return NewFrom(ctx, uri)
+	return NewFrom(ctx, uri, poolConfigFromEnv())
 }
@@ -0,0 +1,121 @@
+package datastore
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+// Actions of the access log
+const (
+	AccessSearch       = "search"
+	AccessGetChunks    = "get_chunks"
+	AccessDownload     = "download"
+	AccessDownloadText = "download_text"
+	AccessListChunks   = "list_chunks"
+	AccessPageImage    = "page_image"
+	AccessGetSources   = "get_sources"
+)
+
+// AccessLogEntry records which documents of a collection a user read.
+type AccessLogEntry struct {
+	// ID of the entry
+	Id uuid.UUID `bson:"_id,omitempty"`
+
+	// UserId of the user that read the documents
+	UserId string `bson:"user_id,omitempty"`
+
+	// OwnerId and CollectionId of the read documents
+	OwnerId      string    `bson:"owner_id,omitempty"`
+	CollectionId uuid.UUID `bson:"collection_id,omitempty"`
+
+	// DocumentIds are the documents that were returned to the user
+	DocumentIds []uuid.UUID `bson:"document_ids,omitempty"`
+
+	// Action is one of the access actions, e.g. AccessSearch
+	Action string `bson:"action,omitempty"`
+
+	// Timestamp of the access
+	Timestamp time.Time `bson:"timestamp,omitempty"`
+}
+
+// InsertAccessLogs stores a batch of access log entries.
+func (service *Service) InsertAccessLogs(ctx context.Context, entries []AccessLogEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	coll := service.mongo.Database(DatabaseName).Collection(CollectionAccessLogs)
+
+	docs := make([]interface{}, len(entries))
+	for idx := range entries {
+		docs[idx] = entries[idx]
+	}
+
+	_, err := coll.InsertMany(ctx, docs, options.InsertMany().SetOrdered(false))
+	return err
+}
+
+// ensureAccessLogIndex creates the index that pages the access log of a collection.
+func (service *Service) ensureAccessLogIndex(ctx context.Context) error {
+	return service.accessLogIndex.ensure(func() error {
+		coll := service.mongo.Database(DatabaseName).Collection(CollectionAccessLogs)
+
+		_, err := coll.Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys: bson.D{
+				{Key: "collection_id", Value: 1},
+				{Key: "timestamp", Value: -1},
+				{Key: "_id", Value: -1},
+			},
+		})
+		return err
+	})
+}
+
+// GetAccessLog returns the newest access log entries of a collection, ordered by
+// timestamp and id. Only entries before the given entry are returned, so that older
+// entries can be paged. Entries with the same timestamp are paged by their id, a nil
+// id returns all entries before the timestamp.
+func (service *Service) GetAccessLog(ctx context.Context, collectionId uuid.UUID, before time.Time, beforeId uuid.UUID, limit int) ([]AccessLogEntry, error) {
+	err := service.ensureAccessLogIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	coll := service.mongo.Database(DatabaseName).Collection(CollectionAccessLogs)
+
+	filter := bson.M{
+		"collection_id": collectionId,
+		"timestamp":     bson.M{"$lt": before},
+	}
+	if beforeId != uuid.Nil {
+		filter = bson.M{
+			"collection_id": collectionId,
+			"$or": bson.A{
+				bson.M{"timestamp": bson.M{"$lt": before}},
+				bson.M{"timestamp": before, "_id": bson.M{"$lt": beforeId}},
+			},
+		}
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "timestamp", Value: -1}, {Key: "_id", Value: -1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var entries []AccessLogEntry
+	err = cursor.All(ctx, &entries)
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,71 @@
+package datastore
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestGetAccessLogPaging(t *testing.T) {
+	uri := os.Getenv("CHATBOT_MONGODB_URI")
+	if uri == "" {
+		t.Skip("CHATBOT_MONGODB_URI not set")
+	}
+
+	ctx := context.Background()
+
+	db, err := NewFrom(ctx, uri, PoolConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	// Entries of a batch share their timestamp, pages must not skip any of them
+	collectionId := uuid.New()
+	timestamp := time.Now().Add(-time.Minute).Truncate(time.Millisecond)
+
+	var entries []AccessLogEntry
+	for range 5 {
+		entries = append(entries, AccessLogEntry{
+			Id:           uuid.New(),
+			UserId:       "test-" + uuid.NewString(),
+			CollectionId: collectionId,
+			DocumentIds:  []uuid.UUID{uuid.New()},
+			Action:       AccessSearch,
+			Timestamp:    timestamp,
+		})
+	}
+
+	err = db.InsertAccessLogs(ctx, entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	before, beforeId := time.Now(), uuid.Nil
+	for {
+		page, err := db.GetAccessLog(ctx, collectionId, before, beforeId, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(page) == 0 {
+			break
+		}
+
+		for _, entry := range page {
+			if seen[entry.Id] {
+				t.Fatalf("entry %s returned twice", entry.Id)
+			}
+			seen[entry.Id] = true
+		}
+
+		last := page[len(page)-1]
+		before, beforeId = last.Timestamp, last.Id
+	}
+
+	if len(seen) != len(entries) {
+		t.Fatalf("expected %d entries, got %d", len(entries), len(seen))
+	}
+}
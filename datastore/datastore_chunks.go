@@ -0,0 +1,105 @@
+package datastore
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Chunk is a document chunk together with the document it belongs to.
+type Chunk struct {
+	DocumentChunk `bson:",inline"`
+
+	// ID of the document
+	DocumentId uuid.UUID `bson:"document_id,omitempty"`
+
+	// ID of the collection of the document
+	CollectionId uuid.UUID `bson:"collection_id,omitempty"`
+
+	// UserId is the owner of the document
+	UserId string `bson:"user_id,omitempty"`
+}
+
+// GetChunks retrieves document chunks by their ids. Only chunks of documents owned
+// by the user or of collections shared with the user are returned, other ids are
+// silently dropped.
+func (service *Service) GetChunks(ctx context.Context, userId string, ids ...uuid.UUID) ([]Chunk, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	readable, err := service.readableFilter(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	coll := service.mongo.Database(DatabaseName).Collection(CollectionDokuments)
+
+	pipeline := bson.A{
+		// The access filter must come first, so that no other user's document is touched
+		bson.M{"$match": bson.M{
+			"$or":        readable,
+			"content.id": bson.M{"$in": ids},
+		}},
+		bson.M{"$unwind": "$content"},
+		bson.M{"$match": bson.M{
+			"content.id": bson.M{"$in": ids},
+		}},
+		bson.M{"$project": bson.M{
+			"_id":           0,
+			"document_id":   "$_id",
+			"collection_id": 1,
+			"user_id":       1,
+			"id":            "$content.id",
+			"text":          "$content.text",
+			"position":      "$content.position",
+			"language":      "$content.language",
+			"type":          "$content.type",
+		}},
+	}
+
+	cursor, err := coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var chunks []Chunk
+	err = cursor.All(ctx, &chunks)
+	if err != nil {
+		return nil, err
+	}
+
+	return chunks, nil
+}
+
+// ListChunks retrieves the chunks of a document in order of their position. Offset
+// and limit select a page of the chunks.
+func (service *Service) ListChunks(ctx context.Context, userId string, documentId uuid.UUID, offset, limit int) ([]*DocumentChunk, error) {
+	coll := service.mongo.Database(DatabaseName).Collection(CollectionDokuments)
+
+	cursor, err := coll.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"_id":     documentId,
+			"user_id": userId,
+		}}},
+		{{Key: "$unwind", Value: "$content"}},
+		{{Key: "$replaceRoot", Value: bson.M{"newRoot": "$content"}}},
+		{{Key: "$sort", Value: bson.D{{Key: "position", Value: 1}, {Key: "id", Value: 1}}}},
+		{{Key: "$skip", Value: offset}},
+		{{Key: "$limit", Value: limit}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var chunks []*DocumentChunk
+	err = cursor.All(ctx, &chunks)
+	if err != nil {
+		return nil, err
+	}
+
+	return chunks, nil
+}
@@ -0,0 +1,68 @@
+package datastore
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"os"
+	"testing"
+)
+
+func TestGetChunksOwnership(t *testing.T) {
+	uri := os.Getenv("CHATBOT_MONGODB_URI")
+	if uri == "" {
+		t.Skip("CHATBOT_MONGODB_URI not set")
+	}
+
+	ctx := context.Background()
+
+	db, err := NewFrom(ctx, uri, PoolConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	owner := "test-" + uuid.NewString()
+	other := "test-" + uuid.NewString()
+
+	chunkId := uuid.New()
+	doc := &Document{
+		Id:           uuid.New(),
+		UserId:       owner,
+		CollectionId: uuid.New(),
+		Name:         "secret",
+		Type:         DocumentTypeWeb,
+		Content: []*DocumentChunk{
+			{Id: chunkId, Text: "secret content", Position: 1},
+			{Id: uuid.New(), Text: "other content", Position: 2},
+		},
+	}
+
+	err = db.InsertDocument(ctx, doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = db.DeleteDocument(ctx, owner, doc.Id) }()
+
+	chunks, err := db.GetChunks(ctx, owner, chunkId)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(chunks) != 1 {
+		t.Fatalf("expected 1 chunk, got %d", len(chunks))
+	}
+
+	if chunks[0].Text != "secret content" || chunks[0].DocumentId != doc.Id {
+		t.Fatalf("unexpected chunk: %+v", chunks[0])
+	}
+
+	// Guessing the chunk id must not reveal the content to another user
+	chunks, err = db.GetChunks(ctx, other, chunkId)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(chunks) != 0 {
+		t.Fatalf("expected no chunks for another user, got %d", len(chunks))
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"regexp"
+	"time"
 )
 
 type Collection struct {
@@ -15,6 +18,43 @@ type Collection struct {
 
 	// Name of the collection
 	Name string `bson:"name,omitempty"`
+
+	// EmbeddingModel used to index the documents of the collection
+	EmbeddingModel string `bson:"embedding_model,omitempty"`
+
+	// Archived collections are hidden by default and block indexing and chats
+	Archived bool `bson:"archived,omitempty"`
+
+	// CreatedAt is zero for collections created before it was recorded
+	CreatedAt time.Time `bson:"created_at,omitempty"`
+
+	// DefaultModel is used for prompts without a model, updates clear it if empty
+	DefaultModel string `bson:"default_model"`
+
+	// Default marks the collection of prompts without a collection, at most one
+	// collection of a user. Updates don't clear it, see SetDefaultCollection
+	Default bool `bson:"default,omitempty"`
+}
+
+// CollectionFilter selects the listed collections.
+type CollectionFilter struct {
+	IncludeArchived bool
+
+	// Name selects collections whose name contains it, ignoring the case
+	Name string
+}
+
+// apply adds the conditions of the filter to a query of the collections.
+func (filter CollectionFilter) apply(query bson.M) bson.M {
+	if !filter.IncludeArchived {
+		query["archived"] = bson.M{"$ne": true}
+	}
+
+	if filter.Name != "" {
+		query["name"] = bson.M{"$regex": regexp.QuoteMeta(filter.Name), "$options": "i"}
+	}
+
+	return query
 }
 
 func (service *Service) InsertCollection(ctx context.Context, collection *Collection) error {
@@ -28,13 +68,15 @@ func (service *Service) InsertCollection(ctx context.Context, collection *Collec
 	return nil
 }
 
-// UpdateCollection updates a collection in the database
+// UpdateCollection updates a collection in the database. Archived collections
+// are not changed.
 func (service *Service) UpdateCollection(ctx context.Context, collection *Collection) error {
 	coll := service.mongo.Database(DatabaseName).Collection(CollectionCollections)
 
 	_, err := coll.UpdateOne(ctx, bson.M{
-		"_id":     collection.Id,
-		"user_id": collection.UserId,
+		"_id":      collection.Id,
+		"user_id":  collection.UserId,
+		"archived": bson.M{"$ne": true},
 	}, bson.M{
 		"$set": collection,
 	})
@@ -61,13 +103,80 @@ func (service *Service) GetCollection(ctx context.Context, userId string, collec
 	return &collection, nil
 }
 
-// GetCollections retrieves all collections from the database
-func (service *Service) GetCollections(ctx context.Context, userId string) ([]Collection, error) {
+// GetCollectionById retrieves a collection of any user, e.g. for admin tasks.
+func (service *Service) GetCollectionById(ctx context.Context, collectionId uuid.UUID) (*Collection, error) {
 	coll := service.mongo.Database(DatabaseName).Collection(CollectionCollections)
 
-	cursor, err := coll.Find(ctx, bson.M{
-		"user_id": userId,
+	var collection Collection
+	err := coll.FindOne(ctx, bson.M{"_id": collectionId}).Decode(&collection)
+	if err != nil {
+		return nil, err
+	}
+
+	return &collection, nil
+}
+
+// GetDefaultCollection returns the default collection of a user. It returns
+// mongo.ErrNoDocuments if the user has no default collection or it is archived.
+func (service *Service) GetDefaultCollection(ctx context.Context, userId string) (*Collection, error) {
+	coll := service.mongo.Database(DatabaseName).Collection(CollectionCollections)
+
+	var collection Collection
+	err := coll.FindOne(ctx, bson.M{
+		"user_id":  userId,
+		"default":  true,
+		"archived": bson.M{"$ne": true},
+	}).Decode(&collection)
+	if err != nil {
+		return nil, err
+	}
+
+	return &collection, nil
+}
+
+// SetDefaultCollection makes a collection the default collection of its owner and
+// clears the flag of the other collections. A nil id only clears the default. It
+// returns mongo.ErrNoDocuments if the collection doesn't exist.
+func (service *Service) SetDefaultCollection(ctx context.Context, userId string, collectionId uuid.UUID) error {
+	coll := service.mongo.Database(DatabaseName).Collection(CollectionCollections)
+
+	return service.WithTransaction(ctx, func(ctx context.Context) error {
+		if collectionId != uuid.Nil {
+			result, err := coll.UpdateOne(ctx, bson.M{
+				"_id":     collectionId,
+				"user_id": userId,
+			}, bson.M{
+				"$set": bson.M{"default": true},
+			})
+			if err != nil {
+				return err
+			}
+
+			if result.MatchedCount == 0 {
+				return mongo.ErrNoDocuments
+			}
+		}
+
+		_, err := coll.UpdateMany(ctx, bson.M{
+			"_id":     bson.M{"$ne": collectionId},
+			"user_id": userId,
+			"default": true,
+		}, bson.M{
+			"$unset": bson.M{"default": ""},
+		})
+
+		return err
 	})
+}
+
+// GetCollections retrieves all collections from the database. Archived collections
+// are only included if includeArchived is set.
+func (service *Service) GetCollections(ctx context.Context, userId string, filter CollectionFilter) ([]Collection, error) {
+	coll := service.mongo.Database(DatabaseName).Collection(CollectionCollections)
+
+	cursor, err := coll.Find(ctx, filter.apply(bson.M{
+		"user_id": userId,
+	}))
 	if err != nil {
 		return nil, err
 	}
@@ -81,11 +190,35 @@ func (service *Service) GetCollections(ctx context.Context, userId string) ([]Co
 	return collections, nil
 }
 
+// SetCollectionArchived archives or restores a collection. It returns
+// mongo.ErrNoDocuments if the collection doesn't exist.
+func (service *Service) SetCollectionArchived(ctx context.Context, userId string, collectionId uuid.UUID, archived bool) error {
+	coll := service.mongo.Database(DatabaseName).Collection(CollectionCollections)
+
+	result, err := coll.UpdateOne(ctx, bson.M{
+		"_id":     collectionId,
+		"user_id": userId,
+	}, bson.M{
+		"$set": bson.M{"archived": archived},
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	return nil
+}
+
 // DeleteCollection deletes a collection from the database
 func (service *Service) DeleteCollection(ctx context.Context, userId string, collectionId uuid.UUID) error {
 	collections := service.mongo.Database(DatabaseName).Collection(CollectionCollections)
 	documents := service.mongo.Database(DatabaseName).Collection(CollectionDokuments)
 	threads := service.mongo.Database(DatabaseName).Collection(CollectionThreads)
+	texts := service.mongo.Database(DatabaseName).Collection(CollectionDocumentTexts)
+	grants := service.mongo.Database(DatabaseName).Collection(CollectionAccessGrants)
 
 	_, err := collections.DeleteOne(ctx, bson.M{
 		"_id":     collectionId,
@@ -103,6 +236,11 @@ func (service *Service) DeleteCollection(ctx context.Context, userId string, col
 		return err
 	}
 
+	threadIds, err := service.GetThreadIDs(ctx, userId, collectionId)
+	if err != nil {
+		return err
+	}
+
 	_, err = threads.DeleteMany(ctx, bson.M{
 		"collection_id": collectionId,
 		"user_id":       userId,
@@ -111,5 +249,64 @@ func (service *Service) DeleteCollection(ctx context.Context, userId string, col
 		return err
 	}
 
+	if len(threadIds) > 0 {
+		err = service.deleteFeedback(ctx, userId, threadIds...)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = texts.DeleteMany(ctx, bson.M{
+		"collection_id": collectionId,
+		"user_id":       userId,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = grants.DeleteMany(ctx, bson.M{
+		"collection_id": collectionId,
+		"owner_id":      userId,
+	})
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
+
+// CountCollectionDocuments returns the number of documents of the collections.
+// Collections without documents are missing in the result.
+func (service *Service) CountCollectionDocuments(ctx context.Context, ids ...uuid.UUID) (map[uuid.UUID]int, error) {
+	coll := service.mongo.Database(DatabaseName).Collection(CollectionDokuments)
+
+	cursor, err := coll.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"collection_id": bson.M{"$in": ids},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   "$collection_id",
+			"count": bson.M{"$sum": 1},
+		}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var groups []struct {
+		CollectionId uuid.UUID `bson:"_id"`
+		Count        int       `bson:"count"`
+	}
+	err = cursor.All(ctx, &groups)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uuid.UUID]int, len(groups))
+	for _, group := range groups {
+		counts[group.CollectionId] = group.Count
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,73 @@
+package datastore
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+	"os"
+	"testing"
+)
+
+func TestSetDefaultCollection(t *testing.T) {
+	uri := os.Getenv("CHATBOT_MONGODB_URI")
+	if uri == "" {
+		t.Skip("CHATBOT_MONGODB_URI not set")
+	}
+
+	ctx := context.Background()
+
+	db, err := NewFrom(ctx, uri, PoolConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	userId := "test-" + uuid.NewString()
+	first := &Collection{Id: uuid.New(), UserId: userId, Name: "first"}
+	second := &Collection{Id: uuid.New(), UserId: userId, Name: "second"}
+
+	for _, collection := range []*Collection{first, second} {
+		err = db.InsertCollection(ctx, collection)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() { _ = db.DeleteCollection(ctx, userId, collection.Id) }()
+	}
+
+	_, err = db.GetDefaultCollection(ctx, userId)
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		t.Fatalf("expected no default collection, got %v", err)
+	}
+
+	for _, collection := range []*Collection{first, second} {
+		err = db.SetDefaultCollection(ctx, userId, collection.Id)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Only the latest default is kept
+		found, err := db.GetDefaultCollection(ctx, userId)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if found.Id != collection.Id {
+			t.Fatalf("expected default %s, got %s", collection.Id, found.Id)
+		}
+	}
+
+	err = db.SetDefaultCollection(ctx, "test-"+uuid.NewString(), first.Id)
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		t.Fatalf("expected collections of other users to be rejected, got %v", err)
+	}
+
+	err = db.SetDefaultCollection(ctx, userId, uuid.Nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = db.GetDefaultCollection(ctx, userId)
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		t.Fatalf("expected the default to be unset, got %v", err)
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
 )
 
 const (
@@ -12,6 +14,12 @@ const (
 	DocumentTypeWeb = "web"
 )
 
+// Types of document chunks. Chunks of plain text have no type
+const (
+	ChunkTypeCode  = "code"
+	ChunkTypeTable = "table"
+)
+
 type Document struct {
 	// ID of the document
 	Id uuid.UUID `bson:"_id,omitempty"`
@@ -31,6 +39,22 @@ type Document struct {
 	// Source can be a URL or a file path
 	Source string `bson:"source,omitempty"`
 
+	// CreatedAt is the time the indexing of the document started
+	CreatedAt time.Time `bson:"created_at,omitempty"`
+
+	// IndexedAt is the time the document was embedded and searchable
+	IndexedAt time.Time `bson:"indexed_at,omitempty"`
+
+	// Language is the ISO 639-1 code of the most frequent language of the chunks
+	Language string `bson:"language,omitempty"`
+
+	// ExternalId is the id of the document in an external system, unique per collection
+	ExternalId string `bson:"external_id,omitempty"`
+
+	// FileSize is the size of the uploaded file in bytes, zero for web pages and
+	// files indexed before the size was recorded
+	FileSize int64 `bson:"file_size,omitempty"`
+
 	// Data chunks
 	Content []*DocumentChunk `bson:"content,omitempty"`
 }
@@ -42,14 +66,33 @@ type DocumentChunk struct {
 	// Content of the document chunk
 	Text string `bson:"text,omitempty"`
 
+	// SearchText is embedded instead of Text if set, e.g. without boilerplate
+	SearchText string `bson:"search_text,omitempty"`
+
+	// Boilerplate is set if the chunk has no text besides boilerplate, it is not embedded
+	Boilerplate bool `bson:"boilerplate,omitempty"`
+
 	// Position of the document chunk
 	Position uint32 `bson:"position,omitempty"`
+
+	// Language is the ISO 639-1 code of the chunk text, empty if unknown
+	Language string `bson:"language,omitempty"`
+
+	// Type is ChunkTypeCode or ChunkTypeTable, empty for plain text
+	Type string `bson:"type,omitempty"`
 }
 
 // InsertDocument stores a document in the database.
 func (service *Service) InsertDocument(ctx context.Context, document *Document) error {
 	coll := service.mongo.Database(DatabaseName).Collection(CollectionDokuments)
 
+	if document.ExternalId != "" {
+		err := service.ensureExternalIdIndex(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
 	_, err := coll.InsertOne(ctx, document)
 	if err != nil {
 		return err
@@ -74,16 +117,115 @@ func (service *Service) GetDocument(ctx context.Context, userId string, id uuid.
 	return &document, nil
 }
 
+// DocumentHeader contains the metadata of a document and its number of pages and chunks.
+type DocumentHeader struct {
+	Document `bson:",inline"`
+
+	// Pages is the number of distinct positions of the chunks. Code blocks and
+	// tables keep the position of their page and don't count as pages.
+	Pages uint32 `bson:"pages,omitempty"`
+
+	// Chunks is the number of content chunks, which ListChunks pages through
+	Chunks uint32 `bson:"chunks,omitempty"`
+}
+
+// GetDocumentHeader retrieves the metadata of a document without its content.
+func (service *Service) GetDocumentHeader(ctx context.Context, userId string, id uuid.UUID) (*DocumentHeader, error) {
+	coll := service.mongo.Database(DatabaseName).Collection(CollectionDokuments)
+
+	cursor, err := coll.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"_id":     id,
+			"user_id": userId,
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":           1,
+			"user_id":       1,
+			"collection_id": 1,
+			"name":          1,
+			"type":          1,
+			"source":        1,
+			"created_at":    1,
+			"indexed_at":    1,
+			"language":      1,
+			"external_id":   1,
+			"pages": bson.M{
+				"$size": bson.M{"$setUnion": bson.A{bson.M{"$ifNull": bson.A{"$content.position", bson.A{}}}}},
+			},
+			"chunks": bson.M{
+				"$size": bson.M{"$ifNull": bson.A{"$content", bson.A{}}},
+			},
+		}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	if !cursor.Next(ctx) {
+		if cursor.Err() != nil {
+			return nil, cursor.Err()
+		}
+
+		return nil, mongo.ErrNoDocuments
+	}
+
+	var header DocumentHeader
+	err = cursor.Decode(&header)
+	if err != nil {
+		return nil, err
+	}
+
+	return &header, nil
+}
+
+// MissingCollectionDocuments returns the ids that are no documents of the
+// collection owned by the user.
+func (service *Service) MissingCollectionDocuments(ctx context.Context, userId string, collectionId uuid.UUID, ids ...uuid.UUID) ([]uuid.UUID, error) {
+	coll := service.mongo.Database(DatabaseName).Collection(CollectionDokuments)
+
+	cursor, err := coll.Find(ctx, bson.M{
+		"_id":           bson.M{"$in": ids},
+		"user_id":       userId,
+		"collection_id": collectionId,
+	}, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var documents []Document
+	err = cursor.All(ctx, &documents)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[uuid.UUID]bool, len(documents))
+	for _, document := range documents {
+		found[document.Id] = true
+	}
+
+	var missing []uuid.UUID
+	for _, id := range ids {
+		if !found[id] {
+			missing = append(missing, id)
+		}
+	}
+
+	return missing, nil
+}
+
 // GetDocumentMeta retrieves the metadata of the documents from the database.
 func (service *Service) GetDocumentMeta(ctx context.Context, userId string, ids ...uuid.UUID) ([]Document, error) {
 	coll := service.mongo.Database(DatabaseName).Collection(CollectionDokuments)
 
 	opts := &options.FindOptions{
 		Projection: bson.M{
-			"_id":    1,
-			"name":   1,
-			"type":   1,
-			"source": 1,
+			"_id":           1,
+			"collection_id": 1,
+			"name":          1,
+			"type":          1,
+			"source":        1,
 		},
 	}
 
@@ -109,34 +251,86 @@ func (service *Service) GetDocumentMeta(ctx context.Context, userId string, ids
 	return documents, nil
 }
 
+// GetDocumentOwner returns the id, owner, collection and index time of a document
+// regardless of the user, so that the access of other users can be checked.
+func (service *Service) GetDocumentOwner(ctx context.Context, id uuid.UUID) (*Document, error) {
+	coll := service.mongo.Database(DatabaseName).Collection(CollectionDokuments)
+
+	opts := &options.FindOneOptions{
+		Projection: bson.M{
+			"_id":           1,
+			"user_id":       1,
+			"collection_id": 1,
+			"indexed_at":    1,
+		},
+	}
+
+	var document Document
+	err := coll.FindOne(ctx, bson.M{"_id": id}, opts).Decode(&document)
+	if err != nil {
+		return nil, err
+	}
+
+	return &document, nil
+}
+
+// Sort keys of the documents
+const (
+	OrderByName      = "name"
+	OrderByCreatedAt = "created_at"
+	OrderByIndexedAt = "indexed_at"
+	OrderByPages     = "pages"
+)
+
 type DocumentFilter struct {
 	UserId       string
 	CollectionId uuid.UUID
 	Query        string
+
+	// OrderBy is the sort key, defaults to OrderByName. Ties are ordered by id.
+	OrderBy    string
+	Descending bool
 }
 
-// ListDocuments retrieves all documents from the database without content.
+// ListDocuments retrieves all documents from the database without content in a
+// stable order.
 func (service *Service) ListDocuments(ctx context.Context, query DocumentFilter) ([]Document, error) {
 	coll := service.mongo.Database(DatabaseName).Collection(CollectionDokuments)
 
-	filter := bson.M{
-		"user_id":       query.UserId,
-		"collection_id": query.CollectionId,
-		"name": bson.M{
-			"$regex": query.Query,
-		},
+	orderBy := query.OrderBy
+	if orderBy == "" {
+		orderBy = OrderByName
 	}
 
-	opts := &options.FindOptions{
-		Projection: bson.M{
-			"_id":    1,
-			"name":   1,
-			"type":   1,
-			"source": 1,
-		},
+	direction := 1
+	if query.Descending {
+		direction = -1
 	}
 
-	cursor, err := coll.Find(ctx, filter, opts)
+	cursor, err := coll.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"user_id":       query.UserId,
+			"collection_id": query.CollectionId,
+			"name": bson.M{
+				"$regex": query.Query,
+			},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":        1,
+			"name":       1,
+			"type":       1,
+			"source":     1,
+			"created_at": 1,
+			"indexed_at": 1,
+			"pages": bson.M{
+				"$size": bson.M{"$setUnion": bson.A{bson.M{"$ifNull": bson.A{"$content.position", bson.A{}}}}},
+			},
+		}}},
+		{{Key: "$sort", Value: bson.D{
+			{Key: orderBy, Value: direction},
+			{Key: "_id", Value: direction},
+		}}},
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -182,6 +376,40 @@ func (service *Service) DeleteDocument(ctx context.Context, userId string, id uu
 		return err
 	}
 
+	texts := service.mongo.Database(DatabaseName).Collection(CollectionDocumentTexts)
+	_, err = texts.DeleteOne(ctx, bson.M{
+		"_id":     id,
+		"user_id": userId,
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteDocuments deletes multiple documents from the database.
+func (service *Service) DeleteDocuments(ctx context.Context, userId string, ids ...uuid.UUID) error {
+	coll := service.mongo.Database(DatabaseName).Collection(CollectionDokuments)
+
+	filter := bson.M{
+		"_id": bson.M{
+			"$in": ids,
+		},
+		"user_id": userId,
+	}
+
+	_, err := coll.DeleteMany(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	texts := service.mongo.Database(DatabaseName).Collection(CollectionDocumentTexts)
+	_, err = texts.DeleteMany(ctx, filter)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
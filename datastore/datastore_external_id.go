@@ -0,0 +1,71 @@
+package datastore
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ensureExternalIdIndex creates the index that keeps the external ids unique per
+// collection. Documents without external id are not indexed.
+func (service *Service) ensureExternalIdIndex(ctx context.Context) error {
+	return service.externalIdIndex.ensure(func() error {
+		coll := service.mongo.Database(DatabaseName).Collection(CollectionDokuments)
+
+		_, err := coll.Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys: bson.D{{Key: "collection_id", Value: 1}, {Key: "external_id", Value: 1}},
+			Options: options.Index().
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"external_id": bson.M{"$type": "string"}}),
+		})
+		return err
+	})
+}
+
+// FindExternalDocument returns the document with the external id in a collection
+// of the user without its content or mongo.ErrNoDocuments.
+func (service *Service) FindExternalDocument(ctx context.Context, userId string, collectionId uuid.UUID, externalId string) (*Document, error) {
+	err := service.ensureExternalIdIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	coll := service.mongo.Database(DatabaseName).Collection(CollectionDokuments)
+
+	var document Document
+	err = coll.FindOne(ctx, bson.M{
+		"user_id":       userId,
+		"collection_id": collectionId,
+		"external_id":   externalId,
+	}, options.FindOne().SetProjection(bson.M{
+		"content.text":        0,
+		"content.search_text": 0,
+	})).Decode(&document)
+	if err != nil {
+		return nil, err
+	}
+
+	return &document, nil
+}
+
+// ReplaceDocument replaces a stored document of the same user, e.g. after it was
+// indexed again.
+func (service *Service) ReplaceDocument(ctx context.Context, document *Document) error {
+	coll := service.mongo.Database(DatabaseName).Collection(CollectionDokuments)
+
+	result, err := coll.ReplaceOne(ctx, bson.M{
+		"_id":     document.Id,
+		"user_id": document.UserId,
+	}, document)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	return nil
+}
@@ -0,0 +1,173 @@
+package datastore
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+// Ratings of a message
+const (
+	RatingUp   = "up"
+	RatingDown = "down"
+)
+
+// Feedback is the rating of a message by a user. Each user has at most one
+// feedback per message.
+type Feedback struct {
+	// ID of the feedback
+	Id uuid.UUID `bson:"_id,omitempty"`
+
+	// User ID
+	UserId string `bson:"user_id,omitempty"`
+
+	// Thread and message the feedback belongs to
+	ThreadId  uuid.UUID `bson:"thread_id,omitempty"`
+	MessageId string    `bson:"message_id,omitempty"`
+
+	// ModelId is the model that generated the message
+	ModelId string `bson:"model_id,omitempty"`
+
+	// Rating is either RatingUp, RatingDown or empty
+	Rating string `bson:"rating,omitempty"`
+
+	// Score from 1 to 5, 0 if not set
+	Score uint32 `bson:"score,omitempty"`
+
+	// Comment, optional
+	Comment string `bson:"comment,omitempty"`
+
+	// Timestamp of the last change
+	Timestamp time.Time `bson:"timestamp,omitempty"`
+}
+
+// ensureFeedbackIndex creates the index that keeps one feedback per user and message.
+func (service *Service) ensureFeedbackIndex(ctx context.Context) error {
+	return service.feedbackIndex.ensure(func() error {
+		coll := service.mongo.Database(DatabaseName).Collection(CollectionFeedback)
+
+		_, err := coll.Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "message_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		})
+		return err
+	})
+}
+
+// StoreFeedback inserts the feedback or replaces the earlier feedback of the user
+// for the message.
+func (service *Service) StoreFeedback(ctx context.Context, feedback *Feedback) error {
+	err := service.ensureFeedbackIndex(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = service.upsertFeedback(ctx, feedback)
+	if mongo.IsDuplicateKeyError(err) {
+		// A concurrent upsert inserted the feedback first, the retry updates it
+		err = service.upsertFeedback(ctx, feedback)
+	}
+
+	return err
+}
+
+func (service *Service) upsertFeedback(ctx context.Context, feedback *Feedback) error {
+	coll := service.mongo.Database(DatabaseName).Collection(CollectionFeedback)
+
+	_, err := coll.UpdateOne(ctx, bson.M{
+		"user_id":    feedback.UserId,
+		"message_id": feedback.MessageId,
+	}, bson.M{
+		"$set": bson.M{
+			"thread_id": feedback.ThreadId,
+			"model_id":  feedback.ModelId,
+			"rating":    feedback.Rating,
+			"score":     feedback.Score,
+			"comment":   feedback.Comment,
+			"timestamp": feedback.Timestamp,
+		},
+		"$setOnInsert": bson.M{
+			"_id": feedback.Id,
+		},
+	}, options.Update().SetUpsert(true))
+	return err
+}
+
+// deleteFeedback deletes the feedback of the user for the messages of the threads.
+func (service *Service) deleteFeedback(ctx context.Context, userId string, threadIds ...uuid.UUID) error {
+	coll := service.mongo.Database(DatabaseName).Collection(CollectionFeedback)
+
+	_, err := coll.DeleteMany(ctx, bson.M{
+		"user_id":   userId,
+		"thread_id": bson.M{"$in": threadIds},
+	})
+	return err
+}
+
+// FeedbackAggregate contains the summed feedback of a model.
+type FeedbackAggregate struct {
+	ModelId string `bson:"model_id,omitempty"`
+	Up      uint32 `bson:"up,omitempty"`
+	Down    uint32 `bson:"down,omitempty"`
+
+	// Number of scores and their average
+	Scores       uint32  `bson:"scores,omitempty"`
+	AverageScore float64 `bson:"average_score,omitempty"`
+}
+
+// FeedbackByModel returns the feedback of a user in the time range [from, to) grouped by model.
+func (service *Service) FeedbackByModel(ctx context.Context, userId string, from, to time.Time) ([]FeedbackAggregate, error) {
+	coll := service.mongo.Database(DatabaseName).Collection(CollectionFeedback)
+
+	count := func(condition bson.M) bson.M {
+		return bson.M{"$sum": bson.M{"$cond": bson.A{condition, 1, 0}}}
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"user_id": userId,
+			"timestamp": bson.M{
+				"$gte": from,
+				"$lt":  to,
+			},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":  "$model_id",
+			"up":   count(bson.M{"$eq": bson.A{"$rating", RatingUp}}),
+			"down": count(bson.M{"$eq": bson.A{"$rating", RatingDown}}),
+			// Feedback without a score stores 0, which must not lower the average
+			"scores": count(bson.M{"$gt": bson.A{"$score", 0}}),
+			"average_score": bson.M{"$avg": bson.M{
+				"$cond": bson.A{bson.M{"$gt": bson.A{"$score", 0}}, "$score", nil},
+			}},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":           0,
+			"model_id":      "$_id",
+			"up":            1,
+			"down":          1,
+			"scores":        1,
+			"average_score": 1,
+		}}},
+		{{Key: "$sort", Value: bson.M{
+			"model_id": 1,
+		}}},
+	}
+
+	cur, err := coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = cur.Close(ctx) }()
+
+	var feedback []FeedbackAggregate
+	err = cur.All(ctx, &feedback)
+	if err != nil {
+		return nil, err
+	}
+
+	return feedback, nil
+}
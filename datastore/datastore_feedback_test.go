@@ -0,0 +1,68 @@
+package datastore
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"testing"
+	"time"
+)
+
+func countFeedback(t *testing.T, db *Service, thread *Thread) int64 {
+	coll := db.mongo.Database(DatabaseName).Collection(CollectionFeedback)
+
+	count, err := coll.CountDocuments(context.Background(), bson.M{
+		"user_id":   thread.UserId,
+		"thread_id": thread.Id,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return count
+}
+
+func TestStoreFeedback(t *testing.T) {
+	db, thread := newTestThread(t)
+	ctx := context.Background()
+	messageId := uuid.NewString()
+
+	for _, rating := range []string{RatingUp, RatingDown} {
+		err := db.StoreFeedback(ctx, &Feedback{
+			Id:        uuid.New(),
+			UserId:    thread.UserId,
+			ThreadId:  thread.Id,
+			MessageId: messageId,
+			Rating:    rating,
+			Timestamp: time.Now(),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if count := countFeedback(t, db, thread); count != 1 {
+		t.Fatalf("expected the feedback to be replaced, got %d entries", count)
+	}
+
+	// Duplicates are rejected by the index, not only by the upsert
+	coll := db.mongo.Database(DatabaseName).Collection(CollectionFeedback)
+	_, err := coll.InsertOne(ctx, &Feedback{
+		Id:        uuid.New(),
+		UserId:    thread.UserId,
+		ThreadId:  thread.Id,
+		MessageId: messageId,
+	})
+	if err == nil {
+		t.Fatal("expected a duplicate key error")
+	}
+
+	err = db.DeleteThread(ctx, thread.UserId, thread.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count := countFeedback(t, db, thread); count != 0 {
+		t.Fatalf("expected the feedback to be deleted with the thread, got %d entries", count)
+	}
+}
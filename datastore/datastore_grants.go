@@ -0,0 +1,254 @@
+package datastore
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+// Roles of a user in a collection. Owners can additionally manage the grants.
+const (
+	RoleOwner = "owner"
+	RoleWrite = "write"
+	RoleRead  = "read"
+)
+
+// AccessGrant gives a user access to the collection of another user.
+type AccessGrant struct {
+	// ID of the grant
+	Id uuid.UUID `bson:"_id,omitempty"`
+
+	// Collection ID
+	CollectionId uuid.UUID `bson:"collection_id,omitempty"`
+
+	// OwnerId is the user ID of the collection owner
+	OwnerId string `bson:"owner_id,omitempty"`
+
+	// GranteeId is the user ID of the user that gets access
+	GranteeId string `bson:"grantee_id,omitempty"`
+
+	// Role is either RoleRead or RoleWrite
+	Role string `bson:"role,omitempty"`
+
+	// CreatedAt is the time the grant was created or last changed
+	CreatedAt time.Time `bson:"created_at,omitempty"`
+}
+
+// CollectionAccess is a collection together with the role of the user in it.
+type CollectionAccess struct {
+	Collection *Collection
+	Role       string
+}
+
+// CanWrite returns true if the user can change the documents of the collection.
+func (access *CollectionAccess) CanWrite() bool {
+	return access.Role == RoleOwner || access.Role == RoleWrite
+}
+
+// StoreGrant inserts a grant or changes the role of an existing grant of the grantee.
+func (service *Service) StoreGrant(ctx context.Context, grant *AccessGrant) error {
+	coll := service.mongo.Database(DatabaseName).Collection(CollectionAccessGrants)
+
+	_, err := coll.UpdateOne(ctx, bson.M{
+		"collection_id": grant.CollectionId,
+		"owner_id":      grant.OwnerId,
+		"grantee_id":    grant.GranteeId,
+	}, bson.M{
+		"$set": bson.M{
+			"role":       grant.Role,
+			"created_at": grant.CreatedAt,
+		},
+		"$setOnInsert": bson.M{
+			"_id": grant.Id,
+		},
+	}, options.Update().SetUpsert(true))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteGrant revokes the access of the grantee. It returns mongo.ErrNoDocuments
+// if the grantee has no access.
+func (service *Service) DeleteGrant(ctx context.Context, ownerId string, collectionId uuid.UUID, granteeId string) error {
+	coll := service.mongo.Database(DatabaseName).Collection(CollectionAccessGrants)
+
+	result, err := coll.DeleteOne(ctx, bson.M{
+		"collection_id": collectionId,
+		"owner_id":      ownerId,
+		"grantee_id":    granteeId,
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	return nil
+}
+
+// ListGrants returns the grants of a collection.
+func (service *Service) ListGrants(ctx context.Context, ownerId string, collectionId uuid.UUID) ([]AccessGrant, error) {
+	coll := service.mongo.Database(DatabaseName).Collection(CollectionAccessGrants)
+
+	cursor, err := coll.Find(ctx, bson.M{
+		"collection_id": collectionId,
+		"owner_id":      ownerId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var grants []AccessGrant
+	err = cursor.All(ctx, &grants)
+	if err != nil {
+		return nil, err
+	}
+
+	return grants, nil
+}
+
+// GetCollectionAccess returns the collection and the role of the user, either as
+// owner or by a grant. It returns mongo.ErrNoDocuments if the collection doesn't
+// exist or the user has no access.
+func (service *Service) GetCollectionAccess(ctx context.Context, userId string, collectionId uuid.UUID) (*CollectionAccess, error) {
+	collections := service.mongo.Database(DatabaseName).Collection(CollectionCollections)
+
+	var collection Collection
+	err := collections.FindOne(ctx, bson.M{
+		"_id": collectionId,
+	}).Decode(&collection)
+	if err != nil {
+		return nil, err
+	}
+
+	if collection.UserId == userId {
+		return &CollectionAccess{
+			Collection: &collection,
+			Role:       RoleOwner,
+		}, nil
+	}
+
+	grants := service.mongo.Database(DatabaseName).Collection(CollectionAccessGrants)
+
+	var grant AccessGrant
+	err = grants.FindOne(ctx, bson.M{
+		"collection_id": collectionId,
+		"owner_id":      collection.UserId,
+		"grantee_id":    userId,
+	}).Decode(&grant)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CollectionAccess{
+		Collection: &collection,
+		Role:       grant.Role,
+	}, nil
+}
+
+// readableFilter returns the conditions of the documents the user can read, to be
+// combined with $or: the documents of the user and of the collections shared with
+// the user. Grants are only valid for the documents of their owner.
+func (service *Service) readableFilter(ctx context.Context, userId string) (bson.A, error) {
+	grants := service.mongo.Database(DatabaseName).Collection(CollectionAccessGrants)
+
+	cursor, err := grants.Find(ctx, bson.M{
+		"grantee_id": userId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var granted []AccessGrant
+	err = cursor.All(ctx, &granted)
+	if err != nil {
+		return nil, err
+	}
+
+	conditions := bson.A{bson.M{"user_id": userId}}
+	for _, grant := range granted {
+		conditions = append(conditions, bson.M{
+			"user_id":       grant.OwnerId,
+			"collection_id": grant.CollectionId,
+		})
+	}
+
+	return conditions, nil
+}
+
+// SharedCollection is a collection of another user that was shared with the user.
+type SharedCollection struct {
+	Collection `bson:",inline"`
+
+	// Role of the user in the collection
+	Role string `bson:"-"`
+}
+
+// GetSharedCollections returns the collections shared with the user that match
+// the filter.
+func (service *Service) GetSharedCollections(ctx context.Context, userId string, filter CollectionFilter) ([]SharedCollection, error) {
+	grants := service.mongo.Database(DatabaseName).Collection(CollectionAccessGrants)
+
+	cursor, err := grants.Find(ctx, bson.M{
+		"grantee_id": userId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var granted []AccessGrant
+	err = cursor.All(ctx, &granted)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(granted) == 0 {
+		return nil, nil
+	}
+
+	// Grants are only valid for the collection of their owner
+	roles := make(map[uuid.UUID]string)
+	owners := make(map[uuid.UUID]string)
+	ids := make([]uuid.UUID, len(granted))
+	for idx, grant := range granted {
+		roles[grant.CollectionId] = grant.Role
+		owners[grant.CollectionId] = grant.OwnerId
+		ids[idx] = grant.CollectionId
+	}
+
+	collections := service.mongo.Database(DatabaseName).Collection(CollectionCollections)
+	cursor, err = collections.Find(ctx, filter.apply(bson.M{
+		"_id": bson.M{"$in": ids},
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	var found []Collection
+	err = cursor.All(ctx, &found)
+	if err != nil {
+		return nil, err
+	}
+
+	var shared []SharedCollection
+	for _, collection := range found {
+		if owners[collection.Id] != collection.UserId {
+			continue
+		}
+
+		shared = append(shared, SharedCollection{
+			Collection: collection,
+			Role:       roles[collection.Id],
+		})
+	}
+
+	return shared, nil
+}
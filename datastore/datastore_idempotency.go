@@ -0,0 +1,146 @@
+package datastore
+
+import (
+	"context"
+	"errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+// IdempotencyTTL defines how long a response is kept for repeated requests.
+const IdempotencyTTL = 24 * time.Hour
+
+// IdempotencyLease defines how long a request in progress holds its key. The key
+// of a request that crashed before it completed or released the key can be
+// reserved again after the lease.
+const IdempotencyLease = 10 * time.Minute
+
+// IdempotencyRecord stores the response of a request with an idempotency key.
+type IdempotencyRecord struct {
+	// ID is the scoped key of the user
+	Id string `bson:"_id,omitempty"`
+
+	// User ID
+	UserId string `bson:"user_id,omitempty"`
+
+	// CreatedAt is the time the key was reserved
+	CreatedAt time.Time `bson:"created_at,omitempty"`
+
+	// LeaseUntil is the time until the request in progress holds the key
+	LeaseUntil time.Time `bson:"lease_until,omitempty"`
+
+	// RequestHash identifies the request, a key can't be reused for other requests
+	RequestHash string `bson:"request_hash,omitempty"`
+
+	// Response is empty while the request is in progress
+	Response []byte `bson:"response,omitempty"`
+}
+
+// stale reports whether the record can be replaced by a new reservation, because
+// it expired or its request didn't complete within the lease.
+func (record *IdempotencyRecord) stale(now time.Time) bool {
+	if now.Sub(record.CreatedAt) > IdempotencyTTL {
+		return true
+	}
+
+	return len(record.Response) == 0 && now.After(record.LeaseUntil)
+}
+
+func idempotencyId(userId, key string) string {
+	return userId + "/" + key
+}
+
+// ensureIdempotencyIndex creates the index that removes expired records.
+func (service *Service) ensureIdempotencyIndex(ctx context.Context) error {
+	coll := service.mongo.Database(DatabaseName).Collection(CollectionIdempotency)
+
+	_, err := coll.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.M{"created_at": 1},
+		Options: options.Index().SetExpireAfterSeconds(int32(IdempotencyTTL.Seconds())),
+	})
+
+	return err
+}
+
+// ReserveIdempotencyKey reserves the key of a user for the request with the hash. If
+// the key was already used within the IdempotencyTTL, the existing record is returned
+// and reserved is false. Keys of requests in progress are reserved again once their
+// IdempotencyLease is over.
+func (service *Service) ReserveIdempotencyKey(ctx context.Context, userId, key, requestHash string) (record *IdempotencyRecord, reserved bool, err error) {
+	err = service.idempotencyIndex.ensure(func() error {
+		return service.ensureIdempotencyIndex(ctx)
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	coll := service.mongo.Database(DatabaseName).Collection(CollectionIdempotency)
+
+	now := time.Now()
+	record = &IdempotencyRecord{
+		Id:          idempotencyId(userId, key),
+		UserId:      userId,
+		CreatedAt:   now,
+		LeaseUntil:  now.Add(IdempotencyLease),
+		RequestHash: requestHash,
+	}
+
+	_, err = coll.InsertOne(ctx, record)
+	if err == nil {
+		return record, true, nil
+	}
+
+	if !mongo.IsDuplicateKeyError(err) {
+		return nil, false, err
+	}
+
+	var existing IdempotencyRecord
+	err = coll.FindOne(ctx, bson.M{"_id": record.Id}).Decode(&existing)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		// The record expired in the meantime
+		return service.ReserveIdempotencyKey(ctx, userId, key, requestHash)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	if existing.stale(time.Now()) {
+		// Expired records are removed by the TTL monitor with a delay. Only the
+		// reservation that was read is removed, so that a concurrent one wins
+		_, err = coll.DeleteOne(ctx, bson.M{"_id": record.Id, "created_at": existing.CreatedAt})
+		if err != nil {
+			return nil, false, err
+		}
+
+		return service.ReserveIdempotencyKey(ctx, userId, key, requestHash)
+	}
+
+	return &existing, false, nil
+}
+
+// CompleteIdempotencyKey stores the response of the request that reserved the record.
+// Records that were reserved again after the lease are not overwritten.
+func (service *Service) CompleteIdempotencyKey(ctx context.Context, record *IdempotencyRecord, response []byte) error {
+	coll := service.mongo.Database(DatabaseName).Collection(CollectionIdempotency)
+
+	_, err := coll.UpdateOne(ctx, bson.M{"_id": record.Id, "created_at": record.CreatedAt}, bson.M{
+		"$set": bson.M{"response": response},
+	})
+
+	return err
+}
+
+// ReleaseIdempotencyKey removes the reservation of a key, so that a failed request can be retried.
+func (service *Service) ReleaseIdempotencyKey(ctx context.Context, record *IdempotencyRecord) error {
+	coll := service.mongo.Database(DatabaseName).Collection(CollectionIdempotency)
+
+	_, err := coll.DeleteOne(ctx, bson.M{
+		"_id":        record.Id,
+		"created_at": record.CreatedAt,
+		"response":   bson.M{"$exists": false},
+	})
+
+	return err
+}
@@ -0,0 +1,27 @@
+package datastore
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIdempotencyRecordStale(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name   string
+		record IdempotencyRecord
+		stale  bool
+	}{
+		{"in progress", IdempotencyRecord{CreatedAt: now, LeaseUntil: now.Add(time.Minute)}, false},
+		{"lease over", IdempotencyRecord{CreatedAt: now.Add(-IdempotencyLease), LeaseUntil: now.Add(-time.Second)}, true},
+		{"completed", IdempotencyRecord{CreatedAt: now.Add(-time.Hour), LeaseUntil: now.Add(-time.Minute), Response: []byte("ok")}, false},
+		{"expired", IdempotencyRecord{CreatedAt: now.Add(-IdempotencyTTL - time.Second), Response: []byte("ok")}, true},
+	}
+
+	for _, tt := range tests {
+		if got := tt.record.stale(now); got != tt.stale {
+			t.Errorf("%s: stale = %v, want %v", tt.name, got, tt.stale)
+		}
+	}
+}
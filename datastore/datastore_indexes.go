@@ -0,0 +1,30 @@
+package datastore
+
+import (
+	"sync"
+)
+
+// lazyIndex creates indexes on first use. Unlike sync.Once, a failed attempt,
+// e.g. of a canceled request, is retried by the next call.
+type lazyIndex struct {
+	mu      sync.Mutex
+	created bool
+}
+
+// ensure calls create until it succeeds once.
+func (index *lazyIndex) ensure(create func() error) error {
+	index.mu.Lock()
+	defer index.mu.Unlock()
+
+	if index.created {
+		return nil
+	}
+
+	err := create()
+	if err != nil {
+		return err
+	}
+
+	index.created = true
+	return nil
+}
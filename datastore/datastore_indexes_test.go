@@ -0,0 +1,31 @@
+package datastore
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLazyIndex(t *testing.T) {
+	var index lazyIndex
+	calls := 0
+
+	failing := func() error {
+		calls++
+		return errors.New("canceled")
+	}
+	if err := index.ensure(failing); err == nil {
+		t.Fatal("expected the error of the first attempt")
+	}
+
+	succeeding := func() error {
+		calls++
+		return nil
+	}
+	if err := index.ensure(succeeding); err != nil {
+		t.Fatalf("expected the retry to succeed, got %v", err)
+	}
+
+	if err := index.ensure(failing); err != nil || calls != 2 {
+		t.Fatalf("expected no attempt after success, got %v after %d calls", err, calls)
+	}
+}
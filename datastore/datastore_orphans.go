@@ -0,0 +1,96 @@
+package datastore
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// missingBatchSize limits the ids of a single query of MissingDocuments.
+const missingBatchSize = 1000
+
+// MissingDocuments returns the ids of the documents that don't exist. The ids are
+// queried in batches to keep the queries small.
+func (service *Service) MissingDocuments(ctx context.Context, ids ...uuid.UUID) ([]uuid.UUID, error) {
+	coll := service.mongo.Database(DatabaseName).Collection(CollectionDokuments)
+
+	opts := &options.FindOptions{
+		Projection: bson.M{"_id": 1},
+	}
+
+	exists := make(map[uuid.UUID]bool)
+	for start := 0; start < len(ids); start += missingBatchSize {
+		batch := ids[start:min(start+missingBatchSize, len(ids))]
+
+		cursor, err := coll.Find(ctx, bson.M{
+			"_id": bson.M{"$in": batch},
+		}, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		var found []Document
+		err = cursor.All(ctx, &found)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, doc := range found {
+			exists[doc.Id] = true
+		}
+	}
+
+	var missing []uuid.UUID
+	for _, id := range ids {
+		if !exists[id] {
+			missing = append(missing, id)
+		}
+	}
+
+	return missing, nil
+}
+
+// OrphanedTexts returns the ids of the stored document texts without a document.
+func (service *Service) OrphanedTexts(ctx context.Context) ([]uuid.UUID, error) {
+	coll := service.mongo.Database(DatabaseName).Collection(CollectionDocumentTexts)
+
+	cursor, err := coll.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$project", Value: bson.M{"_id": 1}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         CollectionDokuments,
+			"localField":   "_id",
+			"foreignField": "_id",
+			"as":           "document",
+		}}},
+		{{Key: "$match", Value: bson.M{"document": bson.M{"$size": 0}}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var texts []DocumentText
+	err = cursor.All(ctx, &texts)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, len(texts))
+	for idx, text := range texts {
+		ids[idx] = text.Id
+	}
+
+	return ids, nil
+}
+
+// DeleteTexts deletes stored document texts regardless of their user.
+func (service *Service) DeleteTexts(ctx context.Context, ids ...uuid.UUID) error {
+	coll := service.mongo.Database(DatabaseName).Collection(CollectionDocumentTexts)
+
+	_, err := coll.DeleteMany(ctx, bson.M{
+		"_id": bson.M{"$in": ids},
+	})
+
+	return err
+}
@@ -0,0 +1,90 @@
+package datastore
+
+import (
+	"go.mongodb.org/mongo-driver/event"
+	"sync/atomic"
+	"time"
+)
+
+// PoolConfig configures the connection pool of the database client. Zero values use the driver defaults.
+type PoolConfig struct {
+	// MaxPoolSize is the maximum number of connections per server
+	MaxPoolSize uint64
+
+	// MinPoolSize is the number of idle connections kept open per server
+	MinPoolSize uint64
+
+	// MaxConnIdleTime is the time after which idle connections are closed
+	MaxConnIdleTime time.Duration
+}
+
+// PoolStats contains the state of the connection pool.
+type PoolStats struct {
+	// Total number of open connections
+	Total int64 `json:"total"`
+
+	// Number of connections in use
+	Acquired int64 `json:"acquired"`
+
+	// Number of open connections not in use
+	Idle int64 `json:"idle"`
+
+	// Number of check-outs that had to wait for a connection
+	WaitCount int64 `json:"wait_count"`
+
+	// Accumulated time spent waiting for connections
+	WaitDuration time.Duration `json:"wait_duration"`
+
+	// Number of check-outs that failed, e.g. due to a timeout
+	FailedCount int64 `json:"failed_count"`
+}
+
+// poolMonitor records connection pool events.
+type poolMonitor struct {
+	total        atomic.Int64
+	acquired     atomic.Int64
+	waitCount    atomic.Int64
+	waitDuration atomic.Int64
+	failedCount  atomic.Int64
+}
+
+// waitThreshold defines from when on a check-out counts as waiting for a connection.
+const waitThreshold = time.Millisecond
+
+func (monitor *poolMonitor) event(evt *event.PoolEvent) {
+	switch evt.Type {
+	case event.ConnectionCreated:
+		monitor.total.Add(1)
+	case event.ConnectionClosed:
+		monitor.total.Add(-1)
+	case event.GetSucceeded:
+		monitor.acquired.Add(1)
+		if evt.Duration >= waitThreshold {
+			monitor.waitCount.Add(1)
+			monitor.waitDuration.Add(int64(evt.Duration))
+		}
+	case event.GetFailed:
+		monitor.failedCount.Add(1)
+		monitor.waitDuration.Add(int64(evt.Duration))
+	case event.ConnectionReturned:
+		monitor.acquired.Add(-1)
+	}
+}
+
+func (monitor *poolMonitor) stats() PoolStats {
+	stats := PoolStats{
+		Total:        monitor.total.Load(),
+		Acquired:     monitor.acquired.Load(),
+		WaitCount:    monitor.waitCount.Load(),
+		WaitDuration: time.Duration(monitor.waitDuration.Load()),
+		FailedCount:  monitor.failedCount.Load(),
+	}
+	stats.Idle = max(stats.Total-stats.Acquired, 0)
+
+	return stats
+}
+
+// PoolStats returns the current state of the connection pool.
+func (service *Service) PoolStats() PoolStats {
+	return service.monitor.stats()
+}
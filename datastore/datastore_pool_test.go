@@ -0,0 +1,49 @@
+package datastore
+
+import (
+	"go.mongodb.org/mongo-driver/event"
+	"testing"
+	"time"
+)
+
+func TestPoolMonitor(t *testing.T) {
+	monitor := &poolMonitor{}
+
+	events := []*event.PoolEvent{
+		{Type: event.ConnectionCreated},
+		{Type: event.ConnectionCreated},
+		{Type: event.ConnectionCreated},
+		{Type: event.GetSucceeded, Duration: time.Microsecond},
+		{Type: event.GetSucceeded, Duration: 5 * time.Millisecond},
+		{Type: event.ConnectionReturned},
+		{Type: event.GetFailed, Duration: 2 * time.Millisecond},
+		{Type: event.ConnectionClosed},
+	}
+	for _, evt := range events {
+		monitor.event(evt)
+	}
+
+	stats := monitor.stats()
+	want := PoolStats{
+		Total:        2,
+		Acquired:     1,
+		Idle:         1,
+		WaitCount:    1,
+		WaitDuration: 7 * time.Millisecond,
+		FailedCount:  1,
+	}
+	if stats != want {
+		t.Fatalf("expected %+v, got %+v", want, stats)
+	}
+}
+
+func TestPoolConfigFromEnv(t *testing.T) {
+	t.Setenv("CHATBOT_MONGODB_MAX_POOL_SIZE", "50")
+	t.Setenv("CHATBOT_MONGODB_MIN_POOL_SIZE", "invalid")
+	t.Setenv("CHATBOT_MONGODB_MAX_IDLE_TIME", "90s")
+
+	pool := poolConfigFromEnv()
+	if pool.MaxPoolSize != 50 || pool.MinPoolSize != 0 || pool.MaxConnIdleTime != 90*time.Second {
+		t.Fatalf("unexpected pool config %+v", pool)
+	}
+}
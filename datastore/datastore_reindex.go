@@ -0,0 +1,59 @@
+package datastore
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+// States of a reindex run
+const (
+	ReindexRunning = "running"
+	ReindexDone    = "done"
+	ReindexFailed  = "failed"
+)
+
+// ReindexRun is the progress of a reembedding of all documents into the search
+// index. It's updated after each batch, so that the progress can be queried
+// while the run is in progress.
+type ReindexRun struct {
+	// ID of the run
+	Id uuid.UUID `bson:"_id,omitempty"`
+
+	// State is one of ReindexRunning, ReindexDone or ReindexFailed
+	State string `bson:"state,omitempty"`
+
+	// TotalChunks of the documents to reembed, ProcessedChunks includes the failed ones
+	TotalChunks     int `bson:"total_chunks"`
+	ProcessedChunks int `bson:"processed_chunks"`
+	FailedChunks    int `bson:"failed_chunks"`
+
+	// Rate is the average number of processed chunks per second of the run
+	Rate float64 `bson:"rate"`
+
+	StartedAt time.Time `bson:"started_at,omitempty"`
+	UpdatedAt time.Time `bson:"updated_at,omitempty"`
+
+	// EstimatedCompletion is zero until the rate is known
+	EstimatedCompletion time.Time `bson:"estimated_completion,omitempty"`
+
+	// Error of a failed run
+	Error string `bson:"error,omitempty"`
+}
+
+// GetLatestReindexRun returns the most recently started reindex run. It returns
+// mongo.ErrNoDocuments if there was no run.
+func (service *Service) GetLatestReindexRun(ctx context.Context) (*ReindexRun, error) {
+	coll := service.mongo.Database(DatabaseName).Collection(CollectionReindexRuns)
+
+	var run ReindexRun
+	opts := options.FindOne().SetSort(bson.M{"started_at": -1})
+	err := coll.FindOne(ctx, bson.M{}, opts).Decode(&run)
+	if err != nil {
+		return nil, err
+	}
+
+	return &run, nil
+}
@@ -0,0 +1,161 @@
+package datastore
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+// CollectionStats summarizes the documents of a collection.
+type CollectionStats struct {
+	Documents int `bson:"documents"`
+	Chunks    int `bson:"chunks"`
+
+	// StorageSize is the size of the uploaded files and the estimated size of
+	// the stored documents and texts in bytes
+	StorageSize int64 `bson:"-"`
+
+	// Tokens is the number of tokens embedded while indexing the documents
+	Tokens int64 `bson:"tokens"`
+
+	// LastIndexedAt is the time the latest document was indexed, zero if unknown
+	LastIndexedAt time.Time `bson:"last_indexed_at"`
+}
+
+// ensureStatsIndexes creates the indexes that keep the statistics from scanning
+// all documents, texts and usages.
+func (service *Service) ensureStatsIndexes(ctx context.Context) error {
+	return service.statsIndexes.ensure(func() error {
+		db := service.mongo.Database(DatabaseName)
+		byCollection := mongo.IndexModel{
+			Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "collection_id", Value: 1}},
+		}
+
+		_, err := db.Collection(CollectionDokuments).Indexes().CreateOne(ctx, byCollection)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Collection(CollectionDocumentTexts).Indexes().CreateOne(ctx, byCollection)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Collection(CollectionModelUsages).Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys:    bson.M{"document_id": 1},
+			Options: options.Index().SetSparse(true),
+		})
+
+		return err
+	})
+}
+
+// averageSize returns the average size in bytes of the entries of a database
+// collection, zero if it is empty.
+func (service *Service) averageSize(ctx context.Context, name string) (int64, error) {
+	cursor, err := service.mongo.Database(DatabaseName).Collection(name).Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$collStats", Value: bson.M{"storageStats": bson.M{}}}},
+		{{Key: "$project", Value: bson.M{"avg_size": "$storageStats.avgObjSize"}}},
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	// Sharded collections have an entry per shard
+	var shards []struct {
+		AvgSize float64 `bson:"avg_size"`
+	}
+	err = cursor.All(ctx, &shards)
+	if err != nil || len(shards) == 0 {
+		return 0, err
+	}
+
+	var sum float64
+	for _, shard := range shards {
+		sum += shard.AvgSize
+	}
+
+	return int64(sum / float64(len(shards))), nil
+}
+
+// GetCollectionStats aggregates the documents of a collection owned by the user
+// and the embedding usage of their indexing. The size of the stored documents and
+// texts is estimated from the average size of the database entries, so that they
+// don't have to be read.
+func (service *Service) GetCollectionStats(ctx context.Context, userId string, collectionId uuid.UUID) (*CollectionStats, error) {
+	err := service.ensureStatsIndexes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	db := service.mongo.Database(DatabaseName)
+	filter := bson.M{
+		"user_id":       userId,
+		"collection_id": collectionId,
+	}
+
+	cursor, err := db.Collection(CollectionDokuments).Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$project", Value: bson.M{
+			"chunks":     bson.M{"$size": bson.M{"$ifNull": bson.A{"$content", bson.A{}}}},
+			"file_size":  1,
+			"indexed_at": 1,
+		}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         CollectionModelUsages,
+			"localField":   "_id",
+			"foreignField": "document_id",
+			"as":           "usages",
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":             nil,
+			"documents":       bson.M{"$sum": 1},
+			"chunks":          bson.M{"$sum": "$chunks"},
+			"file_size":       bson.M{"$sum": "$file_size"},
+			"tokens":          bson.M{"$sum": bson.M{"$sum": "$usages.input_tokens"}},
+			"last_indexed_at": bson.M{"$max": "$indexed_at"},
+		}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var groups []struct {
+		CollectionStats `bson:",inline"`
+		FileSize        int64 `bson:"file_size"`
+	}
+	err = cursor.All(ctx, &groups)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(groups) == 0 {
+		return &CollectionStats{}, nil
+	}
+
+	stats := groups[0].CollectionStats
+
+	texts, err := db.Collection(CollectionDocumentTexts).CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	documentSize, err := service.averageSize(ctx, CollectionDokuments)
+	if err != nil {
+		return nil, err
+	}
+
+	textSize, err := service.averageSize(ctx, CollectionDocumentTexts)
+	if err != nil {
+		return nil, err
+	}
+
+	stats.StorageSize = groups[0].FileSize + int64(stats.Documents)*documentSize + texts*textSize
+
+	return &stats, nil
+}
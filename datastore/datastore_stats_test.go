@@ -0,0 +1,78 @@
+package datastore
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestGetCollectionStats(t *testing.T) {
+	uri := os.Getenv("CHATBOT_MONGODB_URI")
+	if uri == "" {
+		t.Skip("CHATBOT_MONGODB_URI not set")
+	}
+
+	ctx := context.Background()
+
+	db, err := NewFrom(ctx, uri, PoolConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	userId := "test-" + uuid.NewString()
+	collection := &Collection{Id: uuid.New(), UserId: userId, Name: "stats"}
+
+	err = db.InsertCollection(ctx, collection)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = db.DeleteCollection(ctx, userId, collection.Id) }()
+
+	for idx := 0; idx < 2; idx++ {
+		doc := &Document{
+			Id:           uuid.New(),
+			UserId:       userId,
+			CollectionId: collection.Id,
+			Type:         DocumentTypePDF,
+			FileSize:     1000,
+			IndexedAt:    time.Now(),
+			Content: []*DocumentChunk{
+				{Id: uuid.New(), Text: "first page", Position: 0},
+				{Id: uuid.New(), Text: "second page", Position: 1},
+			},
+		}
+
+		err = db.InsertDocument(ctx, doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = db.InsertModelUsage(ctx, &ModelUsage{
+			Id:          uuid.New(),
+			UserId:      userId,
+			Timestamp:   time.Now(),
+			InputTokens: 25,
+			DocumentId:  doc.Id,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stats, err := db.GetCollectionStats(ctx, userId, collection.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if stats.Documents != 2 || stats.Chunks != 4 || stats.Tokens != 50 {
+		t.Fatalf("expected 2 documents, 4 chunks and 50 tokens, got %+v", stats)
+	}
+
+	// The files are counted in full, the documents are estimated
+	if stats.StorageSize <= 2000 {
+		t.Fatalf("expected the file sizes and the documents in the storage size, got %d", stats.StorageSize)
+	}
+}
@@ -0,0 +1,69 @@
+package datastore
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DocumentText is the full text extracted from a document while indexing. It is
+// stored separately from the document to keep the document queries small.
+type DocumentText struct {
+	// ID of the document
+	Id uuid.UUID `bson:"_id,omitempty"`
+
+	// User ID
+	UserId string `bson:"user_id,omitempty"`
+
+	// Collection ID
+	CollectionId uuid.UUID `bson:"collection_id,omitempty"`
+
+	// Text of the whole document
+	Text string `bson:"text,omitempty"`
+
+	// Spans of the chunks in the text
+	Chunks []ChunkSpan `bson:"chunks,omitempty"`
+}
+
+// ChunkSpan locates a document chunk in the document text.
+type ChunkSpan struct {
+	// ID of the document chunk
+	Id uuid.UUID `bson:"id,omitempty"`
+
+	// Start and End are the byte offsets of the chunk in the text
+	Start uint32 `bson:"start"`
+	End   uint32 `bson:"end"`
+}
+
+// StoreDocumentText inserts or replaces the text of a document.
+func (service *Service) StoreDocumentText(ctx context.Context, text *DocumentText) error {
+	coll := service.mongo.Database(DatabaseName).Collection(CollectionDocumentTexts)
+
+	_, err := coll.ReplaceOne(ctx, bson.M{
+		"_id":     text.Id,
+		"user_id": text.UserId,
+	}, text, options.Replace().SetUpsert(true))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetDocumentText returns the text of a document. It returns mongo.ErrNoDocuments
+// for documents indexed before the texts were stored.
+func (service *Service) GetDocumentText(ctx context.Context, userId string, id uuid.UUID) (*DocumentText, error) {
+	coll := service.mongo.Database(DatabaseName).Collection(CollectionDocumentTexts)
+
+	var text DocumentText
+	err := coll.FindOne(ctx, bson.M{
+		"_id":     id,
+		"user_id": userId,
+	}).Decode(&text)
+	if err != nil {
+		return nil, err
+	}
+
+	return &text, nil
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/pzierahn/chatbot_services/llm"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"time"
 )
@@ -20,14 +21,27 @@ type Thread struct {
 	// Collection ID
 	CollectionId uuid.UUID `bson:"collection_id,omitempty"`
 
+	// Title of the thread, optional
+	Title string `bson:"title,omitempty"`
+
 	// Timestamp of the last message
 	Timestamp time.Time `bson:"timestamp,omitempty"`
 
 	// Messages
 	Messages []*llm.Message `bson:"messages,omitempty"`
+
+	// CitationStyle of the last prompt, used for all messages of the thread
+	CitationStyle string `bson:"citation_style,omitempty"`
+
+	// Pending messages are added to the next prompt. They are only changed by
+	// AppendPending and RemovePending, so that concurrent appends aren't lost
+	Pending []*llm.Message `bson:"pending,omitempty"`
 }
 
-// StoreThread stores a thread
+// ErrPendingLimit is returned if appended messages exceed the limit of pending messages.
+var ErrPendingLimit = errors.New("too many pending messages")
+
+// StoreThread stores a thread without its pending messages.
 func (service *Service) StoreThread(ctx context.Context, thread *Thread) error {
 	coll := service.mongo.Database(DatabaseName).Collection(CollectionThreads)
 
@@ -39,8 +53,11 @@ func (service *Service) StoreThread(ctx context.Context, thread *Thread) error {
 		"_id": thread.Id,
 	}
 
+	stored := *thread
+	stored.Pending = nil
+
 	update := bson.M{
-		"$set": thread,
+		"$set": &stored,
 	}
 
 	opts := options.Update().SetUpsert(true)
@@ -70,6 +87,92 @@ func (service *Service) GetThread(ctx context.Context, userId string, threadId u
 	return &thread, nil
 }
 
+// GetThreadCollection returns the collection id of a thread without loading its
+// messages. It returns mongo.ErrNoDocuments if the thread doesn't exist.
+func (service *Service) GetThreadCollection(ctx context.Context, userId string, threadId uuid.UUID) (uuid.UUID, error) {
+	coll := service.mongo.Database(DatabaseName).Collection(CollectionThreads)
+
+	filter := bson.M{
+		"_id":     threadId,
+		"user_id": userId,
+	}
+
+	opts := options.FindOne().SetProjection(bson.M{"collection_id": 1})
+
+	var thread Thread
+	err := coll.FindOne(ctx, filter, opts).Decode(&thread)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return thread.CollectionId, nil
+}
+
+// AppendPending adds messages to the pending messages of a thread, unless the thread
+// would have more than limit pending messages. The limit is part of the update, so
+// that concurrent appends can't exceed it. It returns mongo.ErrNoDocuments if the
+// user has no such thread and ErrPendingLimit if the limit would be exceeded.
+func (service *Service) AppendPending(ctx context.Context, userId string, threadId uuid.UUID, messages []*llm.Message, limit int) error {
+	coll := service.mongo.Database(DatabaseName).Collection(CollectionThreads)
+
+	filter := bson.M{
+		"_id":     threadId,
+		"user_id": userId,
+		"$expr": bson.M{"$lte": bson.A{
+			bson.M{"$size": bson.M{"$ifNull": bson.A{"$pending", bson.A{}}}},
+			limit - len(messages),
+		}},
+	}
+
+	// Pending is null in threads stored by older versions, so it can't be pushed
+	// to. The messages are literals, so that contents starting with $ aren't field paths
+	result, err := coll.UpdateOne(ctx, filter, mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"pending": bson.M{"$concatArrays": bson.A{
+				bson.M{"$ifNull": bson.A{"$pending", bson.A{}}},
+				bson.M{"$literal": messages},
+			}},
+		}}},
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount > 0 {
+		return nil
+	}
+
+	count, err := coll.CountDocuments(ctx, bson.M{"_id": threadId, "user_id": userId})
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	return ErrPendingLimit
+}
+
+// RemovePending removes the pending messages with the ids, e.g. after they were
+// added to a prompt. Messages appended in the meantime are kept. Messages without
+// id, appended by older versions, are removed with any prompt.
+func (service *Service) RemovePending(ctx context.Context, threadId uuid.UUID, ids ...string) error {
+	coll := service.mongo.Database(DatabaseName).Collection(CollectionThreads)
+
+	_, err := coll.UpdateOne(ctx, bson.M{
+		"_id":       threadId,
+		"pending.0": bson.M{"$exists": true},
+	}, bson.M{
+		"$pull": bson.M{"pending": bson.M{"$or": bson.A{
+			bson.M{"id": bson.M{"$in": append([]string{}, ids...)}},
+			bson.M{"id": bson.M{"$exists": false}},
+		}}},
+	})
+
+	return err
+}
+
 // GetThreadIDs returns all thread IDs of a collection for a user
 func (service *Service) GetThreadIDs(ctx context.Context, userId string, collectionId uuid.UUID) ([]uuid.UUID, error) {
 	coll := service.mongo.Database(DatabaseName).Collection(CollectionThreads)
@@ -109,7 +212,7 @@ func (service *Service) GetThreadIDs(ctx context.Context, userId string, collect
 	return result, nil
 }
 
-// DeleteThread deletes a thread by ID
+// DeleteThread deletes a thread by ID together with the feedback for its messages
 func (service *Service) DeleteThread(ctx context.Context, userId string, threadId uuid.UUID) error {
 	coll := service.mongo.Database(DatabaseName).Collection(CollectionThreads)
 
@@ -119,5 +222,9 @@ func (service *Service) DeleteThread(ctx context.Context, userId string, threadI
 	}
 
 	_, err := coll.DeleteOne(ctx, filter)
-	return err
+	if err != nil {
+		return err
+	}
+
+	return service.deleteFeedback(ctx, userId, threadId)
 }
@@ -0,0 +1,136 @@
+package datastore
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/llm"
+	"go.mongodb.org/mongo-driver/mongo"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestThread(t *testing.T) (*Service, *Thread) {
+	uri := os.Getenv("CHATBOT_MONGODB_URI")
+	if uri == "" {
+		t.Skip("CHATBOT_MONGODB_URI not set")
+	}
+
+	ctx := context.Background()
+
+	db, err := NewFrom(ctx, uri, PoolConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(db.Close)
+
+	thread := &Thread{
+		Id:           uuid.New(),
+		UserId:       "test-" + uuid.NewString(),
+		CollectionId: uuid.New(),
+		Timestamp:    time.Now(),
+	}
+
+	err = db.StoreThread(ctx, thread)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.DeleteThread(ctx, thread.UserId, thread.Id) })
+
+	return db, thread
+}
+
+func pendingMessage(content string) *llm.Message {
+	return &llm.Message{Id: uuid.NewString(), Role: llm.RoleUser, Content: content}
+}
+
+func TestAppendPendingConcurrently(t *testing.T) {
+	db, thread := newTestThread(t)
+	ctx := context.Background()
+
+	const limit = 5
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 2*limit)
+	for idx := 0; idx < 2*limit; idx++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- db.AppendPending(ctx, thread.UserId, thread.Id, []*llm.Message{pendingMessage("context")}, limit)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	var appended, rejected int
+	for err := range errs {
+		switch {
+		case err == nil:
+			appended++
+		case errors.Is(err, ErrPendingLimit):
+			rejected++
+		default:
+			t.Fatal(err)
+		}
+	}
+
+	stored, err := db.GetThread(ctx, thread.UserId, thread.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if appended != limit || rejected != limit || len(stored.Pending) != limit {
+		t.Fatalf("expected %d appended messages, got %d appended, %d rejected and %d stored",
+			limit, appended, rejected, len(stored.Pending))
+	}
+}
+
+func TestStoreThreadKeepsPending(t *testing.T) {
+	db, thread := newTestThread(t)
+	ctx := context.Background()
+
+	consumed := pendingMessage("consumed")
+	err := db.AppendPending(ctx, thread.UserId, thread.Id, []*llm.Message{consumed}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A prompt loads the thread while another message is appended
+	loaded, err := db.GetThread(ctx, thread.UserId, thread.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	appended := pendingMessage("appended")
+	err = db.AppendPending(ctx, thread.UserId, thread.Id, []*llm.Message{appended}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded.Messages = []*llm.Message{{Role: llm.RoleUser, Content: "prompt"}}
+	err = db.StoreThread(ctx, loaded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = db.RemovePending(ctx, thread.Id, consumed.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stored, err := db.GetThread(ctx, thread.UserId, thread.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(stored.Pending) != 1 || stored.Pending[0].Id != appended.Id {
+		t.Fatalf("expected only the appended message to be pending, got %+v", stored.Pending)
+	}
+
+	err = db.AppendPending(ctx, uuid.NewString(), thread.Id, []*llm.Message{pendingMessage("other")}, 10)
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		t.Fatalf("expected ErrNoDocuments for threads of other users, got %v", err)
+	}
+}
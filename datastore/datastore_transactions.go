@@ -0,0 +1,57 @@
+package datastore
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// WithTransaction runs fn in a transaction. The context passed to fn must be used
+// for all queries of the transaction. Transactions require a replica set.
+func (service *Service) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	session, err := service.mongo.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(ctx mongo.SessionContext) (interface{}, error) {
+		return nil, fn(ctx)
+	})
+
+	return err
+}
+
+// SupportsTransactions reports whether the server is a replica set member or a
+// sharded cluster router, which support transactions. The answer is cached after
+// the first successful check.
+func (service *Service) SupportsTransactions(ctx context.Context) (bool, error) {
+	service.topology.mu.Lock()
+	defer service.topology.mu.Unlock()
+
+	if service.topology.checked {
+		return service.topology.transactions, nil
+	}
+
+	var hello bson.M
+	err := service.mongo.Database("admin").RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello)
+	if err != nil {
+		return false, err
+	}
+
+	service.topology.transactions = transactionsSupported(hello)
+	service.topology.checked = true
+
+	return service.topology.transactions, nil
+}
+
+// transactionsSupported reports whether the reply of a hello command comes from a
+// replica set member or a mongos. Standalone servers don't support transactions.
+func transactionsSupported(hello bson.M) bool {
+	if name, ok := hello["setName"].(string); ok && name != "" {
+		return true
+	}
+
+	msg, _ := hello["msg"].(string)
+	return msg == "isdbgrid"
+}
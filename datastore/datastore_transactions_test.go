@@ -0,0 +1,24 @@
+package datastore
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"testing"
+)
+
+func TestTransactionsSupported(t *testing.T) {
+	tests := []struct {
+		name  string
+		hello bson.M
+		want  bool
+	}{
+		{"standalone", bson.M{"isWritablePrimary": true}, false},
+		{"replica set", bson.M{"isWritablePrimary": true, "setName": "rs0"}, true},
+		{"mongos", bson.M{"isWritablePrimary": true, "msg": "isdbgrid"}, true},
+	}
+
+	for _, tt := range tests {
+		if got := transactionsSupported(tt.hello); got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
@@ -3,6 +3,9 @@ package datastore
 import (
 	"context"
 	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/llm"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
 	"time"
 )
 
@@ -14,12 +17,28 @@ type ModelUsage struct {
 	ModelId      string    `bson:"model_id,omitempty"`
 	InputTokens  uint32    `bson:"input_tokens,omitempty"`
 	OutputTokens uint32    `bson:"output_tokens,omitempty"`
+
+	// Costs in dollars at the time of the usage
+	Costs float64 `bson:"costs,omitempty"`
+
+	// DocumentId is set for the embeddings created while indexing a document
+	DocumentId uuid.UUID `bson:"document_id,omitempty"`
 }
 
-// InsertModelUsage inserts the given llm model usage into the database.
+// InsertModelUsage inserts the given llm model usage into the database. If the costs are not set,
+// they are estimated with the current prices, so that later price changes don't affect the usage.
 func (service *Service) InsertModelUsage(ctx context.Context, usage *ModelUsage) error {
 	coll := service.mongo.Database(DatabaseName).Collection(CollectionModelUsages)
 
+	if usage.Costs == 0 {
+		usage.Costs = llm.EstimateCost(llm.ModelUsage{
+			Model:        usage.ModelId,
+			UserId:       usage.UserId,
+			InputTokens:  usage.InputTokens,
+			OutputTokens: usage.OutputTokens,
+		})
+	}
+
 	_, err := coll.InsertOne(ctx, usage)
 	if err != nil {
 		return err
@@ -48,3 +67,141 @@ func (service *Service) GetModelUsages(ctx context.Context, userId string) ([]Mo
 
 	return usages, nil
 }
+
+// UsageAggregate contains the summed usage of a model, optionally for a single day.
+type UsageAggregate struct {
+	Day          time.Time `bson:"day,omitempty"`
+	ModelId      string    `bson:"model_id,omitempty"`
+	Requests     uint32    `bson:"requests,omitempty"`
+	InputTokens  uint32    `bson:"input_tokens,omitempty"`
+	OutputTokens uint32    `bson:"output_tokens,omitempty"`
+
+	// Costs is the sum of the persisted costs in dollars
+	Costs float64 `bson:"costs,omitempty"`
+
+	// Tokens of usages without persisted costs
+	UnpricedInputTokens  uint32 `bson:"unpriced_input_tokens,omitempty"`
+	UnpricedOutputTokens uint32 `bson:"unpriced_output_tokens,omitempty"`
+}
+
+// unpriced returns the value of the field for usages without persisted costs and 0 otherwise.
+func unpriced(field string) bson.M {
+	return bson.M{"$cond": bson.A{bson.M{"$gt": bson.A{"$costs", 0}}, 0, field}}
+}
+
+// aggregateUsage sums the usages of a user in the time range [from, to) grouped by the given keys.
+func (service *Service) aggregateUsage(ctx context.Context, userId string, from, to time.Time, group bson.M) ([]UsageAggregate, error) {
+	coll := service.mongo.Database(DatabaseName).Collection(CollectionModelUsages)
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"user_id": userId,
+			"timestamp": bson.M{
+				"$gte": from,
+				"$lt":  to,
+			},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":                    group,
+			"requests":               bson.M{"$sum": 1},
+			"input_tokens":           bson.M{"$sum": "$input_tokens"},
+			"output_tokens":          bson.M{"$sum": "$output_tokens"},
+			"costs":                  bson.M{"$sum": "$costs"},
+			"unpriced_input_tokens":  bson.M{"$sum": unpriced("$input_tokens")},
+			"unpriced_output_tokens": bson.M{"$sum": unpriced("$output_tokens")},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":                    0,
+			"day":                    "$_id.day",
+			"model_id":               "$_id.model_id",
+			"requests":               1,
+			"input_tokens":           1,
+			"output_tokens":          1,
+			"costs":                  1,
+			"unpriced_input_tokens":  1,
+			"unpriced_output_tokens": 1,
+		}}},
+		{{Key: "$sort", Value: bson.D{
+			{Key: "day", Value: 1},
+			{Key: "model_id", Value: 1},
+		}}},
+	}
+
+	cur, err := coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = cur.Close(ctx) }()
+
+	var usages []UsageAggregate
+	err = cur.All(ctx, &usages)
+	if err != nil {
+		return nil, err
+	}
+
+	return usages, nil
+}
+
+// UsageByUser returns the usage of a user in the time range [from, to) grouped by day (UTC) and model.
+func (service *Service) UsageByUser(ctx context.Context, userId string, from, to time.Time) ([]UsageAggregate, error) {
+	return service.aggregateUsage(ctx, userId, from, to, bson.M{
+		"day": bson.M{
+			"$dateTrunc": bson.M{
+				"date": "$timestamp",
+				"unit": "day",
+			},
+		},
+		"model_id": "$model_id",
+	})
+}
+
+// UsageByModel returns the usage of a user in the time range [from, to) grouped by model.
+func (service *Service) UsageByModel(ctx context.Context, userId string, from, to time.Time) ([]UsageAggregate, error) {
+	return service.aggregateUsage(ctx, userId, from, to, bson.M{
+		"model_id": "$model_id",
+	})
+}
+
+// DocumentUsage is the summed embedding usage of indexing a document, including re-indexing.
+type DocumentUsage struct {
+	DocumentId  uuid.UUID `bson:"_id,omitempty"`
+	InputTokens uint32    `bson:"input_tokens,omitempty"`
+
+	// Costs is the sum of the persisted costs in dollars
+	Costs float64 `bson:"costs,omitempty"`
+}
+
+// UsageByDocument returns the indexing usage of the documents. The usage is recorded
+// for the user that indexed a document, which may not be the owner, so callers must
+// check the access to the documents.
+func (service *Service) UsageByDocument(ctx context.Context, documentIds ...uuid.UUID) (map[uuid.UUID]DocumentUsage, error) {
+	coll := service.mongo.Database(DatabaseName).Collection(CollectionModelUsages)
+
+	cur, err := coll.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"document_id": bson.M{"$in": documentIds},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":          "$document_id",
+			"input_tokens": bson.M{"$sum": "$input_tokens"},
+			"costs":        bson.M{"$sum": "$costs"},
+		}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = cur.Close(ctx) }()
+
+	var usages []DocumentUsage
+	err = cur.All(ctx, &usages)
+	if err != nil {
+		return nil, err
+	}
+
+	byDocument := make(map[uuid.UUID]DocumentUsage)
+	for _, usage := range usages {
+		byDocument[usage.DocumentId] = usage
+	}
+
+	return byDocument, nil
+}
@@ -0,0 +1,104 @@
+package datastore
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"go.mongodb.org/mongo-driver/mongo"
+	"log"
+	"time"
+)
+
+// usageRetryDelays are the waits before the retries of a failed usage insert.
+var usageRetryDelays = []time.Duration{
+	500 * time.Millisecond,
+	time.Second,
+	2 * time.Second,
+}
+
+var (
+	usageInsertFailed       expvar.Int
+	usageInsertRetried      expvar.Int
+	usageInsertDeadLettered expvar.Int
+	usageInsertDropped      expvar.Int
+)
+
+func init() {
+	stats := expvar.NewMap("model_usage_inserts")
+	stats.Set("failed", &usageInsertFailed)
+	stats.Set("retried", &usageInsertRetried)
+	stats.Set("dead_lettered", &usageInsertDeadLettered)
+	stats.Set("dropped", &usageInsertDropped)
+}
+
+// FailedUsage is a model usage that couldn't be inserted after all retries. It is
+// kept in a separate collection to be reconciled later.
+type FailedUsage struct {
+	Usage     *ModelUsage `bson:"usage,omitempty"`
+	Error     string      `bson:"error,omitempty"`
+	Timestamp time.Time   `bson:"timestamp,omitempty"`
+}
+
+// insertUsageOnce inserts the usage. A duplicate key means that an earlier attempt
+// succeeded although it returned an error, e.g. because of a timeout.
+func (service *Service) insertUsageOnce(ctx context.Context, usage *ModelUsage) error {
+	err := service.InsertModelUsage(ctx, usage)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil
+	}
+
+	return err
+}
+
+// RecordModelUsage inserts the usage like InsertModelUsage, but doesn't return an
+// error, so that it can be used where a failed insert must not fail the request.
+// Failed inserts are retried in the background and finally stored in the
+// dead-letter collection. The usage must have an id, so that retries don't insert
+// it twice. Close waits for pending retries.
+func (service *Service) RecordModelUsage(ctx context.Context, usage *ModelUsage) {
+	ctx = context.WithoutCancel(ctx)
+
+	err := service.insertUsageOnce(ctx, usage)
+	if err == nil {
+		return
+	}
+
+	usageInsertFailed.Add(1)
+	log.Printf("failed to insert usage %s of %s for %s, retrying: %v", usage.Id, usage.ModelId, usage.UserId, err)
+
+	service.retries.Add(1)
+	go func() {
+		defer service.retries.Done()
+		service.retryModelUsage(ctx, usage)
+	}()
+}
+
+func (service *Service) retryModelUsage(ctx context.Context, usage *ModelUsage) {
+	var err error
+	for _, delay := range usageRetryDelays {
+		time.Sleep(delay)
+
+		usageInsertRetried.Add(1)
+		err = service.insertUsageOnce(ctx, usage)
+		if err == nil {
+			return
+		}
+	}
+
+	coll := service.mongo.Database(DatabaseName).Collection(CollectionFailedUsages)
+	_, deadLetterErr := coll.InsertOne(ctx, &FailedUsage{
+		Usage:     usage,
+		Error:     err.Error(),
+		Timestamp: time.Now(),
+	})
+	if deadLetterErr == nil {
+		usageInsertDeadLettered.Add(1)
+		log.Printf("stored usage %s as failed: %v", usage.Id, err)
+		return
+	}
+
+	// Keep the record in the logs as the last resort to bill it
+	usageInsertDropped.Add(1)
+	record, _ := json.Marshal(usage)
+	log.Printf("dropped usage %s: %v: %s", usage.Id, deadLetterErr, record)
+}
@@ -2,23 +2,148 @@ package anthropic
 
 import (
 	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	"github.com/pzierahn/chatbot_services/llm"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
+// invoker sends requests to the Bedrock runtime, implemented by bedrockruntime.Client.
+type invoker interface {
+	InvokeModel(ctx context.Context, params *bedrockruntime.InvokeModelInput, optFns ...func(*bedrockruntime.Options)) (*bedrockruntime.InvokeModelOutput, error)
+}
+
+// regionClient is a Bedrock client of a single region.
+type regionClient struct {
+	region  string
+	bedrock invoker
+}
+
 type Client struct {
-	bedrock *bedrockruntime.Client
+	regions []regionClient
+
+	// failover sends throttled requests to the next region
+	failover bool
+
+	// timeout limits a request in a single region including its retries
+	timeout time.Duration
 }
 
 const region = "us-west-2"
 
-func New() (*Client, error) {
-	sdkConfig, err := config.LoadDefaultConfig(context.Background(), config.WithRegion(region))
-	if err != nil {
-		return nil, err
+// DefaultMaxAttempts is the number of attempts of a Bedrock request per region, including the first one.
+const DefaultMaxAttempts = 3
+
+// Config configures the Bedrock client.
+type Config struct {
+	// Regions in order of preference, defaults to us-west-2
+	Regions []string
+
+	// Failover retries throttled or unavailable requests in the next region
+	Failover bool
+
+	// Timeout of a request in a region including its retries, defaults to the LLM
+	// call timeout. Each region of the failover has its own timeout
+	Timeout time.Duration
+
+	// MaxAttempts per region, defaults to DefaultMaxAttempts
+	MaxAttempts int
+}
+
+// retryableCodes are Bedrock errors that are worth retrying in addition to the
+// throttling and 5xx errors retried by the SDK.
+var retryableCodes = map[string]struct{}{
+	"ModelNotReadyException":      {},
+	"ModelTimeoutException":       {},
+	"ServiceUnavailableException": {},
+	"InternalServerException":     {},
+}
+
+// newRetryer returns a retryer with exponential backoff for throttling, 5xx and
+// the transient Bedrock errors.
+func newRetryer(maxAttempts int) aws.Retryer {
+	return retry.NewStandard(func(options *retry.StandardOptions) {
+		options.MaxAttempts = maxAttempts
+		options.MaxBackoff = 20 * time.Second
+		options.Retryables = append(options.Retryables, retry.RetryableErrorCode{
+			Codes: retryableCodes,
+		})
+	})
+}
+
+// configFromEnv reads the client configuration from the environment.
+func configFromEnv() Config {
+	var conf Config
+
+	for _, name := range strings.Split(os.Getenv("CHATBOT_BEDROCK_REGIONS"), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			conf.Regions = append(conf.Regions, name)
+		}
 	}
 
-	return &Client{
-		bedrock: bedrockruntime.NewFromConfig(sdkConfig),
-	}, nil
+	conf.Failover = os.Getenv("CHATBOT_BEDROCK_FAILOVER") != "false"
+
+	if timeout, err := time.ParseDuration(os.Getenv("CHATBOT_BEDROCK_TIMEOUT")); err == nil {
+		conf.Timeout = timeout
+	}
+
+	if attempts, err := strconv.Atoi(os.Getenv("CHATBOT_BEDROCK_MAX_ATTEMPTS")); err == nil {
+		conf.MaxAttempts = attempts
+	}
+
+	return conf
+}
+
+// NewFrom creates a Bedrock client with one connection per region.
+func NewFrom(ctx context.Context, conf Config) (*Client, error) {
+	if len(conf.Regions) == 0 {
+		conf.Regions = []string{region}
+	}
+
+	if conf.Timeout <= 0 {
+		conf.Timeout = llm.CallTimeout()
+	}
+
+	if conf.MaxAttempts <= 0 {
+		conf.MaxAttempts = DefaultMaxAttempts
+	}
+
+	client := &Client{
+		failover: conf.Failover,
+		timeout:  conf.Timeout,
+	}
+
+	for _, name := range conf.Regions {
+		sdkConfig, err := config.LoadDefaultConfig(ctx,
+			config.WithRegion(name),
+			config.WithRetryer(func() aws.Retryer {
+				return newRetryer(conf.MaxAttempts)
+			}),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure region %s: %w", name, err)
+		}
+
+		client.regions = append(client.regions, regionClient{
+			region:  name,
+			bedrock: bedrockruntime.NewFromConfig(sdkConfig),
+		})
+	}
+
+	return client, nil
+}
+
+// New creates a Bedrock client configured by the environment. CHATBOT_BEDROCK_REGIONS
+// lists the regions in order of preference and CHATBOT_BEDROCK_FAILOVER=false disables
+// the failover. The timeout of a request per region is configured by
+// CHATBOT_BEDROCK_TIMEOUT and the number of attempts per region by
+// CHATBOT_BEDROCK_MAX_ATTEMPTS.
+func New() (*Client, error) {
+	return NewFrom(context.Background(), configFromEnv())
 }
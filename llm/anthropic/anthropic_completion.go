@@ -3,21 +3,57 @@ package anthropic
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
 	"github.com/pzierahn/chatbot_services/llm"
+	"log"
 	"strings"
 )
 
-func (client *Client) invokeRequest(model string, req *ClaudeRequest) (*ClaudeResponse, error) {
-	body, _ := json.Marshal(req)
-	result, err := client.bedrock.InvokeModel(context.Background(), &bedrockruntime.InvokeModelInput{
+// invokeRegion sends a request to a region. Throttled and failed requests are
+// retried until the timeout of the region expires or the context is canceled.
+func (client *Client) invokeRegion(ctx context.Context, regional regionClient, model string, body []byte) (*bedrockruntime.InvokeModelOutput, error) {
+	ctx, cnl := context.WithTimeout(ctx, client.timeout)
+	defer cnl()
+
+	result, err := regional.bedrock.InvokeModel(ctx, &bedrockruntime.InvokeModelInput{
 		ModelId:     aws.String(model),
 		ContentType: aws.String("application/json"),
 		Accept:      aws.String("application/json"),
 		Body:        body,
 	})
+	if err != nil {
+		return nil, requestError(regional.region, model, err)
+	}
+
+	return result, nil
+}
+
+// invokeRequest sends a request to Bedrock. With failover, requests that are still
+// throttled or timed out in a region are sent to the next region, which starts with
+// a timeout of its own. Canceled requests are not sent to other regions.
+func (client *Client) invokeRequest(ctx context.Context, model string, req *ClaudeRequest) (*ClaudeResponse, error) {
+	body, _ := json.Marshal(req)
+
+	var result *bedrockruntime.InvokeModelOutput
+	var err error
+	for idx, regional := range client.regions {
+		result, err = client.invokeRegion(ctx, regional, model, body)
+		if err == nil {
+			break
+		}
+
+		failover := isFailover(err) || errors.Is(err, context.DeadlineExceeded)
+		if !client.failover || !failover || ctx.Err() != nil {
+			return nil, err
+		}
+
+		if idx+1 < len(client.regions) {
+			log.Printf("bedrock: %v, failing over to %s", err, client.regions[idx+1].region)
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -32,6 +68,9 @@ func (client *Client) invokeRequest(model string, req *ClaudeRequest) (*ClaudeRe
 }
 
 func (client *Client) Completion(ctx context.Context, req *llm.CompletionRequest) (*llm.CompletionResponse, error) {
+	if llm.HasImages(req.Messages) && !supportsImages(req.Model) {
+		return nil, llm.ErrImagesNotSupported
+	}
 
 	messages, err := transformMessages(req.Messages)
 	if err != nil {
@@ -46,25 +85,51 @@ func (client *Client) Completion(ctx context.Context, req *llm.CompletionRequest
 		System:           req.SystemPrompt,
 		MaxTokens:        req.MaxTokens,
 		TopP:             req.TopP,
-		Temperature:      req.Temperature,
+		Temperature:      &req.Temperature,
 		ToolChoice:       getToolConfig(req.ToolChoice),
 		Tools:            tools.toClaude(),
 	}
 
-	response, err := client.invokeRequest(req.Model, &request)
+	// Claude has no JSON mode, so the response is forced into the input of a tool
+	structured := req.ResponseFormat.IsJSON()
+	if structured {
+		respond := responseTool(req.ResponseFormat)
+		request.Tools = append(request.Tools, respond)
+
+		if len(req.Tools) == 0 {
+			request.ToolChoice = &ToolChoice{
+				Type: llm.ToolUseTool,
+				Name: respond.Name,
+			}
+		}
+	}
+
+	enableThinking(&request, req.Model, req.ReasoningBudget)
+
+	response, err := client.invokeRequest(ctx, req.Model, &request)
 	if err != nil {
 		return nil, err
 	}
 
+	reasoning := responseThinking(response)
+
 	usage := llm.ModelUsage{
-		UserId:       req.UserId,
-		Model:        response.Model,
-		InputTokens:  uint32(response.Usage.InputTokens),
-		OutputTokens: uint32(response.Usage.OutputTokens),
+		UserId: req.UserId,
+		Model:  response.Model,
+	}
+	usage.Add(response.Usage)
+
+	isResponse := func() bool {
+		if !structured {
+			return false
+		}
+
+		_, ok := getToolInput(response, req.ResponseFormat.SchemaName())
+		return ok
 	}
 
 	loops := 0
-	for response.StopReason == ContentTypeToolUse && loops < 6 {
+	for response.StopReason == ContentTypeToolUse && loops < llm.MaxToolIterations && !isResponse() {
 		// Reset the tool choice to prevent multiple tool calls
 		request.ToolChoice = nil
 
@@ -96,13 +161,13 @@ func (client *Client) Completion(ctx context.Context, req *llm.CompletionRequest
 			}
 		}
 
-		response, err = client.invokeRequest(req.Model, &request)
+		response, err = client.invokeRequest(ctx, req.Model, &request)
 		if err != nil {
 			return nil, err
 		}
 
-		usage.InputTokens += uint32(response.Usage.InputTokens)
-		usage.OutputTokens += uint32(response.Usage.OutputTokens)
+		usage.Add(response.Usage)
+		reasoning = append(reasoning, responseThinking(response)...)
 
 		loops++
 	}
@@ -112,13 +177,26 @@ func (client *Client) Completion(ctx context.Context, req *llm.CompletionRequest
 		return nil, err
 	}
 
+	// Thinking blocks precede the text of the answer
+	content := responseText(response)
+	if structured {
+		if input, ok := getToolInput(response, req.ResponseFormat.SchemaName()); ok {
+			byt, err := json.Marshal(input)
+			if err != nil {
+				return nil, err
+			}
+			content = string(byt)
+		}
+	}
+
 	thread = append(thread, &llm.Message{
 		Role:    llm.RoleAssistant,
-		Content: strings.TrimSpace(response.Content[0].Text),
+		Content: content,
 	})
 
 	return &llm.CompletionResponse{
-		Messages: thread,
-		Usage:    usage,
+		Messages:  thread,
+		Usage:     usage,
+		Reasoning: strings.Join(reasoning, "\n\n"),
 	}, nil
 }
@@ -0,0 +1,62 @@
+package anthropic
+
+import (
+	"errors"
+	"fmt"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/smithy-go"
+)
+
+// RequestError is a failed Bedrock request. The request id identifies the request
+// in support tickets with AWS.
+type RequestError struct {
+	Region    string
+	Model     string
+	RequestId string
+	Err       error
+}
+
+func (err *RequestError) Error() string {
+	message := err.Err.Error()
+
+	// The API error is shorter than the operation error that wraps it
+	var apiErr smithy.APIError
+	if errors.As(err.Err, &apiErr) {
+		message = fmt.Sprintf("%s: %s", apiErr.ErrorCode(), apiErr.ErrorMessage())
+	}
+
+	return fmt.Sprintf("bedrock request %s for %s in %s failed: %s", err.RequestId, err.Model, err.Region, message)
+}
+
+func (err *RequestError) Unwrap() error {
+	return err.Err
+}
+
+// requestError adds the request id to errors returned by Bedrock. Errors without a
+// response, like canceled contexts, are returned unchanged.
+func requestError(region, model string, err error) error {
+	var response *awshttp.ResponseError
+	if !errors.As(err, &response) || response.ServiceRequestID() == "" {
+		return err
+	}
+
+	return &RequestError{
+		Region:    region,
+		Model:     model,
+		RequestId: response.ServiceRequestID(),
+		Err:       err,
+	}
+}
+
+// failoverCodes are errors caused by the capacity of a region.
+var failoverCodes = map[string]bool{
+	"ThrottlingException":         true,
+	"ServiceUnavailableException": true,
+	"ModelNotReadyException":      true,
+}
+
+// isFailover returns true if the request may succeed in another region.
+func isFailover(err error) bool {
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) && failoverCodes[apiErr.ErrorCode()]
+}
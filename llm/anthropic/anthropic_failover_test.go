@@ -0,0 +1,78 @@
+package anthropic
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	"testing"
+	"time"
+)
+
+// regionFunc is a region that answers with a function.
+type regionFunc func(ctx context.Context) (*bedrockruntime.InvokeModelOutput, error)
+
+func (fn regionFunc) InvokeModel(ctx context.Context, _ *bedrockruntime.InvokeModelInput, _ ...func(*bedrockruntime.Options)) (*bedrockruntime.InvokeModelOutput, error) {
+	return fn(ctx)
+}
+
+func TestInvokeRequestRegionTimeout(t *testing.T) {
+	// The first region retries until its deadline expires
+	hanging := regionFunc(func(ctx context.Context) (*bedrockruntime.InvokeModelOutput, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	var remaining time.Duration
+	answering := regionFunc(func(ctx context.Context) (*bedrockruntime.InvokeModelOutput, error) {
+		deadline, _ := ctx.Deadline()
+		remaining = time.Until(deadline)
+		return &bedrockruntime.InvokeModelOutput{Body: []byte(`{"id":"msg"}`)}, nil
+	})
+
+	timeout := 50 * time.Millisecond
+	client := &Client{
+		regions: []regionClient{
+			{region: "us-west-2", bedrock: hanging},
+			{region: "us-east-1", bedrock: answering},
+		},
+		failover: true,
+		timeout:  timeout,
+	}
+
+	response, err := client.invokeRequest(context.Background(), ClaudeHaiku, &ClaudeRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if response.Id != "msg" {
+		t.Fatalf("expected the response of the second region, got %+v", response)
+	}
+
+	if remaining < timeout/2 {
+		t.Fatalf("expected the second region to have its own deadline, %v remained", remaining)
+	}
+}
+
+func TestInvokeRequestCanceled(t *testing.T) {
+	var calls int
+	canceled := regionFunc(func(ctx context.Context) (*bedrockruntime.InvokeModelOutput, error) {
+		calls++
+		return nil, ctx.Err()
+	})
+
+	client := &Client{
+		regions: []regionClient{
+			{region: "us-west-2", bedrock: canceled},
+			{region: "us-east-1", bedrock: canceled},
+		},
+		failover: true,
+		timeout:  time.Minute,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.invokeRequest(ctx, ClaudeHaiku, &ClaudeRequest{})
+	if err == nil || calls != 1 {
+		t.Fatalf("expected canceled requests not to fail over, got %d calls, %v", calls, err)
+	}
+}
@@ -50,6 +50,20 @@ var ModelCosts = map[string]llm.PricePer1000Tokens{
 	},
 }
 
+// ContextWindows of the model families in tokens
+var ContextWindows = map[string]int{
+	"claude-3":            200_000,
+	"claude-3-sonnet-28k": 28_000,
+	"claude-3-haiku-48k":  48_000,
+	"claude-v2":           100_000,
+	"claude-instant":      100_000,
+}
+
+func init() {
+	llm.RegisterPrices(ModelCosts)
+	llm.RegisterContextWindows(ContextWindows)
+}
+
 func (client *Client) ProvidesModel(name string) bool {
 	switch {
 	case strings.HasPrefix(name, "anthropic."):
@@ -58,3 +72,9 @@ func (client *Client) ProvidesModel(name string) bool {
 		return false
 	}
 }
+
+// supportsImages reports whether the model accepts image content. Only the
+// Claude 2 and Instant models are text-only.
+func supportsImages(model string) bool {
+	return !strings.Contains(model, "claude-v2") && !strings.Contains(model, "claude-instant")
+}
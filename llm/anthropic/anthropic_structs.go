@@ -14,12 +14,19 @@ type ClaudeRequest struct {
 	AnthropicVersion string          `json:"anthropic_version,omitempty"`
 	System           string          `json:"system,omitempty"`
 	MaxTokens        int             `json:"max_tokens,omitempty"`
-	Temperature      float32         `json:"temperature,omitempty"`
+	Temperature      *float32        `json:"temperature,omitempty"`
 	TopP             float32         `json:"top_p,omitempty"`
 	TopK             int             `json:"top_k,omitempty"`
 	Tools            []ClaudeTool    `json:"tools,omitempty"`
 	ToolChoice       *ToolChoice     `json:"tool_choice,omitempty"`
 	Messages         []ClaudeMessage `json:"messages,omitempty"`
+	Thinking         *Thinking       `json:"thinking,omitempty"`
+}
+
+// Thinking enables the extended thinking of Claude.
+type Thinking struct {
+	Type         string `json:"type,omitempty"`
+	BudgetTokens int    `json:"budget_tokens,omitempty"`
 }
 
 type Content struct {
@@ -32,11 +39,38 @@ type Content struct {
 	Name      string                 `json:"name,omitempty"`
 	Input     map[string]interface{} `json:"input,omitempty"`
 	Content   string                 `json:"content,omitempty"`
+
+	// Thinking Parameters, the signature and redacted data must be sent back
+	// unchanged with tool results
+	Thinking  string `json:"thinking,omitempty"`
+	Signature string `json:"signature,omitempty"`
+	Data      string `json:"data,omitempty"`
+
+	// Image Parameters
+	Source *ImageSource `json:"source,omitempty"`
+}
+
+// ImageSource contains the base64 encoded data of an image content.
+type ImageSource struct {
+	Type      string `json:"type,omitempty"`
+	MediaType string `json:"media_type,omitempty"`
+	Data      string `json:"data,omitempty"`
 }
 
 type ClaudeUsage struct {
 	InputTokens  int `json:"input_tokens,omitempty"`
 	OutputTokens int `json:"output_tokens,omitempty"`
+
+	// Prompt tokens written to and read from the prompt cache, not part of InputTokens
+	CacheCreationInputTokens int `json:"cache_creation_input_tokens,omitempty"`
+	CacheReadInputTokens     int `json:"cache_read_input_tokens,omitempty"`
+}
+
+// TokenCount returns the input tokens including the cached prompt tokens. The
+// output tokens include the thinking tokens.
+func (usage ClaudeUsage) TokenCount() (input, output uint32) {
+	input = uint32(usage.InputTokens + usage.CacheCreationInputTokens + usage.CacheReadInputTokens)
+	return input, uint32(usage.OutputTokens)
 }
 
 type ClaudeResponse struct {
@@ -56,6 +90,12 @@ const (
 
 const (
 	ContentTypeText       = "text"
+	ContentTypeImage      = "image"
 	ContentTypeToolUse    = "tool_use"
 	ContentTypeToolResult = "tool_result"
+
+	ContentTypeThinking         = "thinking"
+	ContentTypeRedactedThinking = "redacted_thinking"
 )
+
+const ImageSourceBase64 = "base64"
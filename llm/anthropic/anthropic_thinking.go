@@ -0,0 +1,76 @@
+package anthropic
+
+import (
+	"github.com/pzierahn/chatbot_services/llm"
+	"strings"
+)
+
+// minThinkingBudget is the smallest thinking budget accepted by Claude.
+const minThinkingBudget = 1024
+
+// thinkingAnswerTokens are the tokens left for the answer after thinking if the
+// request has no max tokens. Claude requires more max tokens than thinking tokens.
+const thinkingAnswerTokens = 4096
+
+// supportsThinking reports whether a model has extended thinking, which was
+// introduced with Claude 3.7.
+func supportsThinking(model string) bool {
+	for _, name := range []string{"claude-3-7", "claude-sonnet-4", "claude-opus-4"} {
+		if strings.Contains(model, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// enableThinking enables extended thinking if the model supports it. Claude only
+// thinks if the tool choice isn't forced and sampling is left to the model. The
+// budget is added to the maximum tokens, so that the answer keeps its length.
+// Requests without max tokens get thinkingAnswerTokens for the answer.
+func enableThinking(request *ClaudeRequest, model string, budget int) {
+	if budget <= 0 || !supportsThinking(model) {
+		return
+	}
+
+	if request.ToolChoice != nil && request.ToolChoice.Type != llm.ToolUseAuto {
+		return
+	}
+
+	budget = max(budget, minThinkingBudget)
+	request.Thinking = &Thinking{
+		Type:         "enabled",
+		BudgetTokens: budget,
+	}
+	if request.MaxTokens <= 0 {
+		request.MaxTokens = thinkingAnswerTokens
+	}
+	request.MaxTokens += budget
+	request.Temperature = nil
+	request.TopP = 0
+}
+
+// responseText returns the text blocks of a response without the thinking.
+func responseText(response *ClaudeResponse) string {
+	var parts []string
+	for _, content := range response.Content {
+		if content.Type == ContentTypeText {
+			parts = append(parts, content.Text)
+		}
+	}
+
+	return strings.TrimSpace(strings.Join(parts, "\n"))
+}
+
+// responseThinking returns the thinking blocks of a response. Redacted thinking
+// is encrypted and omitted.
+func responseThinking(response *ClaudeResponse) []string {
+	var parts []string
+	for _, content := range response.Content {
+		if content.Type == ContentTypeThinking && content.Thinking != "" {
+			parts = append(parts, strings.TrimSpace(content.Thinking))
+		}
+	}
+
+	return parts
+}
@@ -0,0 +1,61 @@
+package anthropic
+
+import (
+	"github.com/pzierahn/chatbot_services/llm"
+	"testing"
+)
+
+func TestEnableThinking(t *testing.T) {
+	temperature := float32(0.5)
+	request := &ClaudeRequest{MaxTokens: 1000, Temperature: &temperature, TopP: 0.9}
+	enableThinking(request, ClaudeSonnet37, 500)
+
+	if request.Thinking == nil || request.Thinking.BudgetTokens != minThinkingBudget {
+		t.Fatalf("expected a budget of %d, got %+v", minThinkingBudget, request.Thinking)
+	}
+	if request.MaxTokens != 1000+minThinkingBudget {
+		t.Errorf("expected the budget to be added to the max tokens, got %d", request.MaxTokens)
+	}
+	if request.Temperature != nil || request.TopP != 0 {
+		t.Errorf("sampling must be left to the model, got %v and %v", request.Temperature, request.TopP)
+	}
+
+	// Older models and forced tool choices don't support thinking
+	request = &ClaudeRequest{MaxTokens: 1000}
+	enableThinking(request, ClaudeSonnet35, 2000)
+	if request.Thinking != nil {
+		t.Error("thinking enabled for a model without support")
+	}
+
+	// The answer gets tokens besides the thinking without max tokens
+	request = &ClaudeRequest{}
+	enableThinking(request, ClaudeSonnet37, 2000)
+	if request.MaxTokens != thinkingAnswerTokens+2000 {
+		t.Errorf("expected %d max tokens, got %d", thinkingAnswerTokens+2000, request.MaxTokens)
+	}
+
+	request = &ClaudeRequest{MaxTokens: 1000, ToolChoice: &ToolChoice{Type: llm.ToolUseTool, Name: "respond"}}
+	enableThinking(request, ClaudeSonnet37, 2000)
+	if request.Thinking != nil || request.MaxTokens != 1000 {
+		t.Error("thinking enabled with a forced tool choice")
+	}
+}
+
+func TestResponseThinking(t *testing.T) {
+	response := &ClaudeResponse{
+		Content: []Content{
+			{Type: ContentTypeThinking, Thinking: "The user asks for a greeting.", Signature: "sig"},
+			{Type: ContentTypeRedactedThinking, Data: "encrypted"},
+			{Type: ContentTypeText, Text: "Hello!"},
+		},
+	}
+
+	if text := responseText(response); text != "Hello!" {
+		t.Errorf("expected the answer without thinking, got %q", text)
+	}
+
+	thinking := responseThinking(response)
+	if len(thinking) != 1 || thinking[0] != "The user asks for a greeting." {
+		t.Errorf("unexpected thinking %q", thinking)
+	}
+}
@@ -16,9 +16,11 @@ type ClaudeToolInput struct {
 }
 
 type ClaudeTool struct {
-	Name        string          `json:"name,omitempty"`
-	Description string          `json:"description,omitempty"`
-	InputSchema ClaudeToolInput `json:"input_schema,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	// InputSchema is either a ClaudeToolInput or a llm.Schema
+	InputSchema interface{} `json:"input_schema,omitempty"`
 }
 
 type toolConverter []*llm.ToolDefinition
@@ -54,7 +56,7 @@ func (list toolConverter) toClaude() []ClaudeTool {
 func (list toolConverter) getFunction(name string) (llm.FunctionCall, bool) {
 	for _, tool := range list {
 		if tool.Name == name {
-			return tool.Call, true
+			return tool.SafeCall, true
 		}
 	}
 
@@ -77,3 +79,23 @@ func getToolConfig(config *llm.ToolChoice) *ToolChoice {
 		}
 	}
 }
+
+// responseTool returns a tool that forces Claude to respond with JSON of the given format.
+func responseTool(format *llm.ResponseFormat) ClaudeTool {
+	return ClaudeTool{
+		Name:        format.SchemaName(),
+		Description: "Respond to the user with JSON that matches the input schema.",
+		InputSchema: format.ObjectSchema(),
+	}
+}
+
+// getToolInput returns the input of the first call to the named tool.
+func getToolInput(response *ClaudeResponse, name string) (map[string]interface{}, bool) {
+	for _, content := range response.Content {
+		if content.Type == ContentTypeToolUse && content.Name == name {
+			return content.Input, true
+		}
+	}
+
+	return nil, false
+}
@@ -1,7 +1,9 @@
 package anthropic
 
 import (
+	"encoding/base64"
 	"encoding/json"
+	"fmt"
 	"github.com/pzierahn/chatbot_services/llm"
 )
 
@@ -42,6 +44,25 @@ func transformMessages(messages []*llm.Message) ([]ClaudeMessage, error) {
 			})
 		}
 
+		for _, image := range message.Images {
+			if image.Omitted() {
+				continue
+			}
+
+			if len(image.Data) == 0 {
+				return nil, fmt.Errorf("%w: image urls are not supported, send the image data instead", llm.ErrImagesNotSupported)
+			}
+
+			content = append(content, Content{
+				Type: ContentTypeImage,
+				Source: &ImageSource{
+					Type:      ImageSourceBase64,
+					MediaType: image.MimeType,
+					Data:      base64.StdEncoding.EncodeToString(image.Data),
+				},
+			})
+		}
+
 		if message.Content != "" {
 			content = append(content, Content{
 				Type: ContentTypeText,
@@ -95,6 +116,20 @@ func claudeToMessages(messages []ClaudeMessage) ([]*llm.Message, error) {
 				})
 			case ContentTypeText:
 				llmMessage.Content = content.Text
+			case ContentTypeImage:
+				if content.Source == nil {
+					continue
+				}
+
+				data, err := base64.StdEncoding.DecodeString(content.Source.Data)
+				if err != nil {
+					return nil, err
+				}
+
+				llmMessage.Images = append(llmMessage.Images, llm.Image{
+					MimeType: content.Source.MediaType,
+					Data:     data,
+				})
 			}
 		}
 
@@ -0,0 +1,33 @@
+package anthropic
+
+import (
+	_ "embed"
+	"encoding/json"
+	"github.com/pzierahn/chatbot_services/llm"
+	"testing"
+)
+
+//go:embed anthropic_usage_test_responses.json
+var usageResponsesByt []byte
+
+func TestUsageTokenCount(t *testing.T) {
+	var responses []ClaudeResponse
+	err := json.Unmarshal(usageResponsesByt, &responses)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var usage llm.ModelUsage
+	for _, response := range responses {
+		usage.Add(response.Usage)
+	}
+
+	// Cached prompt tokens are billed as input, but not part of input_tokens
+	if usage.InputTokens != 412+1024+2210+1024 {
+		t.Errorf("expected the input of both rounds including cached tokens, got %d", usage.InputTokens)
+	}
+
+	if usage.OutputTokens != 58+96 {
+		t.Errorf("expected the output of both rounds, got %d", usage.OutputTokens)
+	}
+}
@@ -2,6 +2,9 @@ package llm
 
 import (
 	"context"
+	"strings"
+	"time"
+	"unicode"
 )
 
 const (
@@ -45,6 +48,16 @@ type ToolDefinition struct {
 
 	// Call is the function to call
 	Call FunctionCall
+
+	// Timeout of a call, defaults to DefaultToolTimeout
+	Timeout time.Duration
+
+	// MaxResultBytes limits the size of a result, defaults to MaxToolResultBytes
+	MaxResultBytes int
+
+	// Shrink shortens a result that exceeds maxBytes, e.g. by omitting the least
+	// relevant items. Results are cut if nil
+	Shrink func(output string, maxBytes int) (string, error)
 }
 
 // ToolCall defines which tool to call
@@ -76,13 +89,74 @@ type Message struct {
 	// User or assistant message
 	Content string `json:"content,omitempty" bson:"content,omitempty"`
 
+	// Images attached to a user message
+	Images []Image `json:"images,omitempty" bson:"images,omitempty"`
+
 	// Tool calls by assistant
 	ToolCalls []ToolCall `json:"tool_calls,omitempty" bson:"tool_calls,omitempty"`
 
 	// Tool calls response by tool
 	ToolResponses []ToolResponse `json:"tool_responses,omitempty" bson:"tool_responses,omitempty"`
+
+	// Id of a stored assistant or pending message, not sent to the model
+	Id string `json:"id,omitempty" bson:"id,omitempty"`
+
+	// Model that generated an assistant message, not sent to the model
+	Model string `json:"model,omitempty" bson:"model,omitempty"`
+
+	// Metadata of the completion of an assistant message, not sent to the model
+	Metadata *MessageMetadata `json:"metadata,omitempty" bson:"metadata,omitempty"`
+
+	// Instructions that extended the system prompt for a stored user message, not
+	// sent as part of the message
+	Instructions []string `json:"instructions,omitempty" bson:"instructions,omitempty"`
+
+	// Earlier answers to the same prompt of a stored assistant message, not sent
+	// to the model
+	Alternatives []*Message `json:"alternatives,omitempty" bson:"alternatives,omitempty"`
+}
+
+// MessageMetadata describes the completion that generated an assistant message.
+type MessageMetadata struct {
+	// Provider of the model, e.g. "openai"
+	Provider string `json:"provider,omitempty" bson:"provider,omitempty"`
+
+	// Latency of the completion, including the tool calls
+	Latency time.Duration `json:"latency,omitempty" bson:"latency,omitempty"`
+
+	// Tokens of all model calls of the completion
+	InputTokens  uint32 `json:"input_tokens,omitempty" bson:"input_tokens,omitempty"`
+	OutputTokens uint32 `json:"output_tokens,omitempty" bson:"output_tokens,omitempty"`
+
+	// ModelId and ReasoningBudget of the request, so that the completion can be
+	// generated again with the same options
+	ModelId         string `json:"model_id,omitempty" bson:"model_id,omitempty"`
+	ReasoningBudget int    `json:"reasoning_budget,omitempty" bson:"reasoning_budget,omitempty"`
+
+	// Time the completion finished
+	CreatedAt time.Time `json:"created_at,omitempty" bson:"created_at,omitempty"`
 }
 
+// ModelProvider returns the provider of a model id, e.g. "openai" for "openai.gpt-4o",
+// or an empty string for ids without provider prefix like "gemini-1.5-pro".
+func ModelProvider(model string) string {
+	provider, _, found := strings.Cut(model, ".")
+	if !found || provider == "" {
+		return ""
+	}
+
+	for _, char := range provider {
+		if !unicode.IsLetter(char) {
+			return ""
+		}
+	}
+
+	return provider
+}
+
+// MaxToolIterations limits the number of tool call rounds within a single completion.
+const MaxToolIterations = 6
+
 const (
 	ToolUseAuto = "auto" // ToolUseAuto allows the bot to decide whether to call any provided tools or not
 	ToolUseAny  = "any"  // ToolUseAny forces the bot to call any provided tools
@@ -113,7 +187,7 @@ type CompletionRequest struct {
 	// TopP is the nucleus sampling probability
 	TopP float32 `json:"top_p,omitempty" bson:"top_p,omitempty"`
 
-	// Temperature is the sampling temperature
+	// Temperature is the sampling temperature, 0 selects the most likely tokens
 	Temperature float32 `json:"temperature,omitempty" bson:"temperature,omitempty"`
 
 	// UserId to prevent abuse
@@ -124,6 +198,13 @@ type CompletionRequest struct {
 
 	// Tools to use for completion
 	Tools []*ToolDefinition `json:"tools,omitempty" bson:"tools,omitempty"`
+
+	// ResponseFormat of the completion, text if not set
+	ResponseFormat *ResponseFormat `json:"response_format,omitempty" bson:"response_format,omitempty"`
+
+	// ReasoningBudget is the number of tokens the model may spend on reasoning before
+	// answering, 0 disables reasoning. Models without reasoning support ignore it
+	ReasoningBudget int `json:"reasoning_budget,omitempty" bson:"reasoning_budget,omitempty"`
 }
 
 // CompletionResponse defines the response from the completion API
@@ -133,6 +214,10 @@ type CompletionResponse struct {
 
 	// Usage of the model
 	Usage ModelUsage `json:"usage,omitempty" bson:"usage,omitempty"`
+
+	// Reasoning of the model before the answer, empty if reasoning was disabled or
+	// isn't supported. It's not part of the messages
+	Reasoning string `json:"reasoning,omitempty" bson:"reasoning,omitempty"`
 }
 
 type Chat interface {
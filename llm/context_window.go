@@ -0,0 +1,75 @@
+package llm
+
+import "strings"
+
+// imageTokens is a conservative estimate of the tokens of an image. The providers
+// scale large images down to about 1600 tokens.
+const imageTokens = 1600
+
+// contextWindows contains the registered context windows of model families
+var contextWindows = map[string]int{}
+
+// RegisterContextWindows adds the context windows in tokens of model families,
+// e.g. "gpt-4o". The window covers the input and output tokens of a request.
+func RegisterContextWindows(windows map[string]int) {
+	for family, window := range windows {
+		contextWindows[family] = window
+	}
+}
+
+// ContextWindow returns the context window of a model in tokens, or 0 if it's
+// unknown. The model id matches the family with the longest prefix, ignoring
+// dot-separated prefixes of the id, e.g. "openai.gpt-4o-mini" matches
+// "gpt-4o-mini" before "gpt-4o" and the Bedrock inference profile
+// "us.anthropic.claude-3-haiku" matches "claude-3-haiku".
+func ContextWindow(model string) int {
+	window, matched := 0, -1
+	for name := model; ; {
+		for family, size := range contextWindows {
+			if strings.HasPrefix(name, family) && len(family) > matched {
+				window, matched = size, len(family)
+			}
+		}
+
+		_, rest, found := strings.Cut(name, ".")
+		if !found {
+			break
+		}
+		name = rest
+	}
+
+	return window
+}
+
+// EstimateRequestTokens returns a conservative estimate of the input tokens of a
+// request: the system prompt, the messages with their tool calls and images and
+// the tool definitions.
+func EstimateRequestTokens(req *CompletionRequest) int {
+	tokens := EstimateTokens(req.SystemPrompt)
+
+	for _, message := range req.Messages {
+		tokens += EstimateTokens(message.Content)
+		for _, image := range message.Images {
+			if !image.Omitted() {
+				tokens += imageTokens
+			}
+		}
+
+		for _, call := range message.ToolCalls {
+			tokens += EstimateTokens(call.Name) + EstimateTokens(call.Arguments)
+		}
+
+		for _, response := range message.ToolResponses {
+			tokens += EstimateTokens(response.Content)
+		}
+	}
+
+	for _, tool := range req.Tools {
+		tokens += EstimateTokens(tool.Name) + EstimateTokens(tool.Description)
+		for name, property := range tool.Parameters.Properties {
+			tokens += EstimateTokens(name) + EstimateTokens(property.Description)
+		}
+	}
+
+	return tokens
+}
@@ -0,0 +1,44 @@
+package llm
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestContextWindow(t *testing.T) {
+	RegisterContextWindows(map[string]int{
+		"test-model":      1000,
+		"test-model-mini": 500,
+	})
+
+	tests := map[string]int{
+		"test-model-2024":          1000,
+		"provider.test-model-mini": 500,
+		"test-model-mini-2":        500,
+		"us.provider.test-model-2": 1000,
+		"test-model-3.5":           1000,
+		"unknown-model":            0,
+	}
+
+	for model, want := range tests {
+		if got := ContextWindow(model); got != want {
+			t.Errorf("ContextWindow(%q) = %d, want %d", model, got, want)
+		}
+	}
+}
+
+func TestEstimateRequestTokens(t *testing.T) {
+	req := &CompletionRequest{
+		SystemPrompt: strings.Repeat("a", 30),
+		Messages: []*Message{
+			{Role: RoleUser, Content: strings.Repeat("b", 30), Images: []Image{{MimeType: "image/png", Data: []byte("png")}}},
+			{Role: RoleAssistant, ToolCalls: []ToolCall{{Name: "get", Arguments: strings.Repeat("c", 9)}}},
+			{Role: RoleUser, ToolResponses: []ToolResponse{{Content: strings.Repeat("d", 300)}}},
+		},
+	}
+
+	want := 10 + 10 + imageTokens + 1 + 3 + 100
+	if got := EstimateRequestTokens(req); got != want {
+		t.Errorf("EstimateRequestTokens() = %d, want %d", got, want)
+	}
+}
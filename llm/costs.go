@@ -8,13 +8,33 @@ type ModelUsage struct {
 	// UserId is the user id
 	UserId string `json:"user_id,omitempty"`
 
-	// InputTokens is the number of tokens in the prompt
+	// InputTokens is the number of tokens in the prompt, including image tokens
 	InputTokens uint32 `json:"prompt_tokens,omitempty"`
 
 	// OutputTokens is the number of tokens in the completion
 	OutputTokens uint32 `json:"completion_tokens,omitempty"`
 }
 
+// TokenCounter is the usage of a single model call as reported by a provider.
+// Providers normalize their fields, so that the input tokens include cached
+// prompt tokens and the output tokens include reasoning tokens.
+type TokenCounter interface {
+	TokenCount() (input, output uint32)
+}
+
+// TokenCount returns the summed tokens, so that the usages of completions can be added.
+func (usage ModelUsage) TokenCount() (input, output uint32) {
+	return usage.InputTokens, usage.OutputTokens
+}
+
+// Add adds the usage of a model call. Completions with tool calls add the usage
+// of every round, so that all providers sum the usage the same way.
+func (usage *ModelUsage) Add(call TokenCounter) {
+	input, output := call.TokenCount()
+	usage.InputTokens += input
+	usage.OutputTokens += output
+}
+
 type PricePer1000Tokens struct {
 	Input  float32
 	Output float32
@@ -26,3 +46,30 @@ func (price *PricePer1000Tokens) Cost(input, output uint32) (cost uint32) {
 	cost += uint32(float32(output) * price.Output)
 	return cost / 10
 }
+
+// prices contains the registered prices of all models
+var prices = map[string]PricePer1000Tokens{}
+
+// RegisterPrices adds the prices of models to the pricing table. Embedding models
+// are priced by their input tokens.
+func RegisterPrices(costs map[string]PricePer1000Tokens) {
+	for model, price := range costs {
+		prices[model] = price
+	}
+}
+
+// Price returns the price of a model and false if the model is unknown.
+func Price(model string) (PricePer1000Tokens, bool) {
+	price, ok := prices[model]
+	return price, ok
+}
+
+// EstimateCost returns the cost of a model usage in dollars. Usages of unknown models cost nothing.
+func EstimateCost(usage ModelUsage) float64 {
+	price, ok := prices[usage.Model]
+	if !ok {
+		return 0
+	}
+
+	return (float64(usage.InputTokens)*float64(price.Input) + float64(usage.OutputTokens)*float64(price.Output)) / 1000
+}
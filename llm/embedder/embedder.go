@@ -0,0 +1,60 @@
+// Package embedder creates the embedding client, which is configured independently
+// of the chat models, so that indexing and search can use any embedding provider.
+package embedder
+
+import (
+	"fmt"
+	"github.com/pzierahn/chatbot_services/llm"
+	"github.com/pzierahn/chatbot_services/llm/openai"
+	"github.com/pzierahn/chatbot_services/llm/voyageai"
+	"os"
+)
+
+const (
+	ProviderOpenAI   = "openai"
+	ProviderVoyageAI = "voyageai"
+	ProviderAzure    = "azure"
+)
+
+// New creates an embedding client for the provider and model. An empty provider
+// selects OpenAI and an empty model the default model of the provider.
+func New(provider, model string) (llm.Embedding, error) {
+	var engine llm.Embedding
+	var err error
+
+	switch provider {
+	case "", ProviderOpenAI:
+		if model == "" {
+			model = string(openai.LargeEmbedding3)
+		}
+		engine, err = openai.NewEmbedding(model)
+	case ProviderAzure:
+		if model == "" {
+			model = string(openai.LargeEmbedding3)
+		}
+		engine, err = openai.NewAzureEmbedding(model)
+	case ProviderVoyageAI:
+		if model == "" {
+			model = voyageai.ModelVoyageLarge2Instruct
+		}
+		engine, err = voyageai.New(model)
+	default:
+		return nil, fmt.Errorf("unknown embedding provider: %s", provider)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The dimension selects the search index, so unknown models can't be used
+	if engine.GetEmbeddingDimension() == 0 {
+		return nil, fmt.Errorf("unknown embedding model of %s: %s", provider, model)
+	}
+
+	return engine, nil
+}
+
+// FromEnv creates the embedding client configured by CHATBOT_EMBEDDING_PROVIDER
+// ("openai", "azure" or "voyageai") and CHATBOT_EMBEDDING_MODEL.
+func FromEnv() (llm.Embedding, error) {
+	return New(os.Getenv("CHATBOT_EMBEDDING_PROVIDER"), os.Getenv("CHATBOT_EMBEDDING_MODEL"))
+}
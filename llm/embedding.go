@@ -7,20 +7,38 @@ const (
 	EmbeddingTypeDocument = "document"
 )
 
+// Policies for inputs that exceed the maximum input of the embedding model
+const (
+	TruncationTruncate = "truncate"
+	TruncationError    = "error"
+)
+
 type EmbeddingRequest struct {
 	Inputs []string
 	UserId string
 	Type   string
+
+	// MaxTokens is the maximum input of the model, inputs aren't checked if 0
+	MaxTokens int
+
+	// Truncation is TruncationTruncate or TruncationError, defaults to TruncationError
+	Truncation string
 }
 
 type EmbeddingResponse struct {
 	Embeddings [][]float32
 	Tokens     uint32
 	Model      string
+
+	// Truncated is the number of inputs that were truncated to MaxTokens
+	Truncated int
 }
 
 type Embedding interface {
 	CreateEmbedding(ctx context.Context, req *EmbeddingRequest) (*EmbeddingResponse, error)
 	GetEmbeddingDimension() int
 	GetModelId() string
+
+	// GetMaxInputTokens returns the maximum number of tokens of a single input
+	GetMaxInputTokens() int
 }
@@ -0,0 +1,75 @@
+package llm
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ErrInputTooLong is returned if an embedding input exceeds the maximum input of
+// the model and the truncation policy is TruncationError.
+var ErrInputTooLong = errors.New("embedding input too long")
+
+// bytesPerToken is a conservative estimate of the bytes per token. English text has
+// about four bytes per token, but numbers, code and non-Latin scripts have fewer.
+const bytesPerToken = 3
+
+// EstimateTokens returns a conservative estimate of the tokens of a text. The
+// providers don't expose their tokenizers, so the estimate is based on the size.
+func EstimateTokens(text string) int {
+	return (len(text) + bytesPerToken - 1) / bytesPerToken
+}
+
+// TruncateInput shortens the text to about maxTokens tokens. The text is cut at the
+// last whitespace before the limit, so that no word is split, or at a rune boundary
+// if the text has no whitespace. It reports whether the text was truncated.
+func TruncateInput(text string, maxTokens int) (string, bool) {
+	limit := maxTokens * bytesPerToken
+	if len(text) <= limit {
+		return text, false
+	}
+
+	cut := limit
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+
+	if space := strings.LastIndexFunc(text[:cut], unicode.IsSpace); space > 0 {
+		cut = space
+	}
+
+	return strings.TrimRightFunc(text[:cut], unicode.IsSpace), true
+}
+
+// PrepareInputs applies the truncation policy of the request to its inputs. It
+// returns the inputs to embed and the number of truncated inputs.
+func PrepareInputs(req *EmbeddingRequest) ([]string, int, error) {
+	if req.MaxTokens <= 0 {
+		return req.Inputs, 0, nil
+	}
+
+	inputs := req.Inputs
+	truncated := 0
+	for idx, input := range req.Inputs {
+		if EstimateTokens(input) <= req.MaxTokens {
+			continue
+		}
+
+		if req.Truncation != TruncationTruncate {
+			return nil, 0, fmt.Errorf("%w: input %d has about %d tokens, the maximum is %d",
+				ErrInputTooLong, idx, EstimateTokens(input), req.MaxTokens)
+		}
+
+		// Copy the inputs on the first truncation, they belong to the caller
+		if truncated == 0 {
+			inputs = append([]string(nil), req.Inputs...)
+		}
+
+		inputs[idx], _ = TruncateInput(input, req.MaxTokens)
+		truncated++
+	}
+
+	return inputs, truncated, nil
+}
@@ -0,0 +1,60 @@
+package llm
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTruncateInput(t *testing.T) {
+	text := strings.Repeat("word ", 10)
+
+	truncated, ok := TruncateInput(text, 4)
+	if !ok {
+		t.Fatal("expected the text to be truncated")
+	}
+	if truncated != "word word" {
+		t.Errorf("expected the text to be cut at a word boundary, got %q", truncated)
+	}
+
+	// Multibyte runes are never split
+	truncated, _ = TruncateInput(strings.Repeat("ä", 20), 3)
+	if !strings.HasPrefix(strings.Repeat("ä", 20), truncated) || len(truncated) != 8 {
+		t.Errorf("expected 4 complete runes, got %q", truncated)
+	}
+
+	if _, ok := TruncateInput("short", 4); ok {
+		t.Error("expected short text to be unchanged")
+	}
+}
+
+func TestPrepareInputs(t *testing.T) {
+	long := strings.Repeat("token ", 100)
+	req := &EmbeddingRequest{
+		Inputs:     []string{"short", long},
+		MaxTokens:  10,
+		Truncation: TruncationTruncate,
+	}
+
+	inputs, truncated, err := PrepareInputs(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if truncated != 1 || EstimateTokens(inputs[1]) > 10 || inputs[0] != "short" {
+		t.Errorf("expected the long input to be truncated, got %d truncated: %q", truncated, inputs)
+	}
+	if req.Inputs[1] != long {
+		t.Error("expected the inputs of the request to be unchanged")
+	}
+
+	req.Truncation = TruncationError
+	_, _, err = PrepareInputs(req)
+	if !errors.Is(err, ErrInputTooLong) {
+		t.Errorf("expected ErrInputTooLong, got %v", err)
+	}
+
+	req.MaxTokens = 0
+	if _, _, err = PrepareInputs(req); err != nil {
+		t.Errorf("expected no limit without max tokens, got %v", err)
+	}
+}
@@ -0,0 +1,23 @@
+package llm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SafetyError is returned if a provider blocks a prompt or response for safety reasons.
+type SafetyError struct {
+	// Reason reported by the provider, e.g. the finish or block reason
+	Reason string
+
+	// Categories that triggered the block
+	Categories []string
+}
+
+func (err *SafetyError) Error() string {
+	if len(err.Categories) == 0 {
+		return fmt.Sprintf("blocked for safety reasons: %s", err.Reason)
+	}
+
+	return fmt.Sprintf("blocked for safety reasons: %s (%s)", err.Reason, strings.Join(err.Categories, ", "))
+}
@@ -0,0 +1,118 @@
+package llm
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrImagesNotSupported is returned if images are sent to a text-only model.
+var ErrImagesNotSupported = errors.New("model does not support image input")
+
+// Image is attached to a user message. Either the data or the URL is set. Stored
+// threads keep neither for images sent as data, see WithoutImageData.
+type Image struct {
+	// MimeType of the image, e.g. image/png
+	MimeType string `json:"mime_type,omitempty" bson:"mime_type,omitempty"`
+
+	// Data contains the raw image bytes
+	Data []byte `json:"data,omitempty" bson:"data,omitempty"`
+
+	// URL of the image, used if no data is set
+	URL string `json:"url,omitempty" bson:"url,omitempty"`
+}
+
+// DataURL returns the image as a base64 data URL or its URL.
+func (image Image) DataURL() string {
+	if len(image.Data) == 0 {
+		return image.URL
+	}
+
+	return fmt.Sprintf("data:%s;base64,%s", image.MimeType, base64.StdEncoding.EncodeToString(image.Data))
+}
+
+// Omitted reports whether the data of the image was removed before the thread
+// was stored. Omitted images are not sent to the models.
+func (image Image) Omitted() bool {
+	return len(image.Data) == 0 && image.URL == ""
+}
+
+// HasImages reports whether any of the messages carries images that are sent.
+func HasImages(messages []*Message) bool {
+	for _, message := range messages {
+		for _, image := range message.Images {
+			if !image.Omitted() {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// CheckImageURLs returns ErrImagesNotSupported if the URL of an image doesn't
+// have one of the schemes that the model reads. Without schemes, only image data
+// is supported.
+func CheckImageURLs(messages []*Message, schemes ...string) error {
+	for _, message := range messages {
+		for _, image := range message.Images {
+			if len(image.Data) > 0 || image.URL == "" {
+				continue
+			}
+
+			scheme, _, _ := strings.Cut(image.URL, "://")
+			if !slices.Contains(schemes, scheme) {
+				return fmt.Errorf("%w: %s image urls are not supported, send the image data instead", ErrImagesNotSupported, scheme)
+			}
+		}
+	}
+
+	return nil
+}
+
+// WithoutImageData returns copies of the messages without the raw bytes of their
+// images, so that stored threads stay small. Image URLs and mime types are kept.
+func WithoutImageData(messages []*Message) []*Message {
+	result := make([]*Message, len(messages))
+
+	for idx, message := range messages {
+		result[idx] = message
+		if len(message.Images) == 0 {
+			continue
+		}
+
+		stripped := *message
+		stripped.Images = make([]Image, len(message.Images))
+		for inx, image := range message.Images {
+			stripped.Images[inx] = Image{
+				MimeType: image.MimeType,
+				URL:      image.URL,
+			}
+		}
+
+		result[idx] = &stripped
+	}
+
+	return result
+}
+
+// ImageFromURL reverses DataURL. Base64 data URLs are decoded, other URLs are kept.
+func ImageFromURL(url string) Image {
+	header, data, found := strings.Cut(url, ";base64,")
+	mimeType, isData := strings.CutPrefix(header, "data:")
+	if !found || !isData {
+		return Image{URL: url}
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return Image{URL: url}
+	}
+
+	return Image{
+		MimeType: mimeType,
+		Data:     decoded,
+	}
+}
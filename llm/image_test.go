@@ -0,0 +1,73 @@
+package llm
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestImageFromURL(t *testing.T) {
+	image := Image{
+		MimeType: "image/png",
+		Data:     []byte{0x89, 'P', 'N', 'G'},
+	}
+
+	got := ImageFromURL(image.DataURL())
+	if got.MimeType != image.MimeType || !bytes.Equal(got.Data, image.Data) || got.URL != "" {
+		t.Errorf("round trip = %+v, want %+v", got, image)
+	}
+
+	link := "https://example.com/image.png"
+	if got := ImageFromURL(link); got.URL != link || got.Data != nil {
+		t.Errorf("ImageFromURL(%q) = %+v", link, got)
+	}
+}
+
+func TestWithoutImageData(t *testing.T) {
+	messages := []*Message{
+		{Role: RoleUser, Content: "text"},
+		{Role: RoleUser, Content: "images", Images: []Image{
+			{MimeType: "image/png", Data: []byte("png")},
+			{MimeType: "image/jpeg", URL: "https://example.com/a.jpg"},
+		}},
+	}
+
+	stripped := WithoutImageData(messages)
+
+	if len(messages[1].Images[0].Data) == 0 {
+		t.Fatal("expected the original messages to keep their data")
+	}
+
+	images := stripped[1].Images
+	if len(images) != 2 || !images[0].Omitted() || images[0].MimeType != "image/png" {
+		t.Fatalf("expected the data to be removed, got %+v", images)
+	}
+
+	if images[1].Omitted() || images[1].URL != "https://example.com/a.jpg" {
+		t.Errorf("expected the url to be kept, got %+v", images[1])
+	}
+
+	if HasImages(stripped[:1]) || !HasImages(stripped) {
+		t.Error("expected only sent images to count")
+	}
+
+}
+
+func TestCheckImageURLs(t *testing.T) {
+	messages := []*Message{{Role: RoleUser, Images: []Image{
+		{MimeType: "image/png", Data: []byte("png")},
+		{MimeType: "image/jpeg", URL: "https://example.com/a.jpg"},
+	}}}
+
+	if err := CheckImageURLs(messages, "https"); err != nil {
+		t.Errorf("expected https urls to pass, got %v", err)
+	}
+
+	if err := CheckImageURLs(messages, "gs"); !errors.Is(err, ErrImagesNotSupported) {
+		t.Errorf("expected https urls to be rejected, got %v", err)
+	}
+
+	if err := CheckImageURLs(messages[:0]); err != nil {
+		t.Errorf("expected no error without images, got %v", err)
+	}
+}
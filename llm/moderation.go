@@ -0,0 +1,15 @@
+package llm
+
+import "context"
+
+// Moderator screens texts against a moderation policy.
+type Moderator interface {
+	Check(ctx context.Context, text string) (flagged bool, categories []string, err error)
+}
+
+// NoModeration accepts all texts.
+type NoModeration struct{}
+
+func (NoModeration) Check(context.Context, string) (bool, []string, error) {
+	return false, nil, nil
+}
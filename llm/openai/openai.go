@@ -9,6 +9,9 @@ import (
 type Client struct {
 	client         *openai.Client
 	embeddingModel openai.EmbeddingModel
+
+	// deployments maps the models of Azure to their deployments, nil for OpenAI
+	deployments map[string]string
 }
 
 func New() (*Client, error) {
@@ -22,3 +25,15 @@ func New() (*Client, error) {
 		embeddingModel: LargeEmbedding3,
 	}, nil
 }
+
+// NewEmbedding creates a client that embeds with the given model instead of the default one.
+func NewEmbedding(model string) (*Client, error) {
+	client, err := New()
+	if err != nil {
+		return nil, err
+	}
+
+	client.embeddingModel = openai.EmbeddingModel(model)
+
+	return client, nil
+}
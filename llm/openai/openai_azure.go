@@ -0,0 +1,125 @@
+package openai
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/sashabaranov/go-openai"
+	"net/http"
+	"os"
+)
+
+// azurePrefix is the prefix of the model ids of Azure OpenAI, e.g. "azure.gpt-4o".
+const azurePrefix = "azure."
+
+// DefaultAzureAPIVersion is the api-version of Azure OpenAI requests by default.
+const DefaultAzureAPIVersion = "2024-10-21"
+
+// AzureConfig configures a client of Azure OpenAI.
+type AzureConfig struct {
+	// Resource is the name of the Azure OpenAI resource, which defines the
+	// endpoint https://<resource>.openai.azure.com/
+	Resource string
+
+	// APIVersion of the requests, defaults to DefaultAzureAPIVersion
+	APIVersion string
+
+	// APIKey of the resource, ignored if Credential is set
+	APIKey string
+
+	// Credential provides Microsoft Entra ID access tokens, which are refreshed
+	// shortly before they expire
+	Credential AzureCredential
+
+	// Deployments maps model names without prefix, like "gpt-4o", to the names of
+	// their deployments. Models without deployment are not provided
+	Deployments map[string]string
+}
+
+// NewAzure creates a client of Azure OpenAI. Chat models are selected with the
+// prefix "azure.", requests and responses are translated like those of OpenAI.
+func NewAzure(config AzureConfig) (*Client, error) {
+	if config.Resource == "" {
+		return nil, fmt.Errorf("missing azure resource")
+	}
+
+	if len(config.Deployments) == 0 {
+		return nil, fmt.Errorf("missing azure deployments")
+	}
+
+	if config.APIKey == "" && config.Credential == nil {
+		return nil, fmt.Errorf("missing azure api key or credential")
+	}
+
+	apiKey := config.APIKey
+	if config.Credential != nil {
+		// The token is set on each request instead
+		apiKey = ""
+	}
+
+	clientConfig := openai.DefaultAzureConfig(apiKey, fmt.Sprintf("https://%s.openai.azure.com/", config.Resource))
+	if config.Credential != nil {
+		clientConfig.APIType = openai.APITypeAzureAD
+		clientConfig.HTTPClient = &tokenDoer{
+			client: &http.Client{},
+			tokens: &tokenCache{credential: config.Credential},
+		}
+	}
+	clientConfig.APIVersion = config.APIVersion
+	if clientConfig.APIVersion == "" {
+		clientConfig.APIVersion = DefaultAzureAPIVersion
+	}
+
+	// Requests of models without deployment are rejected by Azure
+	deployments := config.Deployments
+	clientConfig.AzureModelMapperFunc = func(model string) string {
+		return deployments[model]
+	}
+
+	return &Client{
+		client:         openai.NewClientWithConfig(clientConfig),
+		embeddingModel: LargeEmbedding3,
+		deployments:    deployments,
+	}, nil
+}
+
+// AzureFromEnv creates a client of Azure OpenAI configured by AZURE_OPENAI_RESOURCE,
+// AZURE_OPENAI_API_KEY or the service principal AZURE_TENANT_ID, AZURE_CLIENT_ID
+// and AZURE_CLIENT_SECRET, AZURE_OPENAI_API_VERSION and AZURE_OPENAI_DEPLOYMENTS
+// (a JSON object of model names to deployments).
+func AzureFromEnv() (*Client, error) {
+	config := AzureConfig{
+		Resource:   os.Getenv("AZURE_OPENAI_RESOURCE"),
+		APIVersion: os.Getenv("AZURE_OPENAI_API_VERSION"),
+		APIKey:     os.Getenv("AZURE_OPENAI_API_KEY"),
+	}
+
+	if secret := os.Getenv("AZURE_CLIENT_SECRET"); secret != "" {
+		config.Credential = ClientSecretCredential(os.Getenv("AZURE_TENANT_ID"), os.Getenv("AZURE_CLIENT_ID"), secret)
+	}
+
+	if deployments := os.Getenv("AZURE_OPENAI_DEPLOYMENTS"); deployments != "" {
+		err := json.Unmarshal([]byte(deployments), &config.Deployments)
+		if err != nil {
+			return nil, fmt.Errorf("invalid AZURE_OPENAI_DEPLOYMENTS: %v", err)
+		}
+	}
+
+	return NewAzure(config)
+}
+
+// NewAzureEmbedding creates a client of Azure OpenAI that embeds with the given
+// model, which must have a deployment.
+func NewAzureEmbedding(model string) (*Client, error) {
+	client, err := AzureFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := client.deployments[model]; !ok {
+		return nil, fmt.Errorf("no azure deployment of %s", model)
+	}
+
+	client.embeddingModel = openai.EmbeddingModel(model)
+
+	return client, nil
+}
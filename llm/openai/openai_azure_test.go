@@ -0,0 +1,47 @@
+package openai
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNewAzure(t *testing.T) {
+	_, err := NewAzure(AzureConfig{Resource: "resource", APIKey: "key"})
+	if err == nil {
+		t.Error("expected an error without deployments")
+	}
+
+	_, err = NewAzure(AzureConfig{Resource: "resource", Deployments: map[string]string{"gpt-4o": "chat"}})
+	if err == nil {
+		t.Error("expected an error without credentials")
+	}
+
+	client, err := NewAzure(AzureConfig{
+		Resource:    "resource",
+		Credential:  func(context.Context) (AzureToken, error) { return AzureToken{Token: "token"}, nil },
+		Deployments: map[string]string{"gpt-4o": "chat"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		model    string
+		provides bool
+	}{
+		{"azure.gpt-4o", true},
+		{"azure.gpt-4o-mini", false},
+		{"openai.gpt-4o", false},
+		{"gpt-4o", false},
+	}
+
+	for _, tt := range tests {
+		if provides := client.ProvidesModel(tt.model); provides != tt.provides {
+			t.Errorf("ProvidesModel(%s) = %v, expected %v", tt.model, provides, tt.provides)
+		}
+	}
+
+	if client.prefix() != azurePrefix {
+		t.Errorf("expected the prefix %s, got %s", azurePrefix, client.prefix())
+	}
+}
@@ -0,0 +1,118 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AzureTokenScope is the scope of Microsoft Entra ID tokens for Azure OpenAI.
+const AzureTokenScope = "https://cognitiveservices.azure.com/.default"
+
+// azureTokenRefresh is the time before their expiry at which tokens are replaced.
+const azureTokenRefresh = 5 * time.Minute
+
+// azureAuthority is the endpoint of Microsoft Entra ID.
+var azureAuthority = "https://login.microsoftonline.com"
+
+// AzureToken is a Microsoft Entra ID access token.
+type AzureToken struct {
+	Token     string
+	ExpiresOn time.Time
+}
+
+// AzureCredential returns an access token for AzureTokenScope. Credentials of the
+// azidentity package can be used by calling their GetToken with this scope.
+type AzureCredential func(ctx context.Context) (AzureToken, error)
+
+// tokenCache reuses a token until shortly before it expires.
+type tokenCache struct {
+	credential AzureCredential
+
+	mu    sync.Mutex
+	token AzureToken
+}
+
+func (cache *tokenCache) get(ctx context.Context) (string, error) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if cache.token.Token != "" && time.Until(cache.token.ExpiresOn) > azureTokenRefresh {
+		return cache.token.Token, nil
+	}
+
+	token, err := cache.credential(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	cache.token = token
+	return token.Token, nil
+}
+
+// tokenDoer sends requests with the current token of the credential.
+type tokenDoer struct {
+	client *http.Client
+	tokens *tokenCache
+}
+
+func (doer *tokenDoer) Do(req *http.Request) (*http.Response, error) {
+	token, err := doer.tokens.get(req.Context())
+	if err != nil {
+		return nil, fmt.Errorf("azure token: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	return doer.client.Do(req)
+}
+
+// ClientSecretCredential returns the tokens of a service principal, which are
+// requested with the OAuth 2.0 client credentials flow.
+func ClientSecretCredential(tenantId, clientId, secret string) AzureCredential {
+	return func(ctx context.Context) (AzureToken, error) {
+		endpoint := fmt.Sprintf("%s/%s/oauth2/v2.0/token", azureAuthority, url.PathEscape(tenantId))
+		form := url.Values{
+			"grant_type":    {"client_credentials"},
+			"client_id":     {clientId},
+			"client_secret": {secret},
+			"scope":         {AzureTokenScope},
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+		if err != nil {
+			return AzureToken{}, err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return AzureToken{}, err
+		}
+		defer func() { _ = resp.Body.Close() }()
+
+		var result struct {
+			AccessToken      string `json:"access_token"`
+			ExpiresIn        int    `json:"expires_in"`
+			Error            string `json:"error"`
+			ErrorDescription string `json:"error_description"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		if err != nil {
+			return AzureToken{}, fmt.Errorf("invalid token response: %s", resp.Status)
+		}
+
+		if resp.StatusCode != http.StatusOK || result.AccessToken == "" {
+			return AzureToken{}, fmt.Errorf("token request failed: %s: %s", result.Error, result.ErrorDescription)
+		}
+
+		return AzureToken{
+			Token:     result.AccessToken,
+			ExpiresOn: time.Now().Add(time.Duration(result.ExpiresIn) * time.Second),
+		}, nil
+	}
+}
@@ -0,0 +1,80 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTokenDoer(t *testing.T) {
+	var calls int
+	expires := time.Now().Add(time.Hour)
+	credential := func(context.Context) (AzureToken, error) {
+		calls++
+		return AzureToken{Token: fmt.Sprintf("token-%d", calls), ExpiresOn: expires}, nil
+	}
+
+	var header string
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, req *http.Request) {
+		header = req.Header.Get("Authorization")
+	}))
+	defer server.Close()
+
+	doer := &tokenDoer{client: server.Client(), tokens: &tokenCache{credential: credential}}
+
+	send := func() {
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		resp, err := doer.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	send()
+	send()
+	if calls != 1 || header != "Bearer token-1" {
+		t.Fatalf("expected the cached token, got %d calls and %q", calls, header)
+	}
+
+	// Tokens are replaced before they expire
+	expires = time.Now().Add(time.Minute)
+	doer.tokens.token.ExpiresOn = expires
+	send()
+	if calls != 2 || header != "Bearer token-2" {
+		t.Fatalf("expected a new token, got %d calls and %q", calls, header)
+	}
+}
+
+func TestClientSecretCredential(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/tenant/oauth2/v2.0/token" || req.FormValue("client_secret") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = fmt.Fprint(w, `{"error": "invalid_client", "error_description": "wrong secret"}`)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, `{"access_token": "token", "expires_in": 3600}`)
+	}))
+	defer server.Close()
+
+	authority := azureAuthority
+	azureAuthority = server.URL
+	defer func() { azureAuthority = authority }()
+
+	token, err := ClientSecretCredential("tenant", "client", "secret")(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.Token != "token" || time.Until(token.ExpiresOn) < 59*time.Minute {
+		t.Fatalf("unexpected token %+v", token)
+	}
+
+	_, err = ClientSecretCredential("tenant", "client", "wrong")(context.Background())
+	if err == nil {
+		t.Fatal("expected an error for a wrong secret")
+	}
+}
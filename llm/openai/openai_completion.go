@@ -6,9 +6,18 @@ import (
 	"fmt"
 	"github.com/pzierahn/chatbot_services/llm"
 	"github.com/sashabaranov/go-openai"
+	"math"
 	"strings"
 )
 
+// createChatCompletion sends a single completion request limited by the call timeout.
+func (client *Client) createChatCompletion(ctx context.Context, request openai.ChatCompletionRequest) (openai.ChatCompletionResponse, error) {
+	ctx, cnl := llm.WithCallTimeout(ctx)
+	defer cnl()
+
+	return client.client.CreateChatCompletion(ctx, request)
+}
+
 func (client *Client) Completion(ctx context.Context, req *llm.CompletionRequest) (*llm.CompletionResponse, error) {
 	var messages []openai.ChatCompletionMessage
 
@@ -20,13 +29,27 @@ func (client *Client) Completion(ctx context.Context, req *llm.CompletionRequest
 	}
 
 	messages = append(messages, messagesToOpenAI(req.Messages)...)
-	model, _ := strings.CutPrefix(req.Model, modelPrefix)
+	model, _ := strings.CutPrefix(req.Model, client.prefix())
+
+	if llm.HasImages(req.Messages) && !supportsImages(model) {
+		return nil, llm.ErrImagesNotSupported
+	}
+
+	// OpenAI fetches images from public URLs
+	if err := llm.CheckImageURLs(req.Messages, "https"); err != nil {
+		return nil, err
+	}
 
 	tools := toolConverter(req.Tools)
 
+	responseFormat, err := getResponseFormat(req.ResponseFormat)
+	if err != nil {
+		return nil, err
+	}
+
 	request := openai.ChatCompletionRequest{
 		Model:               model,
-		Temperature:         req.Temperature,
+		Temperature:         temperature(req.Temperature),
 		TopP:                req.TopP,
 		MaxCompletionTokens: req.MaxTokens,
 		Messages:            messages,
@@ -34,22 +57,22 @@ func (client *Client) Completion(ctx context.Context, req *llm.CompletionRequest
 		N:                   1,
 		User:                req.UserId,
 		ToolChoice:          getToolChoice(req.ToolChoice),
+		ResponseFormat:      responseFormat,
 	}
 
-	resp, err := client.client.CreateChatCompletion(ctx, request)
+	resp, err := client.createChatCompletion(ctx, request)
 	if err != nil {
 		return nil, err
 	}
 
 	usage := llm.ModelUsage{
-		UserId:       req.UserId,
-		Model:        resp.Model,
-		InputTokens:  uint32(resp.Usage.PromptTokens),
-		OutputTokens: uint32(resp.Usage.CompletionTokens),
+		UserId: req.UserId,
+		Model:  resp.Model,
 	}
+	usage.Add(callUsage(resp.Usage))
 
 	loops := 0
-	for len(resp.Choices[0].Message.ToolCalls) > 0 && loops < 6 {
+	for len(resp.Choices[0].Message.ToolCalls) > 0 && loops < llm.MaxToolIterations {
 		//
 		// The model wants to call tools
 		//
@@ -89,14 +112,13 @@ func (client *Client) Completion(ctx context.Context, req *llm.CompletionRequest
 			request.Messages = append(request.Messages, message)
 		}
 
-		resp, err = client.client.CreateChatCompletion(ctx, request)
+		resp, err = client.createChatCompletion(ctx, request)
 		if err != nil {
 			return nil, err
 		}
 
 		// Add the tool usage to the model usage
-		usage.InputTokens += uint32(resp.Usage.PromptTokens)
-		usage.OutputTokens += uint32(resp.Usage.CompletionTokens)
+		usage.Add(callUsage(resp.Usage))
 
 		loops++
 	}
@@ -112,3 +134,13 @@ func (client *Client) Completion(ctx context.Context, req *llm.CompletionRequest
 		Usage:    usage,
 	}, nil
 }
+
+// temperature returns the temperature of a request for go-openai, which omits a
+// zero temperature. The smallest positive float is sent instead.
+func temperature(value float32) float32 {
+	if value == 0 {
+		return math.SmallestNonzeroFloat32
+	}
+
+	return value
+}
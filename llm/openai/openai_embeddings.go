@@ -7,11 +7,19 @@ import (
 )
 
 func (client *Client) CreateEmbedding(ctx context.Context, req *llm.EmbeddingRequest) (*llm.EmbeddingResponse, error) {
+	ctx, cnl := llm.WithCallTimeout(ctx)
+	defer cnl()
+
+	inputs, truncated, err := llm.PrepareInputs(req)
+	if err != nil {
+		return nil, err
+	}
+
 	resp, err := client.client.CreateEmbeddings(
 		ctx,
 		openai.EmbeddingRequestStrings{
 			Model: client.embeddingModel,
-			Input: req.Inputs,
+			Input: inputs,
 			User:  req.UserId,
 		},
 	)
@@ -23,6 +31,7 @@ func (client *Client) CreateEmbedding(ctx context.Context, req *llm.EmbeddingReq
 		Embeddings: make([][]float32, len(resp.Data)),
 		Model:      client.GetModelId(),
 		Tokens:     uint32(resp.Usage.PromptTokens),
+		Truncated:  truncated,
 	}
 
 	for idx, item := range resp.Data {
@@ -43,6 +52,10 @@ func (client *Client) GetEmbeddingDimension() int {
 	}
 }
 
+func (client *Client) GetMaxInputTokens() int {
+	return MaxEmbeddingTokens
+}
+
 func (client *Client) GetModelId() string {
 	return string(client.embeddingModel)
 }
@@ -18,6 +18,9 @@ const (
 	DimensionModelSmall = 1536
 )
 
+// MaxEmbeddingTokens is the maximum input of the embedding models
+const MaxEmbeddingTokens = 8191
+
 var ModelCosts = map[string]llm.PricePer1000Tokens{
 	//
 	// o3-mini
@@ -129,7 +132,45 @@ var ModelCosts = map[string]llm.PricePer1000Tokens{
 	},
 }
 
+// ContextWindows of the model families in tokens
+var ContextWindows = map[string]int{
+	"o1":                 200_000,
+	"o1-mini":            128_000,
+	"o1-preview":         128_000,
+	"o3-mini":            200_000,
+	"gpt-4o":             128_000,
+	"gpt-4-turbo":        128_000,
+	"gpt-4-0125":         128_000,
+	"gpt-4-1106":         128_000,
+	"gpt-4-32k":          32_768,
+	"gpt-4":              8_192,
+	"gpt-3.5-turbo":      16_385,
+	"gpt-3.5-turbo-0301": 4_096,
+	"gpt-3.5-turbo-0613": 4_096,
+}
+
+func init() {
+	llm.RegisterPrices(ModelCosts)
+	llm.RegisterContextWindows(ContextWindows)
+}
+
+// prefix returns the prefix of the model ids of the client.
+func (client *Client) prefix() string {
+	if client.deployments != nil {
+		return azurePrefix
+	}
+
+	return modelPrefix
+}
+
 func (client *Client) ProvidesModel(name string) bool {
+	if client.deployments != nil {
+		// Azure only serves models with a deployment
+		model, ok := strings.CutPrefix(name, azurePrefix)
+		_, deployed := client.deployments[model]
+		return ok && deployed
+	}
+
 	_, ok := ModelCosts[name]
 
 	switch {
@@ -141,3 +182,16 @@ func (client *Client) ProvidesModel(name string) bool {
 		return false
 	}
 }
+
+// supportsImages reports whether the model accepts image content. GPT-3.5 and
+// the mini reasoning models are text-only.
+func supportsImages(model string) bool {
+	switch {
+	case strings.HasPrefix(model, "gpt-3.5"):
+		return false
+	case strings.HasPrefix(model, "o1-mini"), strings.HasPrefix(model, "o3-mini"):
+		return false
+	default:
+		return true
+	}
+}
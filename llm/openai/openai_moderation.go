@@ -0,0 +1,64 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/pzierahn/chatbot_services/llm"
+	"github.com/sashabaranov/go-openai"
+	"sort"
+)
+
+// Check screens a text with the OpenAI moderation endpoint.
+func (client *Client) Check(ctx context.Context, text string) (bool, []string, error) {
+	ctx, cnl := llm.WithCallTimeout(ctx)
+	defer cnl()
+
+	resp, err := client.client.Moderations(ctx, openai.ModerationRequest{
+		Input: text,
+		Model: openai.ModerationOmniLatest,
+	})
+	if err != nil {
+		return false, nil, err
+	}
+
+	var flagged bool
+	var categories []string
+	for _, result := range resp.Results {
+		if !result.Flagged {
+			continue
+		}
+		flagged = true
+
+		names, err := flaggedCategories(result.Categories)
+		if err != nil {
+			return false, nil, err
+		}
+		categories = append(categories, names...)
+	}
+
+	return flagged, categories, nil
+}
+
+// flaggedCategories returns the names of the flagged categories, e.g. "hate/threatening".
+func flaggedCategories(categories openai.ResultCategories) ([]string, error) {
+	data, err := json.Marshal(categories)
+	if err != nil {
+		return nil, err
+	}
+
+	var values map[string]bool
+	err = json.Unmarshal(data, &values)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for name, flagged := range values {
+		if flagged {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
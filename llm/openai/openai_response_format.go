@@ -0,0 +1,36 @@
+package openai
+
+import (
+	"encoding/json"
+	"github.com/pzierahn/chatbot_services/llm"
+	"github.com/sashabaranov/go-openai"
+)
+
+// getResponseFormat converts the response format to the OpenAI response format.
+func getResponseFormat(format *llm.ResponseFormat) (*openai.ChatCompletionResponseFormat, error) {
+	if format == nil {
+		return nil, nil
+	}
+
+	switch format.Type {
+	case llm.ResponseFormatJSONObject:
+		return &openai.ChatCompletionResponseFormat{
+			Type: openai.ChatCompletionResponseFormatTypeJSONObject,
+		}, nil
+	case llm.ResponseFormatJSONSchema:
+		schema, err := json.Marshal(format.Schema)
+		if err != nil {
+			return nil, err
+		}
+
+		return &openai.ChatCompletionResponseFormat{
+			Type: openai.ChatCompletionResponseFormatTypeJSONSchema,
+			JSONSchema: &openai.ChatCompletionResponseFormatJSONSchema{
+				Name:   format.SchemaName(),
+				Schema: json.RawMessage(schema),
+			},
+		}, nil
+	default:
+		return nil, nil
+	}
+}
@@ -50,7 +50,7 @@ func (tools toolConverter) toOpenAI() []openai.Tool {
 func (tools toolConverter) getFunction(name string) (llm.FunctionCall, bool) {
 	for _, tool := range tools {
 		if tool.Name == name {
-			return tool.Call, true
+			return tool.SafeCall, true
 		}
 	}
 
@@ -13,7 +13,12 @@ func messagesToOpenAI(input []*llm.Message) []openai.ChatCompletionMessage {
 		switch msg.Role {
 		case llm.RoleUser:
 
-			if msg.Content != "" {
+			if llm.HasImages([]*llm.Message{msg}) {
+				messages = append(messages, openai.ChatCompletionMessage{
+					Role:         openai.ChatMessageRoleUser,
+					MultiContent: multiContent(msg),
+				})
+			} else if msg.Content != "" {
 				messages = append(messages, openai.ChatCompletionMessage{
 					Role:    openai.ChatMessageRoleUser,
 					Content: msg.Content,
@@ -59,10 +64,21 @@ func openaiToMessages(input []openai.ChatCompletionMessage) []*llm.Message {
 	for _, msg := range input {
 		switch msg.Role {
 		case openai.ChatMessageRoleUser:
-			messages = append(messages, &llm.Message{
+			message := &llm.Message{
 				Role:    llm.RoleUser,
 				Content: msg.Content,
-			})
+			}
+
+			for _, part := range msg.MultiContent {
+				switch part.Type {
+				case openai.ChatMessagePartTypeText:
+					message.Content = part.Text
+				case openai.ChatMessagePartTypeImageURL:
+					message.Images = append(message.Images, llm.ImageFromURL(part.ImageURL.URL))
+				}
+			}
+
+			messages = append(messages, message)
 		case openai.ChatMessageRoleAssistant:
 			message := &llm.Message{
 				Role:    llm.RoleAssistant,
@@ -96,3 +112,31 @@ func openaiToMessages(input []openai.ChatCompletionMessage) []*llm.Message {
 
 	return messages
 }
+
+// multiContent converts the text and the images of a user message to content parts.
+func multiContent(msg *llm.Message) []openai.ChatMessagePart {
+	var parts []openai.ChatMessagePart
+
+	for _, image := range msg.Images {
+		if image.Omitted() {
+			continue
+		}
+
+		parts = append(parts, openai.ChatMessagePart{
+			Type: openai.ChatMessagePartTypeImageURL,
+			ImageURL: &openai.ChatMessageImageURL{
+				URL:    image.DataURL(),
+				Detail: openai.ImageURLDetailAuto,
+			},
+		})
+	}
+
+	if msg.Content != "" {
+		parts = append(parts, openai.ChatMessagePart{
+			Type: openai.ChatMessagePartTypeText,
+			Text: msg.Content,
+		})
+	}
+
+	return parts
+}
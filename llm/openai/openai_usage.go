@@ -0,0 +1,12 @@
+package openai
+
+import "github.com/sashabaranov/go-openai"
+
+// callUsage is the usage of a single chat completion call.
+type callUsage openai.Usage
+
+// TokenCount returns the prompt and completion tokens. Cached prompt tokens are
+// part of the prompt tokens and reasoning tokens part of the completion tokens.
+func (usage callUsage) TokenCount() (input, output uint32) {
+	return uint32(usage.PromptTokens), uint32(usage.CompletionTokens)
+}
@@ -0,0 +1,34 @@
+package openai
+
+import (
+	_ "embed"
+	"encoding/json"
+	"github.com/pzierahn/chatbot_services/llm"
+	"github.com/sashabaranov/go-openai"
+	"testing"
+)
+
+//go:embed openai_usage_test_responses.json
+var usageResponsesByt []byte
+
+func TestUsageTokenCount(t *testing.T) {
+	var responses []openai.ChatCompletionResponse
+	err := json.Unmarshal(usageResponsesByt, &responses)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var usage llm.ModelUsage
+	for _, response := range responses {
+		usage.Add(callUsage(response.Usage))
+	}
+
+	// Cached and reasoning tokens are already part of the prompt and completion tokens
+	if usage.InputTokens != 380+1950 {
+		t.Errorf("expected the prompt tokens of both rounds, got %d", usage.InputTokens)
+	}
+
+	if usage.OutputTokens != 210+340 {
+		t.Errorf("expected the completion tokens of both rounds, got %d", usage.OutputTokens)
+	}
+}
@@ -0,0 +1,208 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+const (
+	ResponseFormatText       = "text"
+	ResponseFormatJSONObject = "json_object"
+	ResponseFormatJSONSchema = "json_schema"
+)
+
+// DefaultSchemaName is used for schemas without a name.
+const DefaultSchemaName = "response"
+
+// ErrInvalidOutput is returned if a model doesn't return valid JSON for the response format.
+var ErrInvalidOutput = errors.New("invalid model output")
+
+// ResponseFormat defines the format of the completion.
+type ResponseFormat struct {
+	// Type is either ResponseFormatText, ResponseFormatJSONObject or ResponseFormatJSONSchema
+	Type string `json:"type,omitempty" bson:"type,omitempty"`
+
+	// Name of the schema
+	Name string `json:"name,omitempty" bson:"name,omitempty"`
+
+	// Schema the completion has to match, only used for ResponseFormatJSONSchema
+	Schema *Schema `json:"schema,omitempty" bson:"schema,omitempty"`
+}
+
+// Schema is the subset of JSON schema that is supported by all providers.
+type Schema struct {
+	Type        string             `json:"type,omitempty" bson:"type,omitempty"`
+	Description string             `json:"description,omitempty" bson:"description,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty" bson:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty" bson:"required,omitempty"`
+	Items       *Schema            `json:"items,omitempty" bson:"items,omitempty"`
+	Enum        []string           `json:"enum,omitempty" bson:"enum,omitempty"`
+}
+
+// IsJSON returns true if the completion has to be JSON.
+func (format *ResponseFormat) IsJSON() bool {
+	return format != nil && (format.Type == ResponseFormatJSONObject || format.Type == ResponseFormatJSONSchema)
+}
+
+// SchemaName returns the name of the schema or DefaultSchemaName.
+func (format *ResponseFormat) SchemaName() string {
+	if format.Name == "" {
+		return DefaultSchemaName
+	}
+
+	return format.Name
+}
+
+// ObjectSchema returns the schema of the format. JSON objects without schema accept any object.
+func (format *ResponseFormat) ObjectSchema() *Schema {
+	if format.Schema != nil {
+		return format.Schema
+	}
+
+	return &Schema{Type: "object"}
+}
+
+// Validate checks the format definition.
+func (format *ResponseFormat) Validate() error {
+	switch format.Type {
+	case "", ResponseFormatText, ResponseFormatJSONObject:
+		return nil
+	case ResponseFormatJSONSchema:
+		if format.Schema == nil {
+			return errors.New("json_schema requires a schema")
+		}
+
+		if format.Schema.Type != "object" {
+			return errors.New("schema must describe an object")
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("unknown response format: %s", format.Type)
+	}
+}
+
+// Check returns an error if the content doesn't match the format.
+func (format *ResponseFormat) Check(content string) error {
+	if !format.IsJSON() {
+		return nil
+	}
+
+	var value interface{}
+	err := json.Unmarshal([]byte(content), &value)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidOutput, err)
+	}
+
+	err = format.ObjectSchema().Validate(value)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidOutput, err)
+	}
+
+	return nil
+}
+
+// Validate checks a decoded JSON value against the schema.
+func (schema *Schema) Validate(value interface{}) error {
+	return schema.validate("$", value)
+}
+
+func (schema *Schema) validate(path string, value interface{}) error {
+	switch schema.Type {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s must be an object", path)
+		}
+
+		for _, name := range schema.Required {
+			if _, ok := object[name]; !ok {
+				return fmt.Errorf("%s.%s is required", path, name)
+			}
+		}
+
+		for name, property := range schema.Properties {
+			if item, ok := object[name]; ok {
+				if err := property.validate(path+"."+name, item); err != nil {
+					return err
+				}
+			}
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s must be an array", path)
+		}
+
+		if schema.Items != nil {
+			for idx, item := range items {
+				if err := schema.Items.validate(fmt.Sprintf("%s[%d]", path, idx), item); err != nil {
+					return err
+				}
+			}
+		}
+	case "string":
+		text, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%s must be a string", path)
+		}
+
+		if len(schema.Enum) > 0 && !slices.Contains(schema.Enum, text) {
+			return fmt.Errorf("%s must be one of %v", path, schema.Enum)
+		}
+	case "number":
+		if _, ok := value.(float64); !ok {
+			return fmt.Errorf("%s must be a number", path)
+		}
+	case "integer":
+		number, ok := value.(float64)
+		if !ok || number != float64(int64(number)) {
+			return fmt.Errorf("%s must be an integer", path)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s must be a boolean", path)
+		}
+	}
+
+	return nil
+}
+
+// StructuredCompletion runs a completion and checks the result against the response
+// format of the request. Malformed output is retried once with the validation error
+// before ErrInvalidOutput is returned. The response is returned with the errors of the
+// retry, so that the usage of both attempts can be recorded.
+func StructuredCompletion(ctx context.Context, model Chat, req *CompletionRequest) (*CompletionResponse, error) {
+	response, err := model.Completion(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	checkErr := req.ResponseFormat.Check(response.Messages[len(response.Messages)-1].Content)
+	if checkErr == nil {
+		return response, nil
+	}
+
+	retry := *req
+	retry.Messages = append(response.Messages, &Message{
+		Role:    RoleUser,
+		Content: fmt.Sprintf("Your answer is not valid: %v. Answer only with JSON that matches the requested format.", checkErr),
+	})
+
+	retried, err := model.Completion(ctx, &retry)
+	if err != nil {
+		return response, err
+	}
+
+	retried.Usage.Add(response.Usage)
+
+	err = req.ResponseFormat.Check(retried.Messages[len(retried.Messages)-1].Content)
+	if err != nil {
+		return retried, err
+	}
+
+	return retried, nil
+}
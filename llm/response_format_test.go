@@ -0,0 +1,118 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestResponseFormatCheck(t *testing.T) {
+	format := &ResponseFormat{
+		Type: ResponseFormatJSONSchema,
+		Schema: &Schema{
+			Type: "object",
+			Properties: map[string]*Schema{
+				"title": {Type: "string"},
+				"pages": {Type: "integer"},
+				"tags": {
+					Type:  "array",
+					Items: &Schema{Type: "string", Enum: []string{"a", "b"}},
+				},
+			},
+			Required: []string{"title"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		valid   bool
+	}{
+		{"valid", `{"title": "Go", "pages": 3, "tags": ["a"]}`, true},
+		{"only required", `{"title": "Go"}`, true},
+		{"missing required", `{"pages": 3}`, false},
+		{"wrong type", `{"title": 1}`, false},
+		{"fractional integer", `{"title": "Go", "pages": 1.5}`, false},
+		{"enum violation", `{"title": "Go", "tags": ["c"]}`, false},
+		{"not an object", `["Go"]`, false},
+		{"malformed", `{"title": "Go"`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := format.Check(tt.content)
+			if tt.valid && err != nil {
+				t.Fatalf("Check() = %v, want nil", err)
+			}
+
+			if !tt.valid && !errors.Is(err, ErrInvalidOutput) {
+				t.Fatalf("Check() = %v, want ErrInvalidOutput", err)
+			}
+		})
+	}
+}
+
+func TestResponseFormatText(t *testing.T) {
+	var format *ResponseFormat
+	if err := format.Check("plain text"); err != nil {
+		t.Fatalf("Check() = %v, want nil", err)
+	}
+
+	format = &ResponseFormat{Type: ResponseFormatJSONObject}
+	if err := format.Check(`{"any": true}`); err != nil {
+		t.Fatalf("Check() = %v, want nil", err)
+	}
+
+	if err := format.Check("plain text"); err == nil {
+		t.Fatal("Check() = nil, want error")
+	}
+}
+
+// scriptedChat answers with the contents in order and fails once they run out.
+type scriptedChat struct {
+	answers []string
+}
+
+func (chat *scriptedChat) Completion(_ context.Context, req *CompletionRequest) (*CompletionResponse, error) {
+	if len(chat.answers) == 0 {
+		return nil, errors.New("unavailable")
+	}
+
+	answer := chat.answers[0]
+	chat.answers = chat.answers[1:]
+
+	return &CompletionResponse{
+		Messages: append(req.Messages, &Message{Role: RoleAssistant, Content: answer}),
+		Usage:    ModelUsage{InputTokens: 10, OutputTokens: 5},
+	}, nil
+}
+
+func (chat *scriptedChat) ProvidesModel(string) bool {
+	return true
+}
+
+func TestStructuredCompletion(t *testing.T) {
+	req := &CompletionRequest{ResponseFormat: &ResponseFormat{Type: ResponseFormatJSONObject}}
+
+	chat := &scriptedChat{answers: []string{"plain text", `{"valid": true}`}}
+	response, err := StructuredCompletion(context.Background(), chat, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Usage.InputTokens != 20 || response.Usage.OutputTokens != 10 {
+		t.Fatalf("expected the usage of both attempts, got %+v", response.Usage)
+	}
+
+	chat = &scriptedChat{answers: []string{"plain text", "still text"}}
+	response, err = StructuredCompletion(context.Background(), chat, req)
+	if !errors.Is(err, ErrInvalidOutput) || response == nil || response.Usage.InputTokens != 20 {
+		t.Fatalf("expected ErrInvalidOutput with the usage of both attempts, got %v", err)
+	}
+
+	// The first attempt is paid for even if the retry fails
+	chat = &scriptedChat{answers: []string{"plain text"}}
+	response, err = StructuredCompletion(context.Background(), chat, req)
+	if err == nil || response == nil || response.Usage.InputTokens != 10 {
+		t.Fatalf("expected the error with the usage of the first attempt, got %v", err)
+	}
+}
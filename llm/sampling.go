@@ -0,0 +1,72 @@
+package llm
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	// DefaultTemperature is used if no temperature is set.
+	DefaultTemperature = 1.0
+
+	// DefaultTopP is used if no top-p is set, which disables nucleus sampling.
+	DefaultTopP = 1.0
+
+	// MaxTemperature is the highest temperature supported by most providers.
+	MaxTemperature = 2.0
+
+	// MaxReasoningBudget is the highest number of tokens a model may spend on reasoning.
+	MaxReasoningBudget = 32_000
+)
+
+// maxTemperatures defines the highest temperature for models with a lower limit by prefix.
+var maxTemperatures = map[string]float32{
+	"anthropic.": 1.0,
+}
+
+// MaxTemperatureFor returns the highest temperature supported by a model.
+func MaxTemperatureFor(model string) float32 {
+	for prefix, limit := range maxTemperatures {
+		if strings.HasPrefix(model, prefix) {
+			return limit
+		}
+	}
+
+	return MaxTemperature
+}
+
+// ValidateSampling validates the sampling parameters of a model and returns them with
+// defaults for unset values. The temperature must be in [0, MaxTemperatureFor(model)]
+// and top-p in (0, 1].
+func ValidateSampling(model string, temperature, topP *float32) (float32, float32, error) {
+	temp := float32(DefaultTemperature)
+	if temperature != nil {
+		temp = *temperature
+	}
+
+	nucleus := float32(DefaultTopP)
+	if topP != nil {
+		nucleus = *topP
+	}
+
+	limit := MaxTemperatureFor(model)
+	if math.IsNaN(float64(temp)) || temp < 0 || temp > limit {
+		return 0, 0, fmt.Errorf("temperature must be in [0, %v] for %s, got %v", limit, model, temp)
+	}
+
+	if math.IsNaN(float64(nucleus)) || nucleus <= 0 || nucleus > 1 {
+		return 0, 0, fmt.Errorf("top_p must be in (0, 1], got %v", nucleus)
+	}
+
+	return temp, nucleus, nil
+}
+
+// ValidateReasoningBudget checks that a reasoning budget is at most MaxReasoningBudget.
+func ValidateReasoningBudget(budget uint32) error {
+	if budget > MaxReasoningBudget {
+		return fmt.Errorf("reasoning budget %d exceeds the limit of %d", budget, MaxReasoningBudget)
+	}
+
+	return nil
+}
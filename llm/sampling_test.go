@@ -0,0 +1,52 @@
+package llm
+
+import "testing"
+
+func TestValidateSampling(t *testing.T) {
+	value := func(v float32) *float32 { return &v }
+
+	tests := []struct {
+		name        string
+		model       string
+		temperature *float32
+		topP        *float32
+		wantTemp    float32
+		wantTopP    float32
+		wantErr     bool
+	}{
+		{"defaults", "openai.gpt-4o", nil, nil, DefaultTemperature, DefaultTopP, false},
+		{"valid", "openai.gpt-4o", value(1.5), value(0.9), 1.5, 0.9, false},
+		{"zero temperature", "openai.gpt-4o", value(0), nil, 0, DefaultTopP, false},
+		{"openai upper limit", "openai.gpt-4o", value(2), value(1), 2, 1, false},
+		{"temperature too high", "openai.gpt-4o", value(5), value(1), 0, 0, true},
+		{"negative temperature", "openai.gpt-4o", value(-1), value(1), 0, 0, true},
+		{"zero top-p", "openai.gpt-4o", value(1), value(0), 0, 0, true},
+		{"negative top-p", "openai.gpt-4o", value(1), value(-0.1), 0, 0, true},
+		{"top-p too high", "openai.gpt-4o", value(1), value(1.5), 0, 0, true},
+		{"anthropic limit", "anthropic.claude-3-haiku-20240307-v1:0", value(1.5), value(1), 0, 0, true},
+		{"anthropic valid", "anthropic.claude-3-haiku-20240307-v1:0", value(0.7), nil, 0.7, DefaultTopP, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			temperature, topP, err := ValidateSampling(tt.model, tt.temperature, tt.topP)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateSampling() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err == nil && (temperature != tt.wantTemp || topP != tt.wantTopP) {
+				t.Fatalf("ValidateSampling() = (%v, %v), want (%v, %v)", temperature, topP, tt.wantTemp, tt.wantTopP)
+			}
+		})
+	}
+}
+
+func TestValidateReasoningBudget(t *testing.T) {
+	if err := ValidateReasoningBudget(MaxReasoningBudget); err != nil {
+		t.Fatalf("expected the limit to be valid, got %v", err)
+	}
+
+	if err := ValidateReasoningBudget(MaxReasoningBudget + 1); err == nil {
+		t.Fatal("expected budgets above the limit to fail")
+	}
+}
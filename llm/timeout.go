@@ -0,0 +1,27 @@
+package llm
+
+import (
+	"context"
+	"os"
+	"time"
+)
+
+// DefaultCallTimeout limits the duration of a single provider call.
+const DefaultCallTimeout = 2 * time.Minute
+
+// CallTimeout returns the timeout for provider calls configured by CHATBOT_LLM_TIMEOUT
+// or DefaultCallTimeout if not set.
+func CallTimeout() time.Duration {
+	timeout, err := time.ParseDuration(os.Getenv("CHATBOT_LLM_TIMEOUT"))
+	if err != nil || timeout <= 0 {
+		return DefaultCallTimeout
+	}
+
+	return timeout
+}
+
+// WithCallTimeout returns a context for a single provider call. It is canceled when
+// the parent is canceled or the call timeout expires, whichever comes first.
+func WithCallTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, CallTimeout())
+}
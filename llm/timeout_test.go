@@ -0,0 +1,50 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCallTimeout(t *testing.T) {
+	tests := map[string]time.Duration{
+		"":        DefaultCallTimeout,
+		"invalid": DefaultCallTimeout,
+		"-5s":     DefaultCallTimeout,
+		"45s":     45 * time.Second,
+	}
+
+	for value, want := range tests {
+		t.Setenv("CHATBOT_LLM_TIMEOUT", value)
+
+		if got := CallTimeout(); got != want {
+			t.Errorf("CallTimeout with %q = %v, want %v", value, got, want)
+		}
+	}
+}
+
+func TestWithCallTimeout(t *testing.T) {
+	t.Setenv("CHATBOT_LLM_TIMEOUT", "20ms")
+
+	ctx, cnl := WithCallTimeout(context.Background())
+	defer cnl()
+
+	<-ctx.Done()
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		t.Fatalf("expected the call to time out, got %v", ctx.Err())
+	}
+
+	// Canceled clients end the call before the timeout
+	t.Setenv("CHATBOT_LLM_TIMEOUT", "1h")
+
+	parent, cancel := context.WithCancel(context.Background())
+	ctx, cnl = WithCallTimeout(parent)
+	defer cnl()
+
+	cancel()
+	<-ctx.Done()
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		t.Fatalf("expected the call to be canceled, got %v", ctx.Err())
+	}
+}
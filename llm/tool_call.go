@@ -0,0 +1,74 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"runtime/debug"
+	"time"
+)
+
+// DefaultToolTimeout limits the duration of a tool call without a timeout.
+const DefaultToolTimeout = 30 * time.Second
+
+// ToolError is the result of a failed tool call that is returned to the model,
+// so that it can recover or explain the failure instead of aborting the completion.
+type ToolError struct {
+	Error string `json:"error"`
+}
+
+type toolResult struct {
+	output string
+	err    error
+}
+
+// SafeCall calls the tool with its timeout and isolates the completion from the
+// failures of the tool. Errors, timeouts and panics are returned as ToolError
+// results, so the returned error is always nil. Results are limited to the
+// maximum result size of the tool.
+func (tool *ToolDefinition) SafeCall(ctx context.Context, input map[string]interface{}) (string, error) {
+	timeout := tool.Timeout
+	if timeout <= 0 {
+		timeout = DefaultToolTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Buffered, so that a tool that ignores the context doesn't leak the goroutine forever
+	results := make(chan toolResult, 1)
+	go func() {
+		defer func() {
+			if rec := recover(); rec != nil {
+				log.Printf("tool %s panicked: %v\n%s", tool.Name, rec, debug.Stack())
+				results <- toolResult{err: fmt.Errorf("internal error")}
+			}
+		}()
+
+		output, err := tool.Call(ctx, input)
+		results <- toolResult{output: output, err: err}
+	}()
+
+	var result toolResult
+	select {
+	case result = <-results:
+	case <-ctx.Done():
+		result.err = fmt.Errorf("timed out after %v", timeout)
+	}
+
+	if result.err == nil {
+		return tool.limitResult(result.output), nil
+	}
+
+	log.Printf("tool %s failed: %v", tool.Name, result.err)
+
+	byt, err := json.Marshal(ToolError{
+		Error: fmt.Sprintf("tool %s failed: %v", tool.Name, result.err),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(byt), nil
+}
@@ -0,0 +1,120 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSafeCall(t *testing.T) {
+	tests := []struct {
+		name    string
+		call    FunctionCall
+		want    string
+		wantErr string
+	}{
+		{
+			name: "success",
+			call: func(context.Context, map[string]interface{}) (string, error) {
+				return "ok", nil
+			},
+			want: "ok",
+		},
+		{
+			name: "error",
+			call: func(context.Context, map[string]interface{}) (string, error) {
+				return "", errors.New("database unavailable")
+			},
+			wantErr: "database unavailable",
+		},
+		{
+			name: "panic",
+			call: func(context.Context, map[string]interface{}) (string, error) {
+				panic("nil map")
+			},
+			wantErr: "internal error",
+		},
+		{
+			name: "timeout",
+			call: func(ctx context.Context, _ map[string]interface{}) (string, error) {
+				time.Sleep(time.Second)
+				return "too late", nil
+			},
+			wantErr: "timed out",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tool := &ToolDefinition{
+				Name:    "test",
+				Call:    tt.call,
+				Timeout: 20 * time.Millisecond,
+			}
+
+			got, err := tool.SafeCall(context.Background(), nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantErr == "" {
+				if got != tt.want {
+					t.Fatalf("got %q, want %q", got, tt.want)
+				}
+				return
+			}
+
+			var result ToolError
+			if err := json.Unmarshal([]byte(got), &result); err != nil {
+				t.Fatalf("result is no tool error: %q", got)
+			}
+
+			if !strings.Contains(result.Error, tt.wantErr) {
+				t.Fatalf("got error %q, want %q", result.Error, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSafeCallLimitsResult(t *testing.T) {
+	long := strings.Repeat("ä", 100)
+
+	tool := &ToolDefinition{
+		Name: "test",
+		Call: func(context.Context, map[string]interface{}) (string, error) {
+			return long, nil
+		},
+		MaxResultBytes: 120,
+	}
+
+	got, err := tool.SafeCall(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(got) > tool.MaxResultBytes {
+		t.Fatalf("result of %d bytes exceeds the limit", len(got))
+	}
+
+	var result TruncatedResult
+	if err := json.Unmarshal([]byte(got), &result); err != nil {
+		t.Fatalf("result is no truncated result: %q", got)
+	}
+
+	if !result.Truncated || result.Content == "" || !strings.HasPrefix(long, result.Content) {
+		t.Errorf("unexpected truncated result: %+v", result)
+	}
+
+	// Tools with a shrink function shorten their own results
+	tool.Shrink = func(output string, maxBytes int) (string, error) {
+		return "shrunk", nil
+	}
+
+	got, _ = tool.SafeCall(context.Background(), nil)
+	if got != "shrunk" {
+		t.Errorf("expected the shrunk result, got %q", got)
+	}
+}
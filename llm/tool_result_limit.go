@@ -0,0 +1,102 @@
+package llm
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"unicode/utf8"
+)
+
+// DefaultMaxToolResultBytes limits the size of a tool result, about 25k tokens.
+const DefaultMaxToolResultBytes = 96 * 1024
+
+// MaxToolResultBytes returns the limit of tool results configured by
+// CHATBOT_MAX_TOOL_RESULT_BYTES or DefaultMaxToolResultBytes if not set.
+func MaxToolResultBytes() int {
+	limit, err := strconv.Atoi(os.Getenv("CHATBOT_MAX_TOOL_RESULT_BYTES"))
+	if err != nil || limit <= 0 {
+		return DefaultMaxToolResultBytes
+	}
+
+	return limit
+}
+
+// MaxToolResultTokens returns a conservative estimate of the tokens of a tool
+// result of the maximum size.
+func MaxToolResultTokens() int {
+	return (MaxToolResultBytes() + bytesPerToken - 1) / bytesPerToken
+}
+
+// TruncatedResult replaces a tool result that exceeds the limit and can't be
+// shrunk by its tool. Content is the beginning of the original result.
+type TruncatedResult struct {
+	Truncated bool   `json:"truncated"`
+	Note      string `json:"note"`
+	Content   string `json:"content"`
+}
+
+// limitResult enforces the result limit of the tool. Results of tools with a
+// Shrink function are shrunk by the tool, e.g. by omitting the least relevant
+// items, all other results are cut and wrapped in a TruncatedResult.
+func (tool *ToolDefinition) limitResult(output string) string {
+	limit := tool.MaxResultBytes
+	if limit <= 0 {
+		limit = MaxToolResultBytes()
+	}
+
+	if len(output) <= limit {
+		return output
+	}
+
+	if tool.Shrink != nil {
+		shrunk, err := tool.Shrink(output, limit)
+		if err == nil && len(shrunk) <= limit {
+			log.Printf("tool %s: shrunk result from %d to %d bytes", tool.Name, len(output), len(shrunk))
+			return shrunk
+		}
+
+		log.Printf("tool %s: failed to shrink result of %d bytes: %v", tool.Name, len(output), err)
+	}
+
+	log.Printf("tool %s: truncated result of %d bytes to %d bytes", tool.Name, len(output), limit)
+
+	result := TruncatedResult{
+		Truncated: true,
+		Note:      fmt.Sprintf("The result of %d bytes exceeded the limit of %d bytes and was cut.", len(output), limit),
+	}
+
+	// Escaping can grow the content, so it's cut until the encoded result fits
+	cut := limit
+	for cut > 0 {
+		result.Content = truncateUTF8(output, cut)
+
+		byt, err := json.Marshal(result)
+		if err != nil {
+			break
+		}
+		if len(byt) <= limit {
+			return string(byt)
+		}
+
+		cut -= len(byt) - limit
+	}
+
+	result.Content = ""
+	byt, _ := json.Marshal(result)
+	return string(byt)
+}
+
+// truncateUTF8 returns at most maxBytes bytes of the text without splitting a rune.
+func truncateUTF8(text string, maxBytes int) string {
+	if len(text) <= maxBytes {
+		return text
+	}
+
+	for maxBytes > 0 && !utf8.RuneStart(text[maxBytes]) {
+		maxBytes--
+	}
+
+	return text[:maxBytes]
+}
@@ -15,6 +15,21 @@ const (
 	RoleModel = "model"
 )
 
+// sendMessage sends the parts to the chat session limited by the call timeout.
+func sendMessage(ctx context.Context, chat *genai.ChatSession, parts ...genai.Part) (*genai.GenerateContentResponse, error) {
+	ctx, cnl := llm.WithCallTimeout(ctx)
+	defer cnl()
+
+	gen, err := chat.SendMessage(ctx, parts...)
+
+	var blocked *genai.BlockedError
+	if errors.As(err, &blocked) {
+		return nil, safetyError(blocked)
+	}
+
+	return gen, err
+}
+
 func (client *Client) Completion(ctx context.Context, req *llm.CompletionRequest) (*llm.CompletionResponse, error) {
 	if len(req.Messages) == 0 {
 		return nil, errors.New("no messages")
@@ -22,6 +37,15 @@ func (client *Client) Completion(ctx context.Context, req *llm.CompletionRequest
 
 	modelName, _ := strings.CutPrefix(req.Model, modelPrefix)
 
+	if llm.HasImages(req.Messages) && !supportsImages(modelName) {
+		return nil, llm.ErrImagesNotSupported
+	}
+
+	// Gemini only reads images from Cloud Storage
+	if err := llm.CheckImageURLs(req.Messages, "gs"); err != nil {
+		return nil, err
+	}
+
 	outputTokens := int32(req.MaxTokens)
 	tools := toolConverter(req.Tools)
 
@@ -39,6 +63,7 @@ func (client *Client) Completion(ctx context.Context, req *llm.CompletionRequest
 		Parts: []genai.Part{genai.Text(req.SystemPrompt)},
 	}
 	model.Tools = tools.toVertex()
+	setResponseFormat(model, req.ResponseFormat)
 
 	chat := model.StartChat()
 
@@ -50,29 +75,31 @@ func (client *Client) Completion(ctx context.Context, req *llm.CompletionRequest
 
 	// Remove the last message from the history, because the last message needs to be sent to the model
 	chat.History = history[:len(history)-1]
-	gen, err := chat.SendMessage(ctx, history[len(history)-1].Parts...)
+	gen, err := sendMessage(ctx, chat, history[len(history)-1].Parts...)
 	if err != nil {
 		return nil, err
 	}
 
 	// Check if the model returned a response
-	if len(gen.Candidates) == 0 || len(gen.Candidates[0].Content.Parts) == 0 {
-		return nil, nil
+	if err = checkResponse(gen); err != nil {
+		return nil, err
 	}
 
 	usage := llm.ModelUsage{
 		UserId: req.UserId,
 		Model:  modelName,
 	}
+	usage.Add(callUsage{gen.UsageMetadata})
+
+	for idx := 0; idx < llm.MaxToolIterations; idx++ {
+		var calls []genai.FunctionCall
+		for _, part := range gen.Candidates[0].Content.Parts {
+			if fun, ok := part.(genai.FunctionCall); ok {
+				calls = append(calls, fun)
+			}
+		}
 
-	if gen.UsageMetadata != nil {
-		usage.InputTokens = uint32(gen.UsageMetadata.PromptTokenCount)
-		usage.OutputTokens = uint32(gen.UsageMetadata.CandidatesTokenCount)
-	}
-
-	for idx := 0; idx < 6; idx++ {
-		fun, ok := gen.Candidates[0].Content.Parts[0].(genai.FunctionCall)
-		if !ok {
+		if len(calls) == 0 {
 			break
 		}
 
@@ -83,59 +110,71 @@ func (client *Client) Completion(ctx context.Context, req *llm.CompletionRequest
 			},
 		}
 
-		// Add the function call to the history
+		// Add the function calls to the history
 		history = append(history, &genai.Content{
 			Role:  RoleModel,
-			Parts: []genai.Part{fun},
+			Parts: gen.Candidates[0].Content.Parts,
 		})
 
-		call, found := tools.getFunction(fun.Name)
-		if !found {
-			return nil, fmt.Errorf("unknown tool %s", fun.Name)
+		var functionResults []genai.Part
+		for _, fun := range calls {
+			call, found := tools.getFunction(fun.Name)
+			if !found {
+				return nil, fmt.Errorf("unknown tool %s", fun.Name)
+			}
+
+			input := make(map[string]interface{})
+			for key, value := range fun.Args {
+				input[key] = value
+			}
+
+			// Call the function to get the result
+			resultStr, err := call(ctx, input)
+			if err != nil {
+				return nil, err
+			}
+
+			// Parse the result
+			var results map[string]interface{}
+			err = json.Unmarshal([]byte(resultStr), &results)
+			if err != nil {
+				return nil, err
+			}
+
+			functionResults = append(functionResults, genai.FunctionResponse{
+				Name:     fun.Name,
+				Response: results,
+			})
 		}
 
-		input := make(map[string]interface{})
-		for key, value := range fun.Args {
-			input[key] = value
-		}
-
-		// Call the function to get the result
-		resultStr, err := call(ctx, input)
-		if err != nil {
-			return nil, err
-		}
-
-		// Parse the result
-		var results map[string]interface{}
-		err = json.Unmarshal([]byte(resultStr), &results)
-		if err != nil {
-			return nil, err
-		}
-
-		functionResults := genai.FunctionResponse{
-			Name:     fun.Name,
-			Response: results,
-		}
 		history = append(history, &genai.Content{
 			Role:  RoleUser,
-			Parts: []genai.Part{functionResults},
+			Parts: functionResults,
 		})
 		chat.History = history[:len(history)-1]
 
-		gen, err = chat.SendMessage(ctx, functionResults)
+		gen, err = sendMessage(ctx, chat, functionResults...)
 		if err != nil {
 			return nil, err
 		}
 
-		if gen.UsageMetadata != nil {
-			usage.InputTokens += uint32(gen.UsageMetadata.PromptTokenCount)
-			usage.OutputTokens += uint32(gen.UsageMetadata.CandidatesTokenCount)
+		if err = checkResponse(gen); err != nil {
+			return nil, err
+		}
+
+		usage.Add(callUsage{gen.UsageMetadata})
+	}
+
+	// The answer may be split into multiple text parts
+	var txt strings.Builder
+	for _, part := range gen.Candidates[0].Content.Parts {
+		if text, ok := part.(genai.Text); ok {
+			txt.WriteString(string(text))
 		}
 	}
 
-	txt, ok := gen.Candidates[0].Content.Parts[0].(genai.Text)
-	if !ok {
-		return nil, nil
+	if txt.Len() == 0 {
+		return nil, fmt.Errorf("model returned non-text content: %T", gen.Candidates[0].Content.Parts[0])
 	}
 
 	thread, err := transformToMessages(history)
@@ -145,7 +184,7 @@ func (client *Client) Completion(ctx context.Context, req *llm.CompletionRequest
 
 	thread = append(thread, &llm.Message{
 		Role:    llm.RoleAssistant,
-		Content: strings.TrimSpace(string(txt)),
+		Content: strings.TrimSpace(txt.String()),
 	})
 
 	return &llm.CompletionResponse{
@@ -0,0 +1,100 @@
+package vertex
+
+import (
+	"cloud.google.com/go/vertexai/genai"
+	"errors"
+	"fmt"
+	"github.com/pzierahn/chatbot_services/llm"
+	"strings"
+)
+
+// blockedCategories returns the categories of all ratings that blocked the content.
+func blockedCategories(ratings []*genai.SafetyRating) []string {
+	var categories []string
+	for _, rating := range ratings {
+		if rating != nil && rating.Blocked {
+			categories = append(categories, rating.Category.String())
+		}
+	}
+
+	return categories
+}
+
+// safetyError converts a blocked response into a llm.SafetyError.
+func safetyError(blocked *genai.BlockedError) *llm.SafetyError {
+	if blocked.PromptFeedback != nil {
+		return &llm.SafetyError{
+			Reason:     fmt.Sprintf("prompt blocked: %s", blocked.PromptFeedback.BlockReason),
+			Categories: blockedCategories(blocked.PromptFeedback.SafetyRatings),
+		}
+	}
+
+	if blocked.Candidate != nil {
+		return &llm.SafetyError{
+			Reason:     fmt.Sprintf("response blocked: %s", blocked.Candidate.FinishReason),
+			Categories: blockedCategories(blocked.Candidate.SafetyRatings),
+		}
+	}
+
+	return &llm.SafetyError{Reason: "blocked"}
+}
+
+// describeRatings lists the categories of the safety ratings, marking those that blocked the response.
+func describeRatings(ratings []*genai.SafetyRating) string {
+	var parts []string
+	for _, rating := range ratings {
+		if rating == nil {
+			continue
+		}
+
+		part := fmt.Sprintf("%s=%s", rating.Category, rating.Probability)
+		if rating.Blocked {
+			part += " (blocked)"
+		}
+		parts = append(parts, part)
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// checkResponse returns an error if the response contains no content. The error
+// includes the finish reason and safety ratings to distinguish an empty answer
+// from a blocked one.
+func checkResponse(gen *genai.GenerateContentResponse) error {
+	if gen == nil {
+		return errors.New("model returned no response")
+	}
+
+	if len(gen.Candidates) == 0 {
+		if gen.PromptFeedback != nil && gen.PromptFeedback.BlockReason != genai.BlockedReasonUnspecified {
+			return safetyError(&genai.BlockedError{PromptFeedback: gen.PromptFeedback})
+		}
+
+		if gen.PromptFeedback != nil {
+			return fmt.Errorf("model returned no content: prompt blocked: %s (%s)",
+				gen.PromptFeedback.BlockReason, describeRatings(gen.PromptFeedback.SafetyRatings))
+		}
+
+		return errors.New("model returned no content")
+	}
+
+	candidate := gen.Candidates[0]
+	if candidate.FinishReason == genai.FinishReasonSafety {
+		return safetyError(&genai.BlockedError{Candidate: candidate})
+	}
+
+	if candidate.Content == nil || len(candidate.Content.Parts) == 0 {
+		msg := fmt.Sprintf("model returned no content: finish reason %s", candidate.FinishReason)
+		if candidate.FinishMessage != "" {
+			msg += ": " + candidate.FinishMessage
+		}
+
+		if ratings := describeRatings(candidate.SafetyRatings); ratings != "" {
+			msg += " (" + ratings + ")"
+		}
+
+		return errors.New(msg)
+	}
+
+	return nil
+}
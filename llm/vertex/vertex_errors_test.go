@@ -0,0 +1,54 @@
+package vertex
+
+import (
+	"cloud.google.com/go/vertexai/genai"
+	"strings"
+	"testing"
+)
+
+func TestCheckResponse(t *testing.T) {
+	tests := map[string]struct {
+		gen  *genai.GenerateContentResponse
+		want string
+	}{
+		"no response": {
+			gen:  nil,
+			want: "no response",
+		},
+		"no candidates": {
+			gen:  &genai.GenerateContentResponse{},
+			want: "no content",
+		},
+		"no parts": {
+			gen: &genai.GenerateContentResponse{
+				Candidates: []*genai.Candidate{{
+					FinishReason:  genai.FinishReasonMaxTokens,
+					FinishMessage: "too long",
+					SafetyRatings: []*genai.SafetyRating{{
+						Category:    genai.HarmCategoryHateSpeech,
+						Probability: genai.HarmProbabilityNegligible,
+					}},
+				}},
+			},
+			want: "finish reason FinishReasonMaxTokens: too long (HarmCategoryHateSpeech=HarmProbabilityNegligible)",
+		},
+	}
+
+	for name, tt := range tests {
+		err := checkResponse(tt.gen)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: expected an error containing %q, got %v", name, tt.want, err)
+		}
+	}
+
+	answer := &genai.GenerateContentResponse{
+		Candidates: []*genai.Candidate{{
+			FinishReason: genai.FinishReasonStop,
+			Content:      &genai.Content{Parts: []genai.Part{genai.Text("answer")}},
+		}},
+	}
+
+	if err := checkResponse(answer); err != nil {
+		t.Fatalf("expected answers to pass, got %v", err)
+	}
+}
@@ -48,6 +48,20 @@ var ModelCosts = map[string]llm.PricePer1000Tokens{
 	},
 }
 
+// ContextWindows of the model families in tokens
+var ContextWindows = map[string]int{
+	"gemini-2.0":       1_048_576,
+	"gemini-1.5-pro":   2_097_152,
+	"gemini-1.5-flash": 1_048_576,
+	"gemini-1.0-pro":   32_760,
+	"gemini-pro":       32_760,
+}
+
+func init() {
+	llm.RegisterPrices(ModelCosts)
+	llm.RegisterContextWindows(ContextWindows)
+}
+
 func (client *Client) ProvidesModel(name string) bool {
 	switch {
 	case strings.HasPrefix(name, modelPrefix):
@@ -58,3 +72,9 @@ func (client *Client) ProvidesModel(name string) bool {
 		return false
 	}
 }
+
+// supportsImages reports whether the model accepts image content. Only the
+// Gemini 1.0 Pro models are text-only.
+func supportsImages(model string) bool {
+	return model != "gemini-pro" && !strings.HasPrefix(model, "gemini-1.0-pro")
+}
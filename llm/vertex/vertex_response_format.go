@@ -0,0 +1,49 @@
+package vertex
+
+import (
+	"cloud.google.com/go/vertexai/genai"
+	"github.com/pzierahn/chatbot_services/llm"
+)
+
+var schemaTypes = map[string]genai.Type{
+	"string":  genai.TypeString,
+	"number":  genai.TypeNumber,
+	"integer": genai.TypeInteger,
+	"boolean": genai.TypeBoolean,
+	"array":   genai.TypeArray,
+	"object":  genai.TypeObject,
+}
+
+// toSchema converts a JSON schema to a Vertex schema.
+func toSchema(schema *llm.Schema) *genai.Schema {
+	if schema == nil {
+		return nil
+	}
+
+	converted := &genai.Schema{
+		Type:        schemaTypes[schema.Type],
+		Description: schema.Description,
+		Required:    schema.Required,
+		Enum:        schema.Enum,
+		Items:       toSchema(schema.Items),
+	}
+
+	if len(schema.Properties) > 0 {
+		converted.Properties = make(map[string]*genai.Schema)
+		for name, property := range schema.Properties {
+			converted.Properties[name] = toSchema(property)
+		}
+	}
+
+	return converted
+}
+
+// setResponseFormat configures the model to respond with JSON of the given format.
+func setResponseFormat(model *genai.GenerativeModel, format *llm.ResponseFormat) {
+	if !format.IsJSON() {
+		return
+	}
+
+	model.ResponseMIMEType = "application/json"
+	model.ResponseSchema = toSchema(format.Schema)
+}
@@ -50,7 +50,7 @@ func (list toolConverter) toVertex() (tools []*genai.Tool) {
 func (list toolConverter) getFunction(name string) (llm.FunctionCall, bool) {
 	for _, tool := range list {
 		if tool.Name == name {
-			return tool.Call, true
+			return tool.SafeCall, true
 		}
 	}
 
@@ -6,6 +6,21 @@ import (
 	"github.com/pzierahn/chatbot_services/llm"
 )
 
+// imagePart converts an image to inline data or a file reference in Cloud Storage.
+func imagePart(image llm.Image) genai.Part {
+	if len(image.Data) == 0 {
+		return genai.FileData{
+			MIMEType: image.MimeType,
+			FileURI:  image.URL,
+		}
+	}
+
+	return genai.Blob{
+		MIMEType: image.MimeType,
+		Data:     image.Data,
+	}
+}
+
 // transformToHistory transforms a list of messages to a list of history items
 func transformToHistory(messages []*llm.Message) ([]*genai.Content, error) {
 	var history []*genai.Content
@@ -19,10 +34,21 @@ func transformToHistory(messages []*llm.Message) ([]*genai.Content, error) {
 			role = RoleModel
 		}
 
+		var parts []genai.Part
+		for _, image := range msg.Images {
+			if !image.Omitted() {
+				parts = append(parts, imagePart(image))
+			}
+		}
+
 		if msg.Content != "" {
+			parts = append(parts, genai.Text(msg.Content))
+		}
+
+		if len(parts) > 0 {
 			history = append(history, &genai.Content{
 				Role:  role,
-				Parts: []genai.Part{genai.Text(msg.Content)},
+				Parts: parts,
 			})
 		}
 
@@ -87,6 +113,16 @@ func transformToMessages(history []*genai.Content) ([]*llm.Message, error) {
 		for _, part := range content.Parts {
 			if txt, ok := part.(genai.Text); ok {
 				message.Content = string(txt)
+			} else if blob, ok := part.(genai.Blob); ok {
+				message.Images = append(message.Images, llm.Image{
+					MimeType: blob.MIMEType,
+					Data:     blob.Data,
+				})
+			} else if file, ok := part.(genai.FileData); ok {
+				message.Images = append(message.Images, llm.Image{
+					MimeType: file.MIMEType,
+					URL:      file.FileURI,
+				})
 			} else if call, ok := part.(genai.FunctionCall); ok {
 				args, err := json.Marshal(call.Args)
 				if err != nil {
@@ -0,0 +1,26 @@
+package vertex
+
+import "cloud.google.com/go/vertexai/genai"
+
+// callUsage is the usage metadata of a single generate call, nil if the
+// response had none.
+type callUsage struct {
+	metadata *genai.UsageMetadata
+}
+
+// TokenCount returns the prompt tokens and the generated tokens. The candidates
+// don't count the thinking tokens of thinking models, which are only part of the
+// total, so the output is derived from the total if it's larger.
+func (usage callUsage) TokenCount() (input, output uint32) {
+	if usage.metadata == nil {
+		return 0, 0
+	}
+
+	prompt := usage.metadata.PromptTokenCount
+	generated := usage.metadata.CandidatesTokenCount
+	if total := usage.metadata.TotalTokenCount; total-prompt > generated {
+		generated = total - prompt
+	}
+
+	return uint32(prompt), uint32(generated)
+}
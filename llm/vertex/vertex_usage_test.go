@@ -0,0 +1,35 @@
+package vertex
+
+import (
+	"cloud.google.com/go/vertexai/genai"
+	_ "embed"
+	"encoding/json"
+	"github.com/pzierahn/chatbot_services/llm"
+	"testing"
+)
+
+//go:embed vertex_usage_test_responses.json
+var usageMetadataByt []byte
+
+func TestUsageTokenCount(t *testing.T) {
+	// Usage metadata of a tool call, a response without metadata and the answer of a thinking model
+	var rounds []*genai.UsageMetadata
+	err := json.Unmarshal(usageMetadataByt, &rounds)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var usage llm.ModelUsage
+	for _, metadata := range rounds {
+		usage.Add(callUsage{metadata})
+	}
+
+	if usage.InputTokens != 530+2105 {
+		t.Errorf("expected the prompt tokens of all rounds, got %d", usage.InputTokens)
+	}
+
+	// The thinking tokens are only part of the total
+	if usage.OutputTokens != 24+(2697-2105) {
+		t.Errorf("expected the generated tokens including thinking, got %d", usage.OutputTokens)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/pzierahn/chatbot_services/llm"
 	"net/http"
 )
 
@@ -39,6 +40,8 @@ type Request struct {
 
 // callAPI calls the Voyage AI API with the given request and returns the response.
 func (voyage *Client) callAPI(ctx context.Context, request *Request) (*Response, error) {
+	ctx, cnl := llm.WithCallTimeout(ctx)
+	defer cnl()
 
 	requestBody, err := json.Marshal(request)
 	if err != nil {
@@ -6,8 +6,13 @@ import (
 )
 
 func (voyage *Client) CreateEmbedding(ctx context.Context, content *llm.EmbeddingRequest) (*llm.EmbeddingResponse, error) {
+	inputs, truncated, err := llm.PrepareInputs(content)
+	if err != nil {
+		return nil, err
+	}
+
 	request := &Request{
-		Input:     content.Inputs,
+		Input:     inputs,
 		Model:     voyage.model,
 		InputType: content.Type,
 	}
@@ -18,8 +23,9 @@ func (voyage *Client) CreateEmbedding(ctx context.Context, content *llm.Embeddin
 	}
 
 	embeddings := &llm.EmbeddingResponse{
-		Model:  response.Model,
-		Tokens: response.Usage.TotalTokens,
+		Model:     response.Model,
+		Tokens:    response.Usage.TotalTokens,
+		Truncated: truncated,
 	}
 
 	for _, output := range response.Data {
@@ -40,6 +46,10 @@ func (voyage *Client) GetEmbeddingDimension() int {
 	}
 }
 
+func (voyage *Client) GetMaxInputTokens() int {
+	return MaxInputTokens
+}
+
 func (voyage *Client) GetModelId() string {
 	return voyage.model
 }
@@ -1,5 +1,7 @@
 package voyageai
 
+import "github.com/pzierahn/chatbot_services/llm"
+
 //
 // Source https://docs.voyageai.com/docs/embeddings
 //
@@ -13,3 +15,19 @@ const (
 	DimensionVoyageLarge2         = 1536
 	DimensionVoyageLarge2Instruct = 1024
 )
+
+// MaxInputTokens is the context length of the large models
+const MaxInputTokens = 16000
+
+var ModelCosts = map[string]llm.PricePer1000Tokens{
+	ModelVoyageLarge2: {
+		Input: 0.00012,
+	},
+	ModelVoyageLarge2Instruct: {
+		Input: 0.00012,
+	},
+}
+
+func init() {
+	llm.RegisterPrices(ModelCosts)
+}
@@ -5,7 +5,39 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
+// DefaultBatchSize defines how many fragments are upserted at once by default. Pinecone
+// limits the size of an upsert request, which larger batches may exceed.
+const DefaultBatchSize = 100
+
 type Migrator struct {
 	Database *mongo.Client
 	Search   search.Index
+
+	// CheckpointFile stores the id of the last migrated document. If set, a
+	// migration resumes after the stored id.
+	CheckpointFile string
+
+	// BatchSize defines how many fragments are upserted at once. Fragments of
+	// multiple documents are combined until the batch is full.
+	BatchSize int
+
+	// SkipPreflight migrates documents even if the preflight check reports
+	// documents without user or collection.
+	SkipPreflight bool
+}
+
+// Summary reports the outcome of a migration run.
+type Summary struct {
+	Migrated int
+	Skipped  int
+	Failed   int
+	Tokens   uint32
+}
+
+func (migrator *Migrator) batchSize() int {
+	if migrator.BatchSize <= 0 {
+		return DefaultBatchSize
+	}
+
+	return migrator.BatchSize
 }
@@ -0,0 +1,41 @@
+package migration
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"os"
+	"strings"
+)
+
+// readCheckpoint returns the id stored in the checkpoint file or uuid.Nil if there is no checkpoint.
+func (migrator *Migrator) readCheckpoint() (uuid.UUID, error) {
+	if migrator.CheckpointFile == "" {
+		return uuid.Nil, nil
+	}
+
+	data, err := os.ReadFile(migrator.CheckpointFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return uuid.Nil, nil
+	}
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return uuid.Parse(strings.TrimSpace(string(data)))
+}
+
+// writeCheckpoint stores the id of the last migrated document.
+func (migrator *Migrator) writeCheckpoint(id uuid.UUID) error {
+	if migrator.CheckpointFile == "" {
+		return nil
+	}
+
+	// Write to a temporary file first, so that an interrupted write does not corrupt the checkpoint
+	tmp := migrator.CheckpointFile + ".tmp"
+	err := os.WriteFile(tmp, []byte(id.String()), 0644)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, migrator.CheckpointFile)
+}
@@ -0,0 +1,61 @@
+package migration
+
+import (
+	"github.com/google/uuid"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckpoint(t *testing.T) {
+	migrator := &Migrator{CheckpointFile: filepath.Join(t.TempDir(), "checkpoint")}
+
+	// A migration without checkpoint starts from the beginning
+	id, err := migrator.readCheckpoint()
+	if err != nil || id != uuid.Nil {
+		t.Fatalf("expected no checkpoint, got %s, %v", id, err)
+	}
+
+	for range 2 {
+		want := uuid.New()
+		if err := migrator.writeCheckpoint(want); err != nil {
+			t.Fatal(err)
+		}
+
+		id, err = migrator.readCheckpoint()
+		if err != nil || id != want {
+			t.Fatalf("expected checkpoint %s, got %s, %v", want, id, err)
+		}
+	}
+
+	if _, err := os.Stat(migrator.CheckpointFile + ".tmp"); !os.IsNotExist(err) {
+		t.Fatalf("expected the temporary file to be renamed, got %v", err)
+	}
+}
+
+func TestCheckpointInvalid(t *testing.T) {
+	migrator := &Migrator{CheckpointFile: filepath.Join(t.TempDir(), "checkpoint")}
+
+	err := os.WriteFile(migrator.CheckpointFile, []byte("not-a-uuid"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Corrupt checkpoints must not restart the migration from the beginning
+	if _, err := migrator.readCheckpoint(); err == nil {
+		t.Fatal("expected an error for an invalid checkpoint")
+	}
+}
+
+func TestCheckpointDisabled(t *testing.T) {
+	migrator := &Migrator{}
+
+	if err := migrator.writeCheckpoint(uuid.New()); err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := migrator.readCheckpoint()
+	if err != nil || id != uuid.Nil {
+		t.Fatalf("expected no checkpoint without a file, got %s, %v", id, err)
+	}
+}
@@ -0,0 +1,29 @@
+package migration
+
+import (
+	"context"
+	"github.com/pzierahn/chatbot_services/datastore"
+	"go.mongodb.org/mongo-driver/bson"
+	"log"
+)
+
+// AdoptEmbeddingModel marks all collections as indexed with the embedding model of the
+// search index. Switching to a model with another dimension creates a new index, which
+// is filled by MigrateVectorDB. Afterward, the collections are switched to the new model
+// so that searches pass the embedding model check.
+func (migrator *Migrator) AdoptEmbeddingModel(ctx context.Context) (int64, error) {
+	model := migrator.Search.EmbeddingModel()
+
+	collection := migrator.Database.Database(datastore.DatabaseName).Collection(datastore.CollectionCollections)
+	result, err := collection.UpdateMany(ctx,
+		bson.M{"embedding_model": bson.M{"$ne": model}},
+		bson.M{"$set": bson.M{"embedding_model": model}},
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	log.Printf("Switched %d collections to embedding model %s", result.ModifiedCount, model)
+
+	return result.ModifiedCount, nil
+}
@@ -0,0 +1,32 @@
+package migration
+
+import (
+	"context"
+	"github.com/pzierahn/chatbot_services/datastore"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"log"
+)
+
+// BackfillIndexedAt sets the indexed_at time of documents indexed before the time was
+// recorded. Documents were inserted once the indexing finished, so created_at is the
+// best available estimate. Documents without created_at are left unchanged.
+func (migrator *Migrator) BackfillIndexedAt(ctx context.Context) (int64, error) {
+	collection := migrator.Database.Database(datastore.DatabaseName).Collection(datastore.CollectionDokuments)
+	result, err := collection.UpdateMany(ctx,
+		bson.M{
+			"indexed_at": bson.M{"$exists": false},
+			"created_at": bson.M{"$exists": true},
+		},
+		mongo.Pipeline{
+			{{Key: "$set", Value: bson.M{"indexed_at": "$created_at"}}},
+		},
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	log.Printf("Backfilled indexed_at of %d documents", result.ModifiedCount)
+
+	return result.ModifiedCount, nil
+}
@@ -0,0 +1,120 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"log"
+)
+
+// PreflightReport lists documents that cannot be attributed to a user or collection.
+type PreflightReport struct {
+	// Documents without a user id
+	MissingUser []uuid.UUID
+
+	// Documents whose collection does not exist or belongs to another user
+	MissingCollection []uuid.UUID
+}
+
+// Complete returns true if every document is attributed to a user and collection.
+func (report *PreflightReport) Complete() bool {
+	return len(report.MissingUser) == 0 && len(report.MissingCollection) == 0
+}
+
+// Preflight scans all documents before any writes happen and reports every document
+// whose user or collection is missing. Migrating such documents would create search
+// entries that no user can access.
+func (migrator *Migrator) Preflight(ctx context.Context) (*PreflightReport, error) {
+	database := migrator.Database.Database(datastore.DatabaseName)
+
+	//
+	// Collect the owner of every collection
+	//
+
+	cur, err := database.Collection(datastore.CollectionCollections).Find(ctx, bson.M{},
+		options.Find().SetProjection(bson.M{"_id": 1, "user_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+
+	owners := make(map[uuid.UUID]string)
+	for cur.Next(ctx) {
+		var collection datastore.Collection
+		if err = cur.Decode(&collection); err != nil {
+			_ = cur.Close(ctx)
+			return nil, err
+		}
+
+		owners[collection.Id] = collection.UserId
+	}
+	_ = cur.Close(ctx)
+
+	if err = cur.Err(); err != nil {
+		return nil, err
+	}
+
+	//
+	// Check the attribution of every document
+	//
+
+	cur, err = database.Collection(datastore.CollectionDokuments).Find(ctx, bson.M{},
+		options.Find().SetProjection(bson.M{"_id": 1, "user_id": 1, "collection_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = cur.Close(ctx) }()
+
+	report := &PreflightReport{}
+	for cur.Next(ctx) {
+		var doc datastore.Document
+		if err = cur.Decode(&doc); err != nil {
+			return nil, err
+		}
+
+		if doc.UserId == "" {
+			report.MissingUser = append(report.MissingUser, doc.Id)
+			continue
+		}
+
+		if owner, ok := owners[doc.CollectionId]; !ok || owner != doc.UserId {
+			report.MissingCollection = append(report.MissingCollection, doc.Id)
+		}
+	}
+
+	if err = cur.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, id := range report.MissingUser {
+		log.Printf("Preflight: document %s has no user", id)
+	}
+
+	for _, id := range report.MissingCollection {
+		log.Printf("Preflight: document %s has no matching collection", id)
+	}
+
+	return report, nil
+}
+
+// preflight runs the preflight check and aborts if it is incomplete, unless SkipPreflight is set.
+func (migrator *Migrator) preflight(ctx context.Context) error {
+	report, err := migrator.Preflight(ctx)
+	if err != nil {
+		return fmt.Errorf("preflight failed: %v", err)
+	}
+
+	if report.Complete() {
+		return nil
+	}
+
+	if migrator.SkipPreflight {
+		log.Printf("Preflight: ignoring %d unattributed documents", len(report.MissingUser)+len(report.MissingCollection))
+		return nil
+	}
+
+	return fmt.Errorf("preflight found %d documents without user and %d without collection",
+		len(report.MissingUser), len(report.MissingCollection))
+}
@@ -0,0 +1,89 @@
+package migration
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"os"
+	"slices"
+	"testing"
+)
+
+func TestPreflight(t *testing.T) {
+	uri := os.Getenv("CHATBOT_MONGODB_URI")
+	if uri == "" {
+		t.Skip("CHATBOT_MONGODB_URI not set")
+	}
+
+	ctx := context.Background()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = client.Disconnect(ctx) }()
+
+	database := client.Database(datastore.DatabaseName)
+	owner := "test-" + uuid.NewString()
+
+	collection := datastore.Collection{Id: uuid.New(), UserId: owner, Name: "preflight"}
+	_, err = database.Collection(datastore.CollectionCollections).InsertOne(ctx, collection)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_, _ = database.Collection(datastore.CollectionCollections).DeleteOne(ctx, bson.M{"_id": collection.Id})
+	}()
+
+	attributed := datastore.Document{Id: uuid.New(), UserId: owner, CollectionId: collection.Id}
+	noUser := datastore.Document{Id: uuid.New(), CollectionId: collection.Id}
+	otherUser := datastore.Document{Id: uuid.New(), UserId: "test-" + uuid.NewString(), CollectionId: collection.Id}
+	noCollection := datastore.Document{Id: uuid.New(), UserId: owner, CollectionId: uuid.New()}
+
+	docs := []interface{}{attributed, noUser, otherUser, noCollection}
+	_, err = database.Collection(datastore.CollectionDokuments).InsertMany(ctx, docs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		ids := []uuid.UUID{attributed.Id, noUser.Id, otherUser.Id, noCollection.Id}
+		_, _ = database.Collection(datastore.CollectionDokuments).DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	}()
+
+	migrator := &Migrator{Database: client}
+	report, err := migrator.Preflight(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Contains(report.MissingUser, noUser.Id) {
+		t.Error("expected the document without user to be reported")
+	}
+
+	for _, id := range []uuid.UUID{otherUser.Id, noCollection.Id} {
+		if !slices.Contains(report.MissingCollection, id) {
+			t.Errorf("expected document %s without matching collection to be reported", id)
+		}
+	}
+
+	if slices.Contains(report.MissingUser, attributed.Id) || slices.Contains(report.MissingCollection, attributed.Id) {
+		t.Error("expected the attributed document to pass")
+	}
+
+	if report.Complete() {
+		t.Error("expected an incomplete report")
+	}
+
+	// The migration refuses to start unless the check is skipped
+	if err := migrator.preflight(ctx); err == nil {
+		t.Error("expected the preflight to abort the migration")
+	}
+
+	migrator.SkipPreflight = true
+	if err := migrator.preflight(ctx); err != nil {
+		t.Errorf("expected the skipped preflight to pass, got %v", err)
+	}
+}
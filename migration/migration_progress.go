@@ -0,0 +1,109 @@
+package migration
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"log"
+	"time"
+)
+
+// progress tracks the chunks of a reembedding and persists the run, so that
+// operators can query the progress and ETA if the output of the migration is lost.
+type progress struct {
+	runs *mongo.Collection
+	run  datastore.ReindexRun
+}
+
+// countChunks returns the number of chunks of the documents matching the filter.
+func (migrator *Migrator) countChunks(ctx context.Context, filter bson.M) (int, error) {
+	collection := migrator.Database.Database(datastore.DatabaseName).Collection(datastore.CollectionDokuments)
+	cur, err := collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.M{
+			"_id":    nil,
+			"chunks": bson.M{"$sum": bson.M{"$size": bson.M{"$ifNull": bson.A{"$content", bson.A{}}}}},
+		}}},
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = cur.Close(ctx) }()
+
+	var result struct {
+		Chunks int `bson:"chunks"`
+	}
+	if cur.Next(ctx) {
+		err = cur.Decode(&result)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return result.Chunks, cur.Err()
+}
+
+// startProgress records a new run with the total number of chunks.
+func (migrator *Migrator) startProgress(ctx context.Context, total int) *progress {
+	now := time.Now()
+	tracker := &progress{
+		runs: migrator.Database.Database(datastore.DatabaseName).Collection(datastore.CollectionReindexRuns),
+		run: datastore.ReindexRun{
+			Id:          uuid.New(),
+			State:       datastore.ReindexRunning,
+			TotalChunks: total,
+			StartedAt:   now,
+			UpdatedAt:   now,
+		},
+	}
+
+	log.Printf("Reindex run %s: %d chunks", tracker.run.Id, total)
+	tracker.save(ctx)
+
+	return tracker
+}
+
+// add records processed chunks and updates the rate and the estimated completion.
+func (tracker *progress) add(ctx context.Context, processed, failed int) {
+	now := time.Now()
+	run := &tracker.run
+	run.ProcessedChunks += processed
+	run.FailedChunks += failed
+	run.UpdatedAt = now
+
+	elapsed := now.Sub(run.StartedAt).Seconds()
+	if elapsed > 0 && run.ProcessedChunks > 0 {
+		run.Rate = float64(run.ProcessedChunks) / elapsed
+
+		remaining := max(run.TotalChunks-run.ProcessedChunks, 0)
+		run.EstimatedCompletion = now.Add(time.Duration(float64(remaining) / run.Rate * float64(time.Second)))
+	}
+
+	log.Printf("Progress: %d/%d chunks, %.1f chunks/s, ETA %s",
+		run.ProcessedChunks, run.TotalChunks, run.Rate, run.EstimatedCompletion.Format(time.RFC3339))
+	tracker.save(ctx)
+}
+
+// finish records the end of the run, err is nil if it succeeded.
+func (tracker *progress) finish(ctx context.Context, err error) {
+	tracker.run.State = datastore.ReindexDone
+	if err != nil {
+		tracker.run.State = datastore.ReindexFailed
+		tracker.run.Error = err.Error()
+	}
+
+	tracker.run.UpdatedAt = time.Now()
+	tracker.save(ctx)
+}
+
+// save persists the run. Failures are only logged, the progress must not stop
+// the migration.
+func (tracker *progress) save(ctx context.Context) {
+	_, err := tracker.runs.ReplaceOne(ctx, bson.M{"_id": tracker.run.Id}, tracker.run, options.Replace().SetUpsert(true))
+	if err != nil {
+		log.Printf("Error: failed to save reindex progress: %v", err)
+	}
+}
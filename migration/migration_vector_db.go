@@ -2,66 +2,193 @@ package migration
 
 import (
 	"context"
+	"fmt"
+	"github.com/google/uuid"
 	"github.com/pzierahn/chatbot_services/datastore"
 	"github.com/pzierahn/chatbot_services/search"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
 	"log"
 )
 
-func (migrator *Migrator) MigrateVectorDB() {
-	ctx := context.Background()
+// batch collects the fragments of multiple documents for a single upsert.
+type batch struct {
+	documents []uuid.UUID
+	fragments []*search.Fragment
+
+	// chunks of the documents, including those without text
+	chunks int
+}
+
+// MigrateVectorDB upserts the chunks of all documents into the search index. Documents
+// are processed in order of their id, so that an interrupted run can resume after the
+// last checkpoint. A preflight check ensures that all documents are attributed to a
+// user before any writes happen. Upserts are idempotent, which makes it safe to
+// migrate a document twice. The progress and ETA are logged and stored as a
+// datastore.ReindexRun after each batch.
+func (migrator *Migrator) MigrateVectorDB(ctx context.Context) (summary *Summary, err error) {
+	err = migrator.preflight(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	checkpoint, err := migrator.readCheckpoint()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint: %v", err)
+	}
+
+	filter := bson.M{}
+	if checkpoint != uuid.Nil {
+		log.Printf("Resuming after document %s", checkpoint)
+		filter["_id"] = bson.M{"$gt": checkpoint}
+	}
+
+	total, err := migrator.countChunks(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	tracker := migrator.startProgress(ctx, total)
+	defer func() { tracker.finish(context.WithoutCancel(ctx), err) }()
 
 	log.Printf("Migrating documents...")
 
 	collection := migrator.Database.Database(datastore.DatabaseName).Collection(datastore.CollectionDokuments)
-	cur, err := collection.Find(ctx, &bson.M{})
+	opts := options.Find().SetSort(bson.M{"_id": 1})
+	cur, err := collection.Find(ctx, filter, opts)
 	if err != nil {
-		log.Fatalf("Error: %s", err)
+		return nil, err
 	}
 	defer func() { _ = cur.Close(ctx) }()
 
-	var totalUsage uint32
-	var idx uint32
+	summary = &Summary{}
+	pending := &batch{}
+
+	// The checkpoint only advances until the first failure, so that a
+	// resumed run retries all failed documents
+	advance := true
+
+	flush := func() error {
+		if len(pending.documents) == 0 {
+			if pending.chunks > 0 {
+				tracker.add(ctx, pending.chunks, 0)
+				pending.chunks = 0
+			}
+			return nil
+		}
+
+		tokens, err := migrator.upsertFragments(ctx, pending.fragments)
+		if err != nil {
+			log.Printf("Error: failed to migrate %d documents: %v", len(pending.documents), err)
+			summary.Failed += len(pending.documents)
+			advance = false
+			tracker.add(ctx, pending.chunks, pending.chunks)
+		} else {
+			summary.Migrated += len(pending.documents)
+			summary.Tokens += tokens
+			tracker.add(ctx, pending.chunks, 0)
+		}
+
+		last := pending.documents[len(pending.documents)-1]
+		pending = &batch{}
+
+		if !advance {
+			return nil
+		}
+
+		return migrator.writeCheckpoint(last)
+	}
 
 	for cur.Next(ctx) {
 		var doc datastore.Document
-		err := cur.Decode(&doc)
+		err = cur.Decode(&doc)
 		if err != nil {
-			log.Fatalf("Error: %s", err)
+			return summary, err
+		}
+
+		fragments := documentFragments(&doc)
+		if len(fragments) == 0 {
+			summary.Skipped++
+
+			// Counted as processed with the next batch
+			pending.chunks += len(doc.Content)
+			continue
 		}
 
-		log.Printf("[%3d] Migrating document %s (%d)", idx, doc.Id, len(doc.Content))
-		var fragments []*search.Fragment
+		log.Printf("[%3d] Migrating document %s (%d)", summary.Migrated+summary.Failed+len(pending.documents), doc.Id, len(fragments))
 
-		for _, chunk := range doc.Content {
-			if chunk.Text == "" {
-				continue
+		pending.documents = append(pending.documents, doc.Id)
+		pending.fragments = append(pending.fragments, fragments...)
+		pending.chunks += len(doc.Content)
+
+		if len(pending.fragments) >= migrator.batchSize() {
+			if err = flush(); err != nil {
+				return summary, fmt.Errorf("failed to write checkpoint: %v", err)
 			}
+		}
+	}
 
-			fragments = append(fragments, &search.Fragment{
-				Id:           chunk.Id.String(),
-				Text:         chunk.Text,
-				UserId:       doc.UserId,
-				DocumentId:   doc.Id.String(),
-				CollectionId: doc.CollectionId.String(),
-				Position:     chunk.Position,
-			})
+	if err = cur.Err(); err != nil {
+		return summary, err
+	}
+
+	if err = flush(); err != nil {
+		return summary, fmt.Errorf("failed to write checkpoint: %v", err)
+	}
+
+	log.Printf("Migration done: migrated=%d skipped=%d failed=%d tokens=%d",
+		summary.Migrated, summary.Skipped, summary.Failed, summary.Tokens)
+
+	if summary.Failed > 0 {
+		return summary, fmt.Errorf("failed to migrate %d documents", summary.Failed)
+	}
+
+	return summary, nil
+}
+
+// documentFragments returns the search fragments for all chunks of a document that contain text.
+func documentFragments(doc *datastore.Document) []*search.Fragment {
+	var fragments []*search.Fragment
+
+	for _, chunk := range doc.Content {
+		if chunk.Text == "" {
+			continue
 		}
 
-		// Upsert fragments in chunks of 100 to avoid too large requests.
-		for start := 0; start < len(fragments); start += 100 {
-			end := min(start+100, len(fragments))
-			usage, err := migrator.Search.Upsert(ctx, fragments[start:end])
-			if err != nil {
-				log.Fatalf("Error: %s", err)
-			}
+		// Chunks indexed before the language detection have no language
+		language := chunk.Language
+		if language == "" {
+			language = search.DetectLanguage(chunk.Text)
+		}
 
-			totalUsage += usage.Tokens
+		fragments = append(fragments, &search.Fragment{
+			Id:           chunk.Id.String(),
+			Text:         chunk.Text,
+			UserId:       doc.UserId,
+			DocumentId:   doc.Id.String(),
+			CollectionId: doc.CollectionId.String(),
+			Position:     chunk.Position,
+			Language:     language,
+		})
+	}
+
+	return fragments
+}
+
+// upsertFragments upserts the fragments in batches and returns the used tokens.
+func (migrator *Migrator) upsertFragments(ctx context.Context, fragments []*search.Fragment) (uint32, error) {
+	var tokens uint32
+
+	// Large documents may exceed the batch size on their own
+	for start := 0; start < len(fragments); start += migrator.batchSize() {
+		end := min(start+migrator.batchSize(), len(fragments))
+		usage, err := migrator.Search.Upsert(ctx, fragments[start:end], nil)
+		if err != nil {
+			return 0, err
 		}
 
-		idx++
+		tokens += usage.Tokens
 	}
 
-	log.Printf("Total tokens: %d", totalUsage)
-	log.Printf("Migration done")
+	return tokens, nil
 }
@@ -0,0 +1,71 @@
+package migration
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
+	"github.com/pzierahn/chatbot_services/search"
+	"testing"
+)
+
+// batchIndex records the sizes of the upserts.
+type batchIndex struct {
+	search.Index
+	batches []int
+}
+
+func (index *batchIndex) Upsert(_ context.Context, fragments []*search.Fragment, _ search.Progress) (*search.Usage, error) {
+	index.batches = append(index.batches, len(fragments))
+	return &search.Usage{Tokens: uint32(len(fragments))}, nil
+}
+
+func TestUpsertFragments(t *testing.T) {
+	index := &batchIndex{}
+	migrator := &Migrator{Search: index, BatchSize: 4}
+
+	fragments := make([]*search.Fragment, 10)
+	for idx := range fragments {
+		fragments[idx] = &search.Fragment{Id: uuid.NewString()}
+	}
+
+	tokens, err := migrator.upsertFragments(context.Background(), fragments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tokens != 10 {
+		t.Errorf("expected the tokens of all batches, got %d", tokens)
+	}
+
+	if len(index.batches) != 3 || index.batches[0] != 4 || index.batches[1] != 4 || index.batches[2] != 2 {
+		t.Errorf("expected batches of 4, 4 and 2 fragments, got %v", index.batches)
+	}
+}
+
+func TestDocumentFragments(t *testing.T) {
+	doc := &datastore.Document{
+		Id:           uuid.New(),
+		UserId:       "user",
+		CollectionId: uuid.New(),
+		Content: []*datastore.DocumentChunk{
+			{Id: uuid.New(), Text: "The first page", Position: 1, Language: "en"},
+			{Id: uuid.New(), Position: 2},
+			{Id: uuid.New(), Text: "Das ist die dritte Seite und sie wird nicht gelesen", Position: 3},
+		},
+	}
+
+	fragments := documentFragments(doc)
+	if len(fragments) != 2 {
+		t.Fatalf("expected the chunks with text, got %d fragments", len(fragments))
+	}
+
+	first := fragments[0]
+	if first.Id != doc.Content[0].Id.String() || first.DocumentId != doc.Id.String() ||
+		first.CollectionId != doc.CollectionId.String() || first.UserId != "user" || first.Language != "en" {
+		t.Errorf("unexpected fragment %+v", first)
+	}
+
+	if fragments[1].Position != 3 || fragments[1].Language != "de" {
+		t.Errorf("expected the language of chunks without one to be detected, got %+v", fragments[1])
+	}
+}
@@ -0,0 +1,240 @@
+package search
+
+import (
+	"container/list"
+	"context"
+	"expvar"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultCacheTTL defines how long search results are cached by default.
+	DefaultCacheTTL = 30 * time.Second
+
+	// DefaultCacheSize defines how many search results are cached by default.
+	DefaultCacheSize = 1000
+)
+
+var (
+	cacheHits   expvar.Int
+	cacheMisses expvar.Int
+)
+
+func init() {
+	stats := expvar.NewMap("search_cache")
+	stats.Set("hits", &cacheHits)
+	stats.Set("misses", &cacheMisses)
+	stats.Set("hit_rate", expvar.Func(func() any {
+		total := cacheHits.Value() + cacheMisses.Value()
+		if total == 0 {
+			return 0.0
+		}
+
+		return float64(cacheHits.Value()) / float64(total)
+	}))
+}
+
+// CacheConfig reads the cache configuration from CHATBOT_SEARCH_CACHE_TTL and
+// CHATBOT_SEARCH_CACHE_SIZE. A TTL or size of zero disables the cache.
+func CacheConfig() (ttl time.Duration, size int) {
+	ttl = DefaultCacheTTL
+	if value, err := time.ParseDuration(os.Getenv("CHATBOT_SEARCH_CACHE_TTL")); err == nil {
+		ttl = value
+	}
+
+	size = DefaultCacheSize
+	if value, err := strconv.Atoi(os.Getenv("CHATBOT_SEARCH_CACHE_SIZE")); err == nil {
+		size = value
+	}
+
+	return ttl, size
+}
+
+type cacheKey struct {
+	userId       string
+	collectionId string
+	query        string
+	threshold    float32
+	limit        uint32
+	language     string
+	hnswEf       uint32
+	grouping     Grouping
+	documents    string
+	queries      string
+}
+
+type cacheEntry struct {
+	key     cacheKey
+	results *Results
+	expires time.Time
+}
+
+// CachedIndex caches search results of an index. A cache hit skips the embedding of
+// the query and the vector search. Writes through the cache invalidate the results
+// of the affected collection. Writes by other instances are only visible after the TTL.
+type CachedIndex struct {
+	Index
+
+	ttl  time.Duration
+	size int
+
+	mu      sync.Mutex
+	entries map[cacheKey]*list.Element
+	order   *list.List
+}
+
+// NewCachedIndex wraps an index with a cache. The oldest results are evicted once
+// the cache holds size results.
+func NewCachedIndex(index Index, ttl time.Duration, size int) *CachedIndex {
+	return &CachedIndex{
+		Index:   index,
+		ttl:     ttl,
+		size:    size,
+		entries: make(map[cacheKey]*list.Element),
+		order:   list.New(),
+	}
+}
+
+func newCacheKey(query Query) cacheKey {
+	return cacheKey{
+		userId:       query.UserId,
+		collectionId: query.CollectionId,
+		query:        strings.Join(strings.Fields(strings.ToLower(query.Query)), " "),
+		threshold:    query.Threshold,
+		limit:        query.Limit,
+		language:     query.Language,
+		hnswEf:       query.HnswEf,
+		grouping:     query.Grouping,
+		documents:    documentsKey(query.DocumentIds),
+		queries:      queriesKey(query.Queries),
+	}
+}
+
+// copyResults returns a copy of the results, so that callers can't modify cached results.
+func copyResults(results *Results, usage Usage) *Results {
+	clone := &Results{
+		Results: make([]*Result, len(results.Results)),
+		Usage:   usage,
+	}
+
+	copies := make(map[*Result]*Result, len(results.Results))
+	for idx, result := range results.Results {
+		item := *result
+		clone.Results[idx] = &item
+		copies[result] = &item
+	}
+
+	for _, group := range results.Groups {
+		clone.Groups = append(clone.Groups, group.copy(copies))
+	}
+
+	return clone
+}
+
+func (cache *CachedIndex) get(key cacheKey) (*Results, bool) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	element, ok := cache.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := element.Value.(*cacheEntry)
+	if time.Now().After(entry.expires) {
+		cache.order.Remove(element)
+		delete(cache.entries, key)
+		return nil, false
+	}
+
+	// No tokens are used for cached results
+	return copyResults(entry.results, Usage{ModelId: entry.results.Usage.ModelId}), true
+}
+
+func (cache *CachedIndex) put(key cacheKey, results *Results) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if element, ok := cache.entries[key]; ok {
+		cache.order.Remove(element)
+	}
+
+	for cache.order.Len() >= cache.size {
+		oldest := cache.order.Front()
+		cache.order.Remove(oldest)
+		delete(cache.entries, oldest.Value.(*cacheEntry).key)
+	}
+
+	cache.entries[key] = cache.order.PushBack(&cacheEntry{
+		key:     key,
+		results: copyResults(results, results.Usage),
+		expires: time.Now().Add(cache.ttl),
+	})
+}
+
+// invalidate removes all results of a collection.
+func (cache *CachedIndex) invalidate(userId, collectionId string) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	for key, element := range cache.entries {
+		if key.userId == userId && key.collectionId == collectionId {
+			cache.order.Remove(element)
+			delete(cache.entries, key)
+		}
+	}
+}
+
+func (cache *CachedIndex) Search(ctx context.Context, query Query) (*Results, error) {
+	key := newCacheKey(query)
+
+	if results, ok := cache.get(key); ok {
+		cacheHits.Add(1)
+		return results, nil
+	}
+	cacheMisses.Add(1)
+
+	results, err := cache.Index.Search(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.put(key, results)
+
+	return results, nil
+}
+
+func (cache *CachedIndex) Upsert(ctx context.Context, fragments []*Fragment, progress Progress) (*Usage, error) {
+	// Invalidate even if the upsert fails, as some fragments may have been written
+	defer func() {
+		collections := make(map[[2]string]bool)
+		for _, fragment := range fragments {
+			collections[[2]string{fragment.UserId, fragment.CollectionId}] = true
+		}
+
+		for collection := range collections {
+			cache.invalidate(collection[0], collection[1])
+		}
+	}()
+
+	return cache.Index.Upsert(ctx, fragments, progress)
+}
+
+func (cache *CachedIndex) DeleteCollection(ctx context.Context, userId, collectionId string) error {
+	defer cache.invalidate(userId, collectionId)
+	return cache.Index.DeleteCollection(ctx, userId, collectionId)
+}
+
+func (cache *CachedIndex) DeleteDocument(ctx context.Context, userId, collectionId, documentId string) error {
+	defer cache.invalidate(userId, collectionId)
+	return cache.Index.DeleteDocument(ctx, userId, collectionId, documentId)
+}
+
+func (cache *CachedIndex) DeleteFragments(ctx context.Context, userId, collectionId, documentId string, ids ...string) error {
+	defer cache.invalidate(userId, collectionId)
+	return cache.Index.DeleteFragments(ctx, userId, collectionId, documentId, ids...)
+}
@@ -0,0 +1,104 @@
+package search
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// countingIndex returns a single result and counts the searches.
+type countingIndex struct {
+	Index
+	searches int
+}
+
+func (index *countingIndex) Search(context.Context, Query) (*Results, error) {
+	index.searches++
+
+	return &Results{
+		Results: []*Result{{Id: "1", Text: "text"}},
+		Usage:   Usage{ModelId: "model", Tokens: 10},
+	}, nil
+}
+
+func (index *countingIndex) DeleteDocument(context.Context, string, string, string) error {
+	return nil
+}
+
+func TestCachedIndex(t *testing.T) {
+	ctx := context.Background()
+	index := &countingIndex{}
+	cache := NewCachedIndex(index, time.Minute, 2)
+
+	query := Query{UserId: "user", CollectionId: "collection", Query: "What is Go?", Limit: 5}
+
+	results, err := cache.Search(ctx, query)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Callers must not be able to modify cached results
+	results.Results[0].Text = "modified"
+
+	// Normalized queries share the cache entry
+	query.Query = "  what IS go? "
+	results, err = cache.Search(ctx, query)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if index.searches != 1 {
+		t.Fatalf("expected 1 search, got %d", index.searches)
+	}
+
+	if results.Results[0].Text != "text" {
+		t.Fatalf("cached result was modified: %q", results.Results[0].Text)
+	}
+
+	if results.Usage.Tokens != 0 {
+		t.Fatalf("expected no tokens for a cache hit, got %d", results.Usage.Tokens)
+	}
+
+	// Other users don't share the cache
+	_, _ = cache.Search(ctx, Query{UserId: "other", CollectionId: "collection", Query: "What is Go?", Limit: 5})
+	if index.searches != 2 {
+		t.Fatalf("expected 2 searches, got %d", index.searches)
+	}
+
+	// Writes invalidate the collection
+	_ = cache.DeleteDocument(ctx, "user", "collection", "document")
+	_, _ = cache.Search(ctx, query)
+	if index.searches != 3 {
+		t.Fatalf("expected 3 searches after invalidation, got %d", index.searches)
+	}
+}
+
+func TestCachedIndexExpiry(t *testing.T) {
+	ctx := context.Background()
+	index := &countingIndex{}
+	cache := NewCachedIndex(index, time.Millisecond, 10)
+
+	query := Query{UserId: "user", CollectionId: "collection", Query: "query"}
+	_, _ = cache.Search(ctx, query)
+	time.Sleep(5 * time.Millisecond)
+	_, _ = cache.Search(ctx, query)
+
+	if index.searches != 2 {
+		t.Fatalf("expected 2 searches after expiry, got %d", index.searches)
+	}
+}
+
+func TestCachedIndexEviction(t *testing.T) {
+	ctx := context.Background()
+	index := &countingIndex{}
+	cache := NewCachedIndex(index, time.Minute, 2)
+
+	for _, text := range []string{"a", "b", "c", "a"} {
+		_, _ = cache.Search(ctx, Query{UserId: "user", Query: text})
+	}
+
+	// "a" was evicted by "c"
+	if index.searches != 4 {
+		t.Fatalf("expected 4 searches, got %d", index.searches)
+	}
+}
@@ -7,4 +7,6 @@ const (
 	PayloadCollectionId = "collectionId"
 	PayloadText         = "text"
 	PayloadPosition     = "position"
+	PayloadLanguage     = "language"
+	PayloadType         = "type"
 )
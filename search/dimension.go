@@ -0,0 +1,21 @@
+package search
+
+import "fmt"
+
+// IndexName returns the name of the index that stores vectors of the given dimension.
+// Embedding models with different dimensions can't share an index, so every dimension
+// gets its own index within the namespace.
+func IndexName(namespace string, dimension int) string {
+	return fmt.Sprintf("%s-%d", namespace, dimension)
+}
+
+// ResolveIndex returns the index for vectors of the given dimension. Indexes created before
+// the dimension was part of the name are kept if their dimension matches. The legacy
+// dimension is zero if no legacy index exists.
+func ResolveIndex(namespace string, dimension, legacyDimension int) string {
+	if legacyDimension == dimension {
+		return namespace
+	}
+
+	return IndexName(namespace, dimension)
+}
@@ -0,0 +1,46 @@
+package search
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"sort"
+	"strings"
+)
+
+// MaxDocumentIds limits the documents a search can be restricted to.
+const MaxDocumentIds = 100
+
+// ValidateDocumentIds parses the ids of a document filter. It returns an error
+// for malformed ids or more than MaxDocumentIds, duplicates are removed.
+func ValidateDocumentIds(ids []string) ([]uuid.UUID, error) {
+	if len(ids) > MaxDocumentIds {
+		return nil, fmt.Errorf("at most %d documents, got %d", MaxDocumentIds, len(ids))
+	}
+
+	seen := make(map[uuid.UUID]bool, len(ids))
+	parsed := make([]uuid.UUID, 0, len(ids))
+
+	for _, id := range ids {
+		documentId, err := uuid.Parse(id)
+		if err != nil {
+			return nil, fmt.Errorf("malformed document id: %s", id)
+		}
+
+		if seen[documentId] {
+			continue
+		}
+		seen[documentId] = true
+
+		parsed = append(parsed, documentId)
+	}
+
+	return parsed, nil
+}
+
+// documentsKey returns an order-independent key of a document filter.
+func documentsKey(ids []string) string {
+	sorted := append([]string(nil), ids...)
+	sort.Strings(sorted)
+
+	return strings.Join(sorted, ",")
+}
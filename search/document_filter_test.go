@@ -0,0 +1,36 @@
+package search
+
+import (
+	"github.com/google/uuid"
+	"testing"
+)
+
+func TestValidateDocumentIds(t *testing.T) {
+	id := uuid.New().String()
+
+	parsed, err := ValidateDocumentIds([]string{id, id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parsed) != 1 || parsed[0].String() != id {
+		t.Errorf("expected duplicates to be removed, got %v", parsed)
+	}
+
+	if _, err = ValidateDocumentIds([]string{"not-a-uuid"}); err == nil {
+		t.Error("expected an error for a malformed id")
+	}
+
+	if _, err = ValidateDocumentIds(make([]string, MaxDocumentIds+1)); err == nil {
+		t.Error("expected an error for too many ids")
+	}
+}
+
+func TestDocumentsKey(t *testing.T) {
+	if documentsKey([]string{"b", "a"}) != documentsKey([]string{"a", "b"}) {
+		t.Error("expected the key to be independent of the order")
+	}
+
+	if newCacheKey(Query{DocumentIds: []string{"a"}}) == newCacheKey(Query{}) {
+		t.Error("expected filtered searches to have another key")
+	}
+}
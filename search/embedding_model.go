@@ -0,0 +1,32 @@
+package search
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrEmbeddingModelMismatch is returned if a collection was indexed with another embedding model than the index uses.
+var ErrEmbeddingModelMismatch = errors.New("embedding model mismatch")
+
+// CheckEmbeddingModel returns an error if vectors created with the given model can't be
+// searched with the index. Collections without a model predate the model tracking and
+// are accepted.
+func CheckEmbeddingModel(index Index, model string) error {
+	if model == "" || model == index.EmbeddingModel() {
+		return nil
+	}
+
+	return fmt.Errorf("%w: collection uses %s, index uses %s, reindex the collection to search it",
+		ErrEmbeddingModelMismatch, model, index.EmbeddingModel())
+}
+
+// CheckDimension returns an error if the query embedding can't be compared with the
+// vectors of the index, e.g. because the embedding model was changed without reindexing.
+func CheckDimension(dimension int, embedding []float32) error {
+	if len(embedding) == dimension {
+		return nil
+	}
+
+	return fmt.Errorf("%w: query embedding has %d dimensions, index has %d, reindex the collection to search it",
+		ErrEmbeddingModelMismatch, len(embedding), dimension)
+}
@@ -0,0 +1,43 @@
+package search
+
+import (
+	"errors"
+	"testing"
+)
+
+type modelIndex struct {
+	Index
+	model string
+}
+
+func (index *modelIndex) EmbeddingModel() string {
+	return index.model
+}
+
+func TestCheckEmbeddingModel(t *testing.T) {
+	index := &modelIndex{model: "model-b"}
+
+	if err := CheckEmbeddingModel(index, ""); err != nil {
+		t.Fatalf("expected collections without model to be accepted, got %v", err)
+	}
+
+	if err := CheckEmbeddingModel(index, "model-b"); err != nil {
+		t.Fatalf("expected matching models to be accepted, got %v", err)
+	}
+
+	err := CheckEmbeddingModel(index, "model-a")
+	if !errors.Is(err, ErrEmbeddingModelMismatch) {
+		t.Fatalf("expected ErrEmbeddingModelMismatch, got %v", err)
+	}
+}
+
+func TestCheckDimension(t *testing.T) {
+	if err := CheckDimension(3, []float32{1, 2, 3}); err != nil {
+		t.Fatalf("expected matching dimensions to be accepted, got %v", err)
+	}
+
+	err := CheckDimension(4, []float32{1, 2, 3})
+	if !errors.Is(err, ErrEmbeddingModelMismatch) {
+		t.Fatalf("expected ErrEmbeddingModelMismatch, got %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// MaxQueries limits the weighted queries of a search, each query is embedded.
+	MaxQueries = 5
+
+	// RRFConstant dampens the influence of the top ranks in reciprocal rank fusion.
+	RRFConstant = 60
+)
+
+// WeightedQuery is a query of a multi-query search. Results of queries with
+// higher weights rank higher in the fused results.
+type WeightedQuery struct {
+	Query string `json:"query,omitempty" bson:"query,omitempty"`
+
+	// Weight of the query, weights of 0 or less count as 1
+	Weight float32 `json:"weight,omitempty" bson:"weight,omitempty"`
+}
+
+// queriesKey identifies the weighted queries in cache keys.
+func queriesKey(queries []WeightedQuery) string {
+	var key strings.Builder
+	for _, query := range queries {
+		key.WriteString(strconv.FormatFloat(float64(query.Weight), 'g', -1, 32))
+		key.WriteByte(':')
+		key.WriteString(strings.Join(strings.Fields(strings.ToLower(query.Query)), " "))
+		key.WriteByte('\n')
+	}
+
+	return key.String()
+}
+
+// SearchQueries searches each weighted query of a query separately in the index
+// and fuses the results with weighted reciprocal rank fusion. Fragments found by
+// several queries are kept once with their best score. The fused results are
+// ordered by their fused rank, capped at the limit and grouped afterward.
+// Indexes call it for queries with weighted queries.
+func SearchQueries(ctx context.Context, index Index, query Query) (*Results, error) {
+	if len(query.Queries) > MaxQueries {
+		return nil, fmt.Errorf("too many queries: %d exceeds the limit of %d", len(query.Queries), MaxQueries)
+	}
+
+	responses := make([]*Results, len(query.Queries))
+	errs := make([]error, len(query.Queries))
+
+	var wg sync.WaitGroup
+	for idx, weighted := range query.Queries {
+		sub := query
+		sub.Query = weighted.Query
+		sub.Queries = nil
+		sub.Grouping = Grouping{}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			responses[idx], errs[idx] = index.Search(ctx, sub)
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fused := FuseResults(query.Queries, query.Limit, responses...)
+	fused.Group(query.Grouping)
+
+	return fused, nil
+}
+
+// FuseResults merges the results of weighted queries by the sum of their weighted
+// reciprocal ranks. The responses belong to the queries with the same index.
+func FuseResults(queries []WeightedQuery, limit uint32, responses ...*Results) *Results {
+	fused := &Results{}
+	best := make(map[string]*Result)
+	ranks := make(map[string]float64)
+
+	for idx, response := range responses {
+		fused.Metric = response.Metric
+		fused.Usage.ModelId = response.Usage.ModelId
+		fused.Usage.Tokens += response.Usage.Tokens
+		fused.Usage.Truncated += response.Usage.Truncated
+
+		weight := float64(1)
+		if idx < len(queries) && queries[idx].Weight > 0 {
+			weight = float64(queries[idx].Weight)
+		}
+
+		for rank, result := range response.Results {
+			ranks[result.Id] += weight / float64(RRFConstant+rank+1)
+
+			if prev, ok := best[result.Id]; ok && prev.Score >= result.Score {
+				continue
+			}
+			best[result.Id] = result
+		}
+	}
+
+	for _, result := range best {
+		fused.Results = append(fused.Results, result)
+	}
+
+	sort.Slice(fused.Results, func(i, j int) bool {
+		a, b := fused.Results[i], fused.Results[j]
+		if ranks[a.Id] != ranks[b.Id] {
+			return ranks[a.Id] > ranks[b.Id]
+		}
+		return a.Id < b.Id
+	})
+
+	if limit > 0 && len(fused.Results) > int(limit) {
+		fused.Results = fused.Results[:limit]
+	}
+
+	return fused
+}
@@ -0,0 +1,76 @@
+package search
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// queryIndex returns the words of the query as result ids.
+type queryIndex struct {
+	Index
+}
+
+func (index *queryIndex) Search(_ context.Context, query Query) (*Results, error) {
+	results := &Results{Usage: Usage{ModelId: "model", Tokens: 1}}
+	for _, word := range strings.Fields(query.Query) {
+		results.Results = append(results.Results, &Result{Id: word, DocumentId: "doc", Score: 0.5})
+	}
+
+	return results, nil
+}
+
+func TestFuseResults(t *testing.T) {
+	queries := []WeightedQuery{{Query: "first", Weight: 1}, {Query: "second", Weight: 3}}
+	first := &Results{
+		Results: []*Result{{Id: "a", Score: 0.9}, {Id: "b", Score: 0.5}},
+		Usage:   Usage{ModelId: "embedding", Tokens: 3},
+	}
+	second := &Results{
+		Results: []*Result{{Id: "c", Score: 0.6}, {Id: "b", Score: 0.8}},
+		Usage:   Usage{ModelId: "embedding", Tokens: 4},
+	}
+
+	fused := FuseResults(queries, 2, first, second)
+
+	if len(fused.Results) != 2 || fused.Results[0].Id != "b" || fused.Results[1].Id != "c" {
+		t.Fatalf("expected b found by both queries and c of the heavier query, got %v", fused.Results)
+	}
+
+	if fused.Results[0].Score != 0.8 {
+		t.Errorf("expected the best score of b, got %v", fused.Results[0].Score)
+	}
+
+	if fused.Usage.Tokens != 7 {
+		t.Errorf("expected the tokens of both queries, got %d", fused.Usage.Tokens)
+	}
+}
+
+func TestSearchQueries(t *testing.T) {
+	query := Query{
+		Limit: 3,
+		Queries: []WeightedQuery{
+			{Query: "shared first", Weight: 1},
+			{Query: "shared second", Weight: 1},
+		},
+	}
+
+	results, err := SearchQueries(context.Background(), &queryIndex{}, query)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results.Results) != 3 || results.Results[0].Id != "shared" {
+		t.Fatalf("expected the deduplicated results ranked by both queries, got %v", results.Results)
+	}
+
+	if results.Usage.Tokens != 2 {
+		t.Errorf("expected one embedding per query, got %d tokens", results.Usage.Tokens)
+	}
+
+	query.Queries = make([]WeightedQuery, MaxQueries+1)
+	_, err = SearchQueries(context.Background(), &queryIndex{}, query)
+	if err == nil {
+		t.Fatal("expected an error for too many queries")
+	}
+}
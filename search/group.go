@@ -0,0 +1,159 @@
+package search
+
+import (
+	"sort"
+	"strings"
+)
+
+// Grouping groups the results of a search by document.
+type Grouping struct {
+	// PerDocument is the maximum number of results of a document, 0 disables the grouping
+	PerDocument uint32 `json:"per_document,omitempty" bson:"per_document,omitempty"`
+
+	// Stitch merges results of adjacent positions of a document into passages
+	Stitch bool `json:"stitch,omitempty" bson:"stitch,omitempty"`
+}
+
+// Passage is the text of results of a document with adjacent positions.
+type Passage struct {
+	// Id of the first result, which is cited for the passage
+	Id string `json:"id,omitempty" bson:"id,omitempty"`
+
+	// Ids of the results in the passage ordered by position
+	Ids []string `json:"chunk_ids,omitempty" bson:"chunk_ids,omitempty"`
+
+	Text  string `json:"text,omitempty" bson:"text,omitempty"`
+	Start uint32 `json:"start_position" bson:"start_position"`
+	End   uint32 `json:"end_position" bson:"end_position"`
+
+	// Score is the highest score of the results in the passage
+	Score float32 `json:"score,omitempty" bson:"score,omitempty"`
+}
+
+// Group is the best results of a document.
+type Group struct {
+	DocumentId string `json:"document_id,omitempty" bson:"document_id,omitempty"`
+
+	// Score is the highest score of the results of the document
+	Score float32 `json:"score,omitempty" bson:"score,omitempty"`
+
+	// Results of the document ordered by position
+	Results []*Result `json:"results,omitempty" bson:"results,omitempty"`
+
+	// Passages of the results, only set if the results were stitched
+	Passages []*Passage `json:"passages,omitempty" bson:"passages,omitempty"`
+}
+
+// Group keeps the best results of each document and groups them. The remaining
+// results keep their order, the groups are ordered by their best score.
+func (results *Results) Group(grouping Grouping) {
+	if grouping.PerDocument == 0 {
+		return
+	}
+
+	groups := make(map[string]*Group)
+	var order []*Group
+	var kept []*Result
+
+	// Results are ordered by score, so the first results of a document are the best
+	ranked := append([]*Result(nil), results.Results...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Score > ranked[j].Score
+	})
+
+	for _, result := range ranked {
+		group, ok := groups[result.DocumentId]
+		if !ok {
+			group = &Group{DocumentId: result.DocumentId, Score: result.Score}
+			groups[result.DocumentId] = group
+			order = append(order, group)
+		}
+
+		if uint32(len(group.Results)) >= grouping.PerDocument {
+			continue
+		}
+
+		group.Results = append(group.Results, result)
+		kept = append(kept, result)
+	}
+
+	// Keep the original order of the remaining results
+	keep := make(map[*Result]bool, len(kept))
+	for _, result := range kept {
+		keep[result] = true
+	}
+
+	remaining := make([]*Result, 0, len(kept))
+	for _, result := range results.Results {
+		if keep[result] {
+			remaining = append(remaining, result)
+		}
+	}
+
+	for _, group := range order {
+		sort.SliceStable(group.Results, func(i, j int) bool {
+			return group.Results[i].Position < group.Results[j].Position
+		})
+
+		if grouping.Stitch {
+			group.Passages = stitch(group.Results)
+		}
+	}
+
+	results.Results = remaining
+	results.Groups = order
+}
+
+// stitch merges results ordered by position into passages of adjacent positions.
+// Results of the same position, e.g. tables of a page, continue the passage.
+func stitch(results []*Result) []*Passage {
+	var passages []*Passage
+	var texts []string
+
+	for idx, result := range results {
+		if idx == 0 || result.Position > results[idx-1].Position+1 {
+			if len(passages) > 0 {
+				passages[len(passages)-1].Text = strings.Join(texts, "\n")
+			}
+
+			passages = append(passages, &Passage{
+				Id:    result.Id,
+				Start: result.Position,
+			})
+			texts = nil
+		}
+
+		passage := passages[len(passages)-1]
+		passage.Ids = append(passage.Ids, result.Id)
+		passage.End = result.Position
+		passage.Score = max(passage.Score, result.Score)
+		texts = append(texts, result.Text)
+	}
+
+	if len(passages) > 0 {
+		passages[len(passages)-1].Text = strings.Join(texts, "\n")
+	}
+
+	return passages
+}
+
+// copy returns a copy of the group that refers to the copies of its results.
+func (group *Group) copy(copies map[*Result]*Result) *Group {
+	clone := &Group{
+		DocumentId: group.DocumentId,
+		Score:      group.Score,
+		Results:    make([]*Result, len(group.Results)),
+	}
+
+	for idx, result := range group.Results {
+		clone.Results[idx] = copies[result]
+	}
+
+	for _, passage := range group.Passages {
+		item := *passage
+		item.Ids = append([]string(nil), passage.Ids...)
+		clone.Passages = append(clone.Passages, &item)
+	}
+
+	return clone
+}
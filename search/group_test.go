@@ -0,0 +1,60 @@
+package search
+
+import (
+	"testing"
+)
+
+func TestResultsGroup(t *testing.T) {
+	results := &Results{
+		Results: []*Result{
+			{Id: "a3", DocumentId: "a", Position: 3, Score: 0.9, Text: "three"},
+			{Id: "b1", DocumentId: "b", Position: 1, Score: 0.8, Text: "one"},
+			{Id: "a2", DocumentId: "a", Position: 2, Score: 0.7, Text: "two"},
+			{Id: "a7", DocumentId: "a", Position: 7, Score: 0.6, Text: "seven"},
+			{Id: "a4", DocumentId: "a", Position: 4, Score: 0.5, Text: "four"},
+		},
+	}
+
+	results.Group(Grouping{PerDocument: 3, Stitch: true})
+
+	// The worst result of document a exceeds the cap
+	if len(results.Results) != 4 || results.Results[3].Id != "a7" {
+		t.Fatalf("unexpected results %v", results.Results)
+	}
+
+	if len(results.Groups) != 2 || results.Groups[0].DocumentId != "a" || results.Groups[1].DocumentId != "b" {
+		t.Fatalf("unexpected groups %v", results.Groups)
+	}
+
+	group := results.Groups[0]
+	if group.Score != 0.9 {
+		t.Errorf("expected the best score, got %v", group.Score)
+	}
+
+	for idx, id := range []string{"a2", "a3", "a7"} {
+		if group.Results[idx].Id != id {
+			t.Errorf("expected %s at %d, got %s", id, idx, group.Results[idx].Id)
+		}
+	}
+
+	if len(group.Passages) != 2 {
+		t.Fatalf("expected two passages, got %d", len(group.Passages))
+	}
+
+	passage := group.Passages[0]
+	if passage.Id != "a2" || passage.Text != "two\nthree" || passage.Start != 2 || passage.End != 3 || passage.Score != 0.9 {
+		t.Errorf("unexpected passage %+v", passage)
+	}
+}
+
+func TestResultsGroupDisabled(t *testing.T) {
+	results := &Results{
+		Results: []*Result{{Id: "a1", DocumentId: "a"}, {Id: "a2", DocumentId: "a"}},
+	}
+
+	results.Group(Grouping{Stitch: true})
+
+	if len(results.Results) != 2 || results.Groups != nil {
+		t.Errorf("expected the results to stay ungrouped, got %v and %v", results.Results, results.Groups)
+	}
+}
@@ -0,0 +1,32 @@
+package search
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// MaxHnswEf limits the candidates of a search. Larger values make searches slow
+// without improving the recall noticeably.
+const MaxHnswEf = 1024
+
+// ValidateHnswEf returns an error if ef exceeds MaxHnswEf. An ef of 0 uses the
+// default of the index.
+func ValidateHnswEf(ef uint32) error {
+	if ef > MaxHnswEf {
+		return fmt.Errorf("hnsw_ef must be at most %d, got %d", MaxHnswEf, ef)
+	}
+
+	return nil
+}
+
+// DefaultHnswEf returns the ef of searches that don't set one, configured by
+// CHATBOT_SEARCH_HNSW_EF. It returns 0 to use the default of the index if not set.
+func DefaultHnswEf() uint32 {
+	ef, err := strconv.ParseUint(os.Getenv("CHATBOT_SEARCH_HNSW_EF"), 10, 32)
+	if err != nil || ValidateHnswEf(uint32(ef)) != nil {
+		return 0
+	}
+
+	return uint32(ef)
+}
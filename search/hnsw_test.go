@@ -0,0 +1,27 @@
+package search
+
+import "testing"
+
+func TestValidateHnswEf(t *testing.T) {
+	for _, ef := range []uint32{0, 1, 128, MaxHnswEf} {
+		if err := ValidateHnswEf(ef); err != nil {
+			t.Errorf("expected %d to be valid, got %v", ef, err)
+		}
+	}
+
+	if err := ValidateHnswEf(MaxHnswEf + 1); err == nil {
+		t.Errorf("expected %d to be rejected", MaxHnswEf+1)
+	}
+}
+
+func TestDefaultHnswEf(t *testing.T) {
+	t.Setenv("CHATBOT_SEARCH_HNSW_EF", "256")
+	if ef := DefaultHnswEf(); ef != 256 {
+		t.Errorf("expected 256, got %d", ef)
+	}
+
+	t.Setenv("CHATBOT_SEARCH_HNSW_EF", "100000")
+	if ef := DefaultHnswEf(); ef != 0 {
+		t.Errorf("expected invalid values to use the index default, got %d", ef)
+	}
+}
@@ -0,0 +1,56 @@
+package search
+
+import "context"
+
+// IndexParams configure the approximate nearest neighbor index of the vectors.
+// Zero values keep the current setting.
+type IndexParams struct {
+	// M is the number of edges per node of the HNSW graph
+	M uint64
+
+	// EfConstruct is the number of neighbours considered while building the graph
+	EfConstruct uint64
+
+	// IndexingThreshold is the size of a segment in kilobytes before its vectors
+	// are indexed, smaller segments are searched with a full scan
+	IndexingThreshold uint64
+}
+
+// IndexStatus describes the vector index of the vectors.
+type IndexStatus struct {
+	// Name of the index
+	Name string
+
+	// Status is "green" if the index is ready, "yellow" while it's built and
+	// "red" if building failed
+	Status string
+
+	// Points is the number of stored vectors, IndexedVectors the number of vectors
+	// in the vector index. The remaining vectors are searched with a full scan
+	Points         uint64
+	IndexedVectors uint64
+	Segments       uint64
+
+	// Params of the index
+	Params IndexParams
+}
+
+// IndexManager is implemented by indexes whose vector index can be tuned. Hosted
+// indexes like Pinecone manage their vector index themselves.
+type IndexManager interface {
+	IndexStatus(ctx context.Context) (*IndexStatus, error)
+
+	// RebuildIndex changes the parameters of the vector index. The index is
+	// rebuilt in the background, the status is "yellow" until it's ready.
+	RebuildIndex(ctx context.Context, params IndexParams) error
+}
+
+// GetIndexManager returns the manager of the index, unwrapping cached indexes.
+func GetIndexManager(index Index) (IndexManager, bool) {
+	if cache, ok := index.(*CachedIndex); ok {
+		index = cache.Index
+	}
+
+	manager, ok := index.(IndexManager)
+	return manager, ok
+}
@@ -9,38 +9,110 @@ type Fragment struct {
 	DocumentId   string `json:"document_id,omitempty" bson:"document_id,omitempty"`
 	CollectionId string `json:"collection_id,omitempty" bson:"collection_id,omitempty"`
 	Position     uint32 `json:"position,omitempty" bson:"position,omitempty"`
+
+	// Language is the ISO 639-1 code of the text, empty if unknown
+	Language string `json:"language,omitempty" bson:"language,omitempty"`
+
+	// Type of the chunk, e.g. "code" or "table", empty for plain text
+	Type string `json:"type,omitempty" bson:"type,omitempty"`
 }
 
 type Query struct {
-	UserId       string  `json:"user_id,omitempty" bson:"user_id,omitempty"`
-	CollectionId string  `json:"collection_id,omitempty" bson:"collection_id,omitempty"`
-	Query        string  `json:"query,omitempty" bson:"query,omitempty"`
-	Limit        uint32  `json:"limit,omitempty" bson:"limit,omitempty"`
-	Threshold    float32 `json:"threshold,omitempty" bson:"threshold,omitempty"`
+	UserId       string `json:"user_id,omitempty" bson:"user_id,omitempty"`
+	CollectionId string `json:"collection_id,omitempty" bson:"collection_id,omitempty"`
+	Query        string `json:"query,omitempty" bson:"query,omitempty"`
+	Limit        uint32 `json:"limit,omitempty" bson:"limit,omitempty"`
+
+	// Threshold is the minimum similarity in [0, 1] of the results, 0 disables the filtering
+	Threshold float32 `json:"threshold,omitempty" bson:"threshold,omitempty"`
+
+	// Language restricts the results to fragments of the language, empty disables the filtering
+	Language string `json:"language,omitempty" bson:"language,omitempty"`
+
+	// EmbeddingModel is the model the collection was indexed with, empty skips the check
+	EmbeddingModel string `json:"embedding_model,omitempty" bson:"embedding_model,omitempty"`
+
+	// HnswEf is the number of candidates of the HNSW search. Larger values improve
+	// the recall and increase the latency, 0 uses the default of the server.
+	// Indexes without HNSW parameters ignore it
+	HnswEf uint32 `json:"hnsw_ef,omitempty" bson:"hnsw_ef,omitempty"`
+
+	// Grouping keeps the best results per document and groups them, the limit
+	// applies before the grouping
+	Grouping Grouping `json:"grouping,omitempty" bson:"grouping,omitempty"`
+
+	// DocumentIds restricts the results to chunks of these documents, empty searches all documents
+	DocumentIds []string `json:"document_ids,omitempty" bson:"document_ids,omitempty"`
+
+	// Queries are searched instead of Query if set, each with the limit, and fused
+	// with SearchQueries. Fused results are ordered by rank instead of score
+	Queries []WeightedQuery `json:"queries,omitempty" bson:"queries,omitempty"`
 }
 
 type Result struct {
-	Id         string  `json:"id,omitempty" bson:"_id,omitempty"`
-	Text       string  `json:"text,omitempty" bson:"text,omitempty"`
-	DocumentId string  `json:"document_id,omitempty" bson:"document_id,omitempty"`
-	Position   uint32  `json:"position,omitempty" bson:"position,omitempty"`
-	Score      float32 `json:"score,omitempty" bson:"score,omitempty"`
+	Id         string `json:"id,omitempty" bson:"_id,omitempty"`
+	Text       string `json:"text,omitempty" bson:"text,omitempty"`
+	DocumentId string `json:"document_id,omitempty" bson:"document_id,omitempty"`
+	Position   uint32 `json:"position,omitempty" bson:"position,omitempty"`
+
+	// Score is the similarity to the query in [0, 1], independent of the index metric
+	Score float32 `json:"score,omitempty" bson:"score,omitempty"`
+
+	// RawScore is the score of the index metric, e.g. the cosine similarity in [-1, 1]
+	RawScore float32 `json:"raw_score,omitempty" bson:"raw_score,omitempty"`
+
+	// Language is the ISO 639-1 code of the text, empty if unknown
+	Language string `json:"language,omitempty" bson:"language,omitempty"`
+
+	// Type of the chunk, e.g. "code" or "table", empty for plain text
+	Type string `json:"type,omitempty" bson:"type,omitempty"`
 }
 
 type Results struct {
 	Results []*Result `json:"results,omitempty" bson:"results,omitempty"`
 	Usage   Usage     `json:"usage,omitempty" bson:"usage,omitempty"`
+
+	// Metric of the index that produced the raw scores
+	Metric Metric `json:"metric,omitempty" bson:"metric,omitempty"`
+
+	// Groups of the results by document, only set if the query requested a grouping
+	Groups []*Group `json:"groups,omitempty" bson:"groups,omitempty"`
 }
 
 type Usage struct {
 	ModelId string `json:"model_id,omitempty" bson:"model_id,omitempty"`
 	Tokens  uint32 `json:"tokens,omitempty" bson:"tokens,omitempty"`
+
+	// Truncated is the number of fragments that were truncated before embedding
+	Truncated int `json:"truncated,omitempty" bson:"truncated,omitempty"`
 }
 
+// DocumentRef identifies a document with vectors in the index.
+type DocumentRef struct {
+	// UserId is empty if the index doesn't store it in the vector id
+	UserId       string
+	CollectionId string
+	DocumentId   string
+
+	// Vectors is the number of vectors of the document
+	Vectors int
+}
+
+// Progress is called with the number of processed fragments while upserting.
+type Progress func(processed, total int)
+
 type Index interface {
 	Search(context.Context, Query) (*Results, error)
-	Upsert(context.Context, []*Fragment) (*Usage, error)
+	Upsert(context.Context, []*Fragment, Progress) (*Usage, error)
 	DeleteCollection(ctx context.Context, userId, collectionId string) error
 	DeleteDocument(ctx context.Context, userId, collectionId, documentId string) error
+
+	// DeleteFragments deletes single fragments of a document, e.g. of a replaced version
+	DeleteFragments(ctx context.Context, userId, collectionId, documentId string, ids ...string) error
+
+	// ListDocuments calls fn with the documents of each page of the index. A document
+	// with vectors on several pages is passed once per page with the vectors of the page
+	ListDocuments(ctx context.Context, fn func(refs []*DocumentRef) error) error
+	EmbeddingModel() string
 	Close() error
 }
@@ -0,0 +1,87 @@
+package search
+
+import "strings"
+
+// stopwords contains frequent function words of the supported languages, keyed by
+// their ISO 639-1 code. Words shared by several languages count for each of them.
+var stopwords = map[string][]string{
+	"en": {"the", "and", "of", "to", "is", "in", "that", "it", "with", "for", "was", "are", "this", "be", "on", "have", "not", "by", "from", "which", "what", "how", "does"},
+	"de": {"der", "die", "das", "und", "ist", "nicht", "mit", "ein", "eine", "den", "dem", "zu", "von", "auf", "sich", "auch", "wie", "wird", "sind", "werden", "oder", "ich", "was"},
+	"fr": {"le", "les", "des", "est", "et", "une", "dans", "pour", "pas", "qui", "sur", "au", "du", "avec", "sont", "ce", "il", "elle", "nous", "vous", "comment", "quoi"},
+	"es": {"el", "los", "las", "y", "es", "una", "por", "con", "para", "del", "lo", "se", "su", "como", "pero", "más", "está", "son", "qué", "cómo"},
+	"it": {"il", "gli", "di", "che", "è", "una", "per", "non", "sono", "della", "nel", "alla", "anche", "come", "ma", "questo", "cosa", "perché"},
+	"pt": {"os", "as", "um", "uma", "não", "com", "para", "são", "mais", "ao", "da", "do", "dos", "das", "em", "foi", "como", "você", "isso"},
+	"nl": {"het", "een", "van", "en", "is", "niet", "met", "op", "zijn", "voor", "dat", "ook", "wordt", "aan", "bij", "maar", "wat", "hoe"},
+}
+
+// languageOf maps each stopword to its languages.
+var languageOf = func() map[string][]string {
+	index := make(map[string][]string)
+	for language, words := range stopwords {
+		for _, word := range words {
+			index[word] = append(index[word], language)
+		}
+	}
+
+	return index
+}()
+
+// minLanguageHits is the number of stopwords required to detect a language.
+const minLanguageHits = 2
+
+// DetectLanguage returns the ISO 639-1 code of the language of a text based on the
+// frequency of stopwords. It returns an empty string if the language is unknown or
+// the text is too short to decide.
+func DetectLanguage(text string) string {
+	counts := make(map[string]int)
+	for _, word := range terms(text) {
+		for _, language := range languageOf[word] {
+			counts[language]++
+		}
+	}
+
+	var best string
+	var bestCount, secondCount int
+	for language, count := range counts {
+		switch {
+		case count > bestCount || (count == bestCount && language < best):
+			if best != "" {
+				secondCount = max(secondCount, bestCount)
+			}
+			best, bestCount = language, count
+		case count > secondCount:
+			secondCount = count
+		}
+	}
+
+	// Ambiguous texts, like lists of names, are not assigned a language
+	if bestCount < minLanguageHits || bestCount == secondCount {
+		return ""
+	}
+
+	return best
+}
+
+// NormalizeLanguage lower-cases a language code and strips the region, e.g. "en-US" becomes "en".
+func NormalizeLanguage(language string) string {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if idx := strings.IndexAny(language, "-_"); idx >= 0 {
+		language = language[:idx]
+	}
+
+	return language
+}
+
+// LanguageAuto selects the detected language of the query as language filter.
+const LanguageAuto = "auto"
+
+// QueryLanguage resolves the language filter of a query. With LanguageAuto, the
+// language of the query is used and the filter is disabled if it is unknown.
+func QueryLanguage(language, query string) string {
+	language = NormalizeLanguage(language)
+	if language == LanguageAuto {
+		return DetectLanguage(query)
+	}
+
+	return language
+}
@@ -0,0 +1,55 @@
+package search
+
+import "testing"
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"english", "The garbage collector of Go runs concurrently with the program.", "en"},
+		{"german", "Der Garbage Collector von Go läuft nebenläufig und ist nicht blockierend.", "de"},
+		{"french", "Le ramasse-miettes de Go est concurrent et il ne bloque pas le programme.", "fr"},
+		{"spanish", "El recolector de basura de Go es concurrente y no bloquea el programa.", "es"},
+		{"dutch", "De garbage collector van Go is niet blokkerend en draait op de achtergrond.", "nl"},
+		{"too short", "Go", ""},
+		{"names only", "Alan Turing, Ada Lovelace", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DetectLanguage(tt.text)
+			if got != tt.want {
+				t.Fatalf("DetectLanguage(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeLanguage(t *testing.T) {
+	for input, want := range map[string]string{"en-US": "en", " DE ": "de", "pt_BR": "pt", "": ""} {
+		if got := NormalizeLanguage(input); got != want {
+			t.Fatalf("NormalizeLanguage(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestQueryLanguage(t *testing.T) {
+	tests := []struct {
+		language string
+		query    string
+		want     string
+	}{
+		{"", "What is the capital of France?", ""},
+		{"en-GB", "Was ist die Hauptstadt von Frankreich?", "en"},
+		{"auto", "Was ist die Hauptstadt von Frankreich?", "de"},
+		{"AUTO", "Paris", ""},
+	}
+
+	for _, tt := range tests {
+		if got := QueryLanguage(tt.language, tt.query); got != tt.want {
+			t.Fatalf("QueryLanguage(%q, %q) = %q, want %q", tt.language, tt.query, got, tt.want)
+		}
+	}
+}
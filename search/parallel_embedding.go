@@ -3,16 +3,47 @@ package search
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/pzierahn/chatbot_services/llm"
 	"log"
+	"os"
+	"strconv"
 	"time"
 )
 
+// DefaultEmbeddingWorkers defines how many embedding requests are made in parallel by default.
+const DefaultEmbeddingWorkers = 4
+
+// EmbeddingWorkers returns the number of parallel embedding requests configured
+// by CHATBOT_EMBEDDING_WORKERS or DefaultEmbeddingWorkers if not set.
+func EmbeddingWorkers() int {
+	workers, err := strconv.Atoi(os.Getenv("CHATBOT_EMBEDDING_WORKERS"))
+	if err != nil || workers <= 0 {
+		return DefaultEmbeddingWorkers
+	}
+
+	return workers
+}
+
+// EmbeddingTruncation returns the policy for fragments that are longer than the
+// maximum input of the embedding model configured by CHATBOT_EMBEDDING_TRUNCATION,
+// either llm.TruncationTruncate (default) or llm.TruncationError.
+func EmbeddingTruncation() string {
+	if os.Getenv("CHATBOT_EMBEDDING_TRUNCATION") == llm.TruncationError {
+		return llm.TruncationError
+	}
+
+	return llm.TruncationTruncate
+}
+
 // ParallelEmbedding defines an embedding engine that processes multiple fragments in parallel.
 type ParallelEmbedding struct {
 	engine    llm.Embedding // Engine defines the embedding engine
 	batchSize int           // BatchSize defines how many fragments are processed in one request
 	slots     chan struct{} // Slots defines how many requests can be made in parallel
+
+	// truncation is the policy for fragments longer than the maximum input of the model
+	truncation string
 }
 
 // EmbeddingResponse defines the response of a parallel embedding request.
@@ -25,15 +56,18 @@ type embedding struct {
 	id        []string
 	embedding [][]float32
 	tokens    uint32
+	truncated int
 	error     error
 }
 
-// NewParallelEmbedding creates a new parallel embedding engine.
-func NewParallelEmbedding(engine llm.Embedding, agents, batchSize int) *ParallelEmbedding {
+// NewParallelEmbedding creates a new parallel embedding engine. Fragments longer than
+// the maximum input of the model are truncated or rejected depending on truncation.
+func NewParallelEmbedding(engine llm.Embedding, agents, batchSize int, truncation string) *ParallelEmbedding {
 	parallelEmbedding := &ParallelEmbedding{
-		engine:    engine,
-		batchSize: batchSize,
-		slots:     make(chan struct{}, agents),
+		engine:     engine,
+		batchSize:  batchSize,
+		slots:      make(chan struct{}, agents),
+		truncation: truncation,
 	}
 
 	// Slots defines how many requests can be made in parallel
@@ -49,78 +83,96 @@ func (engine *ParallelEmbedding) Close() {
 	close(engine.slots)
 }
 
-// CreateEmbeddings creates embeddings for multiple fragments in parallel.
-func (engine *ParallelEmbedding) CreateEmbeddings(ctx context.Context, fragments []*Fragment) (*EmbeddingResponse, error) {
-	results := make(chan *embedding, len(fragments))
-	defer close(results)
+// embedBatch creates the embeddings for a batch of fragments once a slot is available.
+func (engine *ParallelEmbedding) embedBatch(ctx context.Context, batch []*Fragment) *embedding {
+	select {
+	case <-ctx.Done():
+		// Abort if the context is canceled
+		return &embedding{error: ctx.Err()}
+	case _, ok := <-engine.slots:
+		if !ok {
+			// Abort if the slot is not available
+			return &embedding{error: errors.New("slot not available")}
+		}
+	}
+
+	// Ensure the slot is released after the function returns
+	defer func() { engine.slots <- struct{}{} }()
+
+	var inputs, ids []string
+	for _, fragment := range batch {
+		inputs = append(inputs, fragment.Text)
+		ids = append(ids, fragment.Id)
+	}
 
+	// Allow up to 3 attempts to create an embedding
+	for attempt := 1; ; attempt++ {
+		result, err := engine.engine.CreateEmbedding(ctx, &llm.EmbeddingRequest{
+			Inputs:     inputs,
+			Type:       llm.EmbeddingTypeDocument,
+			MaxTokens:  engine.engine.GetMaxInputTokens(),
+			Truncation: engine.truncation,
+		})
+		if errors.Is(err, llm.ErrInputTooLong) {
+			// Retrying doesn't shorten the input
+			return &embedding{id: ids, error: err}
+		}
+		if err == nil && len(result.Embeddings) != len(ids) {
+			err = fmt.Errorf("expected %d embeddings, got %d", len(ids), len(result.Embeddings))
+		}
+
+		if err == nil {
+			// Successfully created an embedding
+			if result.Truncated > 0 {
+				log.Printf("truncated %d of %d fragments to %d tokens", result.Truncated, len(ids), engine.engine.GetMaxInputTokens())
+			}
+
+			return &embedding{
+				id:        ids,
+				embedding: result.Embeddings,
+				tokens:    result.Tokens,
+				truncated: result.Truncated,
+			}
+		}
+
+		// Failed to create an embedding. This can if too many requests are made in a short time.
+		if attempt >= 3 {
+			return &embedding{id: ids, error: err}
+		}
+
+		// Wait for a short time before retrying
+		select {
+		case <-ctx.Done():
+			return &embedding{id: ids, error: ctx.Err()}
+		case <-time.After(time.Duration(attempt) * 13 * time.Second):
+		}
+	}
+}
+
+// CreateEmbeddings creates embeddings for multiple fragments in parallel. The progress
+// function is called from the calling goroutine after each finished batch. The first
+// error cancels all outstanding requests.
+func (engine *ParallelEmbedding) CreateEmbeddings(ctx context.Context, fragments []*Fragment, progress Progress) (*EmbeddingResponse, error) {
 	ctx, cnl := context.WithCancel(ctx)
 	defer cnl()
 
-	// Process 10 fragments in one request
+	var batches [][]*Fragment
 	for start := 0; start < len(fragments); start += engine.batchSize {
 		end := min(start+engine.batchSize, len(fragments))
-		batch := fragments[start:end]
+		batches = append(batches, fragments[start:end])
+	}
+
+	// Buffered for all batches, so that no goroutine blocks after an error occurred
+	results := make(chan *embedding, len(batches))
 
-		// Start a goroutine for each document in parallel
+	// Start a goroutine for each batch in parallel
+	for _, batch := range batches {
 		go func(batch []*Fragment) {
-			select {
-			case <-ctx.Done():
-				// Abort if the context is canceled
-				results <- &embedding{}
-				return
-			case _, ok := <-engine.slots:
-				if !ok {
-					// Abort if the slot is not available
-					results <- &embedding{
-						error: errors.New("slot not available"),
-					}
-					return
-				}
-
-				// Ensure the slot is released after the function returns
-				defer func() { engine.slots <- struct{}{} }()
-
-				var inputs, ids []string
-				for _, fragment := range batch {
-					inputs = append(inputs, fragment.Text)
-					ids = append(ids, fragment.Id)
-				}
-
-				// Allow up to 3 attempts to create an embedding
-				for attempt := 1; attempt <= 3; attempt++ {
-					result, err := engine.engine.CreateEmbedding(ctx, &llm.EmbeddingRequest{
-						Inputs: inputs,
-						Type:   llm.EmbeddingTypeDocument,
-					})
-					if err == nil {
-						// Successfully created an embedding
-						results <- &embedding{
-							id:        ids,
-							embedding: result.Embeddings,
-							tokens:    result.Tokens,
-						}
-						break
-					} else {
-						// Failed to create an embedding. This can if too many requests are made in a short time.
-						if attempt >= 3 {
-							// Failed to create an embedding
-							results <- &embedding{
-								id:    ids,
-								error: err,
-							}
-							break
-						}
-
-						// Wait for a short time before retrying
-						time.Sleep(time.Duration(attempt) * 13 * time.Second)
-					}
-				}
-			}
+			results <- engine.embedBatch(ctx, batch)
 		}(batch)
 	}
 
-	received := 0
+	processed := 0
 	response := &EmbeddingResponse{
 		Embeddings: make(map[string][]float32),
 		Usage: Usage{
@@ -130,29 +182,34 @@ func (engine *ParallelEmbedding) CreateEmbeddings(ctx context.Context, fragments
 	}
 	var err error
 
-	for result := range results {
-		received += len(result.id)
-		// log.Printf("createEmbeddings: %d/%d", received, len(fragments))
+	// Wait for all batches, so that no request outlives this call
+	for range batches {
+		result := <-results
 
 		if err != nil {
 			// Skip the remaining results if an error occurred
-		} else if result.error != nil {
+			continue
+		}
+
+		if result.error != nil {
 			// Record the error and cancel all other requests
 			err = result.error
 			cnl()
 
 			log.Printf("error creating embeddings: %v", err)
-		} else {
-			// Record the embedding
-			for inx, id := range result.id {
-				response.Embeddings[id] = result.embedding[inx]
-				response.Usage.Tokens += result.tokens
-			}
+			continue
+		}
+
+		// Record the embedding
+		for inx, id := range result.id {
+			response.Embeddings[id] = result.embedding[inx]
 		}
+		response.Usage.Tokens += result.tokens
+		response.Usage.Truncated += result.truncated
 
-		if received == len(fragments) {
-			// All results have been received
-			break
+		processed += len(result.id)
+		if progress != nil {
+			progress(processed, len(fragments))
 		}
 	}
 
@@ -0,0 +1,119 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/pzierahn/chatbot_services/llm"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// countingEmbedding embeds each input with its length and tracks the number of
+// simultaneous requests.
+type countingEmbedding struct {
+	mu      sync.Mutex
+	running int
+	peak    int
+
+	// fail makes requests that contain the input fail
+	fail string
+}
+
+func (engine *countingEmbedding) CreateEmbedding(ctx context.Context, req *llm.EmbeddingRequest) (*llm.EmbeddingResponse, error) {
+	engine.mu.Lock()
+	engine.running++
+	engine.peak = max(engine.peak, engine.running)
+	engine.mu.Unlock()
+
+	defer func() {
+		engine.mu.Lock()
+		engine.running--
+		engine.mu.Unlock()
+	}()
+
+	// Give the other workers time to start
+	time.Sleep(10 * time.Millisecond)
+
+	response := &llm.EmbeddingResponse{}
+	for _, input := range req.Inputs {
+		if engine.fail != "" && input == engine.fail {
+			return nil, fmt.Errorf("%w: %s", llm.ErrInputTooLong, input)
+		}
+
+		response.Embeddings = append(response.Embeddings, []float32{float32(len(input))})
+		response.Tokens += uint32(len(input))
+	}
+
+	return response, nil
+}
+
+func (engine *countingEmbedding) GetEmbeddingDimension() int { return 1 }
+func (engine *countingEmbedding) GetModelId() string         { return "counting" }
+func (engine *countingEmbedding) GetMaxInputTokens() int     { return 0 }
+
+func testFragments(count int) []*Fragment {
+	fragments := make([]*Fragment, count)
+	for idx := range fragments {
+		fragments[idx] = &Fragment{
+			Id:   fmt.Sprintf("f%d", idx),
+			Text: strings.Repeat("x", idx+1),
+		}
+	}
+
+	return fragments
+}
+
+func TestParallelEmbedding(t *testing.T) {
+	engine := &countingEmbedding{}
+	parallel := NewParallelEmbedding(engine, 2, 3, llm.TruncationTruncate)
+	defer parallel.Close()
+
+	var reports []int
+	response, err := parallel.CreateEmbeddings(context.Background(), testFragments(10), func(processed, total int) {
+		if total != 10 {
+			t.Errorf("expected a total of 10, got %d", total)
+		}
+		reports = append(reports, processed)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for idx, fragment := range testFragments(10) {
+		if vector := response.Embeddings[fragment.Id]; len(vector) != 1 || vector[0] != float32(idx+1) {
+			t.Errorf("unexpected embedding of %s: %v", fragment.Id, vector)
+		}
+	}
+
+	if response.Usage.Tokens != 55 || response.Usage.ModelId != "counting" {
+		t.Errorf("unexpected usage %+v", response.Usage)
+	}
+
+	// Four batches of at most 3 fragments
+	if len(reports) != 4 || reports[len(reports)-1] != 10 {
+		t.Errorf("expected progress after each batch up to 10, got %v", reports)
+	}
+	for idx := 1; idx < len(reports); idx++ {
+		if reports[idx] <= reports[idx-1] {
+			t.Errorf("expected increasing progress, got %v", reports)
+		}
+	}
+
+	if engine.peak > 2 {
+		t.Errorf("expected at most 2 parallel requests, got %d", engine.peak)
+	}
+}
+
+func TestParallelEmbeddingError(t *testing.T) {
+	engine := &countingEmbedding{fail: "xxxxx"}
+	parallel := NewParallelEmbedding(engine, 2, 2, llm.TruncationError)
+	defer parallel.Close()
+
+	_, err := parallel.CreateEmbeddings(context.Background(), testFragments(8), nil)
+	if !errors.Is(err, llm.ErrInputTooLong) {
+		t.Fatalf("expected the error of the failed batch, got %v", err)
+	}
+}
@@ -11,11 +11,17 @@ import (
 type Search struct {
 	conn          *pinecone.Client
 	namespace     string
+	index         string
 	embedding     llm.Embedding
 	fastEmbedding *search.ParallelEmbedding
 	dimension     int
 }
 
+// EmbeddingModel returns the id of the model used to create the vectors.
+func (db *Search) EmbeddingModel() string {
+	return db.embedding.GetModelId()
+}
+
 func (db *Search) Close() error {
 	return nil
 }
@@ -31,7 +37,7 @@ func New(engine llm.Embedding, namespace string) (*Search, error) {
 		log.Fatalf("Failed to create Client: %v", err)
 	}
 
-	fastEmbedding := search.NewParallelEmbedding(engine, 10, 100)
+	fastEmbedding := search.NewParallelEmbedding(engine, search.EmbeddingWorkers(), 100, search.EmbeddingTruncation())
 
 	client := &Search{
 		conn:          pc,
@@ -58,3 +58,23 @@ func (db *Search) DeleteDocument(ctx context.Context, _, collectionId, documentI
 	prefix := collectionId + "#" + documentId + "#"
 	return db.deleteByPrefix(ctx, prefix)
 }
+
+func (db *Search) DeleteFragments(ctx context.Context, _, collectionId, documentId string, ids ...string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	idxConnection, err := db.getIndexConnection(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = idxConnection.Close() }()
+
+	vectorIds := make([]string, len(ids))
+	for idx, id := range ids {
+		vectorIds[idx] = collectionId + "#" + documentId + "#" + id
+	}
+
+	return idxConnection.DeleteVectorsById(ctx, vectorIds)
+}
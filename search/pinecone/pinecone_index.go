@@ -9,7 +9,7 @@ import (
 )
 
 func (db *Search) getIndexConnection(ctx context.Context) (*pinecone.IndexConnection, error) {
-	idx, err := db.conn.DescribeIndex(ctx, db.namespace)
+	idx, err := db.conn.DescribeIndex(ctx, db.index)
 	if err != nil {
 		return nil, err
 	}
@@ -22,9 +22,9 @@ func (db *Search) getIndexConnection(ctx context.Context) (*pinecone.IndexConnec
 	return idxConnection, nil
 }
 
-func (db *Search) Upsert(ctx context.Context, fragments []*search.Fragment) (*search.Usage, error) {
+func (db *Search) Upsert(ctx context.Context, fragments []*search.Fragment, progress search.Progress) (*search.Usage, error) {
 
-	embedded, err := db.fastEmbedding.CreateEmbeddings(ctx, fragments)
+	embedded, err := db.fastEmbedding.CreateEmbeddings(ctx, fragments, progress)
 	if err != nil {
 		return nil, err
 	}
@@ -34,14 +34,25 @@ func (db *Search) Upsert(ctx context.Context, fragments []*search.Fragment) (*se
 	for idx := range fragments {
 		fragment := fragments[idx]
 
-		metadata, err := structpb.NewStruct(map[string]any{
+		fields := map[string]any{
 			search.PayloadFragmentId:   fragment.Id,
 			search.PayloadDocumentId:   fragment.DocumentId,
 			search.PayloadUserId:       fragment.UserId,
 			search.PayloadCollectionId: fragment.CollectionId,
 			search.PayloadText:         fragment.Text,
 			search.PayloadPosition:     fragment.Position,
-		})
+		}
+
+		// Fragments without a detected language are excluded by language filters
+		if fragment.Language != "" {
+			fields[search.PayloadLanguage] = fragment.Language
+		}
+
+		if fragment.Type != "" {
+			fields[search.PayloadType] = fragment.Type
+		}
+
+		metadata, err := structpb.NewStruct(fields)
 		if err != nil {
 			return nil, err
 		}
@@ -3,6 +3,7 @@ package pinecone_search
 import (
 	"context"
 	"github.com/pinecone-io/go-pinecone/pinecone"
+	"github.com/pzierahn/chatbot_services/search"
 )
 
 func (db *Search) Init() error {
@@ -14,16 +15,29 @@ func (db *Search) Init() error {
 		return err
 	}
 
+	indexes := make(map[string]*pinecone.Index)
 	for _, index := range list {
-		if index.Name == db.namespace {
-			return nil
-		}
+		indexes[index.Name] = index
 	}
 
-	// Serverless index
-	_, err = db.conn.CreateServerlessIndex(ctx, &pinecone.CreateServerlessIndexRequest{
-		Name:               db.namespace,
-		Dimension:          int32(db.dimension),
+	var legacyDimension int
+	if legacy, ok := indexes[db.namespace]; ok {
+		legacyDimension = int(legacy.Dimension)
+	}
+
+	db.index = search.ResolveIndex(db.namespace, db.dimension, legacyDimension)
+	if _, ok := indexes[db.index]; ok {
+		return nil
+	}
+
+	return db.CreateIndex(ctx, db.dimension)
+}
+
+// CreateIndex creates a serverless index for vectors of the given dimension.
+func (db *Search) CreateIndex(ctx context.Context, dimension int) error {
+	_, err := db.conn.CreateServerlessIndex(ctx, &pinecone.CreateServerlessIndexRequest{
+		Name:               search.IndexName(db.namespace, dimension),
+		Dimension:          int32(dimension),
 		Metric:             pinecone.Cosine,
 		Cloud:              pinecone.Aws,
 		Region:             "us-east-1",
@@ -0,0 +1,66 @@
+package pinecone_search
+
+import (
+	"context"
+	"github.com/pinecone-io/go-pinecone/pinecone"
+	"github.com/pzierahn/chatbot_services/search"
+	"strings"
+)
+
+// ListDocuments lists all vector ids of the index and passes the ids of each page
+// grouped by document to fn. The ids have the format collectionId#documentId#fragmentId,
+// the user id is not part of them.
+func (db *Search) ListDocuments(ctx context.Context, fn func(refs []*search.DocumentRef) error) error {
+
+	idxConnection, err := db.getIndexConnection(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = idxConnection.Close() }()
+
+	limit := uint32(100)
+
+	var token *string
+	for {
+		list, err := idxConnection.ListVectors(ctx, &pinecone.ListVectorsRequest{
+			Limit:           &limit,
+			PaginationToken: token,
+		})
+		if err != nil {
+			return err
+		}
+
+		documents := make(map[string]*search.DocumentRef)
+		var refs []*search.DocumentRef
+
+		for _, id := range list.VectorIds {
+			parts := strings.Split(*id, "#")
+			if len(parts) != 3 {
+				continue
+			}
+
+			ref, ok := documents[parts[1]]
+			if !ok {
+				ref = &search.DocumentRef{
+					CollectionId: parts[0],
+					DocumentId:   parts[1],
+				}
+				documents[parts[1]] = ref
+				refs = append(refs, ref)
+			}
+
+			ref.Vectors++
+		}
+
+		err = fn(refs)
+		if err != nil {
+			return err
+		}
+
+		token = list.NextPaginationToken
+		if token == nil {
+			return nil
+		}
+	}
+}
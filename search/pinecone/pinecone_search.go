@@ -9,6 +9,15 @@ import (
 )
 
 func (db *Search) Search(ctx context.Context, query search.Query) (*search.Results, error) {
+	if len(query.Queries) > 0 {
+		return search.SearchQueries(ctx, db, query)
+	}
+
+	// Vectors of other embedding models would return meaningless scores
+	err := search.CheckEmbeddingModel(db, query.EmbeddingModel)
+	if err != nil {
+		return nil, err
+	}
 
 	embedded, err := db.embedding.CreateEmbedding(ctx, &llm.EmbeddingRequest{
 		Inputs: []string{query.Query},
@@ -18,16 +27,35 @@ func (db *Search) Search(ctx context.Context, query search.Query) (*search.Resul
 		return nil, err
 	}
 
+	err = search.CheckDimension(db.dimension, embedded.Embeddings[0])
+	if err != nil {
+		return nil, err
+	}
+
 	idxConnection, err := db.getIndexConnection(ctx)
 	if err != nil {
 		return nil, err
 	}
 	defer func() { _ = idxConnection.Close() }()
 
-	filter, err := structpb.NewStruct(map[string]any{
+	fields := map[string]any{
 		search.PayloadCollectionId: query.CollectionId,
 		search.PayloadUserId:       query.UserId,
-	})
+	}
+
+	if query.Language != "" {
+		fields[search.PayloadLanguage] = query.Language
+	}
+
+	if len(query.DocumentIds) > 0 {
+		documentIds := make([]any, len(query.DocumentIds))
+		for idx, id := range query.DocumentIds {
+			documentIds[idx] = id
+		}
+		fields[search.PayloadDocumentId] = map[string]any{"$in": documentIds}
+	}
+
+	filter, err := structpb.NewStruct(fields)
 	if err != nil {
 		return nil, err
 	}
@@ -45,7 +73,8 @@ func (db *Search) Search(ctx context.Context, query search.Query) (*search.Resul
 
 	var results []*search.Result
 	for _, match := range vectors.Matches {
-		if match.Vector == nil || match.Score < query.Threshold {
+		// The index is created with the cosine metric
+		if match.Vector == nil || search.NormalizeScore(search.MetricCosine, match.Score) < query.Threshold {
 			continue
 		}
 
@@ -74,20 +103,31 @@ func (db *Search) Search(ctx context.Context, query search.Query) (*search.Resul
 
 		position := uint32(posFloat)
 
+		// Fragments indexed before the language detection have no language
+		language, _ := metadata[search.PayloadLanguage].(string)
+		chunkType, _ := metadata[search.PayloadType].(string)
+
 		results = append(results, &search.Result{
 			Id:         fragmentId,
 			Text:       text,
 			DocumentId: documentId,
 			Position:   position,
-			Score:      match.Score,
+			Language:   language,
+			Type:       chunkType,
+			Score:      search.NormalizeScore(search.MetricCosine, match.Score),
+			RawScore:   match.Score,
 		})
 	}
 
-	return &search.Results{
+	response := &search.Results{
 		Usage: search.Usage{
 			ModelId: embedded.Model,
 			Tokens:  embedded.Tokens,
 		},
 		Results: results,
-	}, nil
+		Metric:  search.MetricCosine,
+	}
+	response.Group(query.Grouping)
+
+	return response, nil
 }
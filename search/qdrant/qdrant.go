@@ -14,9 +14,18 @@ type Search struct {
 	conn          *grpc.ClientConn
 	apiKey        string
 	namespace     string
+	index         string
 	embedding     llm.Embedding
 	fastEmbedding *search.ParallelEmbedding
 	dimension     int
+
+	// hnswEf is used for queries that don't set one, 0 uses the default of qdrant
+	hnswEf uint32
+}
+
+// EmbeddingModel returns the id of the model used to create the vectors.
+func (db *Search) EmbeddingModel() string {
+	return db.embedding.GetModelId()
 }
 
 func (db *Search) Close() error {
@@ -39,7 +48,7 @@ func New(engine llm.Embedding, namespace string) (*Search, error) {
 		return nil, err
 	}
 
-	fastEmbedding := search.NewParallelEmbedding(engine, 10, 100)
+	fastEmbedding := search.NewParallelEmbedding(engine, search.EmbeddingWorkers(), 100, search.EmbeddingTruncation())
 
 	client := &Search{
 		conn:          conn,
@@ -48,6 +57,7 @@ func New(engine llm.Embedding, namespace string) (*Search, error) {
 		embedding:     engine,
 		dimension:     engine.GetEmbeddingDimension(),
 		fastEmbedding: fastEmbedding,
+		hnswEf:        search.DefaultHnswEf(),
 	}
 
 	err = client.Init()
@@ -49,7 +49,7 @@ func (db *Search) DeleteCollection(ctx context.Context, userId, collectionId str
 				},
 			},
 		},
-		CollectionName: db.namespace,
+		CollectionName: db.index,
 	})
 
 	return err
@@ -97,7 +97,43 @@ func (db *Search) DeleteDocument(ctx context.Context, userId, _, documentId stri
 				},
 			},
 		},
-		CollectionName: db.namespace,
+		CollectionName: db.index,
+	})
+
+	return err
+}
+
+// DeleteFragments deletes the points of the fragments, whose ids are the point ids.
+func (db *Search) DeleteFragments(ctx context.Context, _, _, _ string, ids ...string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	ctx = metadata.AppendToOutgoingContext(
+		ctx,
+		"api-key",
+		db.apiKey,
+	)
+
+	pointIds := make([]*qdrant.PointId, len(ids))
+	for idx, id := range ids {
+		pointIds[idx] = &qdrant.PointId{
+			PointIdOptions: &qdrant.PointId_Uuid{
+				Uuid: id,
+			},
+		}
+	}
+
+	points := qdrant.NewPointsClient(db.conn)
+	_, err := points.Delete(ctx, &qdrant.DeletePoints{
+		Points: &qdrant.PointsSelector{
+			PointsSelectorOneOf: &qdrant.PointsSelector_Points{
+				Points: &qdrant.PointsIdsList{
+					Ids: pointIds,
+				},
+			},
+		},
+		CollectionName: db.index,
 	})
 
 	return err
@@ -7,9 +7,9 @@ import (
 	"google.golang.org/grpc/metadata"
 )
 
-func (db *Search) Upsert(ctx context.Context, fragments []*search.Fragment) (*search.Usage, error) {
+func (db *Search) Upsert(ctx context.Context, fragments []*search.Fragment, progress search.Progress) (*search.Usage, error) {
 
-	embedded, err := db.fastEmbedding.CreateEmbeddings(ctx, fragments)
+	embedded, err := db.fastEmbedding.CreateEmbeddings(ctx, fragments, progress)
 	if err != nil {
 		return nil, err
 	}
@@ -21,6 +21,51 @@ func (db *Search) Upsert(ctx context.Context, fragments []*search.Fragment) (*se
 			continue
 		}
 
+		payload := map[string]*qdrant.Value{
+			search.PayloadDocumentId: {
+				Kind: &qdrant.Value_StringValue{
+					StringValue: item.DocumentId,
+				},
+			},
+			search.PayloadCollectionId: {
+				Kind: &qdrant.Value_StringValue{
+					StringValue: item.CollectionId,
+				},
+			},
+			search.PayloadUserId: {
+				Kind: &qdrant.Value_StringValue{
+					StringValue: item.UserId,
+				},
+			},
+			search.PayloadText: {
+				Kind: &qdrant.Value_StringValue{
+					StringValue: item.Text,
+				},
+			},
+			search.PayloadPosition: {
+				Kind: &qdrant.Value_IntegerValue{
+					IntegerValue: int64(item.Position),
+				},
+			},
+		}
+
+		// Fragments without a detected language are excluded by language filters
+		if item.Language != "" {
+			payload[search.PayloadLanguage] = &qdrant.Value{
+				Kind: &qdrant.Value_StringValue{
+					StringValue: item.Language,
+				},
+			}
+		}
+
+		if item.Type != "" {
+			payload[search.PayloadType] = &qdrant.Value{
+				Kind: &qdrant.Value_StringValue{
+					StringValue: item.Type,
+				},
+			}
+		}
+
 		vectors = append(vectors, &qdrant.PointStruct{
 			Id: &qdrant.PointId{
 				PointIdOptions: &qdrant.PointId_Uuid{
@@ -34,33 +79,7 @@ func (db *Search) Upsert(ctx context.Context, fragments []*search.Fragment) (*se
 					},
 				},
 			},
-			Payload: map[string]*qdrant.Value{
-				search.PayloadDocumentId: {
-					Kind: &qdrant.Value_StringValue{
-						StringValue: item.DocumentId,
-					},
-				},
-				search.PayloadCollectionId: {
-					Kind: &qdrant.Value_StringValue{
-						StringValue: item.CollectionId,
-					},
-				},
-				search.PayloadUserId: {
-					Kind: &qdrant.Value_StringValue{
-						StringValue: item.UserId,
-					},
-				},
-				search.PayloadText: {
-					Kind: &qdrant.Value_StringValue{
-						StringValue: item.Text,
-					},
-				},
-				search.PayloadPosition: {
-					Kind: &qdrant.Value_IntegerValue{
-						IntegerValue: int64(item.Position),
-					},
-				},
-			},
+			Payload: payload,
 		})
 	}
 
@@ -75,7 +94,7 @@ func (db *Search) Upsert(ctx context.Context, fragments []*search.Fragment) (*se
 		end := min(start+50, len(vectors))
 
 		_, err := points.Upsert(ctx, &qdrant.UpsertPoints{
-			CollectionName: db.namespace,
+			CollectionName: db.index,
 			Points:         vectors[start:end],
 		})
 		if err != nil {
@@ -2,6 +2,7 @@ package qdrant
 
 import (
 	"context"
+	"github.com/pzierahn/chatbot_services/search"
 	qdrant "github.com/qdrant/go-client/qdrant"
 	"google.golang.org/grpc/metadata"
 )
@@ -17,22 +18,47 @@ func (db *Search) Init() error {
 		return err
 	}
 
+	collections := make(map[string]bool)
 	for _, collection := range list.Collections {
-		if collection.Name == db.namespace {
-			//
-			// Collection already exists. Nothing to do.
-			//
-			return nil
+		collections[collection.Name] = true
+	}
+
+	var legacyDimension int
+	if collections[db.namespace] {
+		info, err := collectionClient.Get(ctx, &qdrant.GetCollectionInfoRequest{
+			CollectionName: db.namespace,
+		})
+		if err != nil {
+			return err
 		}
+
+		params := info.GetResult().GetConfig().GetParams().GetVectorsConfig().GetParams()
+		legacyDimension = int(params.GetSize())
+	}
+
+	db.index = search.ResolveIndex(db.namespace, db.dimension, legacyDimension)
+	if collections[db.index] {
+		//
+		// Collection already exists. Nothing to do.
+		//
+		return nil
 	}
 
+	return db.CreateIndex(ctx, db.dimension)
+}
+
+// CreateIndex creates the collection for vectors of the given dimension.
+func (db *Search) CreateIndex(ctx context.Context, dimension int) error {
+	collectionClient := qdrant.NewCollectionsClient(db.conn)
+	ctx = metadata.AppendToOutgoingContext(ctx, "api-key", db.apiKey)
+
 	onDisk := true
-	_, err = collectionClient.Create(ctx, &qdrant.CreateCollection{
-		CollectionName: db.namespace,
+	_, err := collectionClient.Create(ctx, &qdrant.CreateCollection{
+		CollectionName: search.IndexName(db.namespace, dimension),
 		VectorsConfig: &qdrant.VectorsConfig{
 			Config: &qdrant.VectorsConfig_Params{
 				Params: &qdrant.VectorParams{
-					Size:     uint64(db.dimension),
+					Size:     uint64(dimension),
 					Distance: qdrant.Distance_Cosine,
 					OnDisk:   &onDisk,
 				},
@@ -0,0 +1,79 @@
+package qdrant
+
+import (
+	"context"
+	"github.com/pzierahn/chatbot_services/search"
+	qdrant "github.com/qdrant/go-client/qdrant"
+	"google.golang.org/grpc/metadata"
+)
+
+// scrollLimit is the number of points fetched per scroll request.
+const scrollLimit = 1000
+
+// ListDocuments scrolls through all points of the index and passes the points of
+// each page grouped by document to fn.
+func (db *Search) ListDocuments(ctx context.Context, fn func(refs []*search.DocumentRef) error) error {
+
+	ctx = metadata.AppendToOutgoingContext(
+		ctx,
+		"api-key",
+		db.apiKey,
+	)
+
+	points := qdrant.NewPointsClient(db.conn)
+	limit := uint32(scrollLimit)
+
+	var offset *qdrant.PointId
+	for {
+		response, err := points.Scroll(ctx, &qdrant.ScrollPoints{
+			CollectionName: db.index,
+			Offset:         offset,
+			Limit:          &limit,
+			WithPayload: &qdrant.WithPayloadSelector{
+				SelectorOptions: &qdrant.WithPayloadSelector_Include{
+					Include: &qdrant.PayloadIncludeSelector{
+						Fields: []string{
+							search.PayloadUserId,
+							search.PayloadCollectionId,
+							search.PayloadDocumentId,
+						},
+					},
+				},
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		documents := make(map[string]*search.DocumentRef)
+		var refs []*search.DocumentRef
+
+		for _, point := range response.GetResult() {
+			payload := point.GetPayload()
+			documentId := payload[search.PayloadDocumentId].GetStringValue()
+
+			ref, ok := documents[documentId]
+			if !ok {
+				ref = &search.DocumentRef{
+					UserId:       payload[search.PayloadUserId].GetStringValue(),
+					CollectionId: payload[search.PayloadCollectionId].GetStringValue(),
+					DocumentId:   documentId,
+				}
+				documents[documentId] = ref
+				refs = append(refs, ref)
+			}
+
+			ref.Vectors++
+		}
+
+		err = fn(refs)
+		if err != nil {
+			return err
+		}
+
+		offset = response.GetNextPageOffset()
+		if offset == nil {
+			return nil
+		}
+	}
+}
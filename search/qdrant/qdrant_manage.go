@@ -0,0 +1,74 @@
+package qdrant
+
+import (
+	"context"
+	"errors"
+	"github.com/pzierahn/chatbot_services/search"
+	qdrant "github.com/qdrant/go-client/qdrant"
+	"google.golang.org/grpc/metadata"
+	"strings"
+)
+
+// IndexStatus returns the state of the HNSW index of the collection.
+func (db *Search) IndexStatus(ctx context.Context) (*search.IndexStatus, error) {
+	collectionClient := qdrant.NewCollectionsClient(db.conn)
+	ctx = metadata.AppendToOutgoingContext(ctx, "api-key", db.apiKey)
+
+	info, err := collectionClient.Get(ctx, &qdrant.GetCollectionInfoRequest{
+		CollectionName: db.index,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := info.GetResult()
+	hnsw := result.GetConfig().GetHnswConfig()
+	optimizer := result.GetConfig().GetOptimizerConfig()
+
+	return &search.IndexStatus{
+		Name:           db.index,
+		Status:         strings.ToLower(result.GetStatus().String()),
+		Points:         result.GetPointsCount(),
+		IndexedVectors: result.GetIndexedVectorsCount(),
+		Segments:       result.GetSegmentsCount(),
+		Params: search.IndexParams{
+			M:                 hnsw.GetM(),
+			EfConstruct:       hnsw.GetEfConstruct(),
+			IndexingThreshold: optimizer.GetIndexingThreshold(),
+		},
+	}, nil
+}
+
+// RebuildIndex changes the HNSW parameters of the collection. Qdrant rebuilds the
+// index of all segments in the background if the parameters changed.
+func (db *Search) RebuildIndex(ctx context.Context, params search.IndexParams) error {
+	if params == (search.IndexParams{}) {
+		return errors.New("no index parameters set")
+	}
+
+	collectionClient := qdrant.NewCollectionsClient(db.conn)
+	ctx = metadata.AppendToOutgoingContext(ctx, "api-key", db.apiKey)
+
+	update := &qdrant.UpdateCollection{
+		CollectionName: db.index,
+		HnswConfig:     &qdrant.HnswConfigDiff{},
+	}
+
+	if params.M > 0 {
+		update.HnswConfig.M = &params.M
+	}
+
+	if params.EfConstruct > 0 {
+		update.HnswConfig.EfConstruct = &params.EfConstruct
+	}
+
+	if params.IndexingThreshold > 0 {
+		update.OptimizersConfig = &qdrant.OptimizersConfigDiff{
+			IndexingThreshold: &params.IndexingThreshold,
+		}
+	}
+
+	_, err := collectionClient.Update(ctx, update)
+
+	return err
+}
@@ -9,6 +9,15 @@ import (
 )
 
 func (db *Search) Search(ctx context.Context, query search.Query) (*search.Results, error) {
+	if len(query.Queries) > 0 {
+		return search.SearchQueries(ctx, db, query)
+	}
+
+	// Vectors of other embedding models would return meaningless scores
+	err := search.CheckEmbeddingModel(db, query.EmbeddingModel)
+	if err != nil {
+		return nil, err
+	}
 
 	embedded, err := db.embedding.CreateEmbedding(ctx, &llm.EmbeddingRequest{
 		Inputs: []string{query.Query},
@@ -18,47 +27,104 @@ func (db *Search) Search(ctx context.Context, query search.Query) (*search.Resul
 		return nil, err
 	}
 
+	err = search.CheckDimension(db.dimension, embedded.Embeddings[0])
+	if err != nil {
+		return nil, err
+	}
+
 	ctx = metadata.AppendToOutgoingContext(ctx, "api-key", db.apiKey)
 
-	points := qdrant.NewPointsClient(db.conn)
-	queryResult, err := points.Search(ctx, &qdrant.SearchPoints{
-		CollectionName: db.namespace,
-		WithPayload: &qdrant.WithPayloadSelector{
-			SelectorOptions: &qdrant.WithPayloadSelector_Enable{
-				Enable: true,
+	// The collection is created with the cosine distance. A threshold of 0 disables the filtering.
+	var threshold *float32
+	if query.Threshold > 0 {
+		raw := search.RawThreshold(search.MetricCosine, query.Threshold)
+		threshold = &raw
+	}
+
+	conditions := []*qdrant.Condition{
+		{
+			ConditionOneOf: &qdrant.Condition_Field{
+				Field: &qdrant.FieldCondition{
+					Key: search.PayloadCollectionId,
+					Match: &qdrant.Match{
+						MatchValue: &qdrant.Match_Text{
+							Text: query.CollectionId,
+						},
+					},
+				},
 			},
 		},
-		ScoreThreshold: &query.Threshold,
-		Vector:         embedded.Embeddings[0],
-		Limit:          uint64(query.Limit),
-		Filter: &qdrant.Filter{
-			Must: []*qdrant.Condition{
-				{
-					ConditionOneOf: &qdrant.Condition_Field{
-						Field: &qdrant.FieldCondition{
-							Key: search.PayloadCollectionId,
-							Match: &qdrant.Match{
-								MatchValue: &qdrant.Match_Text{
-									Text: query.CollectionId,
-								},
-							},
+		{
+			ConditionOneOf: &qdrant.Condition_Field{
+				Field: &qdrant.FieldCondition{
+					Key: search.PayloadUserId,
+					Match: &qdrant.Match{
+						MatchValue: &qdrant.Match_Text{
+							Text: query.UserId,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if query.Language != "" {
+		conditions = append(conditions, &qdrant.Condition{
+			ConditionOneOf: &qdrant.Condition_Field{
+				Field: &qdrant.FieldCondition{
+					Key: search.PayloadLanguage,
+					Match: &qdrant.Match{
+						MatchValue: &qdrant.Match_Keyword{
+							Keyword: query.Language,
 						},
 					},
 				},
-				{
-					ConditionOneOf: &qdrant.Condition_Field{
-						Field: &qdrant.FieldCondition{
-							Key: search.PayloadUserId,
-							Match: &qdrant.Match{
-								MatchValue: &qdrant.Match_Text{
-									Text: query.UserId,
-								},
+			},
+		})
+	}
+
+	if len(query.DocumentIds) > 0 {
+		conditions = append(conditions, &qdrant.Condition{
+			ConditionOneOf: &qdrant.Condition_Field{
+				Field: &qdrant.FieldCondition{
+					Key: search.PayloadDocumentId,
+					Match: &qdrant.Match{
+						MatchValue: &qdrant.Match_Keywords{
+							Keywords: &qdrant.RepeatedStrings{
+								Strings: query.DocumentIds,
 							},
 						},
 					},
 				},
 			},
+		})
+	}
+
+	hnswEf := uint64(query.HnswEf)
+	if hnswEf == 0 {
+		hnswEf = uint64(db.hnswEf)
+	}
+
+	var params *qdrant.SearchParams
+	if hnswEf > 0 {
+		params = &qdrant.SearchParams{HnswEf: &hnswEf}
+	}
+
+	points := qdrant.NewPointsClient(db.conn)
+	queryResult, err := points.Search(ctx, &qdrant.SearchPoints{
+		CollectionName: db.index,
+		WithPayload: &qdrant.WithPayloadSelector{
+			SelectorOptions: &qdrant.WithPayloadSelector_Enable{
+				Enable: true,
+			},
+		},
+		ScoreThreshold: threshold,
+		Vector:         embedded.Embeddings[0],
+		Limit:          uint64(query.Limit),
+		Filter: &qdrant.Filter{
+			Must: conditions,
 		},
+		Params: params,
 	})
 	if err != nil {
 		return nil, err
@@ -70,6 +136,7 @@ func (db *Search) Search(ctx context.Context, query search.Query) (*search.Resul
 			ModelId: embedded.Model,
 			Tokens:  embedded.Tokens,
 		},
+		Metric: search.MetricCosine,
 	}
 
 	for idx, item := range queryResult.Result {
@@ -78,9 +145,14 @@ func (db *Search) Search(ctx context.Context, query search.Query) (*search.Resul
 			DocumentId: item.Payload[search.PayloadDocumentId].GetStringValue(),
 			Text:       item.Payload[search.PayloadText].GetStringValue(),
 			Position:   uint32(item.Payload[search.PayloadPosition].GetIntegerValue()),
-			Score:      item.Score,
+			Language:   item.Payload[search.PayloadLanguage].GetStringValue(),
+			Type:       item.Payload[search.PayloadType].GetStringValue(),
+			Score:      search.NormalizeScore(search.MetricCosine, item.Score),
+			RawScore:   item.Score,
 		}
 	}
 
+	results.Group(query.Grouping)
+
 	return results, nil
 }
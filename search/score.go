@@ -0,0 +1,61 @@
+package search
+
+import (
+	"fmt"
+	"math"
+)
+
+// Metric defines the distance metric of a vector index.
+type Metric string
+
+const (
+	MetricCosine Metric = "cosine" // MetricCosine scores are cosine similarities in [-1, 1]
+	MetricL2     Metric = "l2"     // MetricL2 scores are euclidean distances in [0, inf)
+	MetricDot    Metric = "dot"    // MetricDot scores are inner products of normalized vectors in [-1, 1]
+)
+
+// NormalizeScore converts a raw score of the metric into a similarity in [0, 1],
+// where 1 means identical vectors. Cosine similarities map to (1 + cos) / 2, so
+// scores are higher than the raw cosine similarities that were returned before.
+func NormalizeScore(metric Metric, raw float32) float32 {
+	var score float64
+
+	switch metric {
+	case MetricCosine, MetricDot:
+		score = (1 + float64(raw)) / 2
+	case MetricL2:
+		score = 1 / (1 + math.Max(float64(raw), 0))
+	default:
+		score = float64(raw)
+	}
+
+	return float32(math.Min(math.Max(score, 0), 1))
+}
+
+// RawThreshold converts a similarity threshold in [0, 1] into a raw score of the metric.
+// For L2 the result is an upper bound for the distance instead of a lower bound.
+func RawThreshold(metric Metric, threshold float32) float32 {
+	threshold = float32(math.Min(math.Max(float64(threshold), 0), 1))
+
+	switch metric {
+	case MetricCosine, MetricDot:
+		return 2*threshold - 1
+	case MetricL2:
+		if threshold == 0 {
+			return float32(math.Inf(1))
+		}
+		return 1/threshold - 1
+	default:
+		return threshold
+	}
+}
+
+// ValidateThreshold returns an error if the threshold is not a similarity in [0, 1].
+// A threshold of 0 disables the filtering.
+func ValidateThreshold(threshold float32) error {
+	if math.IsNaN(float64(threshold)) || threshold < 0 || threshold > 1 {
+		return fmt.Errorf("threshold must be a similarity in [0, 1], got %v", threshold)
+	}
+
+	return nil
+}
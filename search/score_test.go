@@ -0,0 +1,67 @@
+package search
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNormalizeScore(t *testing.T) {
+	tests := []struct {
+		name   string
+		metric Metric
+		raw    float32
+		want   float32
+	}{
+		{"cosine identical", MetricCosine, 1, 1},
+		{"cosine orthogonal", MetricCosine, 0, 0.5},
+		{"cosine opposite", MetricCosine, -1, 0},
+		{"cosine rounding above one", MetricCosine, 1.0000001, 1},
+		{"l2 identical", MetricL2, 0, 1},
+		{"l2 distance one", MetricL2, 1, 0.5},
+		{"l2 distance three", MetricL2, 3, 0.25},
+		{"l2 negative rounding", MetricL2, -0.0001, 1},
+		{"dot identical", MetricDot, 1, 1},
+		{"dot orthogonal", MetricDot, 0, 0.5},
+		{"dot opposite", MetricDot, -1, 0},
+		{"dot unnormalized", MetricDot, 2.5, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NormalizeScore(tt.metric, tt.raw)
+			if math.Abs(float64(got-tt.want)) > 1e-6 {
+				t.Fatalf("NormalizeScore(%s, %v) = %v, want %v", tt.metric, tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRawThreshold(t *testing.T) {
+	for _, metric := range []Metric{MetricCosine, MetricL2, MetricDot} {
+		for _, threshold := range []float32{0.1, 0.25, 0.5, 0.75, 1} {
+			raw := RawThreshold(metric, threshold)
+			got := NormalizeScore(metric, raw)
+			if math.Abs(float64(got-threshold)) > 1e-6 {
+				t.Fatalf("%s: threshold %v round trips to %v", metric, threshold, got)
+			}
+		}
+	}
+
+	if raw := RawThreshold(MetricL2, 0); !math.IsInf(float64(raw), 1) {
+		t.Fatalf("l2: threshold 0 should not limit the distance, got %v", raw)
+	}
+}
+
+func TestValidateThreshold(t *testing.T) {
+	for _, threshold := range []float32{0, 0.5, 1} {
+		if err := ValidateThreshold(threshold); err != nil {
+			t.Errorf("ValidateThreshold(%v) = %v, want nil", threshold, err)
+		}
+	}
+
+	for _, threshold := range []float32{-0.1, 1.1, float32(math.NaN())} {
+		if err := ValidateThreshold(threshold); err == nil {
+			t.Errorf("ValidateThreshold(%v) = nil, want error", threshold)
+		}
+	}
+}
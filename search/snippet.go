@@ -0,0 +1,107 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DefaultSnippetLength defines the maximum length of a snippet in characters.
+const DefaultSnippetLength = 200
+
+// Snippet is the part of a text that matches a query best.
+type Snippet struct {
+	Text string
+
+	// Start and End are the byte offsets of the snippet in the text
+	Start int
+	End   int
+}
+
+// terms returns the lower-cased words of a text.
+func terms(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// sentences returns the byte offsets of the sentences of a text.
+func sentences(text string) [][2]int {
+	var spans [][2]int
+
+	start := 0
+	for idx, r := range text {
+		if r != '.' && r != '!' && r != '?' && r != '\n' {
+			continue
+		}
+
+		end := idx + utf8.RuneLen(r)
+		if end < len(text) && text[end] != ' ' && text[end] != '\n' {
+			// Not the end of a sentence, e.g. a decimal point
+			continue
+		}
+
+		if strings.TrimSpace(text[start:end]) != "" {
+			spans = append(spans, [2]int{start, end})
+		}
+		start = end
+	}
+
+	if strings.TrimSpace(text[start:]) != "" {
+		spans = append(spans, [2]int{start, len(text)})
+	}
+
+	return spans
+}
+
+// FindSnippet returns the sentence of the text that shares the most words with the
+// query. The snippet is cut to at most length characters and filled up with the
+// following text if the sentence is shorter. Without any shared words, the snippet
+// starts at the beginning of the text.
+func FindSnippet(text, query string, length int) Snippet {
+	if length <= 0 {
+		length = DefaultSnippetLength
+	}
+
+	queryTerms := make(map[string]bool)
+	for _, term := range terms(query) {
+		queryTerms[term] = true
+	}
+
+	start := 0
+	bestScore := 0
+	for _, span := range sentences(text) {
+		matched := make(map[string]bool)
+		for _, term := range terms(text[span[0]:span[1]]) {
+			if queryTerms[term] {
+				matched[term] = true
+			}
+		}
+
+		if len(matched) > bestScore {
+			bestScore = len(matched)
+			start = span[0]
+		}
+	}
+
+	// Skip the whitespace between the sentences
+	for start < len(text) {
+		r, size := utf8.DecodeRuneInString(text[start:])
+		if !unicode.IsSpace(r) {
+			break
+		}
+		start += size
+	}
+
+	end := start
+	for count := 0; end < len(text) && count < length; count++ {
+		_, size := utf8.DecodeRuneInString(text[end:])
+		end += size
+	}
+
+	return Snippet{
+		Text:  text[start:end],
+		Start: start,
+		End:   end,
+	}
+}
@@ -0,0 +1,43 @@
+package search
+
+import "testing"
+
+func TestFindSnippet(t *testing.T) {
+	text := "Go was designed at Google. It is statically typed. The garbage collector runs concurrently."
+
+	tests := []struct {
+		name   string
+		query  string
+		length int
+		want   string
+	}{
+		{"best sentence", "How does the garbage collector work?", 200, "The garbage collector runs concurrently."},
+		{"fill with following text", "statically typed", 30, "It is statically typed. The ga"},
+		{"cut long sentence", "garbage", 11, "The garbage"},
+		{"no match", "rust", 10, "Go was des"},
+		{"default length", "google", 0, text},
+		{"case insensitive", "GOOGLE DESIGN", 26, "Go was designed at Google."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FindSnippet(text, tt.query, tt.length)
+			if got.Text != tt.want {
+				t.Fatalf("FindSnippet(%q) = %q, want %q", tt.query, got.Text, tt.want)
+			}
+
+			if text[got.Start:got.End] != got.Text {
+				t.Fatalf("offsets %d:%d don't match the snippet", got.Start, got.End)
+			}
+		})
+	}
+}
+
+func TestFindSnippetMultibyte(t *testing.T) {
+	text := "Über die Brücke. Die Straße ist lang."
+
+	got := FindSnippet(text, "straße", 10)
+	if got.Text != "Die Straße" {
+		t.Fatalf("FindSnippet() = %q, want %q", got.Text, "Die Straße")
+	}
+}
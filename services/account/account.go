@@ -20,4 +20,7 @@ type Service struct {
 	pb.UnimplementedAccountServer
 	Database *datastore.Service
 	Auth     auth.Service
+
+	// Admins contains the ids of users with access to admin methods
+	Admins map[string]bool
 }
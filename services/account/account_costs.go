@@ -1,24 +1,30 @@
 package account
 
 import (
+	"github.com/pzierahn/chatbot_services/datastore"
 	"github.com/pzierahn/chatbot_services/llm"
-	"github.com/pzierahn/chatbot_services/llm/anthropic"
-	"github.com/pzierahn/chatbot_services/llm/openai"
-	"github.com/pzierahn/chatbot_services/llm/vertex"
-)
 
-var prices = map[string]llm.PricePer1000Tokens{}
+	// Register the prices of all providers
+	_ "github.com/pzierahn/chatbot_services/llm/anthropic"
+	_ "github.com/pzierahn/chatbot_services/llm/openai"
+	_ "github.com/pzierahn/chatbot_services/llm/vertex"
+)
 
-func init() {
-	for name, price := range anthropic.ModelCosts {
-		prices[name] = price
+// usageCosts returns the costs of a usage in dollars. Usages recorded before costs
+// were persisted are priced with the current rates.
+func usageCosts(usage *datastore.ModelUsage) float64 {
+	if usage.Costs > 0 {
+		return usage.Costs
 	}
 
-	for name, price := range openai.ModelCosts {
-		prices[name] = price
-	}
+	return llm.EstimateCost(llm.ModelUsage{
+		Model:        usage.ModelId,
+		InputTokens:  usage.InputTokens,
+		OutputTokens: usage.OutputTokens,
+	})
+}
 
-	for name, price := range vertex.ModelCosts {
-		prices[name] = price
-	}
+// toCents converts dollars to cents.
+func toCents(dollars float64) uint32 {
+	return uint32(dollars * 100)
 }
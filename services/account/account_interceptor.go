@@ -0,0 +1,116 @@
+package account
+
+import (
+	"context"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+)
+
+// Policy defines which verification a method requires.
+type Policy int
+
+const (
+	PolicyAuth    Policy = iota // PolicyAuth requires valid user credentials
+	PolicyFunding               // PolicyFunding requires valid user credentials and funding
+	PolicyPublic                // PolicyPublic requires no credentials
+	PolicyAdmin                 // PolicyAdmin requires the credentials of an admin
+)
+
+// MethodPolicies maps full method names to their policy. Methods that are not
+// listed require authentication, so that new RPCs are never public by accident.
+var MethodPolicies = map[string]Policy{
+	pb.Chat_PostMessage_FullMethodName:     PolicyFunding,
+	pb.Chat_Regenerate_FullMethodName:      PolicyFunding,
+	pb.Chat_Completion_FullMethodName:      PolicyFunding,
+	pb.Embedding_Embed_FullMethodName:      PolicyFunding,
+	pb.Notion_ExecutePrompt_FullMethodName: PolicyFunding,
+
+	pb.Diagnostics_PingProviders_FullMethodName:      PolicyAdmin,
+	pb.Diagnostics_PurgeOrphans_FullMethodName:       PolicyAdmin,
+	pb.Diagnostics_GetVectorIndex_FullMethodName:     PolicyAdmin,
+	pb.Diagnostics_RebuildVectorIndex_FullMethodName: PolicyAdmin,
+	pb.Diagnostics_GetReindexStatus_FullMethodName:   PolicyAdmin,
+	pb.Diagnostics_WarmupCollection_FullMethodName:   PolicyAdmin,
+
+	// Reflection is only registered in debug mode
+	grpc_reflection_v1.ServerReflection_ServerReflectionInfo_FullMethodName:      PolicyPublic,
+	grpc_reflection_v1alpha.ServerReflection_ServerReflectionInfo_FullMethodName: PolicyPublic,
+}
+
+// verified is stored in the context of verified requests.
+type verified struct {
+	userId string
+	funded bool
+}
+
+type verifiedKey struct{}
+
+// verifiedFromContext returns the verification done by the interceptor.
+func verifiedFromContext(ctx context.Context) (verified, bool) {
+	value, ok := ctx.Value(verifiedKey{}).(verified)
+	return value, ok
+}
+
+// verifyMethod verifies the request according to the policy of the method and
+// returns a context that contains the verified user.
+func (service *Service) verifyMethod(ctx context.Context, method string) (context.Context, error) {
+	policy := MethodPolicies[method]
+
+	switch policy {
+	case PolicyPublic:
+		return ctx, nil
+	case PolicyAdmin:
+		userId, err := service.VerifyAdmin(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return context.WithValue(ctx, verifiedKey{}, verified{userId: userId}), nil
+	case PolicyFunding:
+		userId, err := service.VerifyFunding(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return context.WithValue(ctx, verifiedKey{}, verified{userId: userId, funded: true}), nil
+	default:
+		userId, err := service.Verify(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return context.WithValue(ctx, verifiedKey{}, verified{userId: userId}), nil
+	}
+}
+
+// UnaryInterceptor verifies unary requests once before they reach the handler.
+func (service *Service) UnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := service.verifyMethod(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// verifiedStream overrides the context of a server stream.
+type verifiedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (stream *verifiedStream) Context() context.Context {
+	return stream.ctx
+}
+
+// StreamInterceptor verifies streaming requests once before they reach the handler.
+func (service *Service) StreamInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := service.verifyMethod(stream.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &verifiedStream{ServerStream: stream, ctx: ctx})
+}
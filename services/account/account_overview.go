@@ -34,7 +34,7 @@ func (service *Service) getOverview(ctx context.Context, userId string) (*pb.Ove
 }
 
 func (service *Service) GetOverview(ctx context.Context, _ *emptypb.Empty) (*pb.Overview, error) {
-	userId, err := service.Auth.Verify(ctx)
+	userId, err := service.Verify(ctx)
 	if err != nil {
 		return nil, err
 	}
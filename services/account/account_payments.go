@@ -28,7 +28,7 @@ func (service *Service) getPayments(ctx context.Context, userId string) (*pb.Pay
 }
 
 func (service *Service) GetPayments(ctx context.Context, _ *emptypb.Empty) (*pb.Payments, error) {
-	userId, err := service.Auth.Verify(ctx)
+	userId, err := service.Verify(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,100 @@
+package account
+
+import (
+	"context"
+	"github.com/pzierahn/chatbot_services/datastore"
+	"github.com/pzierahn/chatbot_services/llm"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+// defaultReportRange is used if the request does not specify the start of the report.
+const defaultReportRange = 30 * 24 * time.Hour
+
+// modelUsageToProto converts an aggregated usage. Usages without persisted costs are priced with the current rates.
+func modelUsageToProto(usage datastore.UsageAggregate) *pb.ModelUsage {
+	costs := usage.Costs + llm.EstimateCost(llm.ModelUsage{
+		Model:        usage.ModelId,
+		InputTokens:  usage.UnpricedInputTokens,
+		OutputTokens: usage.UnpricedOutputTokens,
+	})
+
+	return &pb.ModelUsage{
+		Model:    usage.ModelId,
+		Input:    usage.InputTokens,
+		Output:   usage.OutputTokens,
+		Costs:    toCents(costs),
+		Requests: usage.Requests,
+	}
+}
+
+// GetUsageReport returns the usage of the user per day and per model and the
+// feedback of the user per model.
+func (service *Service) GetUsageReport(ctx context.Context, req *pb.UsageReportRequest) (*pb.UsageReport, error) {
+	userId, err := service.Verify(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	to := time.Now()
+	if req.To != nil {
+		to = req.To.AsTime()
+	}
+
+	from := to.Add(-defaultReportRange)
+	if req.From != nil {
+		from = req.From.AsTime()
+	}
+
+	if !from.Before(to) {
+		return nil, status.Error(codes.InvalidArgument, "from must be before to")
+	}
+
+	daily, err := service.Database.UsageByUser(ctx, userId, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	models, err := service.Database.UsageByModel(ctx, userId, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	feedback, err := service.Database.FeedbackByModel(ctx, userId, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &pb.UsageReport{}
+
+	// The daily usages are sorted by day
+	for _, usage := range daily {
+		if len(report.Days) == 0 || !report.Days[len(report.Days)-1].Date.AsTime().Equal(usage.Day) {
+			report.Days = append(report.Days, &pb.DailyUsage{
+				Date: timestamppb.New(usage.Day),
+			})
+		}
+
+		day := report.Days[len(report.Days)-1]
+		day.Models = append(day.Models, modelUsageToProto(usage))
+	}
+
+	for _, usage := range models {
+		report.Models = append(report.Models, modelUsageToProto(usage))
+	}
+
+	for _, model := range feedback {
+		report.Feedback = append(report.Feedback, &pb.ModelFeedback{
+			Model:        model.ModelId,
+			Up:           model.Up,
+			Down:         model.Down,
+			Scores:       model.Scores,
+			AverageScore: model.AverageScore,
+		})
+	}
+
+	return report, nil
+}
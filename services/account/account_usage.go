@@ -24,25 +24,24 @@ func (service *Service) getUsage(ctx context.Context, userId string) (*pb.Usage,
 	modelCalls := make(map[string]uint32)
 	inputs := make(map[string]uint32)
 	outputs := make(map[string]uint32)
+	costs := make(map[string]float64)
 
-	for _, usage := range usages {
+	for idx := range usages {
+		usage := &usages[idx]
 		modelCalls[usage.ModelId]++
 		inputs[usage.ModelId] += usage.InputTokens
 		outputs[usage.ModelId] += usage.OutputTokens
+		costs[usage.ModelId] += usageCosts(usage)
 	}
 
 	var usage []*pb.ModelUsage
 
 	for modelId, calls := range modelCalls {
-		price := prices[modelId]
-		input := inputs[modelId]
-		output := outputs[modelId]
-
 		usage = append(usage, &pb.ModelUsage{
 			Model:    modelId,
-			Input:    input,
-			Output:   output,
-			Costs:    price.Cost(input, output),
+			Input:    inputs[modelId],
+			Output:   outputs[modelId],
+			Costs:    toCents(costs[modelId]),
 			Requests: calls,
 		})
 	}
@@ -53,7 +52,7 @@ func (service *Service) getUsage(ctx context.Context, userId string) (*pb.Usage,
 }
 
 func (service *Service) GetCosts(ctx context.Context, _ *emptypb.Empty) (*pb.Usage, error) {
-	userId, err := service.Auth.Verify(ctx)
+	userId, err := service.Verify(ctx)
 	if err != nil {
 		return nil, err
 	}
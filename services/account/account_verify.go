@@ -2,6 +2,8 @@ package account
 
 import (
 	"context"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
@@ -9,15 +11,25 @@ import (
 const NoFundingCode = 17
 
 func NoFundingError() error {
-	return status.Errorf(NoFundingCode, "no funding available, please contact support")
+	return rpcerror.New(NoFundingCode, rpcerror.ReasonNoFunding, "", "no funding available, please contact support")
 }
 
+// Verify returns the user verified by the interceptor or verifies the credentials of the context.
 func (service *Service) Verify(ctx context.Context) (userId string, err error) {
+	if verified, ok := verifiedFromContext(ctx); ok {
+		return verified.userId, nil
+	}
+
 	return service.Auth.Verify(ctx)
 }
 
+// VerifyFunding returns the user verified by the interceptor or verifies the credentials and funding of the context.
 func (service *Service) VerifyFunding(ctx context.Context) (userId string, err error) {
-	userId, err = service.Auth.Verify(ctx)
+	if verified, ok := verifiedFromContext(ctx); ok && verified.funded {
+		return verified.userId, nil
+	}
+
+	userId, err = service.Verify(ctx)
 	if err != nil {
 		return
 	}
@@ -34,3 +46,17 @@ func (service *Service) VerifyFunding(ctx context.Context) (userId string, err e
 
 	return
 }
+
+// VerifyAdmin verifies the credentials of the context and checks that the user is an admin.
+func (service *Service) VerifyAdmin(ctx context.Context) (userId string, err error) {
+	userId, err = service.Verify(ctx)
+	if err != nil {
+		return
+	}
+
+	if !service.Admins[userId] {
+		return "", status.Errorf(codes.PermissionDenied, "admin access required")
+	}
+
+	return
+}
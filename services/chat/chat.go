@@ -6,7 +6,10 @@ import (
 	"github.com/pzierahn/chatbot_services/llm"
 	"github.com/pzierahn/chatbot_services/search"
 	"github.com/pzierahn/chatbot_services/services/account"
+	"github.com/pzierahn/chatbot_services/services/documents"
 	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"google.golang.org/grpc/codes"
 )
 
 type Service struct {
@@ -15,6 +18,32 @@ type Service struct {
 	Auth     account.Verifier
 	Database *datastore.Service
 	Search   search.Index
+
+	// Moderator screens prompts and completions, nil disables the moderation
+	Moderator llm.Moderator
+
+	// Limiter bounds the simultaneous completions, nil disables the limit
+	Limiter *Limiter
+
+	// ThreadLimit caps the messages of a thread, nil allows unlimited threads
+	ThreadLimit *ThreadLimit
+
+	// Retrieval defines the defaults of unset retrieval options, nil uses the package defaults
+	Retrieval *RetrievalDefaults
+
+	// AccessLog records the documents of the sources, nothing is recorded if nil
+	AccessLog *documents.AccessLogger
+
+	// ModelAccess restricts the models of the users, nil allows all models
+	ModelAccess *ModelAccess
+
+	// DefaultModel is used for prompts without a model id to collections without
+	// a default model, prompts require a model id if empty
+	DefaultModel string
+
+	// RewriteModel rewrites the search queries of prompts that request it and
+	// summarizes truncated threads, empty disables both
+	RewriteModel string
 }
 
 // getModel returns the llm.Chat that provides the given model.
@@ -25,5 +54,17 @@ func (service *Service) getModel(name string) (llm.Chat, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("model not found: %s", name)
+	return nil, rpcerror.New(codes.InvalidArgument, rpcerror.ReasonModelNotFound, "model_options.model_id",
+		fmt.Sprintf("model not found: %s", name))
+}
+
+// CheckModel returns an error if the model doesn't exist or the user may not use it.
+func (service *Service) CheckModel(userId, modelId string) error {
+	err := service.ModelAccess.checkModel(userId, modelId)
+	if err != nil {
+		return err
+	}
+
+	_, err = service.getModel(modelId)
+	return err
 }
@@ -0,0 +1,156 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
+	"github.com/pzierahn/chatbot_services/llm"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"strings"
+)
+
+const (
+	// MaxPendingMessages limits the messages appended to a thread before a completion.
+	MaxPendingMessages = 50
+
+	// MaxPendingMessageLength is the maximum number of characters of an appended message.
+	MaxPendingMessageLength = 100_000
+)
+
+// rolePendingSystem marks pending messages that extend the system prompt. The
+// providers only know user and assistant messages in the conversation.
+const rolePendingSystem = "system"
+
+// AppendMessages adds messages to a thread without a completion. They become part
+// of the next prompt posted to the thread, so that the conversation keeps
+// alternating between user and assistant messages as the providers require.
+func (service *Service) AppendMessages(ctx context.Context, req *pb.AppendRequest) (*emptypb.Empty, error) {
+	userId, err := service.Auth.Verify(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	threadId, err := uuid.Parse(req.ThreadId)
+	if err != nil {
+		return nil, rpcerror.InvalidId("thread_id", req.ThreadId)
+	}
+
+	if len(req.Messages) == 0 {
+		return nil, rpcerror.Missing("messages")
+	}
+
+	messages := make([]*llm.Message, len(req.Messages))
+	for idx, message := range req.Messages {
+		field := fmt.Sprintf("messages[%d].content", idx)
+
+		content, err := sanitizePrompt(field, message.Content, MaxPendingMessageLength)
+		if err != nil {
+			return nil, err
+		}
+		if content == "" {
+			return nil, rpcerror.Missing(field)
+		}
+
+		role := llm.RoleUser
+		switch message.Role {
+		case pb.ContextRole_CONTEXT_ROLE_USER:
+		case pb.ContextRole_CONTEXT_ROLE_SYSTEM:
+			role = rolePendingSystem
+		default:
+			return nil, rpcerror.New(codes.InvalidArgument, rpcerror.ReasonInvalidValue,
+				fmt.Sprintf("messages[%d].role", idx), "role must be user or system")
+		}
+
+		err = service.moderate(ctx, moderationPrompt, content)
+		if err != nil {
+			return nil, completionError(err)
+		}
+
+		// The id identifies the message when the next prompt consumes it
+		messages[idx] = &llm.Message{
+			Id:      uuid.NewString(),
+			Role:    role,
+			Content: content,
+		}
+	}
+
+	err = service.Database.AppendPending(ctx, userId, threadId, messages, MaxPendingMessages)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, rpcerror.NotFound("thread", req.ThreadId)
+	}
+	if errors.Is(err, datastore.ErrPendingLimit) {
+		return nil, rpcerror.New(codes.InvalidArgument, rpcerror.ReasonLimitExceeded, "messages",
+			fmt.Sprintf("pending messages exceed the limit of %d", MaxPendingMessages))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// applyPending adds the pending messages of a thread to a prompt. User messages
+// precede the prompt, system messages are returned as instructions that extend
+// the system prompt.
+func applyPending(pending []*llm.Message, prompt string) ([]string, string) {
+	var instructions, contents []string
+	for _, message := range pending {
+		if message.Role == rolePendingSystem {
+			instructions = append(instructions, message.Content)
+		} else {
+			contents = append(contents, message.Content)
+		}
+	}
+
+	if prompt != "" {
+		contents = append(contents, prompt)
+	}
+
+	return instructions, strings.Join(contents, "\n\n")
+}
+
+// withInstructions appends the instructions of a prompt to the system prompt.
+func withInstructions(system string, instructions []string) string {
+	if len(instructions) == 0 {
+		return system
+	}
+
+	return strings.Join(append([]string{system}, instructions...), "\n\n")
+}
+
+// pendingIds returns the ids of the consumed pending messages, an empty list if
+// none of them has an id.
+func pendingIds(pending []*llm.Message) []string {
+	ids := []string{}
+	for _, message := range pending {
+		if message.Id != "" {
+			ids = append(ids, message.Id)
+		}
+	}
+
+	return ids
+}
+
+// pendingToProto converts the pending messages of a thread.
+func pendingToProto(pending []*llm.Message) []*pb.ContextMessage {
+	var messages []*pb.ContextMessage
+	for _, message := range pending {
+		role := pb.ContextRole_CONTEXT_ROLE_USER
+		if message.Role == rolePendingSystem {
+			role = pb.ContextRole_CONTEXT_ROLE_SYSTEM
+		}
+
+		messages = append(messages, &pb.ContextMessage{
+			Role:    role,
+			Content: message.Content,
+		})
+	}
+
+	return messages
+}
@@ -0,0 +1,56 @@
+package chat
+
+import (
+	"github.com/pzierahn/chatbot_services/llm"
+	"testing"
+)
+
+func TestApplyPending(t *testing.T) {
+	pending := []*llm.Message{
+		{Role: llm.RoleUser, Content: "Context A"},
+		{Role: rolePendingSystem, Content: "Answer in German."},
+		{Role: llm.RoleUser, Content: "Context B"},
+	}
+
+	instructions, prompt := applyPending(pending, "Summarize.")
+	if len(instructions) != 1 || instructions[0] != "Answer in German." {
+		t.Errorf("got instructions %q", instructions)
+	}
+	if want := "Context A\n\nContext B\n\nSummarize."; prompt != want {
+		t.Errorf("got prompt %q, want %q", prompt, want)
+	}
+
+	system := withInstructions("You are a helpful assistant.", instructions)
+	if want := "You are a helpful assistant.\n\nAnswer in German."; system != want {
+		t.Errorf("got system %q, want %q", system, want)
+	}
+
+	// A completion over the pending messages alone
+	_, prompt = applyPending(pending, "")
+	if want := "Context A\n\nContext B"; prompt != want {
+		t.Errorf("got prompt %q, want %q", prompt, want)
+	}
+
+	instructions, prompt = applyPending(nil, "prompt")
+	if withInstructions("system", instructions) != "system" || prompt != "prompt" {
+		t.Errorf("expected no changes, got %q and %q", instructions, prompt)
+	}
+}
+
+func TestPendingIds(t *testing.T) {
+	pending := []*llm.Message{
+		{Id: "a", Role: llm.RoleUser, Content: "Context A"},
+		{Role: llm.RoleUser, Content: "appended by an older version"},
+		{Id: "b", Role: rolePendingSystem, Content: "Answer in German."},
+	}
+
+	ids := pendingIds(pending)
+	if len(ids) != 2 || ids[0] != "a" || ids[1] != "b" {
+		t.Fatalf("expected the ids a and b, got %v", ids)
+	}
+
+	// Consumed messages without ids are removed as well, so the list isn't nil
+	if ids := pendingIds(pending[1:2]); ids == nil || len(ids) != 0 {
+		t.Fatalf("expected an empty list, got %#v", ids)
+	}
+}
@@ -0,0 +1,73 @@
+package chat
+
+import (
+	"fmt"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"strings"
+)
+
+// citationInstruction tells the model how to cite sources. The model always cites
+// with \cite{id}, other styles are applied to the completion afterward, because
+// numbered markers depend on the order of the citations.
+const citationInstruction = `Use \cite{id} when referencing sources.`
+
+// citationPlaceholder is replaced by the citation instruction in custom system prompts.
+const citationPlaceholder = "{{citations}}"
+
+// citationMarker returns the marker of a cited id in the style. Numbers start at 1.
+func citationMarker(style pb.CitationStyle, id string, number int) string {
+	switch style {
+	case pb.CitationStyle_CITATION_STYLE_NUMERIC:
+		return fmt.Sprintf("[%d]", number)
+	case pb.CitationStyle_CITATION_STYLE_WIKI:
+		return fmt.Sprintf("[[doc:%s]]", id)
+	case pb.CitationStyle_CITATION_STYLE_FOOTNOTE:
+		return fmt.Sprintf("[^%d]", number)
+	default:
+		return fmt.Sprintf("\\cite{%s}", id)
+	}
+}
+
+// formatCitations replaces the citations of the completion with the markers of the
+// style and names the marker of each cited fragment in the sources.
+func formatCitations(message *pb.Message, style pb.CitationStyle) {
+	markers := make(map[string]string)
+
+	completion := citePattern.ReplaceAllStringFunc(message.Completion, func(cite string) string {
+		var refs strings.Builder
+
+		ids := citePattern.FindStringSubmatch(cite)[1]
+		for _, id := range strings.Split(ids, ",") {
+			id = strings.TrimSpace(id)
+			if id == "" {
+				continue
+			}
+
+			marker, ok := markers[id]
+			if !ok {
+				marker = citationMarker(style, id, len(markers)+1)
+				markers[id] = marker
+			}
+
+			refs.WriteString(marker)
+		}
+
+		return refs.String()
+	})
+
+	// LaTeX citations of several ids stay as written by the model
+	if style != pb.CitationStyle_CITATION_STYLE_LATEX {
+		message.Completion = completion
+	}
+
+	for _, source := range message.Sources {
+		for _, fragment := range source.Fragments {
+			fragment.Citation = markers[fragment.Id]
+		}
+	}
+}
+
+// citationStyle parses the stored citation style of a thread.
+func citationStyle(name string) pb.CitationStyle {
+	return pb.CitationStyle(pb.CitationStyle_value[name])
+}
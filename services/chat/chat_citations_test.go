@@ -0,0 +1,56 @@
+package chat
+
+import (
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"testing"
+)
+
+func TestFormatCitations(t *testing.T) {
+	completion := `RAG retrieves sources \cite{a}. It embeds them \cite{b, a}.`
+
+	tests := []struct {
+		style pb.CitationStyle
+		want  string
+		marks map[string]string
+	}{
+		{pb.CitationStyle_CITATION_STYLE_LATEX, completion, map[string]string{"a": `\cite{a}`, "b": `\cite{b}`}},
+		{pb.CitationStyle_CITATION_STYLE_NUMERIC, "RAG retrieves sources [1]. It embeds them [2][1].", map[string]string{"a": "[1]", "b": "[2]"}},
+		{pb.CitationStyle_CITATION_STYLE_WIKI, "RAG retrieves sources [[doc:a]]. It embeds them [[doc:b]][[doc:a]].", map[string]string{"a": "[[doc:a]]", "b": "[[doc:b]]"}},
+		{pb.CitationStyle_CITATION_STYLE_FOOTNOTE, "RAG retrieves sources [^1]. It embeds them [^2][^1].", map[string]string{"a": "[^1]", "b": "[^2]"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.style.String(), func(t *testing.T) {
+			message := &pb.Message{
+				Completion: completion,
+				Sources: []*pb.Source{{
+					DocumentId: "doc",
+					Fragments:  []*pb.Source_Fragment{{Id: "a"}, {Id: "b"}, {Id: "c"}},
+				}},
+			}
+
+			formatCitations(message, tt.style)
+
+			if message.Completion != tt.want {
+				t.Errorf("completion = %q, want %q", message.Completion, tt.want)
+			}
+
+			for _, fragment := range message.Sources[0].Fragments {
+				if fragment.Citation != tt.marks[fragment.Id] {
+					t.Errorf("citation of %s = %q, want %q", fragment.Id, fragment.Citation, tt.marks[fragment.Id])
+				}
+			}
+		})
+	}
+}
+
+func TestSystemPromptCitations(t *testing.T) {
+	prompt, err := systemPrompt("Answer briefly. {{citations}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if prompt != "Answer briefly. "+citationInstruction {
+		t.Errorf("prompt = %q", prompt)
+	}
+}
@@ -0,0 +1,92 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
+	"github.com/pzierahn/chatbot_services/search"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+)
+
+// checkCollection ensures that the collection is owned by or shared with the user,
+// is not archived and can be searched with the embedding model of the search index.
+// The user id of the returned collection is the owner of the documents.
+func (service *Service) checkCollection(ctx context.Context, userId string, collectionId uuid.UUID) (*datastore.Collection, error) {
+	access, err := service.Database.GetCollectionAccess(ctx, userId, collectionId)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, rpcerror.NotFound("collection", collectionId.String())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if access.Collection.Archived {
+		return nil, rpcerror.New(codes.FailedPrecondition, rpcerror.ReasonArchived, "collection_id",
+			fmt.Sprintf("collection is archived: %s", collectionId))
+	}
+
+	err = search.CheckEmbeddingModel(service.Search, access.Collection.EmbeddingModel)
+	if err != nil {
+		return nil, rpcerror.New(codes.FailedPrecondition, rpcerror.ReasonModelMismatch, "collection_id", err.Error())
+	}
+
+	return access.Collection, nil
+}
+
+// promptCollection returns the id of the collection of a prompt. Prompts without
+// collection use the collection of their thread, or the default collection of the
+// user if they start a new thread.
+func (service *Service) promptCollection(ctx context.Context, userId string, prompt *pb.Prompt) (uuid.UUID, error) {
+	if prompt.CollectionId != "" {
+		collectionId, err := uuid.Parse(prompt.CollectionId)
+		if err != nil {
+			return uuid.Nil, rpcerror.InvalidId("collection_id", prompt.CollectionId)
+		}
+
+		return collectionId, nil
+	}
+
+	if prompt.ThreadId != "" {
+		threadId, err := uuid.Parse(prompt.ThreadId)
+		if err != nil {
+			return uuid.Nil, rpcerror.InvalidId("thread_id", prompt.ThreadId)
+		}
+
+		collectionId, err := service.Database.GetThreadCollection(ctx, userId, threadId)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return uuid.Nil, rpcerror.NotFound("thread", prompt.ThreadId)
+		}
+
+		return collectionId, err
+	}
+
+	collection, err := service.Database.GetDefaultCollection(ctx, userId)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return uuid.Nil, rpcerror.New(codes.InvalidArgument, rpcerror.ReasonMissingField, "collection_id",
+			"collection_id is required without a default collection")
+	}
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return collection.Id, nil
+}
+
+// collectionOwner returns the owner of a collection the user has access to. Users
+// whose access was revoked get a PermissionDenied error.
+func (service *Service) collectionOwner(ctx context.Context, userId string, collectionId uuid.UUID) (string, error) {
+	access, err := service.Database.GetCollectionAccess(ctx, userId, collectionId)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return "", rpcerror.AccessDenied(collectionId.String())
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return access.Collection.UserId, nil
+}
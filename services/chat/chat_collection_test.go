@@ -0,0 +1,154 @@
+package chat
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestCollectionOwner(t *testing.T) {
+	uri := os.Getenv("CHATBOT_MONGODB_URI")
+	if uri == "" {
+		t.Skip("CHATBOT_MONGODB_URI not set")
+	}
+
+	ctx := context.Background()
+
+	db, err := datastore.NewFrom(ctx, uri, datastore.PoolConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	owner := "test-" + uuid.NewString()
+	grantee := "test-" + uuid.NewString()
+	stranger := "test-" + uuid.NewString()
+
+	collection := &datastore.Collection{
+		Id:     uuid.New(),
+		UserId: owner,
+		Name:   "shared",
+	}
+
+	err = db.InsertCollection(ctx, collection)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = db.DeleteCollection(ctx, owner, collection.Id) }()
+
+	err = db.StoreGrant(ctx, &datastore.AccessGrant{
+		Id:           uuid.New(),
+		CollectionId: collection.Id,
+		OwnerId:      owner,
+		GranteeId:    grantee,
+		Role:         datastore.RoleRead,
+		CreatedAt:    time.Now(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	service := &Service{Database: db}
+
+	for _, userId := range []string{owner, grantee} {
+		ownerId, err := service.collectionOwner(ctx, userId, collection.Id)
+		if err != nil || ownerId != owner {
+			t.Errorf("collectionOwner for %s: expected %s, got %q, %v", userId, owner, ownerId, err)
+		}
+	}
+
+	_, err = service.collectionOwner(ctx, stranger, collection.Id)
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected PermissionDenied for strangers, got %v", err)
+	}
+
+	// Revoked grants don't fall back to the documents of the grantee
+	err = db.DeleteGrant(ctx, owner, collection.Id, grantee)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = service.collectionOwner(ctx, grantee, collection.Id)
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected PermissionDenied after the grant was revoked, got %v", err)
+	}
+}
+
+func TestPromptCollection(t *testing.T) {
+	uri := os.Getenv("CHATBOT_MONGODB_URI")
+	if uri == "" {
+		t.Skip("CHATBOT_MONGODB_URI not set")
+	}
+
+	ctx := context.Background()
+
+	db, err := datastore.NewFrom(ctx, uri, datastore.PoolConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	userId := "test-" + uuid.NewString()
+	service := &Service{Database: db}
+
+	var collections []*datastore.Collection
+	for _, name := range []string{"default", "thread"} {
+		collection := &datastore.Collection{
+			Id:     uuid.New(),
+			UserId: userId,
+			Name:   name,
+		}
+
+		err = db.InsertCollection(ctx, collection)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() { _ = db.DeleteCollection(ctx, userId, collection.Id) }()
+
+		collections = append(collections, collection)
+	}
+
+	err = db.SetDefaultCollection(ctx, userId, collections[0].Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	thread := &datastore.Thread{
+		Id:           uuid.New(),
+		UserId:       userId,
+		CollectionId: collections[1].Id,
+		Timestamp:    time.Now(),
+	}
+
+	err = db.StoreThread(ctx, thread)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	collectionId, err := service.promptCollection(ctx, userId, &pb.Prompt{ThreadId: thread.Id.String()})
+	if err != nil || collectionId != thread.CollectionId {
+		t.Fatalf("expected the collection of the thread, got %s, %v", collectionId, err)
+	}
+
+	collectionId, err = service.promptCollection(ctx, userId, &pb.Prompt{})
+	if err != nil || collectionId != collections[0].Id {
+		t.Fatalf("expected the default collection, got %s, %v", collectionId, err)
+	}
+
+	// An archived default collection is not used
+	err = db.SetCollectionArchived(ctx, userId, collections[0].Id, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = service.promptCollection(ctx, userId, &pb.Prompt{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for an archived default collection, got %v", err)
+	}
+}
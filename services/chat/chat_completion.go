@@ -2,16 +2,25 @@ package chat
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
 	"github.com/pzierahn/chatbot_services/datastore"
 	"github.com/pzierahn/chatbot_services/llm"
 	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
 	"log"
 	"strings"
 	"time"
 )
 
+const (
+	systemPromptCompletion = "Be concise and short. Do not repeat parts of the prompt. Don't write any prefaces or introductions."
+)
+
 // getDocumentText returns the text of a document as a single string.
 func getDocumentText(document *datastore.Document) string {
 	var parts []string
@@ -23,23 +32,130 @@ func getDocumentText(document *datastore.Document) string {
 	return strings.Join(parts, "\f")
 }
 
-// Completion retrieves a document from the database and sends it to the language model for completion with the given prompt.
+// responseFormat converts the response format of a completion request.
+func responseFormat(format *pb.ResponseFormat) (*llm.ResponseFormat, error) {
+	if format == nil {
+		return nil, nil
+	}
+
+	converted := &llm.ResponseFormat{
+		Type: format.Type,
+		Name: format.Name,
+	}
+
+	if format.Schema != "" {
+		err := json.Unmarshal([]byte(format.Schema), &converted.Schema)
+		if err != nil {
+			return nil, rpcerror.Invalid("response_format.schema", err)
+		}
+	}
+
+	err := converted.Validate()
+	if err != nil {
+		return nil, rpcerror.Invalid("response_format", err)
+	}
+
+	return converted, nil
+}
+
+// completionMessages converts the messages of a completion request. The messages must
+// alternate between user and assistant and end with a user message.
+func completionMessages(req *pb.CompletionRequest) ([]*llm.Message, error) {
+	var messages []*llm.Message
+	for idx, message := range req.Messages {
+		if message.Role != llm.RoleUser && message.Role != llm.RoleAssistant {
+			return nil, rpcerror.New(codes.InvalidArgument, rpcerror.ReasonInvalidValue, "messages",
+				fmt.Sprintf("invalid role of message %d: %q", idx, message.Role))
+		}
+
+		expected := llm.RoleUser
+		if idx%2 == 1 {
+			expected = llm.RoleAssistant
+		}
+
+		if message.Role != expected {
+			return nil, rpcerror.New(codes.InvalidArgument, rpcerror.ReasonInvalidValue, "messages",
+				fmt.Sprintf("message %d must have the role %s", idx, expected))
+		}
+
+		messages = append(messages, &llm.Message{
+			Role:    message.Role,
+			Content: message.Content,
+		})
+	}
+
+	if req.Prompt != "" {
+		if len(messages) > 0 && messages[len(messages)-1].Role == llm.RoleUser {
+			return nil, rpcerror.New(codes.InvalidArgument, rpcerror.ReasonInvalidValue, "prompt",
+				"prompt must follow an assistant message")
+		}
+
+		messages = append(messages, &llm.Message{
+			Role:    llm.RoleUser,
+			Content: req.Prompt,
+		})
+	}
+
+	if len(messages) == 0 {
+		return nil, rpcerror.Missing("prompt")
+	}
+
+	if messages[len(messages)-1].Role != llm.RoleUser {
+		return nil, rpcerror.New(codes.InvalidArgument, rpcerror.ReasonInvalidValue, "messages",
+			fmt.Sprintf("last message must have the role %s", llm.RoleUser))
+	}
+
+	return messages, nil
+}
+
+// moderationText returns the text of the messages of a completion request, which
+// are all written by the client.
+func moderationText(messages []*llm.Message) string {
+	texts := make([]string, len(messages))
+	for idx, message := range messages {
+		texts[idx] = message.Content
+	}
+
+	return strings.Join(texts, "\n\n")
+}
+
+// Completion sends the prompt to the language model without thread or retrieval. If a
+// document is given, its text is prepended to the first message.
 func (service *Service) Completion(ctx context.Context, prompt *pb.CompletionRequest) (*pb.CompletionResponse, error) {
 	userId, err := service.Auth.VerifyFunding(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	release, err := service.Limiter.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	if prompt.ModelOptions == nil {
-		return nil, fmt.Errorf("options missing")
+		return nil, rpcerror.Missing("model_options")
+	}
+
+	messages, err := completionMessages(prompt)
+	if err != nil {
+		return nil, err
 	}
 
-	docId, err := uuid.Parse(prompt.DocumentId)
+	format, err := responseFormat(prompt.ResponseFormat)
 	if err != nil {
 		return nil, err
 	}
 
-	document, err := service.Database.GetDocument(ctx, userId, docId)
+	system := systemPromptCompletion
+	if prompt.SystemPrompt != "" {
+		system, err = systemPrompt(prompt.SystemPrompt)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = service.ModelAccess.checkModel(userId, prompt.ModelOptions.ModelId)
 	if err != nil {
 		return nil, err
 	}
@@ -49,35 +165,81 @@ func (service *Service) Completion(ctx context.Context, prompt *pb.CompletionReq
 		return nil, err
 	}
 
-	messages := []*llm.Message{{
-		Role:    llm.RoleUser,
-		Content: getDocumentText(document) + "\n\n\n" + prompt.Prompt,
-	}}
+	temperature, topP, err := llm.ValidateSampling(prompt.ModelOptions.ModelId, prompt.ModelOptions.Temperature, prompt.ModelOptions.TopP)
+	if err != nil {
+		return nil, rpcerror.Invalid("model_options", err)
+	}
+
+	err = llm.ValidateReasoningBudget(prompt.ModelOptions.ReasoningBudget)
+	if err != nil {
+		return nil, rpcerror.Invalid("model_options.reasoning_budget", err)
+	}
+
+	// The messages are screened before the text of the document is added
+	err = service.moderate(ctx, moderationPrompt, moderationText(messages))
+	if err != nil {
+		return nil, completionError(err)
+	}
+
+	if prompt.DocumentId != "" {
+		docId, err := uuid.Parse(prompt.DocumentId)
+		if err != nil {
+			return nil, rpcerror.InvalidId("document_id", prompt.DocumentId)
+		}
+
+		document, err := service.Database.GetDocument(ctx, userId, docId)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, rpcerror.NotFound("document", prompt.DocumentId)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		messages[0].Content = getDocumentText(document) + "\n\n\n" + messages[0].Content
+	}
+
+	response, err := llm.StructuredCompletion(ctx, model, &llm.CompletionRequest{
+		SystemPrompt:   system,
+		Messages:       messages,
+		Model:          prompt.ModelOptions.ModelId,
+		MaxTokens:      int(prompt.ModelOptions.MaxTokens),
+		Temperature:    temperature,
+		TopP:           topP,
+		UserId:         userId,
+		ResponseFormat: format,
 
-	response, err := model.Completion(ctx, &llm.CompletionRequest{
-		SystemPrompt: "Be concise and short. Do not repeat parts of the prompt. Don't write any prefaces or introductions.",
-		Messages:     messages,
-		Model:        prompt.ModelOptions.ModelId,
-		MaxTokens:    int(prompt.ModelOptions.MaxTokens),
-		Temperature:  prompt.ModelOptions.Temperature,
-		TopP:         prompt.ModelOptions.TopP,
-		UserId:       userId,
+		ReasoningBudget: int(prompt.ModelOptions.ReasoningBudget),
 	})
+
+	// Failed attempts are paid for as well
+	if response != nil {
+		service.Database.RecordModelUsage(ctx, &datastore.ModelUsage{
+			Id:           uuid.New(),
+			UserId:       userId,
+			Timestamp:    time.Now(),
+			ModelId:      response.Usage.Model,
+			InputTokens:  response.Usage.InputTokens,
+			OutputTokens: response.Usage.OutputTokens,
+		})
+	}
+
 	if err != nil {
 		log.Printf("error: %v", err)
-		return nil, err
+		return nil, completionError(err)
 	}
 
-	_ = service.Database.InsertModelUsage(ctx, &datastore.ModelUsage{
-		Id:           uuid.New(),
-		UserId:       userId,
-		Timestamp:    time.Now(),
-		ModelId:      response.Usage.Model,
-		InputTokens:  response.Usage.InputTokens,
-		OutputTokens: response.Usage.OutputTokens,
-	})
+	// Flagged completions are not returned, they are paid for nevertheless
+	completion := response.Messages[len(response.Messages)-1].Content
+	err = service.moderate(ctx, moderationCompletion, completion)
+	if err != nil {
+		return nil, completionError(err)
+	}
 
 	return &pb.CompletionResponse{
-		Completion: response.Messages[1].Content,
+		Completion:   completion,
+		Model:        response.Usage.Model,
+		InputTokens:  response.Usage.InputTokens,
+		OutputTokens: response.Usage.OutputTokens,
+		Reasoning:    response.Reasoning,
 	}, nil
 }
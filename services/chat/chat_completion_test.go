@@ -0,0 +1,137 @@
+package chat
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
+	"github.com/pzierahn/chatbot_services/llm"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"os"
+	"strings"
+	"testing"
+)
+
+// errorReason returns the reason of the ErrorInfo of an error.
+func errorReason(err error) string {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info.Reason
+		}
+	}
+
+	return ""
+}
+
+func TestCompletionMessages(t *testing.T) {
+	tests := []struct {
+		name   string
+		req    *pb.CompletionRequest
+		reason string
+	}{
+		{"empty", &pb.CompletionRequest{}, rpcerror.ReasonMissingField},
+		{"unknown role", &pb.CompletionRequest{Messages: []*pb.CompletionMessage{{Role: "system"}}}, rpcerror.ReasonInvalidValue},
+		{"wrong order", &pb.CompletionRequest{Messages: []*pb.CompletionMessage{{Role: llm.RoleAssistant}}}, rpcerror.ReasonInvalidValue},
+		{"prompt after prompt", &pb.CompletionRequest{
+			Messages: []*pb.CompletionMessage{{Role: llm.RoleUser}},
+			Prompt:   "question",
+		}, rpcerror.ReasonInvalidValue},
+	}
+
+	for _, tt := range tests {
+		_, err := completionMessages(tt.req)
+		if status.Code(err) != codes.InvalidArgument || errorReason(err) != tt.reason {
+			t.Errorf("%s: expected InvalidArgument with reason %s, got %v", tt.name, tt.reason, err)
+		}
+	}
+
+	messages, err := completionMessages(&pb.CompletionRequest{Prompt: "question"})
+	if err != nil || len(messages) != 1 {
+		t.Fatalf("expected the prompt as message, got %v, %v", messages, err)
+	}
+}
+
+// testModerator flags the texts that contain a word.
+type testModerator struct {
+	word  string
+	texts []string
+}
+
+func (moderator *testModerator) Check(_ context.Context, text string) (bool, []string, error) {
+	moderator.texts = append(moderator.texts, text)
+
+	if strings.Contains(text, moderator.word) {
+		return true, []string{"test"}, nil
+	}
+
+	return false, nil, nil
+}
+
+func TestCompletionModeration(t *testing.T) {
+	model := &testChat{}
+	moderator := &testModerator{word: "forbidden"}
+	service := &Service{
+		Models:    []llm.Chat{model},
+		Auth:      &testVerifier{userId: "user"},
+		Moderator: moderator,
+	}
+
+	_, err := service.Completion(context.Background(), &pb.CompletionRequest{
+		Messages: []*pb.CompletionMessage{
+			{Role: llm.RoleUser, Content: "forbidden question"},
+			{Role: llm.RoleAssistant, Content: "answer"},
+		},
+		Prompt:       "follow-up",
+		ModelOptions: &pb.ModelOptions{ModelId: testModel},
+	})
+	if status.Code(err) != codes.FailedPrecondition || errorReason(err) != rpcerror.ReasonSafetyBlocked {
+		t.Fatalf("expected the flagged messages to be blocked, got %v", err)
+	}
+
+	if model.calls != 0 {
+		t.Fatal("expected flagged messages not to be sent to the model")
+	}
+
+	if len(moderator.texts) != 1 || !strings.Contains(moderator.texts[0], "follow-up") {
+		t.Fatalf("expected the messages and the prompt to be checked, got %q", moderator.texts)
+	}
+}
+
+func TestCompletionModerationResponse(t *testing.T) {
+	uri := os.Getenv("CHATBOT_MONGODB_URI")
+	if uri == "" {
+		t.Skip("CHATBOT_MONGODB_URI not set")
+	}
+
+	ctx := context.Background()
+
+	db, err := datastore.NewFrom(ctx, uri, datastore.PoolConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	// testChat answers with "answer"
+	model := &testChat{}
+	service := &Service{
+		Models:    []llm.Chat{model},
+		Auth:      &testVerifier{userId: "test-" + uuid.NewString()},
+		Database:  db,
+		Moderator: &testModerator{word: "answer"},
+	}
+
+	response, err := service.Completion(ctx, &pb.CompletionRequest{
+		Prompt:       "question",
+		ModelOptions: &pb.ModelOptions{ModelId: testModel},
+	})
+	if status.Code(err) != codes.FailedPrecondition || response != nil {
+		t.Fatalf("expected the flagged completion to be withheld, got %v, %v", response, err)
+	}
+
+	if model.calls != 1 {
+		t.Fatalf("expected one completion, got %d", model.calls)
+	}
+}
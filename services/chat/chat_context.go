@@ -0,0 +1,42 @@
+package chat
+
+import (
+	"fmt"
+	"github.com/pzierahn/chatbot_services/llm"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"google.golang.org/grpc/codes"
+	"strconv"
+)
+
+// maxReserveShare limits the reserve for retrieved sources to a share of the
+// context window. Small windows, e.g. the 8k of gpt-4, are smaller than a tool
+// result of the maximum size, so the full reserve would reject every prompt.
+const maxReserveShare = 4
+
+// checkContextFit rejects requests whose estimated tokens exceed the context
+// window of the model, before the provider fails with an unclear error. The
+// estimate adds the reserve for sources retrieved by tool calls and the maximum
+// completion. Models with unknown context windows are not checked.
+func checkContextFit(req *llm.CompletionRequest, reserve int) error {
+	window := llm.ContextWindow(req.Model)
+	if window == 0 {
+		return nil
+	}
+
+	reserve = min(reserve, window/maxReserveShare)
+
+	estimated := llm.EstimateRequestTokens(req) + reserve + req.MaxTokens + req.ReasoningBudget
+	if estimated <= window {
+		return nil
+	}
+
+	overage := estimated - window
+
+	return rpcerror.New(codes.InvalidArgument, rpcerror.ReasonContextTooLong, "prompt",
+		fmt.Sprintf("the prompt needs about %d tokens, %d more than the context window of %d tokens of %s. "+
+			"Shorten the prompt, attach fewer documents or continue in a new thread with a summary of this one",
+			estimated, overage, window, req.Model),
+		"estimated_tokens", strconv.Itoa(estimated),
+		"context_window", strconv.Itoa(window),
+		"overage", strconv.Itoa(overage))
+}
@@ -0,0 +1,54 @@
+package chat
+
+import (
+	"github.com/pzierahn/chatbot_services/llm"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strings"
+	"testing"
+)
+
+func TestCheckContextFit(t *testing.T) {
+	llm.RegisterContextWindows(map[string]int{"context-test": 1000})
+
+	req := &llm.CompletionRequest{
+		Model:     "context-test",
+		MaxTokens: 300,
+		Messages: []*llm.Message{
+			{Role: llm.RoleUser, Content: strings.Repeat("word ", 300)},
+		},
+	}
+
+	// 500 tokens of the prompt and 300 of the completion
+	if err := checkContextFit(req, 200); err != nil {
+		t.Fatalf("expected the request to fit, got %v", err)
+	}
+
+	// The reserve is capped to a quarter of the window
+	err := checkContextFit(req, 1_000_000)
+	if status.Code(err) != codes.InvalidArgument || !strings.Contains(err.Error(), "50 more than") {
+		t.Fatalf("expected InvalidArgument with the overage, got %v", err)
+	}
+
+	req.Model = "unknown-model"
+	if err = checkContextFit(req, 1_000_000); err != nil {
+		t.Fatalf("expected models with unknown windows to pass, got %v", err)
+	}
+}
+
+func TestCheckContextFitSmallWindow(t *testing.T) {
+	llm.RegisterContextWindows(map[string]int{"small-context-test": 8_192})
+
+	req := &llm.CompletionRequest{
+		Model:     "small-context-test",
+		MaxTokens: 1024,
+		Messages: []*llm.Message{
+			{Role: llm.RoleUser, Content: "hi"},
+		},
+	}
+
+	// The reserve for a tool result of the maximum size exceeds the window
+	if err := checkContextFit(req, llm.MaxToolResultTokens()); err != nil {
+		t.Fatalf("expected a short prompt to fit a small window, got %v", err)
+	}
+}
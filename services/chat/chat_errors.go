@@ -0,0 +1,32 @@
+package chat
+
+import (
+	"errors"
+	"github.com/pzierahn/chatbot_services/llm"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"google.golang.org/grpc/codes"
+	"strings"
+)
+
+// completionError maps errors of the language models to gRPC errors. Safety
+// blocks become FailedPrecondition errors with the triggering categories as details.
+// Images sent to a text-only model are rejected as invalid. Output that doesn't match
+// the response format after the retry is an internal error, retrying won't help.
+func completionError(err error) error {
+	if errors.Is(err, llm.ErrImagesNotSupported) {
+		return rpcerror.New(codes.InvalidArgument, rpcerror.ReasonInvalidValue, "images", err.Error())
+	}
+
+	if errors.Is(err, llm.ErrInvalidOutput) {
+		return rpcerror.New(codes.Internal, rpcerror.ReasonInvalidOutput, "response_format", err.Error())
+	}
+
+	var safety *llm.SafetyError
+	if !errors.As(err, &safety) {
+		return err
+	}
+
+	return rpcerror.New(codes.FailedPrecondition, rpcerror.ReasonSafetyBlocked, "", safety.Error(),
+		"reason", safety.Reason,
+		"categories", strings.Join(safety.Categories, ","))
+}
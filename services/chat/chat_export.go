@@ -0,0 +1,145 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/encoding/protojson"
+	"regexp"
+	"strings"
+)
+
+// citePattern matches citations like \cite{id} or \cite{id1, id2}.
+var citePattern = regexp.MustCompile(`\\cite\{([^}]*)}`)
+
+// ExportThread returns a thread serialized to Markdown or JSON.
+func (service *Service) ExportThread(ctx context.Context, req *pb.ExportRequest) (*pb.ThreadExport, error) {
+	userId, err := service.Auth.Verify(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	threadId, err := uuid.Parse(req.ThreadId)
+	if err != nil {
+		return nil, rpcerror.InvalidId("thread_id", req.ThreadId)
+	}
+
+	thread, err := service.Database.GetThread(ctx, userId, threadId)
+	if err != nil {
+		return nil, err
+	}
+
+	messages, err := messagesToProto(thread.Messages)
+	if err != nil {
+		return nil, err
+	}
+
+	ownerId, err := service.collectionOwner(ctx, userId, thread.CollectionId)
+	if err != nil {
+		return nil, err
+	}
+	for _, message := range messages {
+		service.resolveSourceNames(ctx, ownerId, message.Sources)
+	}
+
+	export := &pb.Thread{
+		Id:       req.ThreadId,
+		Messages: messages,
+	}
+
+	switch req.Format {
+	case pb.ExportFormat_EXPORT_FORMAT_MARKDOWN:
+		return &pb.ThreadExport{
+			Filename:    fmt.Sprintf("thread-%s.md", req.ThreadId),
+			ContentType: "text/markdown",
+			Content:     threadToMarkdown(export),
+		}, nil
+	case pb.ExportFormat_EXPORT_FORMAT_JSON:
+		data, err := protojson.MarshalOptions{Multiline: true}.Marshal(export)
+		if err != nil {
+			return nil, err
+		}
+
+		return &pb.ThreadExport{
+			Filename:    fmt.Sprintf("thread-%s.json", req.ThreadId),
+			ContentType: "application/json",
+			Content:     string(data),
+		}, nil
+	default:
+		return nil, rpcerror.New(codes.InvalidArgument, rpcerror.ReasonInvalidValue, "format",
+			fmt.Sprintf("unsupported export format: %v", req.Format))
+	}
+}
+
+// threadToMarkdown renders the messages of a thread as Markdown. Citations are
+// replaced by footnote references that name the cited document.
+func threadToMarkdown(thread *pb.Thread) string {
+	var builder strings.Builder
+
+	// Footnotes are numbered across the whole thread
+	var footnotes []string
+	numbers := make(map[string]int)
+
+	for idx, message := range thread.Messages {
+		if idx > 0 {
+			builder.WriteString("\n---\n\n")
+		}
+
+		builder.WriteString("## Prompt\n\n")
+		builder.WriteString(message.Prompt)
+		builder.WriteString("\n\n## Answer\n\n")
+
+		// Map fragment ids to their document name
+		fragments := make(map[string]string)
+		for _, source := range message.Sources {
+			name := source.Name
+			if name == "" {
+				name = source.DocumentId
+			}
+
+			for _, fragment := range source.Fragments {
+				fragments[fragment.Id] = fmt.Sprintf("%s, fragment %d", name, fragment.Position)
+			}
+		}
+
+		completion := citePattern.ReplaceAllStringFunc(message.Completion, func(cite string) string {
+			var refs strings.Builder
+
+			ids := citePattern.FindStringSubmatch(cite)[1]
+			for _, id := range strings.Split(ids, ",") {
+				id = strings.TrimSpace(id)
+
+				number, ok := numbers[id]
+				if !ok {
+					reference, ok := fragments[id]
+					if !ok {
+						reference = id
+					}
+
+					footnotes = append(footnotes, reference)
+					number = len(footnotes)
+					numbers[id] = number
+				}
+
+				refs.WriteString(fmt.Sprintf("[^%d]", number))
+			}
+
+			return refs.String()
+		})
+
+		builder.WriteString(completion)
+		builder.WriteString("\n")
+	}
+
+	if len(footnotes) > 0 {
+		builder.WriteString("\n")
+		for idx, footnote := range footnotes {
+			builder.WriteString(fmt.Sprintf("[^%d]: %s\n", idx+1, footnote))
+		}
+	}
+
+	return builder.String()
+}
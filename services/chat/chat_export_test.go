@@ -0,0 +1,56 @@
+package chat
+
+import (
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"testing"
+)
+
+func TestThreadToMarkdown(t *testing.T) {
+	thread := &pb.Thread{
+		Messages: []*pb.Message{
+			{
+				Prompt:     "What is RAG?",
+				Completion: `RAG retrieves sources \cite{a}. It embeds them \cite{b, a}.`,
+				Sources: []*pb.Source{{
+					DocumentId: "doc-1",
+					Name:       "paper.pdf",
+					Fragments:  []*pb.Source_Fragment{{Id: "a", Position: 1}, {Id: "b", Position: 2}},
+				}},
+			},
+			{
+				Prompt:     "And unnamed documents?",
+				Completion: `See \cite{c} and \cite{a} and \cite{x}.`,
+				Sources: []*pb.Source{{
+					DocumentId: "doc-2",
+					Fragments:  []*pb.Source_Fragment{{Id: "c", Position: 3}},
+				}},
+			},
+		},
+	}
+
+	want := "## Prompt\n\nWhat is RAG?\n\n## Answer\n\n" +
+		"RAG retrieves sources [^1]. It embeds them [^2][^1].\n" +
+		"\n---\n\n" +
+		"## Prompt\n\nAnd unnamed documents?\n\n## Answer\n\n" +
+		"See [^3] and [^1] and [^4].\n" +
+		"\n" +
+		"[^1]: paper.pdf, fragment 1\n" +
+		"[^2]: paper.pdf, fragment 2\n" +
+		"[^3]: doc-2, fragment 3\n" +
+		"[^4]: x\n"
+
+	if got := threadToMarkdown(thread); got != want {
+		t.Fatalf("unexpected markdown:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestThreadToMarkdownWithoutCitations(t *testing.T) {
+	thread := &pb.Thread{
+		Messages: []*pb.Message{{Prompt: "Hi", Completion: "Hello"}},
+	}
+
+	want := "## Prompt\n\nHi\n\n## Answer\n\nHello\n"
+	if got := threadToMarkdown(thread); got != want {
+		t.Fatalf("expected no footnotes, got:\n%s", got)
+	}
+}
@@ -0,0 +1,107 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
+	"github.com/pzierahn/chatbot_services/llm"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"time"
+)
+
+const (
+	// MaxFeedbackCommentLength is the maximum number of characters of a feedback comment.
+	MaxFeedbackCommentLength = 2000
+
+	// MaxFeedbackScore is the best score of a message, the worst is 1.
+	MaxFeedbackScore = 5
+)
+
+var ratings = map[pb.Rating]string{
+	pb.Rating_RATING_UP:   datastore.RatingUp,
+	pb.Rating_RATING_DOWN: datastore.RatingDown,
+}
+
+// SubmitFeedback rates a message of a thread of the user. Submitting feedback for
+// the same message again replaces the earlier feedback.
+func (service *Service) SubmitFeedback(ctx context.Context, req *pb.Feedback) (*emptypb.Empty, error) {
+	userId, err := service.Auth.Verify(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	threadId, err := uuid.Parse(req.ThreadId)
+	if err != nil {
+		return nil, rpcerror.InvalidId("thread_id", req.ThreadId)
+	}
+
+	if req.MessageId == "" {
+		return nil, rpcerror.Missing("message_id")
+	}
+
+	if req.Rating == pb.Rating_RATING_UNSPECIFIED && req.Score == nil {
+		return nil, rpcerror.New(codes.InvalidArgument, rpcerror.ReasonMissingField, "rating",
+			"either rating or score must be set")
+	}
+
+	if _, ok := ratings[req.Rating]; !ok && req.Rating != pb.Rating_RATING_UNSPECIFIED {
+		return nil, rpcerror.New(codes.InvalidArgument, rpcerror.ReasonInvalidValue, "rating",
+			fmt.Sprintf("unknown rating: %d", req.Rating))
+	}
+
+	if req.Score != nil && (*req.Score < 1 || *req.Score > MaxFeedbackScore) {
+		return nil, rpcerror.New(codes.InvalidArgument, rpcerror.ReasonInvalidValue, "score",
+			"score must be between 1 and 5")
+	}
+
+	comment, err := sanitizePrompt("comment", req.Comment, MaxFeedbackCommentLength)
+	if err != nil {
+		return nil, err
+	}
+
+	thread, err := service.Database.GetThread(ctx, userId, threadId)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, rpcerror.NotFound("thread", req.ThreadId)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var modelId string
+	var found bool
+	for _, message := range thread.Messages {
+		// Earlier answers kept by Regenerate can be rated as well
+		for _, candidate := range append([]*llm.Message{message}, message.Alternatives...) {
+			if candidate.Id == req.MessageId {
+				modelId = candidate.Model
+				found = true
+			}
+		}
+	}
+	if !found {
+		return nil, rpcerror.NotFound("message", req.MessageId)
+	}
+
+	err = service.Database.StoreFeedback(ctx, &datastore.Feedback{
+		Id:        uuid.New(),
+		UserId:    userId,
+		ThreadId:  threadId,
+		MessageId: req.MessageId,
+		ModelId:   modelId,
+		Rating:    ratings[req.Rating],
+		Score:     req.GetScore(),
+		Comment:   comment,
+		Timestamp: time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,31 @@
+package chat
+
+import (
+	"context"
+	"github.com/google/uuid"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+func TestSubmitFeedbackValidation(t *testing.T) {
+	service := &Service{Auth: &testVerifier{userId: "test"}}
+	score := uint32(MaxFeedbackScore + 1)
+
+	invalid := map[string]*pb.Feedback{
+		"unknown rating": {Rating: pb.Rating(7)},
+		"no rating":      {},
+		"score too high": {Score: &score},
+	}
+
+	for name, req := range invalid {
+		req.ThreadId = uuid.NewString()
+		req.MessageId = uuid.NewString()
+
+		_, err := service.SubmitFeedback(context.Background(), req)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: expected InvalidArgument, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/pzierahn/chatbot_services/search"
+)
+
+// SourceGroup is the sources of a document, so that the model reads the context
+// of each document as a whole.
+type SourceGroup struct {
+	DocumentId string `json:"document_id,omitempty" bson:"document_id,omitempty"`
+	Name       string `json:"name,omitempty" bson:"name,omitempty"`
+
+	// Score is the highest score of the sources of the document
+	Score float32 `json:"score,omitempty" bson:"score,omitempty"`
+
+	// Sources of the document ordered by position, unless they were stitched
+	Sources []*search.Result `json:"sources,omitempty" bson:"sources,omitempty"`
+
+	// Passages of adjacent sources, which are cited with the id of the passage
+	Passages []*search.Passage `json:"passages,omitempty" bson:"passages,omitempty"`
+}
+
+// groupedSources returns the result of get_sources for grouped search results.
+func (service *Service) groupedSources(ctx context.Context, params retrievalParameters, groups []*search.Group) (string, error) {
+	documentIds := make([]string, len(groups))
+	for idx, group := range groups {
+		documentIds[idx] = group.DocumentId
+	}
+
+	names := sanitizeNames(params.names.lookup(ctx, documentIds...))
+
+	items := make([]*SourceGroup, len(groups))
+	for idx, group := range groups {
+		item := &SourceGroup{
+			DocumentId: group.DocumentId,
+			Name:       names[group.DocumentId],
+			Score:      group.Score,
+		}
+
+		if len(group.Passages) > 0 {
+			for _, passage := range group.Passages {
+				clean := *passage
+				clean.Text = escapeCitations(passage.Text)
+				item.Passages = append(item.Passages, &clean)
+			}
+		} else {
+			item.Sources = sanitizeSources(group.Results)
+		}
+
+		items[idx] = item
+	}
+
+	byt, err := json.Marshal(Sources{Groups: items})
+	if err != nil {
+		return "", err
+	}
+
+	return string(byt), nil
+}
+
+// results returns the sources as search results. Passages become a single result
+// with the id of the passage at the position of its first source.
+func (sources *Sources) results() []*search.Result {
+	items := append([]*search.Result(nil), sources.Items...)
+
+	for _, group := range sources.Groups {
+		items = append(items, group.Sources...)
+
+		for _, passage := range group.Passages {
+			items = append(items, &search.Result{
+				Id:         passage.Id,
+				Text:       passage.Text,
+				DocumentId: group.DocumentId,
+				Position:   passage.Start,
+				Score:      passage.Score,
+			})
+		}
+	}
+
+	return items
+}
+
+// shrinkGroups keeps the groups with the highest scores that fit into maxBytes
+// and records how many sources were omitted.
+func shrinkGroups(sources Sources, maxBytes int) (string, error) {
+	// Groups are ordered by score
+	for keep := len(sources.Groups) - 1; keep >= 0; keep-- {
+		omitted := sources.Omitted
+		for _, group := range sources.Groups[keep:] {
+			omitted += len(group.Sources) + len(group.Passages)
+		}
+
+		byt, err := json.Marshal(Sources{
+			Groups:  sources.Groups[:keep],
+			Omitted: omitted,
+		})
+		if err != nil {
+			return "", err
+		}
+
+		if len(byt) <= maxBytes {
+			return string(byt), nil
+		}
+	}
+
+	return "", errors.New("sources don't fit into the limit")
+}
@@ -0,0 +1,107 @@
+package chat
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"log"
+)
+
+// IdempotencyHeader can be used instead of the idempotency_key field of a request.
+const IdempotencyHeader = "idempotency-key"
+
+// idempotencyKey returns the key of the request or the header, if set.
+func idempotencyKey(ctx context.Context, key string) string {
+	if key != "" {
+		return key
+	}
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(IdempotencyHeader); len(values) > 0 {
+			return values[0]
+		}
+	}
+
+	return ""
+}
+
+// requestHash identifies the type and content of a request without its
+// idempotency key.
+func requestHash(req proto.Message) (string, error) {
+	clone := proto.Clone(req).ProtoReflect()
+	if field := clone.Descriptor().Fields().ByName("idempotency_key"); field != nil {
+		clone.Clear(field)
+	}
+
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(clone.Interface())
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(clone.Descriptor().FullName()))
+	hash.Write(data)
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// once runs the request only once per user and key. Repeated requests return
+// the stored response, so that retries are not charged twice. A key can't be
+// reused for another request.
+func (service *Service) once(ctx context.Context, userId, key string, req proto.Message, run func() (*pb.Message, error)) (*pb.Message, error) {
+	hash, err := requestHash(req)
+	if err != nil {
+		return nil, err
+	}
+
+	record, reserved, err := service.Database.ReserveIdempotencyKey(ctx, userId, key, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if !reserved {
+		if record.RequestHash != hash {
+			return nil, rpcerror.New(codes.InvalidArgument, rpcerror.ReasonIdempotencyMismatch, "idempotency_key",
+				"idempotency key was already used for another request")
+		}
+
+		if len(record.Response) == 0 {
+			return nil, status.Error(codes.Aborted, "a request with this idempotency key is in progress")
+		}
+
+		var message pb.Message
+		err = proto.Unmarshal(record.Response, &message)
+		if err != nil {
+			return nil, err
+		}
+
+		return &message, nil
+	}
+
+	message, err := run()
+	if err != nil {
+		// Allow the client to retry the failed request
+		if releaseErr := service.Database.ReleaseIdempotencyKey(context.WithoutCancel(ctx), record); releaseErr != nil {
+			log.Printf("failed to release idempotency key: %v", releaseErr)
+		}
+
+		return nil, err
+	}
+
+	response, err := proto.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+
+	err = service.Database.CompleteIdempotencyKey(context.WithoutCancel(ctx), record, response)
+	if err != nil {
+		log.Printf("failed to store idempotent response: %v", err)
+	}
+
+	return message, nil
+}
@@ -0,0 +1,181 @@
+package chat
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
+	"github.com/pzierahn/chatbot_services/llm"
+	"github.com/pzierahn/chatbot_services/search"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"os"
+	"testing"
+)
+
+const testModel = "test-model"
+
+type testVerifier struct {
+	userId string
+}
+
+func (verifier *testVerifier) Verify(context.Context) (string, error) {
+	return verifier.userId, nil
+}
+
+func (verifier *testVerifier) VerifyFunding(context.Context) (string, error) {
+	return verifier.userId, nil
+}
+
+// testChat answers every prompt without calling tools.
+type testChat struct {
+	calls  int
+	system string
+}
+
+func (chat *testChat) Completion(_ context.Context, req *llm.CompletionRequest) (*llm.CompletionResponse, error) {
+	chat.calls++
+	chat.system = req.SystemPrompt
+
+	return &llm.CompletionResponse{
+		Messages: append(req.Messages, &llm.Message{
+			Role:    llm.RoleAssistant,
+			Content: "answer",
+		}),
+		Usage: llm.ModelUsage{
+			Model:        testModel,
+			UserId:       req.UserId,
+			InputTokens:  10,
+			OutputTokens: 5,
+		},
+	}, nil
+}
+
+func (chat *testChat) ProvidesModel(model string) bool {
+	return model == testModel
+}
+
+type testIndex struct {
+	search.Index
+}
+
+func (index *testIndex) EmbeddingModel() string {
+	return ""
+}
+
+func TestPostMessageIdempotency(t *testing.T) {
+	uri := os.Getenv("CHATBOT_MONGODB_URI")
+	if uri == "" {
+		t.Skip("CHATBOT_MONGODB_URI not set")
+	}
+
+	ctx := context.Background()
+
+	db, err := datastore.NewFrom(ctx, uri, datastore.PoolConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	userId := "test-" + uuid.NewString()
+	collection := &datastore.Collection{
+		Id:     uuid.New(),
+		UserId: userId,
+		Name:   "idempotency",
+	}
+
+	err = db.InsertCollection(ctx, collection)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = db.DeleteCollection(ctx, userId, collection.Id) }()
+
+	model := &testChat{}
+	service := &Service{
+		Models:   []llm.Chat{model},
+		Auth:     &testVerifier{userId: userId},
+		Database: db,
+		Search:   &testIndex{},
+	}
+
+	prompt := &pb.Prompt{
+		CollectionId: collection.Id.String(),
+		Prompt:       "question",
+		ModelOptions: &pb.ModelOptions{
+			ModelId: testModel,
+		},
+		RetrievalOptions: &pb.RetrievalOptions{},
+		IdempotencyKey:   uuid.NewString(),
+	}
+
+	first, err := service.PostMessage(ctx, prompt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := service.PostMessage(ctx, prompt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if first.ThreadId != second.ThreadId || first.Completion != second.Completion {
+		t.Fatalf("expected the same message, got %v and %v", first, second)
+	}
+
+	if model.calls != 1 {
+		t.Fatalf("expected 1 model call, got %d", model.calls)
+	}
+
+	usages, err := db.GetModelUsages(ctx, userId)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(usages) != 1 {
+		t.Fatalf("expected 1 model usage, got %d", len(usages))
+	}
+
+	// The key can't be reused for another prompt
+	other := proto.Clone(prompt).(*pb.Prompt)
+	other.Prompt = "another question"
+
+	_, err = service.PostMessage(ctx, other)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for a reused key, got %v", err)
+	}
+}
+
+func TestRequestHash(t *testing.T) {
+	prompt := &pb.Prompt{
+		Prompt:         "question",
+		ModelOptions:   &pb.ModelOptions{ModelId: testModel},
+		IdempotencyKey: "first",
+	}
+
+	hash, err := requestHash(prompt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	retry := proto.Clone(prompt).(*pb.Prompt)
+	retry.IdempotencyKey = "second"
+	if other, _ := requestHash(retry); other != hash {
+		t.Error("expected the key not to change the hash")
+	}
+
+	changed := proto.Clone(prompt).(*pb.Prompt)
+	changed.ModelOptions.ModelId = "other-model"
+	if other, _ := requestHash(changed); other == hash {
+		t.Error("expected another model to change the hash")
+	}
+
+	if prompt.IdempotencyKey != "first" {
+		t.Error("expected the prompt to be unchanged")
+	}
+
+	// The same key can't be used for a regeneration of the thread
+	if other, _ := requestHash(&pb.RegenerateRequest{ModelOptions: prompt.ModelOptions}); other == hash {
+		t.Error("expected the request type to change the hash")
+	}
+}
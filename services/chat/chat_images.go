@@ -0,0 +1,87 @@
+package chat
+
+import (
+	"fmt"
+	"github.com/pzierahn/chatbot_services/llm"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"google.golang.org/grpc/codes"
+	"net/url"
+)
+
+const (
+	// MaxImages is the maximum number of images attached to a prompt.
+	MaxImages = 4
+
+	// MaxImagesSize is the maximum number of bytes of all images of a prompt. It
+	// stays below the default gRPC message limit of 4 MiB.
+	MaxImagesSize = 3 << 20
+)
+
+// imageMimeTypes are supported by all multimodal providers.
+var imageMimeTypes = map[string]bool{
+	"image/png":  true,
+	"image/jpeg": true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+// promptImages validates the images of the prompt and converts them for the model.
+func promptImages(images []*pb.ImageAttachment) ([]llm.Image, error) {
+	if len(images) > MaxImages {
+		return nil, rpcerror.New(codes.InvalidArgument, rpcerror.ReasonLimitExceeded, "images",
+			fmt.Sprintf("too many images: %d exceeds the limit of %d", len(images), MaxImages))
+	}
+
+	var size int
+	var result []llm.Image
+
+	for _, image := range images {
+		if !imageMimeTypes[image.MimeType] {
+			return nil, rpcerror.New(codes.InvalidArgument, rpcerror.ReasonInvalidValue, "images.mime_type",
+				fmt.Sprintf("unsupported image type: %q", image.MimeType))
+		}
+
+		if len(image.Data) == 0 {
+			link, err := url.Parse(image.Url)
+			if err != nil || (link.Scheme != "https" && link.Scheme != "gs") || link.Host == "" {
+				return nil, rpcerror.New(codes.InvalidArgument, rpcerror.ReasonInvalidValue, "images.url",
+					"image requires data or a https or gs url")
+			}
+		}
+
+		size += len(image.Data)
+		if size > MaxImagesSize {
+			return nil, rpcerror.New(codes.InvalidArgument, rpcerror.ReasonLimitExceeded, "images",
+				fmt.Sprintf("images too large: exceed the limit of %d bytes", MaxImagesSize))
+		}
+
+		result = append(result, llm.Image{
+			MimeType: image.MimeType,
+			Data:     image.Data,
+			URL:      image.Url,
+		})
+	}
+
+	return result, nil
+}
+
+// imagesToProto converts stored images back to attachments of a prompt. Images
+// that were sent as data are not stored and can't be attached again.
+func imagesToProto(images []llm.Image) []*pb.ImageAttachment {
+	var result []*pb.ImageAttachment
+
+	for _, image := range images {
+		if image.Omitted() {
+			continue
+		}
+
+		result = append(result, &pb.ImageAttachment{
+			MimeType: image.MimeType,
+			Data:     image.Data,
+			Url:      image.URL,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,39 @@
+package chat
+
+import (
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"testing"
+)
+
+func TestPromptImages(t *testing.T) {
+	png := &pb.ImageAttachment{MimeType: "image/png", Data: []byte("png")}
+
+	tests := []struct {
+		name    string
+		images  []*pb.ImageAttachment
+		wantErr bool
+	}{
+		{"none", nil, false},
+		{"data", []*pb.ImageAttachment{png}, false},
+		{"url", []*pb.ImageAttachment{{MimeType: "image/jpeg", Url: "https://example.com/a.jpg"}}, false},
+		{"gs url", []*pb.ImageAttachment{{MimeType: "image/jpeg", Url: "gs://bucket/a.jpg"}}, false},
+		{"http url", []*pb.ImageAttachment{{MimeType: "image/jpeg", Url: "http://example.com/a.jpg"}}, true},
+		{"empty", []*pb.ImageAttachment{{MimeType: "image/png"}}, true},
+		{"mime type", []*pb.ImageAttachment{{MimeType: "application/pdf", Data: []byte("pdf")}}, true},
+		{"too many", []*pb.ImageAttachment{png, png, png, png, png}, true},
+		{"too large", []*pb.ImageAttachment{{MimeType: "image/png", Data: make([]byte, MaxImagesSize+1)}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			images, err := promptImages(tt.images)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("promptImages() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err == nil && len(images) != len(tt.images) {
+				t.Errorf("got %d images, want %d", len(images), len(tt.images))
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package chat
+
+import (
+	"context"
+	"expvar"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"os"
+	"strconv"
+	"sync"
+)
+
+const (
+	// LimitPolicyQueue makes excess completions wait for a free slot.
+	LimitPolicyQueue = "queue"
+
+	// LimitPolicyReject rejects excess completions with ResourceExhausted.
+	LimitPolicyReject = "reject"
+)
+
+var (
+	completionsInFlight expvar.Int
+	completionsQueued   expvar.Int
+	completionsRejected expvar.Int
+)
+
+func init() {
+	stats := expvar.NewMap("completions")
+	stats.Set("in_flight", &completionsInFlight)
+	stats.Set("queued", &completionsQueued)
+	stats.Set("rejected", &completionsRejected)
+}
+
+// Limiter bounds the number of simultaneous completions of a service. A nil
+// Limiter doesn't limit the completions.
+type Limiter struct {
+	slots  chan struct{}
+	policy string
+}
+
+// NewLimiter creates a limiter for max simultaneous completions. Excess completions
+// are queued or rejected depending on the policy.
+func NewLimiter(max int, policy string) *Limiter {
+	return &Limiter{
+		slots:  make(chan struct{}, max),
+		policy: policy,
+	}
+}
+
+// LimiterFromEnv creates a limiter from CHATBOT_MAX_CONCURRENT_COMPLETIONS and
+// CHATBOT_COMPLETION_LIMIT_POLICY ("queue" or "reject", defaults to "queue").
+// It returns nil if the maximum is not set.
+func LimiterFromEnv() *Limiter {
+	max, err := strconv.Atoi(os.Getenv("CHATBOT_MAX_CONCURRENT_COMPLETIONS"))
+	if err != nil || max <= 0 {
+		return nil
+	}
+
+	policy := os.Getenv("CHATBOT_COMPLETION_LIMIT_POLICY")
+	if policy != LimitPolicyReject {
+		policy = LimitPolicyQueue
+	}
+
+	return NewLimiter(max, policy)
+}
+
+// Acquire reserves a slot for a completion. Queued requests give up when the
+// context is done. The returned function releases the slot.
+func (limiter *Limiter) Acquire(ctx context.Context) (func(), error) {
+	if limiter == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case limiter.slots <- struct{}{}:
+	default:
+		if limiter.policy == LimitPolicyReject {
+			completionsRejected.Add(1)
+			return nil, rpcerror.New(codes.ResourceExhausted, rpcerror.ReasonTooManyRequests, "",
+				"too many concurrent completions, try again later")
+		}
+
+		completionsQueued.Add(1)
+		select {
+		case limiter.slots <- struct{}{}:
+			completionsQueued.Add(-1)
+		case <-ctx.Done():
+			completionsQueued.Add(-1)
+			completionsRejected.Add(1)
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+	}
+
+	completionsInFlight.Add(1)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			completionsInFlight.Add(-1)
+			<-limiter.slots
+		})
+	}, nil
+}
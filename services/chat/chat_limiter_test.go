@@ -0,0 +1,76 @@
+package chat
+
+import (
+	"context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+	"time"
+)
+
+func TestLimiterReject(t *testing.T) {
+	limiter := NewLimiter(1, LimitPolicyReject)
+
+	release, err := limiter.Acquire(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = limiter.Acquire(context.Background())
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected ResourceExhausted, got %v", err)
+	}
+
+	release()
+	release()
+
+	release, err = limiter.Acquire(context.Background())
+	if err != nil {
+		t.Fatalf("expected a free slot after release, got %v", err)
+	}
+	release()
+}
+
+func TestLimiterQueue(t *testing.T) {
+	limiter := NewLimiter(1, LimitPolicyQueue)
+
+	release, err := limiter.Acquire(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = limiter.Acquire(ctx)
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Fatalf("expected DeadlineExceeded, got %v", err)
+	}
+
+	acquired := make(chan struct{})
+	go func() {
+		next, err := limiter.Acquire(context.Background())
+		if err == nil {
+			next()
+		}
+		close(acquired)
+	}()
+
+	release()
+
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("queued request was not served after release")
+	}
+}
+
+func TestLimiterNil(t *testing.T) {
+	var limiter *Limiter
+
+	release, err := limiter.Acquire(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+}
@@ -0,0 +1,122 @@
+package chat
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/pzierahn/chatbot_services/datastore"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"google.golang.org/grpc/codes"
+	"log"
+	"os"
+	"strings"
+)
+
+// ModelAccess restricts the models users can select. Entries are model ids or
+// prefixes ending with "*", like "anthropic.*". A nil ModelAccess allows all models.
+type ModelAccess struct {
+	// Default models of users without their own list, empty allows all models
+	Default []string
+
+	// Users maps user ids to their models, replacing the default models
+	Users map[string][]string
+}
+
+// splitModels splits a comma separated list of models.
+func splitModels(list string) []string {
+	var models []string
+	for _, model := range strings.Split(list, ",") {
+		if model = strings.TrimSpace(model); model != "" {
+			models = append(models, model)
+		}
+	}
+
+	return models
+}
+
+// ModelAccessFromEnv reads the default models from CHATBOT_ALLOWED_MODELS (comma
+// separated) and the models of single users from CHATBOT_USER_ALLOWED_MODELS (a
+// JSON object of user ids to model lists). It returns nil if neither is set.
+func ModelAccessFromEnv() *ModelAccess {
+	access := &ModelAccess{
+		Default: splitModels(os.Getenv("CHATBOT_ALLOWED_MODELS")),
+	}
+
+	if users := os.Getenv("CHATBOT_USER_ALLOWED_MODELS"); users != "" {
+		err := json.Unmarshal([]byte(users), &access.Users)
+		if err != nil {
+			log.Fatalf("invalid CHATBOT_USER_ALLOWED_MODELS: %v", err)
+		}
+	}
+
+	if len(access.Default) == 0 && len(access.Users) == 0 {
+		return nil
+	}
+
+	return access
+}
+
+// Models returns the models of the user, nil if all models are allowed. Users with
+// their own list only get its models, a nil list like a JSON null allows none.
+func (access *ModelAccess) Models(userId string) []string {
+	if access == nil {
+		return nil
+	}
+
+	if models, ok := access.Users[userId]; ok {
+		if models == nil {
+			return []string{}
+		}
+		return models
+	}
+
+	return access.Default
+}
+
+// allowed returns true if the user may use the model.
+func (access *ModelAccess) allowed(userId, modelId string) bool {
+	models := access.Models(userId)
+	if models == nil {
+		return true
+	}
+
+	for _, model := range models {
+		if prefix, ok := strings.CutSuffix(model, "*"); ok {
+			if strings.HasPrefix(modelId, prefix) {
+				return true
+			}
+		} else if model == modelId {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkModel returns PermissionDenied if the user may not use the model. The
+// error lists the models of the user.
+func (access *ModelAccess) checkModel(userId, modelId string) error {
+	if access.allowed(userId, modelId) {
+		return nil
+	}
+
+	models := strings.Join(access.Models(userId), ", ")
+
+	return rpcerror.New(codes.PermissionDenied, rpcerror.ReasonModelNotAllowed, "model_options.model_id",
+		fmt.Sprintf("model %s is not allowed, allowed models: %s", modelId, models),
+		"allowed_models", models)
+}
+
+// modelId returns the model of a prompt. Prompts without a model id fall back to
+// the default model of the collection and then to the default of the service.
+func (service *Service) modelId(options *pb.ModelOptions, collection *datastore.Collection) string {
+	if options.ModelId != "" {
+		return options.ModelId
+	}
+
+	if collection.DefaultModel != "" {
+		return collection.DefaultModel
+	}
+
+	return service.DefaultModel
+}
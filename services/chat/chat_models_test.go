@@ -0,0 +1,99 @@
+package chat
+
+import (
+	"github.com/pzierahn/chatbot_services/datastore"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strings"
+	"testing"
+)
+
+func TestModelAccess(t *testing.T) {
+	access := &ModelAccess{
+		Default: []string{"openai.gpt-4o-mini", "google.*"},
+		Users: map[string][]string{
+			"premium": {"anthropic.*", "openai.gpt-4o"},
+			"blocked": {},
+			"nulled":  nil,
+		},
+	}
+
+	tests := []struct {
+		user    string
+		model   string
+		allowed bool
+	}{
+		{"user", "openai.gpt-4o-mini", true},
+		{"user", "google.gemini-1.5-flash", true},
+		{"user", "openai.gpt-4o", false},
+		{"user", "anthropic.claude-3-5-sonnet", false},
+		{"premium", "anthropic.claude-3-5-sonnet", true},
+		{"premium", "openai.gpt-4o", true},
+		{"premium", "openai.gpt-4o-mini", false},
+		{"blocked", "openai.gpt-4o-mini", false},
+		{"nulled", "openai.gpt-4o-mini", false},
+	}
+
+	for _, tt := range tests {
+		err := access.checkModel(tt.user, tt.model)
+		if tt.allowed && err != nil {
+			t.Errorf("%s: expected %s to be allowed, got %v", tt.user, tt.model, err)
+		}
+		if !tt.allowed && status.Code(err) != codes.PermissionDenied {
+			t.Errorf("%s: expected %s to be denied, got %v", tt.user, tt.model, err)
+		}
+	}
+
+	err := access.checkModel("user", "openai.gpt-4o")
+	if !strings.Contains(err.Error(), "openai.gpt-4o-mini, google.*") {
+		t.Errorf("expected the error to list the allowed models, got %v", err)
+	}
+}
+
+func TestModelAccessFromEnvNull(t *testing.T) {
+	t.Setenv("CHATBOT_ALLOWED_MODELS", "")
+	t.Setenv("CHATBOT_USER_ALLOWED_MODELS", `{"blocked": null}`)
+
+	access := ModelAccessFromEnv()
+	if err := access.checkModel("blocked", "openai.gpt-4o-mini"); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected a null list to allow no models, got %v", err)
+	}
+
+	if err := access.checkModel("user", "openai.gpt-4o-mini"); err != nil {
+		t.Errorf("expected users without a list to keep all models, got %v", err)
+	}
+}
+
+func TestModelAccessNil(t *testing.T) {
+	var access *ModelAccess
+	if err := access.checkModel("user", "anthropic.claude-3-5-sonnet"); err != nil {
+		t.Errorf("expected all models to be allowed, got %v", err)
+	}
+}
+
+func TestModelIdFallback(t *testing.T) {
+	service := &Service{DefaultModel: "openai.gpt-4o-mini"}
+	collection := &datastore.Collection{DefaultModel: "anthropic.claude-3-haiku"}
+
+	tests := []struct {
+		options    *pb.ModelOptions
+		collection *datastore.Collection
+		expected   string
+	}{
+		{&pb.ModelOptions{ModelId: "openai.gpt-4o"}, collection, "openai.gpt-4o"},
+		{&pb.ModelOptions{}, collection, "anthropic.claude-3-haiku"},
+		{&pb.ModelOptions{}, &datastore.Collection{}, "openai.gpt-4o-mini"},
+	}
+
+	for _, tt := range tests {
+		if model := service.modelId(tt.options, tt.collection); model != tt.expected {
+			t.Errorf("expected %s, got %s", tt.expected, model)
+		}
+	}
+
+	// Without defaults the prompt must name the model
+	if model := (&Service{}).modelId(&pb.ModelOptions{}, &datastore.Collection{}); model != "" {
+		t.Errorf("expected no model, got %s", model)
+	}
+}
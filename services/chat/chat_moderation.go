@@ -0,0 +1,34 @@
+package chat
+
+import (
+	"context"
+	"github.com/pzierahn/chatbot_services/llm"
+)
+
+const (
+	moderationPrompt     = "prompt moderation"
+	moderationCompletion = "completion moderation"
+)
+
+// moderate checks a text with the moderator of the service. Flagged texts return a
+// llm.SafetyError. Texts are rejected if the moderator fails, so that no unchecked
+// content is stored or returned.
+func (service *Service) moderate(ctx context.Context, reason, text string) error {
+	if service.Moderator == nil || text == "" {
+		return nil
+	}
+
+	flagged, categories, err := service.Moderator.Check(ctx, text)
+	if err != nil {
+		return err
+	}
+
+	if flagged {
+		return &llm.SafetyError{
+			Reason:     reason,
+			Categories: categories,
+		}
+	}
+
+	return nil
+}
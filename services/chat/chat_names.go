@@ -0,0 +1,90 @@
+package chat
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"log"
+	"sync"
+)
+
+// documentNames caches the document names of a user for the duration of a request.
+type documentNames struct {
+	// meta returns the stored documents of the ids
+	meta   func(ctx context.Context, userId string, ids ...uuid.UUID) ([]datastore.Document, error)
+	userId string
+
+	mu    sync.Mutex
+	names map[string]string
+}
+
+func (service *Service) newDocumentNames(userId string) *documentNames {
+	return &documentNames{
+		meta:   service.Database.GetDocumentMeta,
+		userId: userId,
+		names:  make(map[string]string),
+	}
+}
+
+// lookup returns the names of the documents. Unknown ids are fetched with a single
+// query. Documents that don't exist or aren't owned by the user have no name. Ids
+// of a failed query are looked up again by the next call.
+func (names *documentNames) lookup(ctx context.Context, ids ...string) map[string]string {
+	names.mu.Lock()
+	defer names.mu.Unlock()
+
+	var missing []uuid.UUID
+	var missingIds []string
+	for _, id := range ids {
+		if _, ok := names.names[id]; ok {
+			continue
+		}
+
+		docId, err := uuid.Parse(id)
+		if err != nil {
+			continue
+		}
+
+		missing = append(missing, docId)
+		missingIds = append(missingIds, id)
+	}
+
+	if len(missing) > 0 {
+		docs, err := names.meta(ctx, names.userId, missing...)
+		if err != nil {
+			log.Printf("failed to look up document names: %v", err)
+		} else {
+			// Remember unknown documents as well
+			for _, id := range missingIds {
+				names.names[id] = ""
+			}
+
+			for _, doc := range docs {
+				names.names[doc.Id.String()] = doc.Name
+			}
+		}
+	}
+
+	results := make(map[string]string)
+	for _, id := range ids {
+		if name := names.names[id]; name != "" {
+			results[id] = name
+		}
+	}
+
+	return results
+}
+
+// setSourceNames sets the document names of the sources. Sources of deleted documents keep an empty name.
+func (names *documentNames) setSourceNames(ctx context.Context, sources []*pb.Source) {
+	ids := make([]string, len(sources))
+	for idx, source := range sources {
+		ids[idx] = source.DocumentId
+	}
+
+	resolved := names.lookup(ctx, ids...)
+	for _, source := range sources {
+		source.Name = resolved[source.DocumentId]
+	}
+}
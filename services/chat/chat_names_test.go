@@ -0,0 +1,44 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
+	"testing"
+)
+
+func TestDocumentNamesRetry(t *testing.T) {
+	known, unknown := uuid.New(), uuid.New()
+
+	var queries int
+	fail := true
+	names := &documentNames{
+		meta: func(_ context.Context, _ string, ids ...uuid.UUID) ([]datastore.Document, error) {
+			queries++
+			if fail {
+				return nil, errors.New("database unavailable")
+			}
+
+			return []datastore.Document{{Id: known, Name: "Known"}}, nil
+		},
+		names: make(map[string]string),
+	}
+
+	ctx := context.Background()
+	if resolved := names.lookup(ctx, known.String(), unknown.String()); len(resolved) != 0 {
+		t.Fatalf("expected no names of a failed query, got %v", resolved)
+	}
+
+	fail = false
+	resolved := names.lookup(ctx, known.String(), unknown.String())
+	if len(resolved) != 1 || resolved[known.String()] != "Known" {
+		t.Fatalf("expected the failed ids to be looked up again, got %v", resolved)
+	}
+
+	// Unknown documents of a successful query are cached
+	names.lookup(ctx, known.String(), unknown.String())
+	if queries != 2 {
+		t.Fatalf("expected 2 queries, got %d", queries)
+	}
+}
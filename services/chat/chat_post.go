@@ -7,12 +7,18 @@ import (
 	"github.com/google/uuid"
 	"github.com/pzierahn/chatbot_services/datastore"
 	"github.com/pzierahn/chatbot_services/llm"
+	"github.com/pzierahn/chatbot_services/search"
+	"github.com/pzierahn/chatbot_services/services/docfilter"
 	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"log"
 	"time"
 )
 
 const (
-	systemPromptLatex = `You are a helpful assistant. Provide accurate, concise answers in Markdown. Use \cite{id} when referencing sources.`
+	systemPromptLatex = `You are a helpful assistant. Provide accurate, concise answers in Markdown. ` + citationInstruction
 )
 
 // PostMessage is a gRPC endpoint that receives a prompt and returns a completion.
@@ -22,35 +28,161 @@ func (service *Service) PostMessage(ctx context.Context, prompt *pb.Prompt) (*pb
 		return nil, err
 	}
 
+	key := idempotencyKey(ctx, prompt.IdempotencyKey)
+	if key == "" {
+		return service.postMessage(ctx, userId, prompt, false)
+	}
+
+	return service.once(ctx, userId, key, prompt, func() (*pb.Message, error) {
+		return service.postMessage(ctx, userId, prompt, false)
+	})
+}
+
+// postMessage generates the completion for a prompt and stores it in the thread.
+// If regenerate is set, the completion replaces the last turn of the thread and
+// keeps its answer as an alternative.
+func (service *Service) postMessage(ctx context.Context, userId string, prompt *pb.Prompt, regenerate bool) (*pb.Message, error) {
+	release, err := service.Limiter.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	//
 	// Integrity check
 	//
 
-	collectionId, err := uuid.Parse(prompt.CollectionId)
+	collectionId, err := service.promptCollection(ctx, userId, prompt)
 	if err != nil {
-		return nil, errors.New("invalid collection id")
+		return nil, err
 	}
 
 	modelOps := prompt.GetModelOptions()
 	if modelOps == nil {
-		return nil, fmt.Errorf("options missing")
+		return nil, rpcerror.Missing("model_options")
 	}
 
 	retrievalOptions := prompt.GetRetrievalOptions()
 	if retrievalOptions == nil {
-		return nil, fmt.Errorf("retrieval options missing")
+		return nil, rpcerror.Missing("retrieval_options")
+	}
+
+	documents, threshold, err := service.retrievalLimits(retrievalOptions)
+	if err != nil {
+		return nil, err
 	}
 
-	model, err := service.getModel(modelOps.ModelId)
+	err = search.ValidateHnswEf(retrievalOptions.HnswEf)
+	if err != nil {
+		return nil, rpcerror.Invalid("retrieval_options.hnsw_ef", err)
+	}
+
+	system, err := systemPrompt(prompt.SystemPrompt)
+	if err != nil {
+		return nil, err
+	}
+
+	text, err := promptText(prompt)
 	if err != nil {
 		return nil, err
 	}
 
+	images, err := promptImages(prompt.Images)
+	if err != nil {
+		return nil, err
+	}
+
+	toolDescription, err := sanitizePrompt("retrieval_options.tool_description", retrievalOptions.ToolDescription, MaxToolDescriptionLength)
+	if err != nil {
+		return nil, err
+	}
+
+	// Shared collections are searched with the documents of the owner, while the
+	// thread and the usage belong to the user
+	collection, err := service.checkCollection(ctx, userId, collectionId)
+	if err != nil {
+		return nil, err
+	}
+	ownerId := collection.UserId
+
+	documentIds, err := docfilter.Check(ctx, service.Database, "retrieval_options.document_ids",
+		ownerId, collectionId, retrievalOptions.DocumentIds)
+	if err != nil {
+		return nil, err
+	}
+
+	modelId := service.modelId(modelOps, collection)
+	if modelId == "" {
+		return nil, rpcerror.Missing("model_options.model_id")
+	}
+
+	err = service.ModelAccess.checkModel(userId, modelId)
+	if err != nil {
+		return nil, err
+	}
+
+	model, err := service.getModel(modelId)
+	if err != nil {
+		return nil, err
+	}
+
+	temperature, topP, err := llm.ValidateSampling(modelId, modelOps.Temperature, modelOps.TopP)
+	if err != nil {
+		return nil, rpcerror.Invalid("model_options", err)
+	}
+
+	err = llm.ValidateReasoningBudget(modelOps.ReasoningBudget)
+	if err != nil {
+		return nil, rpcerror.Invalid("model_options.reasoning_budget", err)
+	}
+
+	docParams := documentParameters{
+		userId: ownerId,
+	}
+	if ownerId != userId {
+		docParams.collectionId = collectionId
+	}
+
+	err = service.moderate(ctx, moderationPrompt, text)
+	if err != nil {
+		return nil, completionError(err)
+	}
+
+	// Document names and usage are shared by all tool calls of the request
+	names := service.newDocumentNames(ownerId)
+	usage := &usageRecorder{}
+
+	retrieval := retrievalParameters{
+		prompt:         text,
+		userId:         userId,
+		ownerId:        ownerId,
+		collectionId:   collectionId.String(),
+		embeddingModel: collection.EmbeddingModel,
+		fragmentCount:  documents,
+		threshold:      threshold,
+		language:       retrievalOptions.Language,
+		hnswEf:         retrievalOptions.HnswEf,
+		description:    toolDescription,
+		names:          names,
+		usage:          usage,
+		grouping: search.Grouping{
+			PerDocument: retrievalOptions.GroupPerDocument,
+			Stitch:      retrievalOptions.Stitch,
+		},
+		rewrite:     retrievalOptions.QueryRewrite,
+		documentIds: documentIds,
+	}
+
+	if prompt.PreviewSources {
+		return service.previewSources(ctx, prompt, retrieval)
+	}
+
 	//
 	// Get the thread messages
 	//
 
 	var thread *datastore.Thread
+	var replaced []*llm.Message
 	if prompt.ThreadId != "" {
 		//
 		// Get the thread from the database
@@ -58,13 +190,31 @@ func (service *Service) PostMessage(ctx context.Context, prompt *pb.Prompt) (*pb
 
 		threadId, err := uuid.Parse(prompt.ThreadId)
 		if err != nil {
-			return nil, err
+			return nil, rpcerror.InvalidId("thread_id", prompt.ThreadId)
 		}
 
 		thread, err = service.Database.GetThread(ctx, userId, threadId)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, rpcerror.NotFound("thread", prompt.ThreadId)
+		}
 		if err != nil {
 			return nil, err
 		}
+
+		if thread.CollectionId != collectionId {
+			return nil, rpcerror.New(codes.InvalidArgument, rpcerror.ReasonInvalidValue, "collection_id",
+				fmt.Sprintf("thread %s belongs to another collection", prompt.ThreadId))
+		}
+
+		if regenerate {
+			// The last turn is replaced by the new completion
+			turns := splitTurns(thread.Messages)
+			replaced = turns[len(turns)-1]
+			thread.Messages = nil
+			for _, turn := range turns[:len(turns)-1] {
+				thread.Messages = append(thread.Messages, turn...)
+			}
+		}
 	} else {
 		//
 		// Create a new thread
@@ -78,20 +228,41 @@ func (service *Service) PostMessage(ctx context.Context, prompt *pb.Prompt) (*pb
 		}
 	}
 
+	err = service.limitThread(ctx, userId, thread, usage)
+	if err != nil {
+		service.recordUsages(context.WithoutCancel(ctx), usage.list())
+		return nil, err
+	}
+
+	// Messages appended without a completion are part of this prompt. Regenerated
+	// prompts already contain the user messages pending at the time and reuse
+	// the stored system instructions. Messages appended during the completion
+	// stay pending for the next prompt
+	var consumed, instructions []string
+	if regenerate {
+		instructions = replaced[0].Instructions
+	} else {
+		instructions, text = applyPending(thread.Pending, text)
+		consumed = pendingIds(thread.Pending)
+	}
+	system = withInstructions(system, instructions)
+
 	//
 	// Call the model
 	//
 
 	messages := append(thread.Messages, &llm.Message{
-		Role:    llm.RoleUser,
-		Content: prompt.Prompt,
+		Role:         llm.RoleUser,
+		Content:      text,
+		Images:       images,
+		Instructions: instructions,
 	})
 
 	// Add manual attachments
 	for _, documentId := range prompt.Attachments {
 		callId := uuid.New()
 
-		document, err := service.getDocumentById(ctx, userId, documentId)
+		document, err := service.getDocumentById(ctx, docParams, documentId)
 		if err != nil {
 			return nil, err
 		}
@@ -127,22 +298,14 @@ func (service *Service) PostMessage(ctx context.Context, prompt *pb.Prompt) (*pb
 		//
 		toolChoice.Type = llm.ToolUseNone
 		tools = []*llm.ToolDefinition{
-			service.getAttachDocumentTool(documentParameters{
-				userId: userId,
-			}),
+			service.getAttachDocumentTool(docParams),
 		}
 	} else {
 		//
 		// Retrieval mode
 		//
 		tools = []*llm.ToolDefinition{
-			service.getSourceTools(retrievalParameters{
-				prompt:        prompt.Prompt,
-				userId:        userId,
-				collectionId:  prompt.CollectionId,
-				fragmentCount: retrievalOptions.Documents,
-				threshold:     retrievalOptions.Threshold,
-			}),
+			service.getSourceTools(retrieval),
 		}
 
 		if len(messages) == 1 {
@@ -156,33 +319,73 @@ func (service *Service) PostMessage(ctx context.Context, prompt *pb.Prompt) (*pb
 	}
 
 	request := &llm.CompletionRequest{
-		SystemPrompt: systemPromptLatex,
+		SystemPrompt: system,
 		Messages:     messages,
-		Model:        modelOps.ModelId,
+		Model:        modelId,
 		MaxTokens:    int(modelOps.MaxTokens),
-		TopP:         modelOps.TopP,
-		Temperature:  modelOps.Temperature,
+		TopP:         topP,
+		Temperature:  temperature,
 		UserId:       userId,
 		ToolChoice:   toolChoice,
 		Tools:        tools,
+
+		ReasoningBudget: int(modelOps.ReasoningBudget),
 	}
 
-	response, err := model.Completion(ctx, request)
+	// Retrieved sources are added by the tool calls, attached documents are already part of the messages
+	var reserve int
+	if len(prompt.Attachments) == 0 {
+		reserve = llm.MaxToolResultTokens()
+	}
+
+	err = checkContextFit(request, reserve)
 	if err != nil {
+		service.recordUsages(context.WithoutCancel(ctx), usage.list())
 		return nil, err
 	}
 
+	// The completion is paid for, so it's saved even if the client disconnects
+	persistCtx := context.WithoutCancel(ctx)
+
+	start := time.Now()
+	response, err := model.Completion(ctx, request)
+	latency := time.Since(start)
+	if err != nil {
+		// Searches of the tool calls are paid for even if the completion failed
+		service.recordUsages(persistCtx, usage.list())
+		return nil, completionError(err)
+	}
+
+	// Flagged completions are neither stored nor returned
+	err = service.moderate(ctx, moderationCompletion, response.Messages[len(response.Messages)-1].Content)
+	if err != nil {
+		service.recordUsages(persistCtx, usage.list())
+		return nil, completionError(err)
+	}
+
 	//
 	// Save the response
 	//
 
-	thread.Messages = response.Messages
-	err = service.Database.StoreThread(ctx, thread)
-	if err != nil {
-		return nil, err
+	// Identify the answer, so that it can be rated
+	completion := response.Messages[len(response.Messages)-1]
+	completion.Id = uuid.NewString()
+	completion.Model = response.Usage.Model
+	completion.Metadata = &llm.MessageMetadata{
+		Provider:     llm.ModelProvider(modelId),
+		Latency:      latency,
+		InputTokens:  response.Usage.InputTokens,
+		OutputTokens: response.Usage.OutputTokens,
+		CreatedAt:    time.Now(),
+
+		ModelId:         modelId,
+		ReasoningBudget: int(modelOps.ReasoningBudget),
+	}
+	if regenerate {
+		completion.Alternatives = alternatives(replaced)
 	}
 
-	_ = service.Database.InsertModelUsage(ctx, &datastore.ModelUsage{
+	usage.add(&datastore.ModelUsage{
 		Id:           uuid.New(),
 		UserId:       userId,
 		Timestamp:    time.Now(),
@@ -191,26 +394,33 @@ func (service *Service) PostMessage(ctx context.Context, prompt *pb.Prompt) (*pb
 		OutputTokens: response.Usage.OutputTokens,
 	})
 
+	thread.Messages = response.Messages
+	thread.CitationStyle = prompt.CitationStyle.String()
+	err = service.storeTurn(persistCtx, thread, consumed, usage.list())
+	if err != nil {
+		// The thread is lost, but the usage must still be billed
+		log.Printf("failed to store thread %s: %v", thread.Id, err)
+		service.recordUsages(persistCtx, usage.list())
+		return nil, err
+	}
+
 	// Get the document names
 	sources := getSources(response.Messages)
+	names.setSourceNames(ctx, sources)
 
-	for idx, source := range sources {
-		docId, err := uuid.Parse(source.DocumentId)
-		if err != nil {
-			continue
-		}
+	message := &pb.Message{
+		ThreadId:   thread.Id.String(),
+		Prompt:     text,
+		Completion: completion.Content,
+		Sources:    sources,
+		Id:         completion.Id,
+		Model:      completion.Model,
+		Reasoning:  response.Reasoning,
+		Metadata:   messageMetadata(completion.Metadata),
 
-		docName, err := service.Database.GetDocumentName(ctx, userId, docId)
-		if err != nil {
-			continue
-		}
-		sources[idx].Name = docName
+		Alternatives: alternativesToProto(completion.Alternatives),
 	}
+	formatCitations(message, prompt.CitationStyle)
 
-	return &pb.Message{
-		ThreadId:   thread.Id.String(),
-		Prompt:     prompt.Prompt,
-		Completion: thread.Messages[len(thread.Messages)-1].Content,
-		Sources:    sources,
-	}, nil
+	return message, nil
 }
@@ -0,0 +1,34 @@
+package chat
+
+import (
+	"context"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"google.golang.org/grpc/codes"
+)
+
+// previewSources searches the sources the get_sources tool would find for the
+// prompt, without calling the model or changing the thread. Only the usage of the
+// embedding and the query rewriting is stored.
+func (service *Service) previewSources(ctx context.Context, prompt *pb.Prompt, params retrievalParameters) (*pb.Message, error) {
+	if len(prompt.Attachments) > 0 {
+		return nil, rpcerror.New(codes.InvalidArgument, rpcerror.ReasonInvalidValue, "attachments",
+			"prompts with attachments don't search sources")
+	}
+
+	results, err := service.searchSources(ctx, params, params.prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	service.recordUsages(ctx, params.usage.list())
+
+	sources := resultsToSources(results.Results)
+	params.names.setSourceNames(ctx, sources)
+
+	return &pb.Message{
+		ThreadId: prompt.ThreadId,
+		Prompt:   params.prompt,
+		Sources:  sources,
+	}, nil
+}
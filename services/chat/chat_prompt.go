@@ -0,0 +1,55 @@
+package chat
+
+import (
+	"fmt"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"google.golang.org/grpc/codes"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// MaxSystemPromptLength is the maximum number of characters of a custom system prompt.
+	MaxSystemPromptLength = 4000
+
+	// MaxToolDescriptionLength is the maximum number of characters of a custom tool description.
+	MaxToolDescriptionLength = 1000
+
+	// MaxThreadTitleLength is the maximum number of characters of a thread title.
+	MaxThreadTitleLength = 200
+)
+
+// sanitizePrompt removes control characters except for line breaks and tabs and
+// trims surrounding whitespace. Prompts exceeding the limit are rejected.
+func sanitizePrompt(field, prompt string, limit int) (string, error) {
+	prompt = strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\t' || !unicode.IsControl(r) {
+			return r
+		}
+		return -1
+	}, prompt)
+	prompt = strings.TrimSpace(prompt)
+
+	if length := utf8.RuneCountInString(prompt); length > limit {
+		return "", rpcerror.New(codes.InvalidArgument, rpcerror.ReasonLimitExceeded, field,
+			fmt.Sprintf("%s too long: %d characters exceeds the limit of %d", field, length, limit))
+	}
+
+	return prompt, nil
+}
+
+// systemPrompt returns the custom system prompt of the request or the default one.
+// The citation placeholder of custom prompts is replaced by the citation instruction.
+func systemPrompt(custom string) (string, error) {
+	prompt, err := sanitizePrompt("system_prompt", custom, MaxSystemPromptLength)
+	if err != nil {
+		return "", err
+	}
+
+	if prompt == "" {
+		return systemPromptLatex, nil
+	}
+
+	return strings.ReplaceAll(prompt, citationPlaceholder, citationInstruction), nil
+}
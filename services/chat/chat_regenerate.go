@@ -0,0 +1,114 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/llm"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+)
+
+// MaxAlternatives limits the earlier answers kept for a prompt, the oldest are
+// dropped first.
+const MaxAlternatives = 10
+
+// Regenerate answers the last prompt of a thread again, optionally with another
+// model, over the messages before it. The new answer replaces the previous one,
+// which is kept as an alternative, and the returned message names the model that
+// generated it. System instructions appended before the prompt apply again.
+// Documents attached to the prompt are not attached again, while its image URLs
+// are sent again. Images sent as data are not stored with the thread.
+func (service *Service) Regenerate(ctx context.Context, req *pb.RegenerateRequest) (*pb.Message, error) {
+	userId, err := service.Auth.VerifyFunding(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	key := idempotencyKey(ctx, req.IdempotencyKey)
+	if key == "" {
+		return service.regenerate(ctx, userId, req)
+	}
+
+	return service.once(ctx, userId, key, req, func() (*pb.Message, error) {
+		return service.regenerate(ctx, userId, req)
+	})
+}
+
+func (service *Service) regenerate(ctx context.Context, userId string, req *pb.RegenerateRequest) (*pb.Message, error) {
+	threadId, err := uuid.Parse(req.ThreadId)
+	if err != nil {
+		return nil, rpcerror.InvalidId("thread_id", req.ThreadId)
+	}
+
+	thread, err := service.Database.GetThread(ctx, userId, threadId)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, rpcerror.NotFound("thread", req.ThreadId)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	turns := splitTurns(thread.Messages)
+	if len(turns) == 0 {
+		return nil, rpcerror.New(codes.FailedPrecondition, rpcerror.ReasonInvalidValue, "thread_id",
+			"thread has no prompt to regenerate")
+	}
+	last := turns[len(turns)-1]
+
+	return service.postMessage(ctx, userId, &pb.Prompt{
+		ThreadId:         req.ThreadId,
+		CollectionId:     thread.CollectionId.String(),
+		Prompt:           last[0].Content,
+		Images:           imagesToProto(last[0].Images),
+		ModelOptions:     regenerateOptions(req.ModelOptions, last),
+		RetrievalOptions: req.RetrievalOptions,
+		CitationStyle:    citationStyle(thread.CitationStyle),
+	}, true)
+}
+
+// regenerateOptions returns the model options of a regenerated answer. Without
+// options, the model of the replaced answer is used. Options without a reasoning
+// budget keep the budget of the replaced answer.
+func regenerateOptions(options *pb.ModelOptions, turn []*llm.Message) *pb.ModelOptions {
+	meta := turn[len(turn)-1].Metadata
+	if meta == nil {
+		return options
+	}
+
+	if options == nil {
+		return &pb.ModelOptions{
+			ModelId:         meta.ModelId,
+			ReasoningBudget: uint32(meta.ReasoningBudget),
+		}
+	}
+
+	if options.ReasoningBudget == 0 && meta.ReasoningBudget > 0 {
+		options = proto.Clone(options).(*pb.ModelOptions)
+		options.ReasoningBudget = uint32(meta.ReasoningBudget)
+	}
+
+	return options
+}
+
+// alternatives returns the earlier answers of a replaced turn followed by its
+// answer. Only the final answer is kept, not the tool calls before it.
+func alternatives(turn []*llm.Message) []*llm.Message {
+	answer := turn[len(turn)-1]
+	if answer.Role != llm.RoleAssistant || len(answer.ToolCalls) > 0 {
+		return nil
+	}
+
+	previous := *answer
+	previous.Alternatives = nil
+
+	result := append(append([]*llm.Message{}, answer.Alternatives...), &previous)
+	if len(result) > MaxAlternatives {
+		result = result[len(result)-MaxAlternatives:]
+	}
+
+	return result
+}
@@ -0,0 +1,180 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
+	"github.com/pzierahn/chatbot_services/llm"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestAlternatives(t *testing.T) {
+	turn := []*llm.Message{
+		{Role: llm.RoleUser, Content: "question"},
+		{Role: llm.RoleAssistant, Id: "b", Content: "second", Alternatives: []*llm.Message{
+			{Role: llm.RoleAssistant, Id: "a", Content: "first"},
+		}},
+	}
+
+	result := alternatives(turn)
+	if len(result) != 2 || result[0].Id != "a" || result[1].Id != "b" {
+		t.Fatalf("expected the answers a and b, got %v", result)
+	}
+	if result[1].Alternatives != nil {
+		t.Fatal("expected the alternatives not to be nested")
+	}
+	if len(turn[1].Alternatives) != 1 {
+		t.Fatal("expected the replaced turn to be unchanged")
+	}
+
+	// The oldest answers are dropped
+	for idx := 0; idx < MaxAlternatives; idx++ {
+		turn[1].Alternatives = append(turn[1].Alternatives, &llm.Message{Id: fmt.Sprint(idx)})
+	}
+	result = alternatives(turn)
+	if len(result) != MaxAlternatives || result[len(result)-1].Id != "b" {
+		t.Fatalf("expected %d answers ending with b, got %d", MaxAlternatives, len(result))
+	}
+}
+
+func TestRegenerateOptions(t *testing.T) {
+	turn := []*llm.Message{
+		{Role: llm.RoleUser, Content: "question"},
+		{Role: llm.RoleAssistant, Content: "answer", Metadata: &llm.MessageMetadata{
+			ModelId:         testModel,
+			ReasoningBudget: 2048,
+		}},
+	}
+
+	options := regenerateOptions(nil, turn)
+	if options.ModelId != testModel || options.ReasoningBudget != 2048 {
+		t.Fatalf("expected the options of the answer, got %v", options)
+	}
+
+	requested := &pb.ModelOptions{ModelId: "other-model"}
+	options = regenerateOptions(requested, turn)
+	if options.ModelId != "other-model" || options.ReasoningBudget != 2048 {
+		t.Fatalf("expected the other model with the budget of the answer, got %v", options)
+	}
+	if requested.ReasoningBudget != 0 {
+		t.Fatal("expected the requested options to be unchanged")
+	}
+
+	requested = &pb.ModelOptions{ModelId: "other-model", ReasoningBudget: 1024}
+	if options = regenerateOptions(requested, turn); options.ReasoningBudget != 1024 {
+		t.Fatalf("expected the requested budget, got %d", options.ReasoningBudget)
+	}
+}
+
+func TestRegenerate(t *testing.T) {
+	uri := os.Getenv("CHATBOT_MONGODB_URI")
+	if uri == "" {
+		t.Skip("CHATBOT_MONGODB_URI not set")
+	}
+
+	ctx := context.Background()
+
+	db, err := datastore.NewFrom(ctx, uri, datastore.PoolConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	userId := "test-" + uuid.NewString()
+	collection := &datastore.Collection{
+		Id:     uuid.New(),
+		UserId: userId,
+		Name:   "regenerate",
+	}
+
+	err = db.InsertCollection(ctx, collection)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = db.DeleteCollection(ctx, userId, collection.Id) }()
+
+	model := &testChat{}
+	service := &Service{
+		Models:   []llm.Chat{model},
+		Auth:     &testVerifier{userId: userId},
+		Database: db,
+		Search:   &testIndex{},
+	}
+
+	options := &pb.ModelOptions{ModelId: testModel}
+	first, err := service.PostMessage(ctx, &pb.Prompt{
+		CollectionId:     collection.Id.String(),
+		Prompt:           "question",
+		ModelOptions:     options,
+		RetrievalOptions: &pb.RetrievalOptions{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = service.AppendMessages(ctx, &pb.AppendRequest{
+		ThreadId: first.ThreadId,
+		Messages: []*pb.ContextMessage{{
+			Role:    pb.ContextRole_CONTEXT_ROLE_SYSTEM,
+			Content: "Answer in German.",
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := service.PostMessage(ctx, &pb.Prompt{
+		ThreadId:         first.ThreadId,
+		CollectionId:     collection.Id.String(),
+		Prompt:           "follow-up",
+		ModelOptions:     options,
+		RetrievalOptions: &pb.RetrievalOptions{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := &pb.RegenerateRequest{
+		ThreadId:         first.ThreadId,
+		ModelOptions:     options,
+		RetrievalOptions: &pb.RetrievalOptions{},
+		IdempotencyKey:   uuid.NewString(),
+	}
+
+	model.system = ""
+	regenerated, err := service.Regenerate(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasSuffix(model.system, "Answer in German.") {
+		t.Fatalf("expected the pending instruction in the system prompt, got %q", model.system)
+	}
+
+	if len(regenerated.Alternatives) != 1 || regenerated.Alternatives[0].Id != second.Id {
+		t.Fatalf("expected the previous answer as alternative, got %v", regenerated.Alternatives)
+	}
+
+	calls := model.calls
+	retried, err := service.Regenerate(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if model.calls != calls || retried.Id != regenerated.Id {
+		t.Fatal("expected the retry to return the first regeneration")
+	}
+
+	thread, err := service.GetThread(ctx, &pb.ThreadID{Id: first.ThreadId})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	last := thread.Messages[len(thread.Messages)-1]
+	if len(thread.Messages) != 2 || last.Id != regenerated.Id || len(last.Alternatives) != 1 {
+		t.Fatalf("expected the regenerated answer with one alternative, got %v", thread.Messages)
+	}
+}
@@ -0,0 +1,95 @@
+package chat
+
+import (
+	"github.com/pzierahn/chatbot_services/search"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"google.golang.org/grpc/codes"
+	"log"
+	"os"
+	"strconv"
+)
+
+// Defaults for prompts that don't set the retrieval limits. Clients that predate
+// the optional threshold send 0 to disable the filtering, which is indistinguishable
+// from an unset threshold, so the threshold is disabled by default.
+const (
+	DefaultRetrievalDocuments = 8
+	DefaultRetrievalThreshold = 0
+)
+
+// RetrievalDefaults are used for the retrieval options that a prompt doesn't set.
+type RetrievalDefaults struct {
+	// Documents is the maximum number of sources per search
+	Documents uint32
+
+	// Threshold is the minimum similarity of the sources, 0 disables the filtering
+	Threshold float32
+}
+
+// RetrievalDefaultsFromEnv reads the defaults from CHATBOT_RETRIEVAL_DOCUMENTS and
+// CHATBOT_RETRIEVAL_THRESHOLD. Unset or invalid values keep the package defaults. A
+// default threshold also applies to older clients that send 0 to disable it.
+func RetrievalDefaultsFromEnv() *RetrievalDefaults {
+	defaults := &RetrievalDefaults{
+		Documents: DefaultRetrievalDocuments,
+		Threshold: DefaultRetrievalThreshold,
+	}
+
+	if value := os.Getenv("CHATBOT_RETRIEVAL_DOCUMENTS"); value != "" {
+		documents, err := strconv.ParseUint(value, 10, 32)
+		if err != nil || documents == 0 {
+			log.Printf("invalid CHATBOT_RETRIEVAL_DOCUMENTS: %s", value)
+		} else {
+			defaults.Documents = uint32(documents)
+		}
+	}
+
+	if value := os.Getenv("CHATBOT_RETRIEVAL_THRESHOLD"); value != "" {
+		threshold, err := strconv.ParseFloat(value, 32)
+		if err == nil {
+			err = search.ValidateThreshold(float32(threshold))
+		}
+
+		if err != nil {
+			log.Printf("invalid CHATBOT_RETRIEVAL_THRESHOLD: %s", value)
+		} else {
+			defaults.Threshold = float32(threshold)
+		}
+	}
+
+	return defaults
+}
+
+// retrievalLimits returns the number of sources and the threshold of the options.
+// Unset options use the defaults of the service, an explicit limit of 0 is rejected.
+func (service *Service) retrievalLimits(options *pb.RetrievalOptions) (uint32, float32, error) {
+	defaults := service.Retrieval
+	if defaults == nil {
+		defaults = &RetrievalDefaults{
+			Documents: DefaultRetrievalDocuments,
+			Threshold: DefaultRetrievalThreshold,
+		}
+	}
+
+	documents := defaults.Documents
+	if options.Documents != nil {
+		documents = options.GetDocuments()
+		if documents == 0 {
+			return 0, 0, rpcerror.New(codes.InvalidArgument, rpcerror.ReasonInvalidValue, "retrieval_options.documents",
+				"documents must be greater than 0, omit it to use the default")
+		}
+	}
+
+	threshold := defaults.Threshold
+	if options.Threshold != nil {
+		threshold = options.GetThreshold()
+
+		err := search.ValidateThreshold(threshold)
+		if err != nil {
+			return 0, 0, rpcerror.Invalid("retrieval_options.threshold", err)
+		}
+	}
+
+	return documents, threshold, nil
+}
@@ -0,0 +1,49 @@
+package chat
+
+import (
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"testing"
+)
+
+func TestRetrievalLimits(t *testing.T) {
+	service := &Service{}
+
+	documents, threshold, err := service.retrievalLimits(&pb.RetrievalOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if documents != DefaultRetrievalDocuments || threshold != 0 {
+		t.Fatalf("expected the defaults without threshold, got %d and %v", documents, threshold)
+	}
+
+	service.Retrieval = &RetrievalDefaults{Documents: 3, Threshold: 0.5}
+
+	documents, threshold, err = service.retrievalLimits(&pb.RetrievalOptions{
+		Threshold: proto.Float32(0),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if documents != 3 || threshold != 0 {
+		t.Fatalf("expected 3 documents without threshold, got %d and %v", documents, threshold)
+	}
+
+	_, _, err = service.retrievalLimits(&pb.RetrievalOptions{
+		Documents: proto.Uint32(0),
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for an explicit zero limit, got %v", err)
+	}
+
+	_, _, err = service.retrievalLimits(&pb.RetrievalOptions{
+		Threshold: proto.Float32(1.5),
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for an invalid threshold, got %v", err)
+	}
+}
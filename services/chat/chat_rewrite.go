@@ -0,0 +1,145 @@
+package chat
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
+	"github.com/pzierahn/chatbot_services/llm"
+	"github.com/pzierahn/chatbot_services/search"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxSubQueries is the maximum number of sub-queries of a decomposed query.
+	MaxSubQueries = 3
+
+	// rewriteMaxTokens limits the rewritten query, a hypothetical answer is the longest
+	rewriteMaxTokens = 300
+)
+
+var rewritePrompts = map[pb.QueryRewrite]string{
+	pb.QueryRewrite_QUERY_REWRITE_EXPAND: "Rewrite the search query for a semantic search over scientific documents. " +
+		"Expand acronyms and abbreviations and add synonyms of the key terms. " +
+		"Answer only with the rewritten query in the language of the query.",
+	pb.QueryRewrite_QUERY_REWRITE_HYDE: "Write a short passage of a scientific document that answers the search query. " +
+		"The passage is used to find similar documents, so make up plausible details if necessary. " +
+		"Answer only with the passage in the language of the query.",
+	pb.QueryRewrite_QUERY_REWRITE_DECOMPOSE: "Split the search query into at most three self-contained sub-queries " +
+		"that together cover the query. Answer only with the sub-queries, one per line, in the language of the query.",
+}
+
+// rewriteQuery rewrites the search query with the rewrite model and records its
+// usage. The original query is searched if rewriting is disabled or fails, or if
+// the user may not use the rewrite model.
+func (service *Service) rewriteQuery(ctx context.Context, params retrievalParameters, query string) []string {
+	prompt, ok := rewritePrompts[params.rewrite]
+	if !ok || service.RewriteModel == "" {
+		return []string{query}
+	}
+
+	if !service.ModelAccess.allowed(params.userId, service.RewriteModel) {
+		return []string{query}
+	}
+
+	model, err := service.getModel(service.RewriteModel)
+	if err != nil {
+		log.Printf("rewrite: %v", err)
+		return []string{query}
+	}
+
+	response, err := model.Completion(ctx, &llm.CompletionRequest{
+		SystemPrompt: prompt,
+		Messages: []*llm.Message{{
+			Role:    llm.RoleUser,
+			Content: query,
+		}},
+		Model:       service.RewriteModel,
+		MaxTokens:   rewriteMaxTokens,
+		TopP:        llm.DefaultTopP,
+		Temperature: llm.DefaultTemperature,
+		UserId:      params.userId,
+	})
+	if err != nil {
+		log.Printf("rewrite: %v", err)
+		return []string{query}
+	}
+
+	params.usage.add(&datastore.ModelUsage{
+		Id:           uuid.New(),
+		UserId:       params.userId,
+		Timestamp:    time.Now(),
+		ModelId:      response.Usage.Model,
+		InputTokens:  response.Usage.InputTokens,
+		OutputTokens: response.Usage.OutputTokens,
+	})
+
+	text := response.Messages[len(response.Messages)-1].Content
+
+	var queries []string
+	if params.rewrite == pb.QueryRewrite_QUERY_REWRITE_DECOMPOSE {
+		queries = subQueries(text)
+	} else if text = strings.TrimSpace(text); text != "" {
+		queries = []string{text}
+	}
+
+	if len(queries) == 0 {
+		return []string{query}
+	}
+
+	return queries
+}
+
+// subQueries splits the lines of a decomposed query and removes list markers.
+func subQueries(text string) []string {
+	var queries []string
+
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimLeft(strings.TrimSpace(line), "-*•0123456789.) ")
+		if line == "" {
+			continue
+		}
+
+		queries = append(queries, line)
+		if len(queries) == MaxSubQueries {
+			break
+		}
+	}
+
+	return queries
+}
+
+// weightedQueries returns the query with weight 1 followed by the sub-queries, one
+// per line and optionally prefixed with a weight like "2: query". Duplicates are
+// dropped and the queries are capped at search.MaxQueries.
+func weightedQueries(query, subQueries string) []search.WeightedQuery {
+	queries := []search.WeightedQuery{{Query: query, Weight: 1}}
+	seen := map[string]bool{strings.ToLower(query): true}
+
+	for _, line := range strings.Split(subQueries, "\n") {
+		weight := float32(1)
+		text := strings.TrimLeft(strings.TrimSpace(line), "-*• ")
+
+		if prefix, rest, found := strings.Cut(text, ":"); found {
+			if value, err := strconv.ParseFloat(strings.TrimSpace(prefix), 32); err == nil && value > 0 {
+				weight = float32(value)
+				text = strings.TrimSpace(rest)
+			}
+		}
+
+		if text == "" || seen[strings.ToLower(text)] {
+			continue
+		}
+
+		seen[strings.ToLower(text)] = true
+		queries = append(queries, search.WeightedQuery{Query: text, Weight: weight})
+		if len(queries) == search.MaxQueries {
+			break
+		}
+	}
+
+	return queries
+}
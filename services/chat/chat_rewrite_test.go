@@ -0,0 +1,118 @@
+package chat
+
+import (
+	"context"
+	"github.com/pzierahn/chatbot_services/llm"
+	"github.com/pzierahn/chatbot_services/search"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"reflect"
+	"testing"
+)
+
+func TestSubQueries(t *testing.T) {
+	text := "1. What is RAG?\n\n- How are chunks embedded?\n* Which index is used?\nWhat is HyDE?"
+
+	got := subQueries(text)
+	want := []string{"What is RAG?", "How are chunks embedded?", "Which index is used?"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("subQueries() = %q, want %q", got, want)
+	}
+}
+
+// rewriteChat answers every prompt with the same rewritten query.
+type rewriteChat struct {
+	answer string
+	calls  int
+}
+
+func (chat *rewriteChat) Completion(_ context.Context, req *llm.CompletionRequest) (*llm.CompletionResponse, error) {
+	chat.calls++
+	return &llm.CompletionResponse{
+		Messages: append(req.Messages, &llm.Message{Role: llm.RoleAssistant, Content: chat.answer}),
+		Usage:    llm.ModelUsage{Model: testModel},
+	}, nil
+}
+
+func (chat *rewriteChat) ProvidesModel(model string) bool {
+	return model == testModel
+}
+
+// queryIndex records the queries of the searches.
+type queryIndex struct {
+	search.Index
+	queries []search.Query
+}
+
+func (index *queryIndex) Search(_ context.Context, query search.Query) (*search.Results, error) {
+	index.queries = append(index.queries, query)
+	return &search.Results{}, nil
+}
+
+func TestRewriteQueryModelAccess(t *testing.T) {
+	model := &rewriteChat{answer: "expanded query"}
+	service := &Service{
+		Models:       []llm.Chat{model},
+		RewriteModel: testModel,
+		ModelAccess:  &ModelAccess{Default: []string{"other-model"}},
+	}
+
+	params := retrievalParameters{
+		userId:  "user",
+		rewrite: pb.QueryRewrite_QUERY_REWRITE_EXPAND,
+		usage:   &usageRecorder{},
+	}
+
+	queries := service.rewriteQuery(context.Background(), params, "query")
+	if !reflect.DeepEqual(queries, []string{"query"}) || model.calls != 0 {
+		t.Fatalf("expected the original query without a model call, got %q after %d calls", queries, model.calls)
+	}
+
+	service.ModelAccess = &ModelAccess{Default: []string{testModel}}
+	queries = service.rewriteQuery(context.Background(), params, "query")
+	if !reflect.DeepEqual(queries, []string{"expanded query"}) {
+		t.Fatalf("expected the rewritten query, got %q", queries)
+	}
+}
+
+func TestSearchSourcesDecompose(t *testing.T) {
+	index := &queryIndex{}
+	service := &Service{
+		Models:       []llm.Chat{&rewriteChat{answer: "1. What is RAG?\n2. What is HyDE?"}},
+		Search:       index,
+		RewriteModel: testModel,
+	}
+
+	params := retrievalParameters{
+		userId:  "user",
+		rewrite: pb.QueryRewrite_QUERY_REWRITE_DECOMPOSE,
+		usage:   &usageRecorder{},
+	}
+
+	_, err := service.searchSources(context.Background(), params, "What are RAG and HyDE?")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The sub-queries are fused by the index in a single search
+	if len(index.queries) != 1 {
+		t.Fatalf("expected a single search, got %d", len(index.queries))
+	}
+
+	want := []search.WeightedQuery{{Query: "What is RAG?", Weight: 1}, {Query: "What is HyDE?", Weight: 1}}
+	if !reflect.DeepEqual(index.queries[0].Queries, want) {
+		t.Errorf("queries = %v, want %v", index.queries[0].Queries, want)
+	}
+}
+
+func TestWeightedQueries(t *testing.T) {
+	got := weightedQueries("difference between RAG and fine-tuning", "2: RAG\n- fine-tuning\n\nrag\nratio: 1:2")
+	want := []search.WeightedQuery{
+		{Query: "difference between RAG and fine-tuning", Weight: 1},
+		{Query: "RAG", Weight: 2},
+		{Query: "fine-tuning", Weight: 1},
+		{Query: "ratio: 1:2", Weight: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("weightedQueries() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,39 @@
+package chat
+
+import (
+	"github.com/pzierahn/chatbot_services/search"
+	"regexp"
+)
+
+// sourceCitePattern matches citation commands in source texts, including variants
+// like \citep{...}, \cite*{...} or \cite {...} and repeated backslashes.
+var sourceCitePattern = regexp.MustCompile(`(?i)\\+\s*(cite[a-z]*\*?)\s*\{`)
+
+// escapeCitations removes the backslash of citation commands in source texts, so
+// that citations quoted by a document aren't mistaken for citations of the model.
+func escapeCitations(text string) string {
+	return sourceCitePattern.ReplaceAllString(text, "$1{")
+}
+
+// sanitizeSources returns copies of the sources with escaped citations. The
+// sources aren't changed in place, because they may be shared by the search cache.
+func sanitizeSources(sources []*search.Result) []*search.Result {
+	sanitized := make([]*search.Result, len(sources))
+	for idx, source := range sources {
+		clean := *source
+		clean.Text = escapeCitations(source.Text)
+		sanitized[idx] = &clean
+	}
+
+	return sanitized
+}
+
+// sanitizeNames escapes citations in document names.
+func sanitizeNames(names map[string]string) map[string]string {
+	sanitized := make(map[string]string, len(names))
+	for id, name := range names {
+		sanitized[id] = escapeCitations(name)
+	}
+
+	return sanitized
+}
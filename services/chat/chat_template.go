@@ -0,0 +1,81 @@
+package chat
+
+import (
+	"fmt"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"google.golang.org/grpc/codes"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxTemplatePromptLength is the maximum number of characters of a rendered prompt template.
+const MaxTemplatePromptLength = 100_000
+
+// placeholderPattern matches {{name}} and {{ name }}. A preceding backslash escapes
+// the placeholder.
+var placeholderPattern = regexp.MustCompile(`(\\?)\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*}}`)
+
+// renderTemplate substitutes the placeholders of the template with the variables.
+// Values are inserted as they are and are not rendered again, so they can't inject
+// placeholders. Unknown placeholders are rejected instead of rendering empty.
+func renderTemplate(template *pb.PromptTemplate) (string, error) {
+	var unknown []string
+	seen := make(map[string]bool)
+
+	var builder strings.Builder
+	last := 0
+	for _, match := range placeholderPattern.FindAllStringSubmatchIndex(template.Template, -1) {
+		builder.WriteString(template.Template[last:match[0]])
+		last = match[1]
+
+		// Escaped placeholders are rendered without the backslash
+		if match[3] > match[2] {
+			builder.WriteString(template.Template[match[3]:match[1]])
+			continue
+		}
+
+		name := template.Template[match[4]:match[5]]
+		value, ok := template.Variables[name]
+		if !ok {
+			if !seen[name] {
+				unknown = append(unknown, name)
+				seen[name] = true
+			}
+			continue
+		}
+
+		builder.WriteString(value)
+	}
+	builder.WriteString(template.Template[last:])
+
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return "", rpcerror.New(codes.InvalidArgument, rpcerror.ReasonInvalidValue, "template.variables",
+			fmt.Sprintf("unknown template variables: %s", strings.Join(unknown, ", ")))
+	}
+
+	prompt := builder.String()
+	if length := utf8.RuneCountInString(prompt); length > MaxTemplatePromptLength {
+		return "", rpcerror.New(codes.InvalidArgument, rpcerror.ReasonLimitExceeded, "template",
+			fmt.Sprintf("rendered template too long: %d characters exceeds the limit of %d", length, MaxTemplatePromptLength))
+	}
+
+	return prompt, nil
+}
+
+// promptText returns the prompt or the rendered template of the request.
+func promptText(prompt *pb.Prompt) (string, error) {
+	if prompt.Template == nil {
+		return prompt.Prompt, nil
+	}
+
+	if prompt.Prompt != "" {
+		return "", rpcerror.New(codes.InvalidArgument, rpcerror.ReasonInvalidValue, "template",
+			"prompt and template are mutually exclusive")
+	}
+
+	return renderTemplate(prompt.Template)
+}
@@ -0,0 +1,74 @@
+package chat
+
+import (
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strings"
+	"testing"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	tests := []struct {
+		name      string
+		template  string
+		variables map[string]string
+		want      string
+	}{
+		{"plain", "Summarize the text.", nil, "Summarize the text."},
+		{"variables", "Answer in {{language}}: {{ question }}", map[string]string{
+			"language": "German",
+			"question": "What is RAG?",
+		}, "Answer in German: What is RAG?"},
+		{"repeated", "{{a}} and {{a}}", map[string]string{"a": "x"}, "x and x"},
+		{"escaped", `Keep \{{name}} as is`, nil, "Keep {{name}} as is"},
+		{"values are not rendered", "{{a}}", map[string]string{"a": "{{b}}"}, "{{b}}"},
+		{"invalid names are literal", "{{ not a name }}", nil, "{{ not a name }}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := renderTemplate(&pb.PromptTemplate{
+				Template:  tt.template,
+				Variables: tt.variables,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderTemplateErrors(t *testing.T) {
+	_, err := renderTemplate(&pb.PromptTemplate{
+		Template: "{{known}} {{missing}}",
+		Variables: map[string]string{
+			"known": "value",
+		},
+	})
+	if status.Code(err) != codes.InvalidArgument || !strings.Contains(err.Error(), "missing") {
+		t.Errorf("expected the unknown variable to be rejected, got %v", err)
+	}
+
+	_, err = renderTemplate(&pb.PromptTemplate{
+		Template: "{{text}}",
+		Variables: map[string]string{
+			"text": strings.Repeat("a", MaxTemplatePromptLength+1),
+		},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected the rendered template to exceed the limit, got %v", err)
+	}
+
+	_, err = promptText(&pb.Prompt{
+		Prompt:   "prompt",
+		Template: &pb.PromptTemplate{Template: "template"},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected prompt and template to be exclusive, got %v", err)
+	}
+}
@@ -2,12 +2,72 @@ package chat
 
 import (
 	"context"
-	"errors"
+	"fmt"
 	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
+	"github.com/pzierahn/chatbot_services/llm"
 	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"time"
 )
 
+// resolveSourceNames sets the document names of the sources. Sources of deleted documents keep an empty name.
+func (service *Service) resolveSourceNames(ctx context.Context, userId string, sources []*pb.Source) {
+	service.newDocumentNames(userId).setSourceNames(ctx, sources)
+}
+
+// omitSourceContent removes the content of the fragments and keeps their ids, positions and scores.
+func omitSourceContent(sources []*pb.Source) {
+	for _, source := range sources {
+		for _, fragment := range source.Fragments {
+			fragment.Content = ""
+		}
+	}
+}
+
+// CreateThread creates an empty thread in a collection owned by or shared with the
+// user, so that clients can set a title before the first message is posted.
+func (service *Service) CreateThread(ctx context.Context, req *pb.NewThread) (*pb.ThreadID, error) {
+	userId, err := service.Auth.Verify(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	collectionId, err := uuid.Parse(req.CollectionId)
+	if err != nil {
+		return nil, rpcerror.InvalidId("collection_id", req.CollectionId)
+	}
+
+	title, err := sanitizePrompt("title", req.Title, MaxThreadTitleLength)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = service.checkCollection(ctx, userId, collectionId)
+	if err != nil {
+		return nil, err
+	}
+
+	thread := &datastore.Thread{
+		Id:           uuid.New(),
+		UserId:       userId,
+		CollectionId: collectionId,
+		Title:        title,
+		Timestamp:    time.Now(),
+	}
+
+	err = service.Database.StoreThread(ctx, thread)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ThreadID{
+		Id: thread.Id.String(),
+	}, nil
+}
+
 // ListThreadIDs returns a list of thread IDs for a given collection.
 func (service *Service) ListThreadIDs(ctx context.Context, collection *pb.CollectionId) (*pb.ThreadIDs, error) {
 	userId, err := service.Auth.Verify(ctx)
@@ -55,24 +115,35 @@ func (service *Service) GetThread(ctx context.Context, req *pb.ThreadID) (*pb.Th
 		return nil, err
 	}
 
-	for idx, message := range messages {
-		for idy, source := range message.Sources {
-			docId, err := uuid.Parse(source.DocumentId)
-			if err != nil {
-				continue
-			}
+	count := uint32(len(messages))
+	if req.Start > count {
+		return nil, rpcerror.New(codes.InvalidArgument, rpcerror.ReasonInvalidValue, "start",
+			fmt.Sprintf("start %d exceeds the message count %d", req.Start, count))
+	}
+	messages = messages[req.Start:]
+
+	// Sources of shared collections are documents of the owner
+	ownerId, err := service.collectionOwner(ctx, userId, thread.CollectionId)
+	if err != nil {
+		return nil, err
+	}
 
-			docName, err := service.Database.GetDocumentName(ctx, userId, docId)
-			if err != nil {
-				continue
-			}
-			messages[idx].Sources[idy].Name = docName
+	style := citationStyle(thread.CitationStyle)
+	for _, message := range messages {
+		service.resolveSourceNames(ctx, ownerId, message.Sources)
+		formatCitations(message, style)
+
+		if req.OmitSourceContent {
+			omitSourceContent(message.Sources)
 		}
 	}
 
 	results := &pb.Thread{
-		Id:       req.Id,
-		Messages: messages,
+		Id:           req.Id,
+		Messages:     messages,
+		MessageCount: count,
+		Title:        thread.Title,
+		Pending:      pendingToProto(thread.Pending),
 	}
 
 	return results, nil
@@ -107,7 +178,7 @@ func (service *Service) DeleteMessageFromThread(ctx context.Context, req *pb.Mes
 
 	threadId, err := uuid.Parse(req.ThreadId)
 	if err != nil {
-		return nil, err
+		return nil, rpcerror.InvalidId("thread_id", req.ThreadId)
 	}
 
 	// Get the thread for the message
@@ -116,13 +187,38 @@ func (service *Service) DeleteMessageFromThread(ctx context.Context, req *pb.Mes
 		return nil, err
 	}
 
-	// Check if the message index is valid
-	if req.Index+1 >= uint32(len(thread.Messages)) {
-		return nil, errors.New("invalid message index")
+	// The index refers to the messages returned by GetThread, where each
+	// message is a turn including its tool calls and responses
+	turns := splitTurns(thread.Messages)
+	if req.Index >= uint32(len(turns)) {
+		return nil, rpcerror.New(codes.InvalidArgument, rpcerror.ReasonInvalidValue, "index",
+			fmt.Sprintf("invalid message index: %d", req.Index))
 	}
 
-	// Delete two messages from the thread at the given index
-	thread.Messages = append(thread.Messages[:req.Index], thread.Messages[req.Index+2:]...)
+	// Remove the whole turn, so that no tool call or response is orphaned
+	turns = append(turns[:req.Index], turns[req.Index+1:]...)
+
+	if len(turns) == 0 {
+		// A thread without messages is removed entirely
+		err = service.Database.DeleteThread(ctx, userId, threadId)
+		if err != nil {
+			return nil, err
+		}
+
+		return &emptypb.Empty{}, nil
+	}
+
+	var messages []*llm.Message
+	for _, turn := range turns {
+		messages = append(messages, turn...)
+	}
+
+	err = validateMessages(messages)
+	if err != nil {
+		return nil, rpcerror.New(codes.FailedPrecondition, rpcerror.ReasonInvalidValue, "index",
+			fmt.Sprintf("cannot delete message: %v", err))
+	}
+	thread.Messages = messages
 
 	// Store the thread back to the database
 	err = service.Database.StoreThread(ctx, thread)
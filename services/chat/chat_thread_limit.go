@@ -0,0 +1,160 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
+	"github.com/pzierahn/chatbot_services/llm"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"google.golang.org/grpc/codes"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// ThreadPolicyReject rejects prompts to full threads with FailedPrecondition.
+	ThreadPolicyReject = "reject"
+
+	// ThreadPolicyTruncate replaces the oldest messages of full threads by a summary.
+	ThreadPolicyTruncate = "truncate"
+
+	// summaryRequest is the prompt of the turn that holds the summary of truncated messages
+	summaryRequest = "Summarize our conversation so far."
+
+	summaryMaxTokens = 1000
+
+	summaryPrompt = "Summarize the conversation between a user and an assistant about scientific documents. " +
+		"Keep the questions, the key findings and the cited sources, so that the conversation can be continued " +
+		"without the original messages. Answer only with the summary in the language of the conversation."
+)
+
+// ThreadLimit caps the number of messages of a thread. A message is a prompt
+// with its completion, like in GetThread.
+type ThreadLimit struct {
+	MaxMessages int
+	Policy      string
+}
+
+// ThreadLimitFromEnv creates a thread limit from CHATBOT_MAX_THREAD_MESSAGES and
+// CHATBOT_THREAD_LIMIT_POLICY ("reject" or "truncate", defaults to "reject").
+// It returns nil if the maximum is not set.
+func ThreadLimitFromEnv() *ThreadLimit {
+	max, err := strconv.Atoi(os.Getenv("CHATBOT_MAX_THREAD_MESSAGES"))
+	if err != nil || max <= 0 {
+		return nil
+	}
+
+	policy := os.Getenv("CHATBOT_THREAD_LIMIT_POLICY")
+	if policy != ThreadPolicyTruncate {
+		policy = ThreadPolicyReject
+	}
+
+	return &ThreadLimit{
+		MaxMessages: max,
+		Policy:      policy,
+	}
+}
+
+// limitThread makes room for a new prompt in a full thread. With the truncate
+// policy, the oldest messages are summarized by the rewrite model, which keeps
+// about half of the thread. Otherwise, or if summarizing fails, the prompt is
+// rejected.
+func (service *Service) limitThread(ctx context.Context, userId string, thread *datastore.Thread, usage *usageRecorder) error {
+	limit := service.ThreadLimit
+	if limit == nil {
+		return nil
+	}
+
+	turns := splitTurns(thread.Messages)
+	if len(turns) < limit.MaxMessages {
+		return nil
+	}
+
+	reject := rpcerror.New(codes.FailedPrecondition, rpcerror.ReasonThreadTooLong, "thread_id",
+		fmt.Sprintf("thread reached the maximum of %d messages, start a new thread", limit.MaxMessages),
+		"max_messages", strconv.Itoa(limit.MaxMessages))
+
+	// The summary and the new prompt are two messages
+	keep := limit.MaxMessages/2 - 1
+	if limit.Policy != ThreadPolicyTruncate || service.RewriteModel == "" || keep < 0 {
+		return reject
+	}
+
+	summary, err := service.summarizeTurns(ctx, userId, turns[:len(turns)-keep], usage)
+	if err != nil {
+		log.Printf("failed to summarize thread %s: %v", thread.Id, err)
+		return reject
+	}
+
+	thread.Messages = summary
+	for _, turn := range turns[len(turns)-keep:] {
+		thread.Messages = append(thread.Messages, turn...)
+	}
+
+	return nil
+}
+
+// summarizeTurns summarizes the prompts and completions of the turns with the
+// rewrite model and returns a turn with the summary as completion.
+func (service *Service) summarizeTurns(ctx context.Context, userId string, turns [][]*llm.Message, usage *usageRecorder) ([]*llm.Message, error) {
+	model, err := service.getModel(service.RewriteModel)
+	if err != nil {
+		return nil, err
+	}
+
+	var conversation strings.Builder
+	for _, turn := range turns {
+		conversation.WriteString("User: ")
+		conversation.WriteString(turn[0].Content)
+		conversation.WriteString("\n\nAssistant: ")
+		conversation.WriteString(turn[len(turn)-1].Content)
+		conversation.WriteString("\n\n")
+	}
+
+	response, err := model.Completion(ctx, &llm.CompletionRequest{
+		SystemPrompt: summaryPrompt,
+		Messages: []*llm.Message{{
+			Role:    llm.RoleUser,
+			Content: conversation.String(),
+		}},
+		Model:       service.RewriteModel,
+		MaxTokens:   summaryMaxTokens,
+		TopP:        llm.DefaultTopP,
+		Temperature: llm.DefaultTemperature,
+		UserId:      userId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	usage.add(&datastore.ModelUsage{
+		Id:           uuid.New(),
+		UserId:       userId,
+		Timestamp:    time.Now(),
+		ModelId:      response.Usage.Model,
+		InputTokens:  response.Usage.InputTokens,
+		OutputTokens: response.Usage.OutputTokens,
+	})
+
+	text := strings.TrimSpace(response.Messages[len(response.Messages)-1].Content)
+	if text == "" {
+		return nil, fmt.Errorf("empty summary")
+	}
+
+	return []*llm.Message{
+		{
+			Role:    llm.RoleUser,
+			Content: summaryRequest,
+		},
+		{
+			Role:    llm.RoleAssistant,
+			Content: text,
+			Id:      uuid.NewString(),
+			Model:   response.Usage.Model,
+		},
+	}, nil
+}
@@ -0,0 +1,90 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"github.com/pzierahn/chatbot_services/datastore"
+	"github.com/pzierahn/chatbot_services/llm"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+func testThread(turns int) *datastore.Thread {
+	thread := &datastore.Thread{}
+	for idx := 0; idx < turns; idx++ {
+		thread.Messages = append(thread.Messages,
+			&llm.Message{Role: llm.RoleUser, Content: fmt.Sprintf("prompt %d", idx)},
+			&llm.Message{Role: llm.RoleAssistant, Content: fmt.Sprintf("completion %d", idx)},
+		)
+	}
+
+	return thread
+}
+
+func TestLimitThreadReject(t *testing.T) {
+	service := &Service{
+		ThreadLimit: &ThreadLimit{MaxMessages: 4, Policy: ThreadPolicyReject},
+	}
+
+	err := service.limitThread(context.Background(), "user", testThread(3), &usageRecorder{})
+	if err != nil {
+		t.Fatalf("expected room for one more message, got %v", err)
+	}
+
+	err = service.limitThread(context.Background(), "user", testThread(4), &usageRecorder{})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition, got %v", err)
+	}
+
+	service.ThreadLimit = nil
+	err = service.limitThread(context.Background(), "user", testThread(100), &usageRecorder{})
+	if err != nil {
+		t.Fatalf("expected no limit, got %v", err)
+	}
+}
+
+func TestLimitThreadTruncate(t *testing.T) {
+	model := &testChat{}
+	service := &Service{
+		Models:       []llm.Chat{model},
+		ThreadLimit:  &ThreadLimit{MaxMessages: 6, Policy: ThreadPolicyTruncate},
+		RewriteModel: testModel,
+	}
+
+	thread := testThread(6)
+	usage := &usageRecorder{}
+
+	err := service.limitThread(context.Background(), "user", thread, usage)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	turns := splitTurns(thread.Messages)
+	if len(turns) != 3 {
+		t.Fatalf("expected the summary and two messages, got %d turns", len(turns))
+	}
+
+	if turns[0][0].Content != summaryRequest || turns[0][1].Content != "answer" {
+		t.Fatalf("expected the summary first, got %v", turns[0])
+	}
+
+	if turns[1][0].Content != "prompt 4" || turns[2][0].Content != "prompt 5" {
+		t.Fatalf("expected the latest messages to be kept, got %v", thread.Messages)
+	}
+
+	if err = validateMessages(thread.Messages); err != nil {
+		t.Fatal(err)
+	}
+
+	if model.calls != 1 || len(usage.list()) != 1 {
+		t.Fatalf("expected one summary completion with usage, got %d calls", model.calls)
+	}
+
+	// Without a summary model, truncating falls back to rejecting
+	service.RewriteModel = ""
+	err = service.limitThread(context.Background(), "user", testThread(6), &usageRecorder{})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition, got %v", err)
+	}
+}
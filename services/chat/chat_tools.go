@@ -8,6 +8,8 @@ import (
 	"github.com/pzierahn/chatbot_services/datastore"
 	"github.com/pzierahn/chatbot_services/llm"
 	"github.com/pzierahn/chatbot_services/search"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"go.mongodb.org/mongo-driver/mongo"
 	"log"
 	"sort"
 	"time"
@@ -16,17 +18,53 @@ import (
 type retrievalParameters struct {
 	prompt        string
 	userId        string
+	ownerId       string
 	collectionId  string
 	fragmentCount uint32
 	threshold     float32
+	description   string
+	language      string
+	names         *documentNames
+
+	// usage records the embeddings of the searches
+	usage *usageRecorder
+
+	// embeddingModel is the model the collection was indexed with
+	embeddingModel string
+
+	// hnswEf is the number of candidates of the vector search, 0 uses the default
+	hnswEf uint32
+
+	// grouping presents the sources grouped by document, if enabled
+	grouping search.Grouping
+
+	// rewrite is the rewriting of the search queries before the embedding
+	rewrite pb.QueryRewrite
+
+	// documentIds restricts the sources to these documents, if set
+	documentIds []string
 }
 
 type documentParameters struct {
+	// userId is the owner of the documents
 	userId string
+
+	// collectionId restricts the documents to a shared collection, if set
+	collectionId uuid.UUID
 }
 
 type Sources struct {
 	Items []*search.Result `json:"sources,omitempty" bson:"sources,omitempty"`
+
+	// Documents maps the document ids of the sources to their names
+	Documents map[string]string `json:"documents,omitempty" bson:"documents,omitempty"`
+
+	// Omitted is the number of less relevant sources that were dropped, because
+	// the result exceeded the size limit of tool results
+	Omitted int `json:"omitted,omitempty" bson:"omitted,omitempty"`
+
+	// Groups replace the items and documents if the sources are grouped by document
+	Groups []*SourceGroup `json:"groups,omitempty" bson:"groups,omitempty"`
 }
 
 const (
@@ -34,10 +72,19 @@ const (
 	toolAttachDocument = "attach_document"
 )
 
+const (
+	toolGetSourcesDescription = "Retrieves relevant knowledge and sources for a given query from a curated knowledge base. The search query should be clear, concise and containing key terms related to the desired information. The tool will return a list of document fragments with text and documentId, and the names of the documents. It should be used when the user asks about specific topics and requires precise information."
+)
+
 func (service *Service) getSourceTools(params retrievalParameters) *llm.ToolDefinition {
+	description := params.description
+	if description == "" {
+		description = toolGetSourcesDescription
+	}
+
 	return &llm.ToolDefinition{
 		Name:        toolGetSources,
-		Description: "Retrieves relevant knowledge and sources for a given query from a curated knowledge base. The search query should be clear, concise and containing key terms related to the desired information. The tool will return a list of document fragments with text and documentId. It should be used when the user asks about specific topics and requires precise information.",
+		Description: description,
 		Parameters: llm.ToolParameters{
 			Type: "object",
 			Properties: map[string]llm.ParametersProperties{
@@ -45,6 +92,12 @@ func (service *Service) getSourceTools(params retrievalParameters) *llm.ToolDefi
 					Type:        "string",
 					Description: "A query or statement for which the information is requested.",
 				},
+				"sub_queries": {
+					Type: "string",
+					Description: "Optional sub-queries that are searched in addition to the query, e.g. one per compared " +
+						"subject of a comparison. One sub-query per line, optionally prefixed with a weight, " +
+						"e.g. \"2: first subject\". Sources of sub-queries with higher weights rank higher.",
+				},
 			},
 			Required: []string{
 				"query",
@@ -58,37 +111,39 @@ func (service *Service) getSourceTools(params retrievalParameters) *llm.ToolDefi
 
 			log.Printf("get_sources: \"%v\"", query)
 
-			response, err := service.Search.Search(ctx, search.Query{
-				UserId:       params.userId,
-				CollectionId: params.collectionId,
-				Query:        query,
-				Limit:        params.fragmentCount,
-				Threshold:    params.threshold,
-			})
+			var weighted []search.WeightedQuery
+			if text, ok := parameters["sub_queries"].(string); ok {
+				weighted = weightedQueries(query, text)
+			}
+
+			var results *search.Results
+			var err error
+			if len(weighted) > 1 {
+				results, err = service.searchQuery(ctx, params, query, weighted, params.grouping)
+			} else {
+				results, err = service.searchSources(ctx, params, query)
+			}
 			if err != nil {
 				return "", err
 			}
 
-			_ = service.Database.InsertModelUsage(ctx, &datastore.ModelUsage{
-				Id:          uuid.New(),
-				UserId:      params.userId,
-				Timestamp:   time.Now(),
-				ModelId:     response.Usage.ModelId,
-				InputTokens: response.Usage.Tokens,
-			})
+			service.logSources(params, results)
 
-			sources := response.Results
+			if len(results.Groups) > 0 {
+				return service.groupedSources(ctx, params, results.Groups)
+			}
 
-			// Group by document and sort by position
-			sort.Slice(sources, func(i, j int) bool {
-				if sources[i].DocumentId != sources[j].DocumentId {
-					return sources[i].DocumentId < sources[j].DocumentId
-				}
-				return sources[i].Position < sources[j].Position
-			})
+			sources := results.Results
+			sortSources(sources)
+
+			documentIds := make([]string, len(sources))
+			for idx, source := range sources {
+				documentIds[idx] = source.DocumentId
+			}
 
 			byt, err := json.Marshal(Sources{
-				Items: sources,
+				Items:     sanitizeSources(sources),
+				Documents: sanitizeNames(params.names.lookup(ctx, documentIds...)),
 			})
 			if err != nil {
 				return "", err
@@ -96,26 +151,176 @@ func (service *Service) getSourceTools(params retrievalParameters) *llm.ToolDefi
 
 			return string(byt), nil
 		},
+		Shrink: shrinkSources,
+	}
+}
+
+// logSources records the documents of the sources in the access log of the collection.
+func (service *Service) logSources(params retrievalParameters, results *search.Results) {
+	collectionId, err := uuid.Parse(params.collectionId)
+	if err != nil {
+		return
+	}
+
+	seen := make(map[string]bool)
+	var docIds []uuid.UUID
+	add := func(documentId string) {
+		if seen[documentId] {
+			return
+		}
+		seen[documentId] = true
+
+		if docId, err := uuid.Parse(documentId); err == nil {
+			docIds = append(docIds, docId)
+		}
+	}
+
+	for _, group := range results.Groups {
+		add(group.DocumentId)
 	}
+	for _, result := range results.Results {
+		add(result.DocumentId)
+	}
+
+	service.AccessLog.Log(datastore.AccessLogEntry{
+		UserId:       params.userId,
+		OwnerId:      params.ownerId,
+		CollectionId: collectionId,
+		DocumentIds:  docIds,
+		Action:       datastore.AccessGetSources,
+	})
 }
 
-func (service *Service) getDocumentById(ctx context.Context, userId, docId string) (string, error) {
+// sortSources groups the sources by document and sorts them by position.
+func sortSources(sources []*search.Result) {
+	sort.Slice(sources, func(i, j int) bool {
+		if sources[i].DocumentId != sources[j].DocumentId {
+			return sources[i].DocumentId < sources[j].DocumentId
+		}
+		return sources[i].Position < sources[j].Position
+	})
+}
+
+// shrinkSources keeps the sources with the highest scores that fit into maxBytes
+// and records how many were omitted.
+func shrinkSources(output string, maxBytes int) (string, error) {
+	var sources Sources
+	err := json.Unmarshal([]byte(output), &sources)
+	if err != nil {
+		return "", err
+	}
+
+	if len(sources.Groups) > 0 {
+		return shrinkGroups(sources, maxBytes)
+	}
+
+	ranked := append([]*search.Result(nil), sources.Items...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Score > ranked[j].Score
+	})
+
+	for keep := len(ranked) - 1; keep >= 0; keep-- {
+		items := append([]*search.Result(nil), ranked[:keep]...)
+		sortSources(items)
+
+		// Only the names of the kept documents are needed
+		names := make(map[string]string)
+		for _, item := range items {
+			if name, ok := sources.Documents[item.DocumentId]; ok {
+				names[item.DocumentId] = name
+			}
+		}
+
+		byt, err := json.Marshal(Sources{
+			Items:     items,
+			Documents: names,
+			Omitted:   sources.Omitted + len(ranked) - keep,
+		})
+		if err != nil {
+			return "", err
+		}
+
+		if len(byt) <= maxBytes {
+			return string(byt), nil
+		}
+	}
+
+	return "", errors.New("sources don't fit into the limit")
+}
+
+// searchSources searches the collection for the query, rewritten if requested,
+// and records the usage of the embeddings. The sub-queries of a decomposed query
+// are fused like weighted queries of equal weight.
+func (service *Service) searchSources(ctx context.Context, params retrievalParameters, query string) (*search.Results, error) {
+	queries := service.rewriteQuery(ctx, params, query)
+
+	texts := make([]search.WeightedQuery, len(queries))
+	for idx, text := range queries {
+		texts[idx] = search.WeightedQuery{Query: text, Weight: 1}
+	}
+
+	return service.searchQuery(ctx, params, query, texts, params.grouping)
+}
+
+// searchQuery searches the texts and records the usage of the embeddings. A single
+// text is searched directly, several are fused by their weights. The language is
+// detected from the original query.
+func (service *Service) searchQuery(ctx context.Context, params retrievalParameters, query string, texts []search.WeightedQuery, grouping search.Grouping) (*search.Results, error) {
+	var weighted []search.WeightedQuery
+	if len(texts) > 1 {
+		weighted = texts
+	}
+
+	response, err := service.Search.Search(ctx, search.Query{
+		UserId:         params.ownerId,
+		CollectionId:   params.collectionId,
+		Query:          texts[0].Query,
+		Queries:        weighted,
+		Limit:          params.fragmentCount,
+		Threshold:      params.threshold,
+		Language:       search.QueryLanguage(params.language, query),
+		EmbeddingModel: params.embeddingModel,
+		HnswEf:         params.hnswEf,
+		Grouping:       grouping,
+		DocumentIds:    params.documentIds,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	params.usage.add(&datastore.ModelUsage{
+		Id:          uuid.New(),
+		UserId:      params.userId,
+		Timestamp:   time.Now(),
+		ModelId:     response.Usage.ModelId,
+		InputTokens: response.Usage.Tokens,
+	})
+
+	return response, nil
+}
+
+func (service *Service) getDocumentById(ctx context.Context, params documentParameters, docId string) (string, error) {
 	documentId, err := uuid.Parse(docId)
 	if err != nil {
 		return "", err
 	}
 
-	document, err := service.Database.GetDocument(ctx, userId, documentId)
+	document, err := service.Database.GetDocument(ctx, params.userId, documentId)
 	if err != nil {
 		return "", err
 	}
 
+	// Other documents of the owner are not shared
+	if params.collectionId != uuid.Nil && document.CollectionId != params.collectionId {
+		return "", mongo.ErrNoDocuments
+	}
+
 	sources := make([]*search.Result, len(document.Content))
 
 	for idx, fragment := range document.Content {
 		sources[idx] = &search.Result{
 			Id:         fragment.Id.String(),
-			Text:       fragment.Text,
+			Text:       escapeCitations(fragment.Text),
 			DocumentId: docId,
 			Position:   fragment.Position,
 		}
@@ -123,6 +328,9 @@ func (service *Service) getDocumentById(ctx context.Context, userId, docId strin
 
 	response, err := json.Marshal(Sources{
 		Items: sources,
+		Documents: map[string]string{
+			docId: escapeCitations(document.Name),
+		},
 	})
 	if err != nil {
 		return "", err
@@ -155,7 +363,7 @@ func (service *Service) getAttachDocumentTool(params documentParameters) *llm.To
 
 			log.Printf("attach_document: \"%v\"", documentId)
 
-			return service.getDocumentById(ctx, params.userId, documentId)
+			return service.getDocumentById(ctx, params, documentId)
 		},
 	}
 }
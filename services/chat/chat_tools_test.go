@@ -0,0 +1,135 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
+	"github.com/pzierahn/chatbot_services/search"
+	"github.com/pzierahn/chatbot_services/services/documents"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestShrinkSources(t *testing.T) {
+	text := strings.Repeat("x", 100)
+	output, err := json.Marshal(Sources{
+		Items: []*search.Result{
+			{Id: "a1", DocumentId: "a", Position: 1, Score: 0.9, Text: text},
+			{Id: "a2", DocumentId: "a", Position: 2, Score: 0.5, Text: text},
+			{Id: "b1", DocumentId: "b", Position: 1, Score: 0.7, Text: text},
+		},
+		Documents: map[string]string{"a": "A", "b": "B"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	shrunk, err := shrinkSources(string(output), 400)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(shrunk) > 400 {
+		t.Fatalf("result of %d bytes exceeds the limit", len(shrunk))
+	}
+
+	var sources Sources
+	if err := json.Unmarshal([]byte(shrunk), &sources); err != nil {
+		t.Fatal(err)
+	}
+
+	// The source with the lowest score is dropped, the others stay in document order
+	if len(sources.Items) != 2 || sources.Items[0].Id != "a1" || sources.Items[1].Id != "b1" {
+		t.Errorf("unexpected sources: %+v", sources.Items)
+	}
+	if sources.Omitted != 1 {
+		t.Errorf("expected 1 omitted source, got %d", sources.Omitted)
+	}
+
+	if _, err := shrinkSources(string(output), 10); err == nil {
+		t.Error("expected an error if no source fits")
+	}
+}
+
+func TestShrinkGroupedSources(t *testing.T) {
+	text := strings.Repeat("x", 100)
+	output, err := json.Marshal(Sources{
+		Groups: []*SourceGroup{
+			{DocumentId: "a", Score: 0.9, Passages: []*search.Passage{{Id: "a1", Ids: []string{"a1", "a2"}, Text: text}}},
+			{DocumentId: "b", Score: 0.7, Sources: []*search.Result{{Id: "b1", DocumentId: "b", Text: text}, {Id: "b3", DocumentId: "b", Text: text}}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	shrunk, err := shrinkSources(string(output), 300)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var sources Sources
+	if err := json.Unmarshal([]byte(shrunk), &sources); err != nil {
+		t.Fatal(err)
+	}
+
+	// The group with the lowest score is dropped as a whole
+	if len(sources.Groups) != 1 || sources.Groups[0].DocumentId != "a" || sources.Omitted != 2 {
+		t.Fatalf("unexpected groups %+v, omitted %d", sources.Groups, sources.Omitted)
+	}
+
+	// Passages are cited with the id of their first source
+	results := sources.results()
+	if len(results) != 1 || results[0].Id != "a1" || results[0].DocumentId != "a" || results[0].Text != text {
+		t.Errorf("unexpected results %+v", results)
+	}
+}
+
+func TestLogSources(t *testing.T) {
+	var logged []datastore.AccessLogEntry
+	store := func(ctx context.Context, entries []datastore.AccessLogEntry) error {
+		logged = append(logged, entries...)
+		return nil
+	}
+
+	service := &Service{AccessLog: documents.NewAccessLogger(store, 10, time.Hour)}
+
+	collectionId, docA, docB := uuid.New(), uuid.New(), uuid.New()
+	params := retrievalParameters{
+		userId:       "grantee",
+		ownerId:      "owner",
+		collectionId: collectionId.String(),
+	}
+
+	service.logSources(params, &search.Results{
+		Results: []*search.Result{
+			{Id: "a1", DocumentId: docA.String()},
+			{Id: "a2", DocumentId: docA.String()},
+			{Id: "b1", DocumentId: docB.String()},
+		},
+	})
+	service.logSources(params, &search.Results{
+		Groups: []*search.Group{{DocumentId: docB.String()}},
+	})
+
+	// Searches without sources are not logged
+	service.logSources(params, &search.Results{})
+	service.AccessLog.Close()
+
+	if len(logged) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(logged))
+	}
+
+	entry := logged[0]
+	if entry.UserId != "grantee" || entry.OwnerId != "owner" || entry.CollectionId != collectionId ||
+		entry.Action != datastore.AccessGetSources {
+		t.Errorf("unexpected entry %+v", entry)
+	}
+	if len(entry.DocumentIds) != 2 || entry.DocumentIds[0] != docA || entry.DocumentIds[1] != docB {
+		t.Errorf("expected the distinct documents of the sources, got %v", entry.DocumentIds)
+	}
+	if ids := logged[1].DocumentIds; len(ids) != 1 || ids[0] != docB {
+		t.Errorf("expected the documents of the groups, got %v", ids)
+	}
+}
@@ -0,0 +1,102 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/llm"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"google.golang.org/grpc/codes"
+)
+
+// GetTranscript returns all messages of a turn including the intermediate tool
+// calls and responses, so that the answer of the assistant can be audited.
+func (service *Service) GetTranscript(ctx context.Context, req *pb.TranscriptRequest) (*pb.Transcript, error) {
+	userId, err := service.Auth.Verify(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	threadId, err := uuid.Parse(req.ThreadId)
+	if err != nil {
+		return nil, rpcerror.InvalidId("thread_id", req.ThreadId)
+	}
+
+	thread, err := service.Database.GetThread(ctx, userId, threadId)
+	if err != nil {
+		return nil, err
+	}
+
+	turns := splitTurns(thread.Messages)
+	if req.Index >= uint32(len(turns)) {
+		return nil, rpcerror.New(codes.InvalidArgument, rpcerror.ReasonInvalidValue, "index",
+			fmt.Sprintf("invalid message index: %d", req.Index))
+	}
+
+	return transcriptToProto(turns[req.Index], req.RedactSources), nil
+}
+
+// transcriptToProto converts the messages of a turn. With redact set, the text of
+// the sources returned by source tools is removed while ids and scores are kept.
+func transcriptToProto(messages []*llm.Message, redact bool) *pb.Transcript {
+	transcript := &pb.Transcript{}
+	isSourceCall := make(map[string]bool)
+
+	for _, message := range messages {
+		entry := &pb.TranscriptEntry{
+			Role:    message.Role,
+			Content: message.Content,
+		}
+
+		for _, call := range message.ToolCalls {
+			if call.Name == toolGetSources || call.Name == toolAttachDocument {
+				isSourceCall[call.CallID] = true
+			}
+
+			entry.ToolCalls = append(entry.ToolCalls, &pb.ToolCall{
+				Id:        call.CallID,
+				Name:      call.Name,
+				Arguments: call.Arguments,
+			})
+		}
+
+		for _, response := range message.ToolResponses {
+			content := response.Content
+			if redact && isSourceCall[response.CallID] {
+				content = redactSources(content)
+			}
+
+			entry.ToolResponses = append(entry.ToolResponses, &pb.ToolResponse{
+				Id:      response.CallID,
+				Content: content,
+			})
+		}
+
+		transcript.Entries = append(transcript.Entries, entry)
+	}
+
+	return transcript
+}
+
+// redactSources removes the text of the fragments from a source tool response.
+// Responses that cannot be parsed, such as error messages, are returned unchanged.
+func redactSources(content string) string {
+	var sources Sources
+	err := json.Unmarshal([]byte(content), &sources)
+	if err != nil {
+		return content
+	}
+
+	for _, item := range sources.Items {
+		item.Text = ""
+	}
+
+	data, err := json.Marshal(sources)
+	if err != nil {
+		return content
+	}
+
+	return string(data)
+}
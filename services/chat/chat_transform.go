@@ -5,7 +5,9 @@ import (
 	"errors"
 	"github.com/google/uuid"
 	"github.com/pzierahn/chatbot_services/llm"
+	"github.com/pzierahn/chatbot_services/search"
 	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"log"
 	"sort"
 )
@@ -15,7 +17,7 @@ func getSources(messages []*llm.Message) []*pb.Source {
 		return nil
 	}
 
-	fragments := make(map[uuid.UUID][]*pb.Source_Fragment)
+	var items []*search.Result
 
 	for idx := len(messages) - 2; idx > 0; idx-- {
 		message := messages[idx]
@@ -40,24 +42,34 @@ func getSources(messages []*llm.Message) []*pb.Source {
 					continue
 				}
 
-				for _, item := range source.Items {
-					docId, err := uuid.Parse(item.DocumentId)
-					if err != nil {
-						log.Printf("error: %v", err)
-						continue
-					}
-
-					fragments[docId] = append(fragments[docId], &pb.Source_Fragment{
-						Id:       item.Id,
-						Content:  item.Text,
-						Position: item.Position,
-						Score:    item.Score,
-					})
-				}
+				items = append(items, source.results()...)
 			}
 		}
 	}
 
+	return resultsToSources(items)
+}
+
+// resultsToSources groups search results by document and orders the fragments of
+// each document by position.
+func resultsToSources(items []*search.Result) []*pb.Source {
+	fragments := make(map[uuid.UUID][]*pb.Source_Fragment)
+
+	for _, item := range items {
+		docId, err := uuid.Parse(item.DocumentId)
+		if err != nil {
+			log.Printf("error: %v", err)
+			continue
+		}
+
+		fragments[docId] = append(fragments[docId], &pb.Source_Fragment{
+			Id:       item.Id,
+			Content:  item.Text,
+			Position: item.Position,
+			Score:    item.Score,
+		})
+	}
+
 	sources := make([]*pb.Source, 0)
 	for docId, parts := range fragments {
 		sort.Slice(parts, func(i, j int) bool {
@@ -153,6 +165,10 @@ func messagesToProto(messages []*llm.Message) ([]*pb.Message, error) {
 		}
 
 		protoMessage.Completion = assistant.Content
+		protoMessage.Id = assistant.Id
+		protoMessage.Model = assistant.Model
+		protoMessage.Metadata = messageMetadata(assistant.Metadata)
+		protoMessage.Alternatives = alternativesToProto(assistant.Alternatives)
 		protoMessages = append(protoMessages, protoMessage)
 
 		idx += 2
@@ -160,3 +176,34 @@ func messagesToProto(messages []*llm.Message) ([]*pb.Message, error) {
 
 	return protoMessages, nil
 }
+
+// messageMetadata converts the metadata of an assistant message, nil for messages
+// stored without metadata.
+func messageMetadata(metadata *llm.MessageMetadata) *pb.MessageMetadata {
+	if metadata == nil {
+		return nil
+	}
+
+	return &pb.MessageMetadata{
+		Provider:     metadata.Provider,
+		LatencyMs:    uint32(metadata.Latency.Milliseconds()),
+		InputTokens:  metadata.InputTokens,
+		OutputTokens: metadata.OutputTokens,
+		CreatedAt:    timestamppb.New(metadata.CreatedAt),
+	}
+}
+
+// alternativesToProto converts the earlier answers of an assistant message.
+func alternativesToProto(alternatives []*llm.Message) []*pb.Message {
+	var messages []*pb.Message
+	for _, alternative := range alternatives {
+		messages = append(messages, &pb.Message{
+			Id:         alternative.Id,
+			Completion: alternative.Content,
+			Model:      alternative.Model,
+			Metadata:   messageMetadata(alternative.Metadata),
+		})
+	}
+
+	return messages
+}
@@ -0,0 +1,48 @@
+package chat
+
+import (
+	"github.com/pzierahn/chatbot_services/llm"
+	"testing"
+	"time"
+)
+
+func TestMessagesToProtoMetadata(t *testing.T) {
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	messages := []*llm.Message{
+		{Role: llm.RoleUser, Content: "first"},
+		{Role: llm.RoleAssistant, Content: "old answer", Model: "openai.gpt-4o"},
+		{Role: llm.RoleUser, Content: "second"},
+		{
+			Role:    llm.RoleAssistant,
+			Content: "answer",
+			Model:   "anthropic.claude-3-haiku",
+			Metadata: &llm.MessageMetadata{
+				Provider:     llm.ModelProvider("anthropic.claude-3-haiku"),
+				Latency:      1500 * time.Millisecond,
+				InputTokens:  120,
+				OutputTokens: 30,
+				CreatedAt:    created,
+			},
+		},
+	}
+
+	protoMessages, err := messagesToProto(messages)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if protoMessages[0].Metadata != nil {
+		t.Fatalf("expected no metadata for old messages, got %v", protoMessages[0].Metadata)
+	}
+
+	metadata := protoMessages[1].Metadata
+	if metadata.GetProvider() != "anthropic" || metadata.GetLatencyMs() != 1500 ||
+		metadata.GetInputTokens() != 120 || metadata.GetOutputTokens() != 30 ||
+		!metadata.GetCreatedAt().AsTime().Equal(created) {
+		t.Fatalf("unexpected metadata: %v", metadata)
+	}
+
+	if provider := llm.ModelProvider("gemini-1.5-pro"); provider != "" {
+		t.Fatalf("expected no provider without prefix, got %q", provider)
+	}
+}
@@ -0,0 +1,81 @@
+package chat
+
+import (
+	"errors"
+	"fmt"
+	"github.com/pzierahn/chatbot_services/llm"
+)
+
+// isPrompt returns true if the message is a prompt written by the user and not a tool response.
+func isPrompt(message *llm.Message) bool {
+	return message.Role == llm.RoleUser && len(message.ToolResponses) == 0
+}
+
+// splitTurns groups the messages of a thread into turns. A turn starts with a user
+// prompt and contains all following tool calls, tool responses and the completion.
+func splitTurns(messages []*llm.Message) [][]*llm.Message {
+	var turns [][]*llm.Message
+
+	for _, message := range messages {
+		if isPrompt(message) || len(turns) == 0 {
+			turns = append(turns, nil)
+		}
+
+		turns[len(turns)-1] = append(turns[len(turns)-1], message)
+	}
+
+	return turns
+}
+
+// validateMessages checks that every tool call is answered by a tool response in
+// the following message, that no tool response is orphaned and that every turn
+// ends with a completion. Providers reject threads that violate these rules.
+func validateMessages(messages []*llm.Message) error {
+	for idx, message := range messages {
+		if idx == 0 && !isPrompt(message) {
+			return errors.New("thread does not start with a prompt")
+		}
+
+		if message.Role == llm.RoleAssistant && len(message.ToolCalls) > 0 {
+			if idx+1 >= len(messages) {
+				return fmt.Errorf("message %d: tool calls without responses", idx)
+			}
+
+			responses := make(map[string]bool)
+			for _, response := range messages[idx+1].ToolResponses {
+				responses[response.CallID] = true
+			}
+
+			for _, call := range message.ToolCalls {
+				if !responses[call.CallID] {
+					return fmt.Errorf("message %d: tool call %s without response", idx, call.CallID)
+				}
+			}
+		}
+
+		if len(message.ToolResponses) > 0 {
+			if idx == 0 || messages[idx-1].Role != llm.RoleAssistant {
+				return fmt.Errorf("message %d: tool responses without calls", idx)
+			}
+
+			calls := make(map[string]bool)
+			for _, call := range messages[idx-1].ToolCalls {
+				calls[call.CallID] = true
+			}
+
+			for _, response := range message.ToolResponses {
+				if !calls[response.CallID] {
+					return fmt.Errorf("message %d: orphaned tool response %s", idx, response.CallID)
+				}
+			}
+		}
+
+		next := idx + 1
+		if (next == len(messages) || isPrompt(messages[next])) &&
+			(message.Role != llm.RoleAssistant || len(message.ToolCalls) > 0) {
+			return fmt.Errorf("message %d: turn does not end with a completion", idx)
+		}
+	}
+
+	return nil
+}
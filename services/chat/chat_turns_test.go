@@ -0,0 +1,60 @@
+package chat
+
+import (
+	"github.com/pzierahn/chatbot_services/llm"
+	"testing"
+)
+
+// toolTurn is a turn with a tool call, its response and the completion.
+func toolTurn(prompt, callId string) []*llm.Message {
+	return []*llm.Message{
+		{Role: llm.RoleUser, Content: prompt},
+		{Role: llm.RoleAssistant, ToolCalls: []llm.ToolCall{{CallID: callId, Name: toolGetSources}}},
+		{Role: llm.RoleUser, ToolResponses: []llm.ToolResponse{{CallID: callId, Content: "{}"}}},
+		{Role: llm.RoleAssistant, Content: "answer to " + prompt},
+	}
+}
+
+func TestSplitTurns(t *testing.T) {
+	messages := append(toolTurn("first", "call-1"),
+		&llm.Message{Role: llm.RoleUser, Content: "second"},
+		&llm.Message{Role: llm.RoleAssistant, Content: "answer to second"},
+	)
+
+	turns := splitTurns(messages)
+	if len(turns) != 2 || len(turns[0]) != 4 || len(turns[1]) != 2 {
+		t.Fatalf("expected turns of 4 and 2 messages, got %d turns", len(turns))
+	}
+
+	if turns[1][0].Content != "second" {
+		t.Fatalf("expected the second turn to start with its prompt, got %q", turns[1][0].Content)
+	}
+}
+
+func TestValidateMessages(t *testing.T) {
+	valid := append(toolTurn("first", "call-1"), toolTurn("second", "call-2")...)
+	if err := validateMessages(valid); err != nil {
+		t.Fatalf("expected a valid thread, got %v", err)
+	}
+
+	turn := toolTurn("first", "call-1")
+	invalid := map[string][]*llm.Message{
+		"starts with a completion":  {turn[3]},
+		"starts with a response":    turn[2:],
+		"call without response":     {turn[0], turn[1], turn[3]},
+		"response without call":     {turn[0], turn[2], turn[3]},
+		"ends with a tool call":     turn[:2],
+		"ends without a completion": {turn[0]},
+		"orphaned response": {
+			turn[0], turn[1],
+			{Role: llm.RoleUser, ToolResponses: []llm.ToolResponse{{CallID: "call-1"}, {CallID: "other"}}},
+			turn[3],
+		},
+	}
+
+	for name, messages := range invalid {
+		if err := validateMessages(messages); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
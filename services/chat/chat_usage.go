@@ -0,0 +1,84 @@
+package chat
+
+import (
+	"context"
+	"github.com/pzierahn/chatbot_services/datastore"
+	"github.com/pzierahn/chatbot_services/llm"
+	"sync"
+)
+
+// usageRecorder collects the model usage of a request, e.g. the embeddings of tool
+// calls, so that it is stored together with the thread.
+type usageRecorder struct {
+	mu     sync.Mutex
+	usages []*datastore.ModelUsage
+}
+
+// add records a usage. Tools can be called concurrently.
+func (recorder *usageRecorder) add(usage *datastore.ModelUsage) {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+
+	recorder.usages = append(recorder.usages, usage)
+}
+
+// list returns the recorded usages.
+func (recorder *usageRecorder) list() []*datastore.ModelUsage {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+
+	return append([]*datastore.ModelUsage(nil), recorder.usages...)
+}
+
+// recordUsages stores the usages without the thread, e.g. of a failed completion
+// whose embeddings are already paid for. Failed inserts are retried in the background.
+func (service *Service) recordUsages(ctx context.Context, usages []*datastore.ModelUsage) {
+	for _, usage := range usages {
+		service.Database.RecordModelUsage(ctx, usage)
+	}
+}
+
+// storeTurn stores the thread, removes the consumed pending messages and stores the
+// usage of the request in one transaction, so that a crash never records usage
+// without the messages or vice versa. Standalone servers don't support transactions,
+// there the writes are made one after another. Consumed is nil if the prompt didn't
+// consume the pending messages. The image data of the prompts is not stored, it
+// would quickly exceed the document size.
+func (service *Service) storeTurn(ctx context.Context, thread *datastore.Thread, consumed []string, usages []*datastore.ModelUsage) error {
+	stored := *thread
+	stored.Messages = llm.WithoutImageData(thread.Messages)
+
+	store := func(ctx context.Context) error {
+		err := service.Database.StoreThread(ctx, &stored)
+		if err != nil {
+			return err
+		}
+
+		if consumed != nil {
+			err = service.Database.RemovePending(ctx, thread.Id, consumed...)
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, usage := range usages {
+			err = service.Database.InsertModelUsage(ctx, usage)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	transactions, err := service.Database.SupportsTransactions(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !transactions {
+		return store(ctx)
+	}
+
+	return service.Database.WithTransaction(ctx, store)
+}
@@ -0,0 +1,85 @@
+package chat
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
+	"github.com/pzierahn/chatbot_services/llm"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestStoreTurn(t *testing.T) {
+	uri := os.Getenv("CHATBOT_MONGODB_URI")
+	if uri == "" {
+		t.Skip("CHATBOT_MONGODB_URI not set")
+	}
+
+	ctx := context.Background()
+
+	db, err := datastore.NewFrom(ctx, uri, datastore.PoolConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	userId := "test-" + uuid.NewString()
+	thread := &datastore.Thread{
+		Id:           uuid.New(),
+		UserId:       userId,
+		CollectionId: uuid.New(),
+		Timestamp:    time.Now(),
+	}
+
+	err = db.StoreThread(ctx, thread)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = db.DeleteThread(ctx, userId, thread.Id) }()
+
+	err = db.AppendPending(ctx, userId, thread.Id, []*llm.Message{
+		{Id: "consumed", Role: llm.RoleUser, Content: "instruction"},
+		{Id: "later", Role: llm.RoleUser, Content: "appended meanwhile"},
+	}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	thread.Messages = []*llm.Message{
+		{Role: llm.RoleUser, Content: "question"},
+		{Role: llm.RoleAssistant, Content: "answer"},
+	}
+
+	service := &Service{Database: db}
+	err = service.storeTurn(ctx, thread, []string{"consumed"}, []*datastore.ModelUsage{{
+		Id:           uuid.New(),
+		UserId:       userId,
+		Timestamp:    time.Now(),
+		ModelId:      testModel,
+		InputTokens:  10,
+		OutputTokens: 5,
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stored, err := db.GetThread(ctx, userId, thread.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stored.Messages) != 2 {
+		t.Fatalf("expected the messages of the turn, got %d", len(stored.Messages))
+	}
+	if len(stored.Pending) != 1 || stored.Pending[0].Id != "later" {
+		t.Fatalf("expected only the consumed message to be removed, got %v", stored.Pending)
+	}
+
+	usages, err := db.GetModelUsages(ctx, userId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(usages) != 1 || usages[0].InputTokens != 10 {
+		t.Fatalf("expected the usage of the turn, got %v", usages)
+	}
+}
@@ -14,4 +14,10 @@ type Service struct {
 	Database *datastore.Service
 	Storage  *storage.BucketHandle
 	Search   search.Index
+
+	// CheckModel validates the default models of collections, nil accepts all models
+	CheckModel func(userId, modelId string) error
+
+	// stats caches the statistics of large collections
+	stats statsCache
 }
@@ -0,0 +1,44 @@
+package collections
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Archive hides a collection from the default list and blocks indexing and chats.
+// Documents and threads are kept.
+func (server *Service) Archive(ctx context.Context, collection *pb.Collection) (*emptypb.Empty, error) {
+	return server.setArchived(ctx, collection.Id, true)
+}
+
+// Unarchive restores an archived collection.
+func (server *Service) Unarchive(ctx context.Context, collection *pb.Collection) (*emptypb.Empty, error) {
+	return server.setArchived(ctx, collection.Id, false)
+}
+
+func (server *Service) setArchived(ctx context.Context, id string, archived bool) (*emptypb.Empty, error) {
+	userId, err := server.Auth.Verify(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	collectionId, err := uuid.Parse(id)
+	if err != nil {
+		return nil, rpcerror.InvalidId("id", id)
+	}
+
+	err = server.Database.SetCollectionArchived(ctx, userId, collectionId, archived)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, rpcerror.NotFound("collection", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,89 @@
+package collections
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"os"
+	"testing"
+)
+
+type testVerifier struct {
+	userId string
+}
+
+func (verifier *testVerifier) Verify(context.Context) (string, error) {
+	return verifier.userId, nil
+}
+
+func (verifier *testVerifier) VerifyFunding(context.Context) (string, error) {
+	return verifier.userId, nil
+}
+
+func TestArchiveInvalidId(t *testing.T) {
+	service := &Service{Auth: &testVerifier{userId: "test"}}
+
+	_, err := service.Archive(context.Background(), &pb.Collection{Id: "not-a-uuid"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestUpdateArchived(t *testing.T) {
+	uri := os.Getenv("CHATBOT_MONGODB_URI")
+	if uri == "" {
+		t.Skip("CHATBOT_MONGODB_URI not set")
+	}
+
+	ctx := context.Background()
+
+	db, err := datastore.NewFrom(ctx, uri, datastore.PoolConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	userId := "test-" + uuid.NewString()
+	collection := &datastore.Collection{
+		Id:     uuid.New(),
+		UserId: userId,
+		Name:   "archived",
+	}
+
+	err = db.InsertCollection(ctx, collection)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = db.DeleteCollection(ctx, userId, collection.Id) }()
+
+	service := &Service{
+		Auth:     &testVerifier{userId: userId},
+		Database: db,
+	}
+
+	_, err = service.Archive(ctx, &pb.Collection{Id: collection.Id.String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = service.Update(ctx, &pb.Collection{Id: collection.Id.String(), Name: "renamed"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition for archived collections, got %v", err)
+	}
+
+	stored, err := db.GetCollection(ctx, userId, collection.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Name != "archived" {
+		t.Fatalf("expected the archived collection to be unchanged, got %q", stored.Name)
+	}
+
+	_, err = service.Archive(ctx, &pb.Collection{Id: uuid.NewString()})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound for unknown collections, got %v", err)
+	}
+}
@@ -3,14 +3,35 @@ package collections
 import (
 	"cloud.google.com/go/storage"
 	"context"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
 	"github.com/pzierahn/chatbot_services/datastore"
 	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"log"
+	"time"
 )
 
+// checkDefaultModel returns InvalidArgument if the default model of a collection
+// doesn't exist or the owner may not use it.
+func (server *Service) checkDefaultModel(userId, modelId string) error {
+	if modelId == "" || server.CheckModel == nil {
+		return nil
+	}
+
+	err := server.CheckModel(userId, modelId)
+	if err != nil {
+		return rpcerror.Invalid("default_model", errors.New(status.Convert(err).Message()))
+	}
+
+	return nil
+}
+
 func (server *Service) Insert(ctx context.Context, collection *pb.Collection) (*emptypb.Empty, error) {
 	log.Printf("Insert: %v", collection)
 
@@ -19,10 +40,18 @@ func (server *Service) Insert(ctx context.Context, collection *pb.Collection) (*
 		return nil, err
 	}
 
+	err = server.checkDefaultModel(userId, collection.DefaultModel)
+	if err != nil {
+		return nil, err
+	}
+
 	err = server.Database.InsertCollection(ctx, &datastore.Collection{
-		Id:     uuid.New(),
-		UserId: userId,
-		Name:   collection.Name,
+		Id:             uuid.New(),
+		UserId:         userId,
+		Name:           collection.Name,
+		EmbeddingModel: server.Search.EmbeddingModel(),
+		CreatedAt:      time.Now(),
+		DefaultModel:   collection.DefaultModel,
 	})
 	if err != nil {
 		log.Printf("failed to store collection: %s", err)
@@ -32,6 +61,25 @@ func (server *Service) Insert(ctx context.Context, collection *pb.Collection) (*
 	return &emptypb.Empty{}, nil
 }
 
+// checkNotArchived returns NotFound if the user has no such collection and
+// FailedPrecondition if it is archived.
+func (server *Service) checkNotArchived(ctx context.Context, userId string, collectionId uuid.UUID) error {
+	stored, err := server.Database.GetCollection(ctx, userId, collectionId)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return rpcerror.NotFound("collection", collectionId.String())
+	}
+	if err != nil {
+		return err
+	}
+
+	if stored.Archived {
+		return rpcerror.New(codes.FailedPrecondition, rpcerror.ReasonArchived, "id",
+			fmt.Sprintf("collection is archived: %s", collectionId))
+	}
+
+	return nil
+}
+
 func (server *Service) Update(ctx context.Context, collection *pb.Collection) (*emptypb.Empty, error) {
 	log.Printf("Update: %v", collection)
 
@@ -40,20 +88,39 @@ func (server *Service) Update(ctx context.Context, collection *pb.Collection) (*
 		return nil, err
 	}
 
+	err = server.checkDefaultModel(userId, collection.DefaultModel)
+	if err != nil {
+		return nil, err
+	}
+
+	// New collections are indexed with the current embedding model
+	var embeddingModel string
+	var createdAt time.Time
+
 	var collectionId uuid.UUID
 	if collection.Id == "" {
 		collectionId = uuid.New()
+		embeddingModel = server.Search.EmbeddingModel()
+		createdAt = time.Now()
 	} else {
 		collectionId, err = uuid.Parse(collection.Id)
 		if err != nil {
-			return nil, fmt.Errorf("invalid collection id: %s", collection.Id)
+			return nil, rpcerror.InvalidId("id", collection.Id)
+		}
+
+		err = server.checkNotArchived(ctx, userId, collectionId)
+		if err != nil {
+			return nil, err
 		}
 	}
 
 	err = server.Database.UpdateCollection(ctx, &datastore.Collection{
-		Id:     collectionId,
-		UserId: userId,
-		Name:   collection.Name,
+		Id:             collectionId,
+		UserId:         userId,
+		Name:           collection.Name,
+		EmbeddingModel: embeddingModel,
+		CreatedAt:      createdAt,
+		DefaultModel:   collection.DefaultModel,
 	})
 	if err != nil {
 		log.Printf("failed to store collection: %s", err)
@@ -71,7 +138,7 @@ func (server *Service) Delete(ctx context.Context, collection *pb.Collection) (*
 
 	collectionId, err := uuid.Parse(collection.Id)
 	if err != nil {
-		return nil, fmt.Errorf("invalid collection id: %s", collection.Id)
+		return nil, rpcerror.InvalidId("id", collection.Id)
 	}
 
 	err = server.Search.DeleteCollection(ctx, userId, collection.Id)
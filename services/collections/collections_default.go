@@ -0,0 +1,32 @@
+package collections
+
+import (
+	"context"
+	"github.com/google/uuid"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// SetDefault makes a collection of the user the default of prompts without a
+// collection id, or unsets the default if the id is empty.
+func (server *Service) SetDefault(ctx context.Context, collection *pb.Collection) (*emptypb.Empty, error) {
+	userId, err := server.Auth.Verify(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	collectionId := uuid.Nil
+	if collection.Id != "" {
+		collectionId, err = server.ownedCollection(ctx, userId, collection.Id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = server.Database.SetDefaultCollection(ctx, userId, collectionId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,142 @@
+package collections
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"time"
+)
+
+var roles = map[pb.AccessRole]string{
+	pb.AccessRole_ACCESS_ROLE_READ:  datastore.RoleRead,
+	pb.AccessRole_ACCESS_ROLE_WRITE: datastore.RoleWrite,
+	pb.AccessRole_ACCESS_ROLE_OWNER: datastore.RoleOwner,
+}
+
+// roleToProto converts a datastore role to the proto role.
+func roleToProto(role string) pb.AccessRole {
+	for key, value := range roles {
+		if value == role {
+			return key
+		}
+	}
+
+	return pb.AccessRole_ACCESS_ROLE_UNSPECIFIED
+}
+
+// ownedCollection parses the collection id and ensures that the collection is owned
+// by the user. Only owners can manage the access to a collection.
+func (server *Service) ownedCollection(ctx context.Context, userId, id string) (uuid.UUID, error) {
+	collectionId, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, rpcerror.InvalidId("collection_id", id)
+	}
+
+	_, err = server.Database.GetCollection(ctx, userId, collectionId)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return uuid.Nil, rpcerror.NotFound("collection", id)
+	}
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return collectionId, nil
+}
+
+// Grant gives another user read or write access to a collection. Granting access
+// to a user that already has access changes the role.
+func (server *Service) Grant(ctx context.Context, req *pb.AccessGrant) (*emptypb.Empty, error) {
+	userId, err := server.Auth.Verify(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	collectionId, err := server.ownedCollection(ctx, userId, req.CollectionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.UserId == "" {
+		return nil, rpcerror.Missing("user_id")
+	}
+
+	if req.UserId == userId {
+		return nil, rpcerror.New(codes.InvalidArgument, rpcerror.ReasonInvalidValue, "user_id", "owners can't grant access to themselves")
+	}
+
+	if req.Role != pb.AccessRole_ACCESS_ROLE_READ && req.Role != pb.AccessRole_ACCESS_ROLE_WRITE {
+		return nil, rpcerror.New(codes.InvalidArgument, rpcerror.ReasonInvalidValue, "role", "role must be read or write")
+	}
+
+	err = server.Database.StoreGrant(ctx, &datastore.AccessGrant{
+		Id:           uuid.New(),
+		CollectionId: collectionId,
+		OwnerId:      userId,
+		GranteeId:    req.UserId,
+		Role:         roles[req.Role],
+		CreatedAt:    time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// Revoke removes the access of a user to a collection.
+func (server *Service) Revoke(ctx context.Context, req *pb.AccessGrant) (*emptypb.Empty, error) {
+	userId, err := server.Auth.Verify(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	collectionId, err := server.ownedCollection(ctx, userId, req.CollectionId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = server.Database.DeleteGrant(ctx, userId, collectionId, req.UserId)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, rpcerror.NotFound("grant", req.UserId)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// ListGrants returns the users with access to a collection.
+func (server *Service) ListGrants(ctx context.Context, collection *pb.Collection) (*pb.AccessGrants, error) {
+	userId, err := server.Auth.Verify(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	collectionId, err := server.ownedCollection(ctx, userId, collection.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	grants, err := server.Database.ListGrants(ctx, userId, collectionId)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &pb.AccessGrants{}
+	for _, grant := range grants {
+		list.Items = append(list.Items, &pb.AccessGrant{
+			CollectionId: grant.CollectionId.String(),
+			UserId:       grant.GranteeId,
+			Role:         roleToProto(grant.Role),
+		})
+	}
+
+	return list, nil
+}
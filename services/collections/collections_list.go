@@ -2,30 +2,127 @@ package collections
 
 import (
 	"context"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
 	pb "github.com/pzierahn/chatbot_services/services/proto"
-	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"sort"
+	"strings"
+	"time"
 )
 
-func (server *Service) List(ctx context.Context, _ *emptypb.Empty) (*pb.CollectionList, error) {
+func (server *Service) List(ctx context.Context, filter *pb.CollectionFilter) (*pb.CollectionList, error) {
 	userId, err := server.Auth.Verify(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	collections, err := server.Database.GetCollections(ctx, userId)
+	query := datastore.CollectionFilter{
+		IncludeArchived: filter.IncludeArchived,
+		Name:            filter.Name,
+	}
+
+	collections, err := server.Database.GetCollections(ctx, userId, query)
 	if err != nil {
 		return nil, err
 	}
 
-	list := make([]*pb.Collection, len(collections))
-	for idx, collection := range collections {
-		list[idx] = &pb.Collection{
-			Id:   collection.Id.String(),
-			Name: collection.Name,
-		}
+	shared, err := server.Database.GetSharedCollections(ctx, userId, query)
+	if err != nil {
+		return nil, err
 	}
 
+	ids := make([]uuid.UUID, 0, len(collections)+len(shared))
+	for _, collection := range collections {
+		ids = append(ids, collection.Id)
+	}
+	for _, collection := range shared {
+		ids = append(ids, collection.Id)
+	}
+
+	counts, err := server.Database.CountCollectionDocuments(ctx, ids...)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*pb.Collection, 0, len(ids))
+	for _, collection := range collections {
+		list = append(list, collectionToProto(&collection, pb.AccessRole_ACCESS_ROLE_OWNER, counts))
+	}
+
+	for _, collection := range shared {
+		list = append(list, collectionToProto(&collection.Collection, roleToProto(collection.Role), counts))
+	}
+
+	sortCollections(list, filter.Sort, filter.Descending)
+
 	return &pb.CollectionList{
 		Items: list,
 	}, nil
 }
+
+// collectionToProto converts a collection with the role of the user.
+func collectionToProto(collection *datastore.Collection, role pb.AccessRole, counts map[uuid.UUID]int) *pb.Collection {
+	item := &pb.Collection{
+		Id:             collection.Id.String(),
+		Name:           collection.Name,
+		EmbeddingModel: collection.EmbeddingModel,
+		Archived:       collection.Archived,
+		Role:           role,
+		DocumentCount:  uint32(counts[collection.Id]),
+		DefaultModel:   collection.DefaultModel,
+
+		// Only the owner's prompts fall back to the collection
+		IsDefault: collection.Default && role == pb.AccessRole_ACCESS_ROLE_OWNER,
+	}
+
+	if !collection.CreatedAt.IsZero() {
+		item.CreatedAt = timestamppb.New(collection.CreatedAt)
+	}
+
+	return item
+}
+
+// sortCollections orders the collections by the sort key. Collections with equal
+// keys are ordered by name and id, so that the order is stable across requests.
+func sortCollections(list []*pb.Collection, by pb.CollectionSort, descending bool) {
+	byName := func(a, b *pb.Collection) int {
+		if cmp := strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)); cmp != 0 {
+			return cmp
+		}
+		return strings.Compare(a.Id, b.Id)
+	}
+
+	compare := func(a, b *pb.Collection) int {
+		switch by {
+		case pb.CollectionSort_COLLECTION_SORT_DOCUMENTS:
+			if a.DocumentCount != b.DocumentCount {
+				if a.DocumentCount < b.DocumentCount {
+					return -1
+				}
+				return 1
+			}
+		case pb.CollectionSort_COLLECTION_SORT_CREATED_AT:
+			// Collections without a creation time are the oldest
+			var createdA, createdB time.Time
+			if a.CreatedAt != nil {
+				createdA = a.CreatedAt.AsTime()
+			}
+			if b.CreatedAt != nil {
+				createdB = b.CreatedAt.AsTime()
+			}
+			if cmp := createdA.Compare(createdB); cmp != 0 {
+				return cmp
+			}
+		}
+
+		return byName(a, b)
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		if descending {
+			return compare(list[i], list[j]) > 0
+		}
+		return compare(list[i], list[j]) < 0
+	})
+}
@@ -0,0 +1,107 @@
+package collections
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"sync"
+	"time"
+)
+
+const (
+	// StatsCacheTTL defines how long the statistics of large collections are cached.
+	StatsCacheTTL = time.Minute
+
+	// StatsCacheDocuments is the number of documents from which statistics are cached.
+	StatsCacheDocuments = 100
+)
+
+type cachedStats struct {
+	stats   *datastore.CollectionStats
+	expires time.Time
+}
+
+// statsCache keeps the statistics of large collections, whose aggregation is expensive.
+type statsCache struct {
+	mu    sync.Mutex
+	items map[uuid.UUID]cachedStats
+}
+
+func (cache *statsCache) get(collectionId uuid.UUID) (*datastore.CollectionStats, bool) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	item, ok := cache.items[collectionId]
+	if !ok || time.Now().After(item.expires) {
+		delete(cache.items, collectionId)
+		return nil, false
+	}
+
+	return item.stats, true
+}
+
+func (cache *statsCache) put(collectionId uuid.UUID, stats *datastore.CollectionStats) {
+	if stats.Documents < StatsCacheDocuments {
+		return
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if cache.items == nil {
+		cache.items = make(map[uuid.UUID]cachedStats)
+	}
+
+	// Drop expired entries, so that deleted collections don't accumulate
+	now := time.Now()
+	for id, item := range cache.items {
+		if now.After(item.expires) {
+			delete(cache.items, id)
+		}
+	}
+
+	cache.items[collectionId] = cachedStats{
+		stats:   stats,
+		expires: now.Add(StatsCacheTTL),
+	}
+}
+
+// GetCollectionStats returns the document, chunk and token counts of a collection
+// owned by the user. Statistics of large collections may be up to a minute old.
+func (server *Service) GetCollectionStats(ctx context.Context, collection *pb.Collection) (*pb.CollectionStats, error) {
+	userId, err := server.Auth.Verify(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	collectionId, err := server.ownedCollection(ctx, userId, collection.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, ok := server.stats.get(collectionId)
+	if !ok {
+		stats, err = server.Database.GetCollectionStats(ctx, userId, collectionId)
+		if err != nil {
+			return nil, err
+		}
+
+		server.stats.put(collectionId, stats)
+	}
+
+	result := &pb.CollectionStats{
+		CollectionId:  collectionId.String(),
+		DocumentCount: uint32(stats.Documents),
+		ChunkCount:    uint32(stats.Chunks),
+		IndexedTokens: uint64(stats.Tokens),
+		StorageSize:   uint64(stats.StorageSize),
+	}
+
+	if !stats.LastIndexedAt.IsZero() {
+		result.LastIndexedAt = timestamppb.New(stats.LastIndexedAt)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,32 @@
+package collections
+
+import (
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
+	"testing"
+	"time"
+)
+
+func TestStatsCache(t *testing.T) {
+	var cache statsCache
+
+	small := uuid.New()
+	cache.put(small, &datastore.CollectionStats{Documents: StatsCacheDocuments - 1})
+	if _, ok := cache.get(small); ok {
+		t.Errorf("small collection was cached")
+	}
+
+	large := uuid.New()
+	cache.put(large, &datastore.CollectionStats{Documents: StatsCacheDocuments})
+	if stats, ok := cache.get(large); !ok || stats.Documents != StatsCacheDocuments {
+		t.Errorf("large collection was not cached")
+	}
+
+	cache.items[large] = cachedStats{
+		stats:   cache.items[large].stats,
+		expires: time.Now().Add(-time.Second),
+	}
+	if _, ok := cache.get(large); ok {
+		t.Errorf("expired statistics were returned")
+	}
+}
@@ -0,0 +1,40 @@
+package diagnostics
+
+import (
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
+	"github.com/pzierahn/chatbot_services/llm"
+	"github.com/pzierahn/chatbot_services/search"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"time"
+)
+
+// PingTimeout limits the duration of a single provider check.
+const PingTimeout = 30 * time.Second
+
+// Provider is a configured LLM backend with a model that is used for the checks.
+type Provider struct {
+	Name  string
+	Model string
+
+	// Chat is checked with a minimal completion if set
+	Chat llm.Chat
+
+	// Embedding is checked with a single embedding if set
+	Embedding llm.Embedding
+}
+
+// Service checks the configured providers and maintains the data for operators.
+type Service struct {
+	pb.UnimplementedDiagnosticsServer
+	Providers []Provider
+	Database  *datastore.Service
+	Search    search.Index
+
+	// Indexing reports whether a document is being indexed, PurgeOrphans skips
+	// its vectors. Running jobs are not known if nil
+	Indexing func(documentId uuid.UUID) bool
+
+	// Warmup defines the searches of WarmupCollection, nil uses the default queries
+	Warmup *Warmup
+}
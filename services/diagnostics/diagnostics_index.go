@@ -0,0 +1,95 @@
+package diagnostics
+
+import (
+	"context"
+	"github.com/pzierahn/chatbot_services/search"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// MaxHnswM limits the edges per node, larger graphs need a lot of memory.
+const MaxHnswM = 128
+
+// indexManager returns the manager of the search index or Unimplemented.
+func (service *Service) indexManager() (search.IndexManager, error) {
+	manager, ok := search.GetIndexManager(service.Search)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "the search index manages its vector index itself")
+	}
+
+	return manager, nil
+}
+
+func indexStatusToProto(index *search.IndexStatus) *pb.VectorIndexStatus {
+	return &pb.VectorIndexStatus{
+		Name:           index.Name,
+		Status:         index.Status,
+		Points:         index.Points,
+		IndexedVectors: index.IndexedVectors,
+		Segments:       index.Segments,
+		Params: &pb.VectorIndexParams{
+			M:                 index.Params.M,
+			EfConstruct:       index.Params.EfConstruct,
+			IndexingThreshold: index.Params.IndexingThreshold,
+		},
+	}
+}
+
+// GetVectorIndex returns the state of the vector index and the number of vectors
+// that are not indexed yet.
+func (service *Service) GetVectorIndex(ctx context.Context, _ *emptypb.Empty) (*pb.VectorIndexStatus, error) {
+	manager, err := service.indexManager()
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := manager.IndexStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return indexStatusToProto(index), nil
+}
+
+// RebuildVectorIndex changes the parameters of the vector index. The index is
+// rebuilt in the background, GetVectorIndex reports when it's ready.
+func (service *Service) RebuildVectorIndex(ctx context.Context, req *pb.VectorIndexParams) (*pb.VectorIndexStatus, error) {
+	manager, err := service.indexManager()
+	if err != nil {
+		return nil, err
+	}
+
+	if req.M == 0 && req.EfConstruct == 0 && req.IndexingThreshold == 0 {
+		return nil, rpcerror.New(codes.InvalidArgument, rpcerror.ReasonMissingField, "m",
+			"at least one index parameter must be set")
+	}
+
+	if req.M > MaxHnswM {
+		return nil, rpcerror.New(codes.InvalidArgument, rpcerror.ReasonLimitExceeded, "m",
+			"m exceeds the limit of 128")
+	}
+
+	if req.EfConstruct > 0 && req.EfConstruct < 4 {
+		return nil, rpcerror.New(codes.InvalidArgument, rpcerror.ReasonInvalidValue, "ef_construct",
+			"ef_construct must be at least 4")
+	}
+
+	err = manager.RebuildIndex(ctx, search.IndexParams{
+		M:                 req.M,
+		EfConstruct:       req.EfConstruct,
+		IndexingThreshold: req.IndexingThreshold,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := manager.IndexStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return indexStatusToProto(index), nil
+}
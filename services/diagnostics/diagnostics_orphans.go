@@ -0,0 +1,182 @@
+package diagnostics
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/search"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"log"
+	"sort"
+)
+
+// orphanScan collects the documents with vectors but without a database entry
+// page by page, so that neither the index nor the ids are loaded at once.
+type orphanScan struct {
+	// missing returns the ids of the documents that don't exist
+	missing func(ctx context.Context, ids ...uuid.UUID) ([]uuid.UUID, error)
+
+	// indexing reports whether a document is being indexed, nil if unknown
+	indexing func(documentId uuid.UUID) bool
+
+	orphans map[uuid.UUID]*search.DocumentRef
+}
+
+// skip reports whether the vectors of the document may not be purged. Documents
+// that are being indexed have vectors before they are stored.
+func (scan *orphanScan) skip(docId uuid.UUID) bool {
+	return scan.indexing != nil && scan.indexing(docId)
+}
+
+// add checks the documents of a page of the index.
+func (scan *orphanScan) add(ctx context.Context, refs []*search.DocumentRef) error {
+	byId := make(map[uuid.UUID]*search.DocumentRef)
+	var ids []uuid.UUID
+	for _, ref := range refs {
+		docId, err := uuid.Parse(ref.DocumentId)
+		if err != nil {
+			log.Printf("PurgeOrphans: invalid document id in index: %s", ref.DocumentId)
+			continue
+		}
+
+		if scan.skip(docId) {
+			continue
+		}
+
+		byId[docId] = ref
+		ids = append(ids, docId)
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	missing, err := scan.missing(ctx, ids...)
+	if err != nil {
+		return err
+	}
+
+	isMissing := make(map[uuid.UUID]bool)
+	for _, docId := range missing {
+		isMissing[docId] = true
+	}
+
+	for docId, ref := range byId {
+		if !isMissing[docId] {
+			// The document may have been stored since an earlier page
+			delete(scan.orphans, docId)
+			continue
+		}
+
+		if orphan, ok := scan.orphans[docId]; ok {
+			orphan.Vectors += ref.Vectors
+			continue
+		}
+
+		orphan := *ref
+		scan.orphans[docId] = &orphan
+	}
+
+	return nil
+}
+
+// verify checks the collected orphans again and returns the ones that are
+// still missing, sorted by document id.
+func (scan *orphanScan) verify(ctx context.Context) ([]*search.DocumentRef, error) {
+	var ids []uuid.UUID
+	for docId := range scan.orphans {
+		if !scan.skip(docId) {
+			ids = append(ids, docId)
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	missing, err := scan.missing(ctx, ids...)
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make([]*search.DocumentRef, len(missing))
+	for idx, docId := range missing {
+		refs[idx] = scan.orphans[docId]
+	}
+
+	sort.Slice(refs, func(i, j int) bool {
+		return refs[i].DocumentId < refs[j].DocumentId
+	})
+
+	return refs, nil
+}
+
+// PurgeOrphans finds the vectors and stored texts of documents that don't exist in
+// the database, e.g. after failed deletes. The orphans are only deleted if requested.
+// The stored texts are checked and deleted in a transaction, so that documents
+// created in the meantime are not touched. The vectors are deleted after the
+// transaction, since the index is not part of it. Documents that are being indexed
+// by this instance are skipped, indexing jobs of other instances are not known.
+func (service *Service) PurgeOrphans(ctx context.Context, req *pb.PurgeRequest) (*pb.PurgeReport, error) {
+	scan := &orphanScan{
+		missing:  service.Database.MissingDocuments,
+		indexing: service.Indexing,
+		orphans:  make(map[uuid.UUID]*search.DocumentRef),
+	}
+
+	err := service.Search.ListDocuments(ctx, func(refs []*search.DocumentRef) error {
+		return scan.add(ctx, refs)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var report *pb.PurgeReport
+	err = service.Database.WithTransaction(ctx, func(ctx context.Context) error {
+		// Retried transactions start with a new report
+		report = &pb.PurgeReport{}
+
+		texts, err := service.Database.OrphanedTexts(ctx)
+		if err != nil {
+			return err
+		}
+		report.Texts = uint32(len(texts))
+
+		if req.Delete && len(texts) > 0 {
+			err = service.Database.DeleteTexts(ctx, texts...)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	orphans, err := scan.verify(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ref := range orphans {
+		report.DocumentIds = append(report.DocumentIds, ref.DocumentId)
+		report.Vectors += uint32(ref.Vectors)
+	}
+
+	if req.Delete {
+		for _, ref := range orphans {
+			err = service.Search.DeleteDocument(ctx, ref.UserId, ref.CollectionId, ref.DocumentId)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		report.Deleted = true
+	}
+
+	log.Printf("PurgeOrphans: documents=%d vectors=%d texts=%d deleted=%v",
+		len(report.DocumentIds), report.Vectors, report.Texts, report.Deleted)
+
+	return report, nil
+}
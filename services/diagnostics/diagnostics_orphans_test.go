@@ -0,0 +1,105 @@
+package diagnostics
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/search"
+	"testing"
+)
+
+// fakeDocuments is a database with the stored document ids.
+type fakeDocuments struct {
+	stored  map[uuid.UUID]bool
+	queries [][]uuid.UUID
+}
+
+func (docs *fakeDocuments) missing(_ context.Context, ids ...uuid.UUID) ([]uuid.UUID, error) {
+	docs.queries = append(docs.queries, ids)
+
+	var missing []uuid.UUID
+	for _, id := range ids {
+		if !docs.stored[id] {
+			missing = append(missing, id)
+		}
+	}
+
+	return missing, nil
+}
+
+func TestOrphanScan(t *testing.T) {
+	stored, orphan, indexing, late := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	docs := &fakeDocuments{stored: map[uuid.UUID]bool{stored: true}}
+	scan := &orphanScan{
+		missing:  docs.missing,
+		indexing: func(docId uuid.UUID) bool { return docId == indexing },
+		orphans:  make(map[uuid.UUID]*search.DocumentRef),
+	}
+
+	pages := [][]*search.DocumentRef{
+		{
+			{DocumentId: stored.String(), Vectors: 2},
+			{DocumentId: orphan.String(), Vectors: 3},
+			{DocumentId: indexing.String(), Vectors: 4},
+			{DocumentId: late.String(), Vectors: 1},
+		},
+		{
+			{DocumentId: orphan.String(), Vectors: 2},
+			{DocumentId: "not-a-uuid", Vectors: 1},
+		},
+	}
+
+	for _, page := range pages {
+		if err := scan.add(context.Background(), page); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The late document is stored after it was scanned, e.g. by a finished job
+	docs.stored[late] = true
+
+	orphans, err := scan.verify(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(orphans) != 1 || orphans[0].DocumentId != orphan.String() || orphans[0].Vectors != 5 {
+		t.Fatalf("expected the orphan with the vectors of both pages, got %+v", orphans)
+	}
+
+	// Each page and the verification are single queries without running jobs
+	for _, query := range docs.queries {
+		for _, id := range query {
+			if id == indexing {
+				t.Fatal("documents that are being indexed must not be checked")
+			}
+		}
+	}
+	if len(docs.queries) != 3 {
+		t.Fatalf("expected 3 queries, got %d", len(docs.queries))
+	}
+}
+
+func TestOrphanScanStoredLater(t *testing.T) {
+	docId := uuid.New()
+	docs := &fakeDocuments{stored: map[uuid.UUID]bool{}}
+	scan := &orphanScan{
+		missing: docs.missing,
+		orphans: make(map[uuid.UUID]*search.DocumentRef),
+	}
+
+	ref := []*search.DocumentRef{{DocumentId: docId.String(), Vectors: 1}}
+	if err := scan.add(context.Background(), ref); err != nil {
+		t.Fatal(err)
+	}
+
+	// Stored before the second page of the index was scanned
+	docs.stored[docId] = true
+	if err := scan.add(context.Background(), ref); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(scan.orphans) != 0 {
+		t.Fatalf("expected stored documents to be dropped, got %d orphans", len(scan.orphans))
+	}
+}
@@ -0,0 +1,91 @@
+package diagnostics
+
+import (
+	"context"
+	"github.com/pzierahn/chatbot_services/llm"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"sync"
+	"time"
+)
+
+const (
+	checkCompletion = "completion"
+	checkEmbedding  = "embedding"
+)
+
+// check runs a single provider call and reports its outcome and latency.
+func check(ctx context.Context, provider Provider, name, model string, call func(context.Context) error) *pb.ProviderStatus {
+	ctx, cancel := context.WithTimeout(ctx, PingTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := call(ctx)
+
+	status := &pb.ProviderStatus{
+		Provider: provider.Name,
+		Model:    model,
+		Check:    name,
+		Ok:       err == nil,
+		Latency:  durationpb.New(time.Since(start)),
+	}
+
+	if err != nil {
+		status.Error = err.Error()
+	}
+
+	return status
+}
+
+// PingProviders sends a minimal completion or embedding request to every provider
+// in parallel. Failing providers are reported instead of failing the whole request.
+func (service *Service) PingProviders(ctx context.Context, _ *emptypb.Empty) (*pb.ProviderReport, error) {
+	var checks []func() *pb.ProviderStatus
+
+	for _, provider := range service.Providers {
+		if provider.Chat != nil {
+			checks = append(checks, func() *pb.ProviderStatus {
+				return check(ctx, provider, checkCompletion, provider.Model, func(ctx context.Context) error {
+					_, err := provider.Chat.Completion(ctx, &llm.CompletionRequest{
+						SystemPrompt: "You are a health check.",
+						Model:        provider.Model,
+						MaxTokens:    5,
+						Messages: []*llm.Message{{
+							Role:    llm.RoleUser,
+							Content: "Reply with OK.",
+						}},
+					})
+					return err
+				})
+			})
+		}
+
+		if provider.Embedding != nil {
+			checks = append(checks, func() *pb.ProviderStatus {
+				return check(ctx, provider, checkEmbedding, provider.Embedding.GetModelId(), func(ctx context.Context) error {
+					_, err := provider.Embedding.CreateEmbedding(ctx, &llm.EmbeddingRequest{
+						Inputs: []string{"ping"},
+					})
+					return err
+				})
+			})
+		}
+	}
+
+	report := &pb.ProviderReport{
+		Statuses: make([]*pb.ProviderStatus, len(checks)),
+	}
+
+	var wg sync.WaitGroup
+	for idx, run := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report.Statuses[idx] = run()
+		}()
+	}
+	wg.Wait()
+
+	return report, nil
+}
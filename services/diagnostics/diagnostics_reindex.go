@@ -0,0 +1,41 @@
+package diagnostics
+
+import (
+	"context"
+	"errors"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GetReindexStatus returns the persisted progress of the latest reembedding, so
+// that operators can follow a run without access to the output of the migration.
+func (service *Service) GetReindexStatus(ctx context.Context, _ *emptypb.Empty) (*pb.ReindexStatus, error) {
+	run, err := service.Database.GetLatestReindexRun(ctx)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, rpcerror.NotFound("reindex run", "latest")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	status := &pb.ReindexStatus{
+		RunId:           run.Id.String(),
+		State:           run.State,
+		TotalChunks:     uint64(run.TotalChunks),
+		ProcessedChunks: uint64(run.ProcessedChunks),
+		FailedChunks:    uint64(run.FailedChunks),
+		Rate:            run.Rate,
+		StartedAt:       timestamppb.New(run.StartedAt),
+		UpdatedAt:       timestamppb.New(run.UpdatedAt),
+		Error:           run.Error,
+	}
+
+	if !run.EstimatedCompletion.IsZero() {
+		status.EstimatedCompletion = timestamppb.New(run.EstimatedCompletion)
+	}
+
+	return status, nil
+}
@@ -0,0 +1,178 @@
+package diagnostics
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
+	"github.com/pzierahn/chatbot_services/search"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+// MaxWarmupQueries limits the searches of a warmup, each query is embedded.
+const MaxWarmupQueries = 20
+
+// DefaultWarmupQueries cover the typical questions about scientific documents.
+var DefaultWarmupQueries = []string{
+	"What is the main contribution?",
+	"Which methods are used?",
+	"What are the results?",
+	"What are the limitations?",
+	"Summarize the related work.",
+}
+
+// Warmup defines the searches of WarmupCollection. Limit and threshold should
+// match the retrieval defaults of the chat, so that the cached results are hit.
+type Warmup struct {
+	Queries   []string
+	Limit     uint32
+	Threshold float32
+
+	// Language filter of the searches like the language of the retrieval options
+	// of the chat, e.g. search.LanguageAuto
+	Language string
+}
+
+// WarmupFromEnv reads the queries separated by ";" from CHATBOT_WARMUP_QUERIES,
+// DefaultWarmupQueries if not set, and the language from CHATBOT_WARMUP_LANGUAGE.
+func WarmupFromEnv(limit uint32, threshold float32) *Warmup {
+	warmup := &Warmup{
+		Queries:   DefaultWarmupQueries,
+		Limit:     limit,
+		Threshold: threshold,
+		Language:  os.Getenv("CHATBOT_WARMUP_LANGUAGE"),
+	}
+
+	if value := os.Getenv("CHATBOT_WARMUP_QUERIES"); value != "" {
+		warmup.Queries = warmupQueries(strings.Split(value, ";"))
+	}
+
+	return warmup
+}
+
+// warmupQueries removes empty and duplicate queries and caps them at MaxWarmupQueries.
+func warmupQueries(list []string) []string {
+	var queries []string
+	seen := make(map[string]bool)
+
+	for _, query := range list {
+		query = strings.TrimSpace(query)
+		if query == "" || seen[query] {
+			continue
+		}
+
+		seen[query] = true
+		queries = append(queries, query)
+		if len(queries) == MaxWarmupQueries {
+			break
+		}
+	}
+
+	return queries
+}
+
+// WarmupCollection searches the warmup queries in a collection, which fills the
+// search cache and loads the vectors of the collection into memory. Cached results
+// expire after the TTL of the cache, the loaded index stays warm.
+func (service *Service) WarmupCollection(ctx context.Context, req *pb.WarmupRequest) (*pb.WarmupReport, error) {
+	collectionId, err := uuid.Parse(req.CollectionId)
+	if err != nil {
+		return nil, rpcerror.InvalidId("collection_id", req.CollectionId)
+	}
+
+	if len(req.Queries) > MaxWarmupQueries {
+		return nil, rpcerror.New(codes.InvalidArgument, rpcerror.ReasonLimitExceeded, "queries",
+			"too many warmup queries")
+	}
+
+	warmup := service.Warmup
+	if warmup == nil {
+		warmup = &Warmup{Queries: DefaultWarmupQueries}
+	}
+
+	queries := warmup.Queries
+	if len(req.Queries) > 0 {
+		queries = warmupQueries(req.Queries)
+	}
+
+	if len(queries) == 0 {
+		return nil, rpcerror.Missing("queries")
+	}
+
+	collection, err := service.Database.GetCollectionById(ctx, collectionId)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, rpcerror.NotFound("collection", req.CollectionId)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := warmup.run(ctx, service.Search, collection, queries)
+	if err != nil {
+		return nil, err
+	}
+
+	// The embeddings of the queries are paid for like the searches of the owner
+	if report.Tokens > 0 {
+		service.Database.RecordModelUsage(ctx, &datastore.ModelUsage{
+			Id:          uuid.New(),
+			UserId:      collection.UserId,
+			Timestamp:   time.Now(),
+			ModelId:     report.model,
+			InputTokens: report.Tokens,
+		})
+	}
+
+	return report.WarmupReport, nil
+}
+
+// warmupReport is the report of a warmup with the embedding model of the queries.
+type warmupReport struct {
+	*pb.WarmupReport
+	model string
+}
+
+// run searches the queries in the collection like the chat does, so that the
+// cached results are hit by the chat.
+func (warmup *Warmup) run(ctx context.Context, index search.Index, collection *datastore.Collection, queries []string) (*warmupReport, error) {
+	report := &warmupReport{WarmupReport: &pb.WarmupReport{}}
+	start := time.Now()
+
+	for _, query := range queries {
+		report.Queries++
+
+		results, err := index.Search(ctx, search.Query{
+			UserId:         collection.UserId,
+			CollectionId:   collection.Id.String(),
+			Query:          query,
+			Limit:          warmup.Limit,
+			Threshold:      warmup.Threshold,
+			Language:       search.QueryLanguage(warmup.Language, query),
+			EmbeddingModel: collection.EmbeddingModel,
+		})
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if err != nil {
+			log.Printf("WarmupCollection: %s: %v", collection.Id, err)
+			report.Failed++
+			continue
+		}
+
+		report.Results += uint32(len(results.Results))
+		report.Tokens += results.Usage.Tokens
+		report.model = results.Usage.ModelId
+	}
+
+	report.Duration = durationpb.New(time.Since(start))
+
+	return report, nil
+}
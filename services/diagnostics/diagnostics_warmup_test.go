@@ -0,0 +1,78 @@
+package diagnostics
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
+	"github.com/pzierahn/chatbot_services/search"
+	"reflect"
+	"testing"
+)
+
+func TestWarmupFromEnv(t *testing.T) {
+	t.Setenv("CHATBOT_WARMUP_QUERIES", " first question ; ;second question;first question")
+
+	warmup := WarmupFromEnv(8, 0.75)
+	expected := []string{"first question", "second question"}
+	if !reflect.DeepEqual(warmup.Queries, expected) {
+		t.Fatalf("expected %q, got %q", expected, warmup.Queries)
+	}
+
+	if warmup.Limit != 8 || warmup.Threshold != 0.75 {
+		t.Fatalf("unexpected retrieval parameters: %+v", warmup)
+	}
+
+	t.Setenv("CHATBOT_WARMUP_QUERIES", "")
+	if queries := WarmupFromEnv(8, 0.75).Queries; !reflect.DeepEqual(queries, DefaultWarmupQueries) {
+		t.Fatalf("expected the default queries, got %q", queries)
+	}
+}
+
+func TestWarmupQueriesLimit(t *testing.T) {
+	var list []string
+	for idx := 0; idx < 2*MaxWarmupQueries; idx++ {
+		list = append(list, fmt.Sprintf("query %d", idx))
+	}
+
+	if queries := warmupQueries(list); len(queries) != MaxWarmupQueries {
+		t.Fatalf("expected %d queries, got %d", MaxWarmupQueries, len(queries))
+	}
+}
+
+// recordingIndex answers every search with one result and records the queries.
+type recordingIndex struct {
+	search.Index
+	queries []search.Query
+}
+
+func (index *recordingIndex) Search(_ context.Context, query search.Query) (*search.Results, error) {
+	index.queries = append(index.queries, query)
+
+	return &search.Results{
+		Results: []*search.Result{{Id: "fragment"}},
+		Usage:   search.Usage{ModelId: "embedding", Tokens: 4},
+	}, nil
+}
+
+func TestWarmupRun(t *testing.T) {
+	index := &recordingIndex{}
+	warmup := &Warmup{Limit: 8, Threshold: 0.5, Language: search.LanguageAuto}
+	collection := &datastore.Collection{Id: uuid.New(), UserId: "owner"}
+
+	query := "Welche Methoden werden in der Arbeit verwendet und warum?"
+	report, err := warmup.run(context.Background(), index, collection, []string{query})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Queries != 1 || report.Results != 1 || report.Tokens != 4 || report.model != "embedding" {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+
+	// The searches match the searches of the chat, so that their results are cached
+	want := search.QueryLanguage(search.LanguageAuto, query)
+	if len(index.queries) != 1 || index.queries[0].Language != want || index.queries[0].UserId != "owner" {
+		t.Fatalf("expected a search of the owner with language %q, got %+v", want, index.queries)
+	}
+}
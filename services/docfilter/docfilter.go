@@ -0,0 +1,44 @@
+// Package docfilter checks the documents a search or a chat is restricted to.
+package docfilter
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/search"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+)
+
+// Database returns the ids that are no documents of a collection.
+type Database interface {
+	MissingCollectionDocuments(ctx context.Context, userId string, collectionId uuid.UUID, ids ...uuid.UUID) ([]uuid.UUID, error)
+}
+
+// Check returns an error if the ids are malformed or no documents of the
+// collection of the owner. The field names the ids in the error. The returned
+// ids are deduplicated in canonical form, so that the index matches them.
+func Check(ctx context.Context, db Database, field, ownerId string, collectionId uuid.UUID, ids []string) ([]string, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	documentIds, err := search.ValidateDocumentIds(ids)
+	if err != nil {
+		return nil, rpcerror.Invalid(field, err)
+	}
+
+	missing, err := db.MissingCollectionDocuments(ctx, ownerId, collectionId, documentIds...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(missing) > 0 {
+		return nil, rpcerror.NotFound("document", missing[0].String())
+	}
+
+	filter := make([]string, len(documentIds))
+	for idx, documentId := range documentIds {
+		filter[idx] = documentId.String()
+	}
+
+	return filter, nil
+}
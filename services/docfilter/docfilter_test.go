@@ -0,0 +1,57 @@
+package docfilter
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strings"
+	"testing"
+)
+
+// testDatabase knows the documents of a single collection.
+type testDatabase struct {
+	documents map[uuid.UUID]bool
+}
+
+func (db *testDatabase) MissingCollectionDocuments(_ context.Context, _ string, _ uuid.UUID, ids ...uuid.UUID) ([]uuid.UUID, error) {
+	var missing []uuid.UUID
+	for _, id := range ids {
+		if !db.documents[id] {
+			missing = append(missing, id)
+		}
+	}
+
+	return missing, nil
+}
+
+func TestCheck(t *testing.T) {
+	ctx := context.Background()
+	documentId := uuid.New()
+	db := &testDatabase{documents: map[uuid.UUID]bool{documentId: true}}
+
+	filter, err := Check(ctx, db, "document_ids", "owner", uuid.New(), nil)
+	if err != nil || filter != nil {
+		t.Fatalf("expected no filter without ids, got %v, %v", filter, err)
+	}
+
+	// Upper case and duplicate ids are passed to the index in canonical form
+	upper := strings.ToUpper(documentId.String())
+	filter, err = Check(ctx, db, "document_ids", "owner", uuid.New(), []string{upper, documentId.String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(filter) != 1 || filter[0] != documentId.String() {
+		t.Fatalf("expected the canonical id, got %v", filter)
+	}
+
+	_, err = Check(ctx, db, "document_ids", "owner", uuid.New(), []string{"not-a-uuid"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for malformed ids, got %v", err)
+	}
+
+	_, err = Check(ctx, db, "document_ids", "owner", uuid.New(), []string{uuid.NewString()})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound for documents of other collections, got %v", err)
+	}
+}
@@ -14,4 +14,19 @@ type Service struct {
 	Database    *datastore.Service
 	Storage     *storage.BucketHandle
 	SearchIndex search.Index
+
+	// Limits restrict the size of indexed documents
+	Limits Limits
+
+	// WebhookSecret is used to sign the payload of index callbacks
+	WebhookSecret string
+
+	// PageCache keeps rendered page images, pages are rendered on every request if nil
+	PageCache *PageCache
+
+	// AccessLog records which documents users read, nothing is recorded if nil
+	AccessLog *AccessLogger
+
+	// jobs are the running indexing jobs, which can be stopped by CancelIndex
+	jobs indexJobs
 }
@@ -0,0 +1,279 @@
+package documents
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"log"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultAccessLogBatch is the number of entries that are written at once.
+	DefaultAccessLogBatch = 100
+
+	// DefaultAccessLogInterval is the maximum time an entry is buffered.
+	DefaultAccessLogInterval = time.Second
+
+	// accessLogBuffer is the number of entries that can wait to be written. Further
+	// entries are dropped, so that a slow database never delays the reads.
+	accessLogBuffer = 4096
+)
+
+var (
+	accessLogWritten expvar.Int
+	accessLogDropped expvar.Int
+)
+
+func init() {
+	stats := expvar.NewMap("access_log")
+	stats.Set("written", &accessLogWritten)
+	stats.Set("dropped", &accessLogDropped)
+}
+
+// AccessLogStore writes a batch of access log entries.
+type AccessLogStore func(ctx context.Context, entries []datastore.AccessLogEntry) error
+
+// AccessLogger records document reads in the background. Entries are buffered and
+// written in batches. A nil AccessLogger doesn't log.
+type AccessLogger struct {
+	store    AccessLogStore
+	batch    int
+	interval time.Duration
+
+	// mu guards entries against sends after Close
+	mu      sync.RWMutex
+	closed  bool
+	entries chan datastore.AccessLogEntry
+	done    chan struct{}
+}
+
+// NewAccessLogger starts a logger that writes batches of up to batch entries to store
+// at least once per interval.
+func NewAccessLogger(store AccessLogStore, batch int, interval time.Duration) *AccessLogger {
+	logger := &AccessLogger{
+		store:    store,
+		batch:    batch,
+		interval: interval,
+		entries:  make(chan datastore.AccessLogEntry, accessLogBuffer),
+		done:     make(chan struct{}),
+	}
+
+	go logger.run()
+
+	return logger
+}
+
+// AccessLoggerFromEnv creates a logger if CHATBOT_ACCESS_LOG is true. The batch size
+// and interval are read from CHATBOT_ACCESS_LOG_BATCH and CHATBOT_ACCESS_LOG_INTERVAL.
+func AccessLoggerFromEnv(store AccessLogStore) *AccessLogger {
+	enabled, _ := strconv.ParseBool(os.Getenv("CHATBOT_ACCESS_LOG"))
+	if !enabled {
+		return nil
+	}
+
+	batch := DefaultAccessLogBatch
+	if value, err := strconv.Atoi(os.Getenv("CHATBOT_ACCESS_LOG_BATCH")); err == nil && value > 0 {
+		batch = value
+	}
+
+	interval := DefaultAccessLogInterval
+	if value, err := time.ParseDuration(os.Getenv("CHATBOT_ACCESS_LOG_INTERVAL")); err == nil && value > 0 {
+		interval = value
+	}
+
+	return NewAccessLogger(store, batch, interval)
+}
+
+// Log queues an access of the documents of a collection. It never blocks, entries
+// are dropped if the buffer is full.
+func (logger *AccessLogger) Log(entry datastore.AccessLogEntry) {
+	if logger == nil || len(entry.DocumentIds) == 0 {
+		return
+	}
+
+	entry.Id = uuid.New()
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now()
+	}
+
+	logger.mu.RLock()
+	defer logger.mu.RUnlock()
+
+	if logger.closed {
+		accessLogDropped.Add(1)
+		return
+	}
+
+	select {
+	case logger.entries <- entry:
+	default:
+		accessLogDropped.Add(1)
+	}
+}
+
+// Close writes the buffered entries and stops the logger. Entries logged after
+// Close are dropped.
+func (logger *AccessLogger) Close() {
+	if logger == nil {
+		return
+	}
+
+	logger.mu.Lock()
+	if !logger.closed {
+		logger.closed = true
+		close(logger.entries)
+	}
+	logger.mu.Unlock()
+
+	<-logger.done
+}
+
+func (logger *AccessLogger) run() {
+	defer close(logger.done)
+
+	ticker := time.NewTicker(logger.interval)
+	defer ticker.Stop()
+
+	buffer := make([]datastore.AccessLogEntry, 0, logger.batch)
+	flush := func() {
+		if len(buffer) == 0 {
+			return
+		}
+
+		err := logger.store(context.Background(), buffer)
+		if err != nil {
+			log.Printf("access log: %v", err)
+			accessLogDropped.Add(int64(len(buffer)))
+		} else {
+			accessLogWritten.Add(int64(len(buffer)))
+		}
+
+		buffer = make([]datastore.AccessLogEntry, 0, logger.batch)
+	}
+
+	for {
+		select {
+		case entry, ok := <-logger.entries:
+			if !ok {
+				flush()
+				return
+			}
+
+			buffer = append(buffer, entry)
+			if len(buffer) >= logger.batch {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+// logDocument queues a read of a single document by the user. The document must
+// carry its owner and collection, as returned by documentAccess.
+func (service *Service) logDocument(userId string, doc *datastore.Document, action string) {
+	service.AccessLog.Log(datastore.AccessLogEntry{
+		UserId:       userId,
+		OwnerId:      doc.UserId,
+		CollectionId: doc.CollectionId,
+		DocumentIds:  []uuid.UUID{doc.Id},
+		Action:       action,
+	})
+}
+
+const (
+	// defaultAccessLogLimit is the number of entries returned if no limit is set.
+	defaultAccessLogLimit = 100
+
+	// MaxAccessLogLimit is the maximum number of entries returned at once.
+	MaxAccessLogLimit = 1000
+)
+
+// GetAccessLog returns the newest access log entries of a collection. Only the
+// owner of the collection can read them.
+func (service *Service) GetAccessLog(ctx context.Context, req *pb.AccessLogRequest) (*pb.AccessLog, error) {
+	userId, err := service.Auth.Verify(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	collectionId, err := uuid.Parse(req.CollectionId)
+	if err != nil {
+		return nil, rpcerror.InvalidId("collection_id", req.CollectionId)
+	}
+
+	if req.Limit > MaxAccessLogLimit {
+		return nil, rpcerror.New(codes.InvalidArgument, rpcerror.ReasonLimitExceeded, "limit",
+			fmt.Sprintf("limit %d exceeds the limit of %d", req.Limit, MaxAccessLogLimit))
+	}
+
+	var beforeId uuid.UUID
+	if req.BeforeId != "" {
+		beforeId, err = uuid.Parse(req.BeforeId)
+		if err != nil {
+			return nil, rpcerror.InvalidId("before_id", req.BeforeId)
+		}
+
+		if req.Before == nil {
+			return nil, rpcerror.Missing("before")
+		}
+	}
+
+	limit := defaultAccessLogLimit
+	if req.Limit > 0 {
+		limit = int(req.Limit)
+	}
+
+	access, err := service.Database.GetCollectionAccess(ctx, userId, collectionId)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, rpcerror.NotFound("collection", req.CollectionId)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if access.Role != datastore.RoleOwner {
+		return nil, rpcerror.New(codes.PermissionDenied, rpcerror.ReasonAccessDenied, "collection_id",
+			fmt.Sprintf("only the owner can read the access log of %s", req.CollectionId))
+	}
+
+	before := time.Now()
+	if req.Before != nil {
+		before = req.Before.AsTime()
+	}
+
+	entries, err := service.Database.GetAccessLog(ctx, collectionId, before, beforeId, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	accessLog := &pb.AccessLog{}
+	for _, entry := range entries {
+		docIds := make([]string, len(entry.DocumentIds))
+		for idx, docId := range entry.DocumentIds {
+			docIds[idx] = docId.String()
+		}
+
+		accessLog.Items = append(accessLog.Items, &pb.AccessLogEntry{
+			Id:          entry.Id.String(),
+			UserId:      entry.UserId,
+			DocumentIds: docIds,
+			Action:      entry.Action,
+			Timestamp:   timestamppb.New(entry.Timestamp),
+		})
+	}
+
+	return accessLog, nil
+}
@@ -0,0 +1,89 @@
+package documents
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAccessLoggerBatches(t *testing.T) {
+	var mu sync.Mutex
+	var batches [][]datastore.AccessLogEntry
+
+	store := func(ctx context.Context, entries []datastore.AccessLogEntry) error {
+		mu.Lock()
+		defer mu.Unlock()
+		batches = append(batches, entries)
+		return nil
+	}
+
+	// The interval is long enough that only full batches and Close flush
+	logger := NewAccessLogger(store, 2, time.Hour)
+
+	for range 5 {
+		logger.Log(datastore.AccessLogEntry{
+			UserId:      "user",
+			DocumentIds: []uuid.UUID{uuid.New()},
+			Action:      datastore.AccessSearch,
+		})
+	}
+
+	// Entries without documents are not logged
+	logger.Log(datastore.AccessLogEntry{UserId: "user"})
+
+	logger.Close()
+
+	// Logging after Close must not panic
+	logger.Log(datastore.AccessLogEntry{DocumentIds: []uuid.UUID{uuid.New()}})
+
+	sizes := make([]int, len(batches))
+	for idx, batch := range batches {
+		sizes[idx] = len(batch)
+	}
+
+	if len(sizes) != 3 || sizes[0] != 2 || sizes[1] != 2 || sizes[2] != 1 {
+		t.Fatalf("expected batches of 2, 2 and 1 entries, got %v", sizes)
+	}
+
+	for _, entry := range batches[0] {
+		if entry.Id == uuid.Nil || entry.Timestamp.IsZero() {
+			t.Errorf("expected id and timestamp to be set: %+v", entry)
+		}
+	}
+}
+
+func TestAccessLoggerNil(t *testing.T) {
+	var logger *AccessLogger
+	logger.Log(datastore.AccessLogEntry{DocumentIds: []uuid.UUID{uuid.New()}})
+	logger.Close()
+}
+
+func TestGetAccessLogPageToken(t *testing.T) {
+	service := &Service{Auth: &testVerifier{userId: "owner"}}
+	collectionId := uuid.NewString()
+
+	_, err := service.GetAccessLog(context.Background(), &pb.AccessLogRequest{
+		CollectionId: collectionId,
+		BeforeId:     "not-a-uuid",
+		Before:       timestamppb.Now(),
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for an invalid id, got %v", err)
+	}
+
+	// The id only pages the entries of a timestamp
+	_, err = service.GetAccessLog(context.Background(), &pb.AccessLogRequest{
+		CollectionId: collectionId,
+		BeforeId:     uuid.NewString(),
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for an id without timestamp, got %v", err)
+	}
+}
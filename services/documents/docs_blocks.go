@@ -0,0 +1,207 @@
+package documents
+
+import (
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
+	"regexp"
+	"strings"
+)
+
+const (
+	// blockMinLines is the minimum number of code or table lines of a block, so
+	// that single lines that look like code don't split the prose of a page.
+	blockMinLines = 3
+
+	// tableCellsMin is the minimum number of columns of a table row. Two columns
+	// are not enough, because the layout of two column pages looks the same.
+	tableCellsMin = 3
+
+	// tableRowGroup is the number of rows per chunk of large tables.
+	tableRowGroup = 20
+
+	// codeIndent is the additional indentation that continues a code block, e.g.
+	// the body of a function.
+	codeIndent = 2
+)
+
+var (
+	// tableSeparator matches the gaps between the columns of a table in the layout
+	// of pdftotext and the separators of markdown tables.
+	tableSeparator = regexp.MustCompile(`\s{2,}|\t|\|`)
+
+	// codeKeyValue matches assignments and config entries like "port: 8080",
+	// "name = value" or "server:", but not prose like "Note: see below".
+	codeKeyValue = regexp.MustCompile(`^[\w.\-\[\]"']+\s*(:|=|:=)\s*("[^"]*"|'[^']*'|\S+)?\s*[,;]?$`)
+
+	// codeStatement matches lines that start with common keywords or end like code.
+	codeStatement = regexp.MustCompile(`^((func|def|class|import|from|package|return|if|for|while|const|var|let|public|private)\b|#include|#!|\$ |</?\w+[\s>])|[{};]$|^[}\])]|\)\s*:$`)
+)
+
+// block is a range of lines [start, end) of a page.
+type block struct {
+	chunkType string
+	start     int
+	end       int
+}
+
+// tableCells returns the columns of a table row.
+func tableCells(line string) []string {
+	var cells []string
+	for _, cell := range tableSeparator.Split(strings.TrimSpace(line), -1) {
+		if cell != "" {
+			cells = append(cells, cell)
+		}
+	}
+
+	return cells
+}
+
+// isTableRow reports whether a line has the columns of a table.
+func isTableRow(line string) bool {
+	return len(tableCells(line)) >= tableCellsMin
+}
+
+// isCodeLine reports whether a line looks like source code or configuration.
+func isCodeLine(line string) bool {
+	line = strings.TrimSpace(line)
+	return line != "" && (codeStatement.MatchString(line) || codeKeyValue.MatchString(line))
+}
+
+// indentation returns the number of leading spaces of a line, tabs count as four.
+func indentation(line string) int {
+	indent := 0
+	for _, char := range line {
+		switch char {
+		case ' ':
+			indent++
+		case '\t':
+			indent += 4
+		default:
+			return indent
+		}
+	}
+
+	return indent
+}
+
+// findBlocks returns the code blocks and tables of the lines of a page.
+func findBlocks(lines []string) []block {
+	var blocks []block
+
+	for idx := 0; idx < len(lines); {
+		switch {
+		case isTableRow(lines[idx]):
+			end := idx + 1
+			for end < len(lines) && isTableRow(lines[end]) {
+				end++
+			}
+
+			if end-idx >= blockMinLines {
+				blocks = append(blocks, block{chunkType: datastore.ChunkTypeTable, start: idx, end: end})
+			}
+			idx = end
+		case isCodeLine(lines[idx]):
+			// Indented lines and single blank lines between code lines belong to
+			// the block, e.g. the statements of a function body
+			base := indentation(lines[idx])
+			end, codeLines := idx+1, 1
+			for end < len(lines) {
+				line := lines[end]
+				if isCodeLine(line) {
+					codeLines++
+				} else if strings.TrimSpace(line) == "" {
+					if end+1 >= len(lines) || strings.TrimSpace(lines[end+1]) == "" {
+						break
+					}
+				} else if indentation(line) < base+codeIndent {
+					break
+				}
+				end++
+			}
+
+			// Don't include a trailing blank line
+			for end > idx && strings.TrimSpace(lines[end-1]) == "" {
+				end--
+			}
+
+			if codeLines >= blockMinLines {
+				blocks = append(blocks, block{chunkType: datastore.ChunkTypeCode, start: idx, end: end})
+			}
+			idx = end
+		default:
+			idx++
+		}
+	}
+
+	return blocks
+}
+
+// tableChunks splits a table into groups of rows. Each group starts with the
+// header row, so that the columns of every chunk are known.
+func tableChunks(rows []string) []string {
+	if len(rows) <= tableRowGroup+1 {
+		return []string{strings.Join(rows, "\n")}
+	}
+
+	header, body := rows[0], rows[1:]
+
+	var chunks []string
+	for start := 0; start < len(body); start += tableRowGroup {
+		group := body[start:min(start+tableRowGroup, len(body))]
+		chunks = append(chunks, header+"\n"+strings.Join(group, "\n"))
+	}
+
+	return chunks
+}
+
+// splitBlocks moves the code blocks and tables of the pages into separate chunks
+// of their type. The prose of a page stays in one chunk, which is dropped if the
+// page only contains blocks. All chunks keep the position of their page.
+func splitBlocks(pages []*datastore.DocumentChunk) []*datastore.DocumentChunk {
+	var chunks []*datastore.DocumentChunk
+
+	for _, page := range pages {
+		lines := strings.Split(page.Text, "\n")
+		blocks := findBlocks(lines)
+		if len(blocks) == 0 {
+			chunks = append(chunks, page)
+			continue
+		}
+
+		var prose []string
+		var extra []*datastore.DocumentChunk
+		next := 0
+		for _, blk := range blocks {
+			prose = append(prose, lines[next:blk.start]...)
+			next = blk.end
+
+			texts := []string{strings.Join(lines[blk.start:blk.end], "\n")}
+			if blk.chunkType == datastore.ChunkTypeTable {
+				texts = tableChunks(lines[blk.start:blk.end])
+			}
+
+			for _, text := range texts {
+				extra = append(extra, &datastore.DocumentChunk{
+					Id:       uuid.New(),
+					Text:     text,
+					Position: page.Position,
+					Type:     blk.chunkType,
+				})
+			}
+		}
+		prose = append(prose, lines[next:]...)
+
+		text := strings.TrimSpace(strings.Join(prose, "\n"))
+		if text != "" {
+			chunks = append(chunks, &datastore.DocumentChunk{
+				Id:       page.Id,
+				Text:     text,
+				Position: page.Position,
+			})
+		}
+
+		chunks = append(chunks, extra...)
+	}
+
+	return chunks
+}
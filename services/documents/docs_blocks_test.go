@@ -0,0 +1,120 @@
+package documents
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
+	"strings"
+	"testing"
+)
+
+func TestSplitBlocks(t *testing.T) {
+	page := strings.Join([]string{
+		"The server reads its configuration from a file.",
+		"",
+		"server:",
+		"  port: 8080",
+		"  host: localhost",
+		"  timeout: 30s",
+		"",
+		"The limits of the plans are:",
+		"Plan       Documents     Price",
+		"Free       10            0 USD",
+		"Pro        1000          10 USD",
+		"For more details see the pricing page.",
+	}, "\n")
+
+	pageId := uuid.New()
+	chunks := splitBlocks([]*datastore.DocumentChunk{
+		{Id: pageId, Text: page, Position: 4},
+	})
+
+	if len(chunks) != 3 {
+		t.Fatalf("expected 3 chunks, got %d", len(chunks))
+	}
+
+	prose, code, table := chunks[0], chunks[1], chunks[2]
+
+	if prose.Id != pageId || prose.Type != "" {
+		t.Errorf("expected the prose to keep the page id: %+v", prose)
+	}
+	if strings.Contains(prose.Text, "port") || strings.Contains(prose.Text, "Pro") {
+		t.Errorf("expected the blocks to be removed from the prose: %q", prose.Text)
+	}
+
+	if code.Type != datastore.ChunkTypeCode || !strings.HasPrefix(code.Text, "server:") || !strings.HasSuffix(code.Text, "timeout: 30s") {
+		t.Errorf("unexpected code chunk: %+v", code)
+	}
+
+	if table.Type != datastore.ChunkTypeTable || strings.Count(table.Text, "\n") != 2 {
+		t.Errorf("unexpected table chunk: %+v", table)
+	}
+
+	for _, chunk := range chunks {
+		if chunk.Position != 4 {
+			t.Errorf("expected position 4, got %d", chunk.Position)
+		}
+	}
+}
+
+func TestSplitBlocksProse(t *testing.T) {
+	page := strings.Join([]string{
+		"Note: the results depend on the configuration (see above).",
+		"for the most part, the defaults work well;",
+		"if they don't, change them.",
+	}, "\n")
+
+	chunks := splitBlocks([]*datastore.DocumentChunk{{Id: uuid.New(), Text: page}})
+
+	if len(chunks) != 1 || chunks[0].Text != page {
+		t.Errorf("expected the prose to be unchanged, got %d chunks", len(chunks))
+	}
+}
+
+func TestTableChunks(t *testing.T) {
+	rows := []string{"Name  Size  Price"}
+	for idx := range 45 {
+		rows = append(rows, fmt.Sprintf("row%d  %d  %d", idx, idx, idx))
+	}
+
+	chunks := tableChunks(rows)
+	if len(chunks) != 3 {
+		t.Fatalf("expected 3 row groups, got %d", len(chunks))
+	}
+
+	for _, chunk := range chunks {
+		if !strings.HasPrefix(chunk, rows[0]+"\n") {
+			t.Errorf("expected the group to start with the header: %q", chunk)
+		}
+	}
+
+	if strings.Count(chunks[2], "\n") != 5 {
+		t.Errorf("expected 5 rows in the last group: %q", chunks[2])
+	}
+}
+
+func TestCheckContentCountsPages(t *testing.T) {
+	service := &Service{Limits: Limits{MaxPages: 1}}
+
+	page := strings.Join([]string{
+		"Prose before the code.",
+		"func main() {",
+		"  fmt.Println(1);",
+		"  fmt.Println(2);",
+		"}",
+	}, "\n")
+
+	chunks := splitBlocks([]*datastore.DocumentChunk{{Id: uuid.New(), Text: page}})
+	if len(chunks) != 2 {
+		t.Fatalf("expected the prose and a code block, got %d chunks", len(chunks))
+	}
+
+	if err := service.checkContent(chunks); err != nil {
+		t.Fatalf("expected the blocks of a page to count as one page, got %v", err)
+	}
+
+	chunks = append(chunks, &datastore.DocumentChunk{Id: uuid.New(), Text: "next page", Position: 1})
+	if err := service.checkContent(chunks); err == nil {
+		t.Fatal("expected two pages to exceed the limit")
+	}
+}
@@ -0,0 +1,82 @@
+package documents
+
+import (
+	"github.com/pzierahn/chatbot_services/datastore"
+	"math"
+	"regexp"
+	"strings"
+)
+
+const (
+	// boilerplateMinPages is the minimum number of pages to detect boilerplate,
+	// because lines of shorter documents repeat by chance.
+	boilerplateMinPages = 3
+
+	// boilerplateShare is the share of pages a line must appear on to be boilerplate.
+	boilerplateShare = 0.6
+)
+
+// boilerplateDigits matches numbers, so that page numbers like "Page 3 of 10" match
+// on all pages.
+var boilerplateDigits = regexp.MustCompile(`\d+`)
+
+// normalizeLine returns the key of a line to compare it across pages.
+func normalizeLine(line string) string {
+	line = strings.Join(strings.Fields(line), " ")
+	return boilerplateDigits.ReplaceAllString(strings.ToLower(line), "#")
+}
+
+// stripBoilerplate sets the search text of the pages to their text without lines
+// that appear on most pages, like headers, footers and page numbers. Pages with only
+// boilerplate are marked, so that they aren't embedded. The text of the pages is
+// kept for display. It returns the number of removed lines.
+func stripBoilerplate(pages []*datastore.DocumentChunk) int {
+	if len(pages) < boilerplateMinPages {
+		return 0
+	}
+
+	// Count on how many pages each line appears
+	counts := make(map[string]int)
+	for _, page := range pages {
+		seen := make(map[string]bool)
+		for _, line := range strings.Split(page.Text, "\n") {
+			key := normalizeLine(line)
+			if key == "" || seen[key] {
+				continue
+			}
+
+			seen[key] = true
+			counts[key]++
+		}
+	}
+
+	threshold := int(math.Ceil(float64(len(pages)) * boilerplateShare))
+	boilerplate := make(map[string]bool)
+	for key, count := range counts {
+		if count >= threshold {
+			boilerplate[key] = true
+		}
+	}
+
+	if len(boilerplate) == 0 {
+		return 0
+	}
+
+	var stripped int
+	for _, page := range pages {
+		var lines []string
+		for _, line := range strings.Split(page.Text, "\n") {
+			if boilerplate[normalizeLine(line)] {
+				stripped++
+				continue
+			}
+
+			lines = append(lines, line)
+		}
+
+		page.SearchText = strings.TrimSpace(strings.Join(lines, "\n"))
+		page.Boilerplate = page.SearchText == ""
+	}
+
+	return stripped
+}
@@ -0,0 +1,64 @@
+package documents
+
+import (
+	"github.com/pzierahn/chatbot_services/datastore"
+	"testing"
+)
+
+func TestStripBoilerplate(t *testing.T) {
+	pages := []*datastore.DocumentChunk{
+		{Text: "ACME Annual Report\nRevenue grew strongly.\nPage 1 of 4"},
+		{Text: "ACME Annual Report\nCosts were reduced.\nPage 2 of 4"},
+		{Text: "ACME  annual report\nThe outlook is positive.\nPage 3 of 4"},
+		{Text: "Appendix\nPage 4 of 4"},
+		{Text: "ACME Annual Report\nPage 5 of 5"},
+	}
+
+	stripped := stripBoilerplate(pages)
+	if stripped != 9 {
+		t.Errorf("expected 9 stripped lines, got %d", stripped)
+	}
+
+	want := []string{
+		"Revenue grew strongly.",
+		"Costs were reduced.",
+		"The outlook is positive.",
+		"Appendix",
+		"",
+	}
+	for idx, page := range pages {
+		if page.SearchText != want[idx] {
+			t.Errorf("page %d: got %q, want %q", idx, page.SearchText, want[idx])
+		}
+	}
+
+	// Pages with only boilerplate are not embedded instead of embedding their text
+	if !pages[4].Boilerplate || searchText(pages[4]) != "" {
+		t.Errorf("expected the boilerplate page not to be embedded, got %q", searchText(pages[4]))
+	}
+	if pages[0].Boilerplate || searchText(pages[0]) != want[0] {
+		t.Errorf("expected the stripped text to be embedded, got %q", searchText(pages[0]))
+	}
+
+	// The page text is kept for display
+	if pages[0].Text != "ACME Annual Report\nRevenue grew strongly.\nPage 1 of 4" {
+		t.Errorf("page text was changed: %q", pages[0].Text)
+	}
+}
+
+func TestStripBoilerplateShortDocument(t *testing.T) {
+	pages := []*datastore.DocumentChunk{
+		{Text: "Header\nFirst page"},
+		{Text: "Header\nSecond page"},
+	}
+
+	if stripped := stripBoilerplate(pages); stripped != 0 {
+		t.Errorf("expected no stripped lines, got %d", stripped)
+	}
+
+	for _, page := range pages {
+		if page.SearchText != "" {
+			t.Errorf("expected no search text, got %q", page.SearchText)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package documents
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"google.golang.org/grpc/codes"
+)
+
+// MaxChunkIds is the maximum number of chunks that can be requested at once.
+const MaxChunkIds = 100
+
+// GetChunks returns chunks by their ids. Chunks of documents that are neither owned
+// by the user nor part of a collection shared with the user are omitted from the results.
+func (service *Service) GetChunks(ctx context.Context, req *pb.ChunkIDs) (*pb.Chunks, error) {
+	userId, err := service.Auth.Verify(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.Ids) > MaxChunkIds {
+		return nil, rpcerror.New(codes.InvalidArgument, rpcerror.ReasonLimitExceeded, "ids", fmt.Sprintf("too many chunk ids: %d > %d", len(req.Ids), MaxChunkIds))
+	}
+
+	ids := make([]uuid.UUID, 0, len(req.Ids))
+	for _, id := range req.Ids {
+		chunkId, err := uuid.Parse(id)
+		if err != nil {
+			return nil, rpcerror.InvalidId("ids", id)
+		}
+		ids = append(ids, chunkId)
+	}
+
+	chunks, err := service.Database.GetChunks(ctx, userId, ids...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Chunks can belong to documents of several collections, each with one owner
+	accessed := make(map[uuid.UUID][]uuid.UUID)
+	owners := make(map[uuid.UUID]string)
+	seen := make(map[uuid.UUID]bool)
+	for _, chunk := range chunks {
+		if seen[chunk.DocumentId] {
+			continue
+		}
+		seen[chunk.DocumentId] = true
+		accessed[chunk.CollectionId] = append(accessed[chunk.CollectionId], chunk.DocumentId)
+		owners[chunk.CollectionId] = chunk.UserId
+	}
+
+	for collectionId, docIds := range accessed {
+		service.AccessLog.Log(datastore.AccessLogEntry{
+			UserId:       userId,
+			OwnerId:      owners[collectionId],
+			CollectionId: collectionId,
+			DocumentIds:  docIds,
+			Action:       datastore.AccessGetChunks,
+		})
+	}
+
+	results := &pb.Chunks{}
+	for _, chunk := range chunks {
+		results.Items = append(results.Items, &pb.Chunk{
+			Id:         chunk.Id.String(),
+			Text:       chunk.Text,
+			Postion:    chunk.Position,
+			DocumentId: chunk.DocumentId.String(),
+			Language:   chunk.Language,
+			Type:       chunk.Type,
+		})
+	}
+
+	return results, nil
+}
@@ -0,0 +1,103 @@
+package documents
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
+	"github.com/pzierahn/chatbot_services/search"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+)
+
+// getCollection returns the collection and the role of the user if the collection
+// is owned by or shared with the user and was indexed with the embedding model of
+// the search index. Mixing embedding models would silently return unrelated search
+// results.
+func (service *Service) getCollection(ctx context.Context, userId string, collectionId uuid.UUID) (*datastore.CollectionAccess, error) {
+	access, err := service.Database.GetCollectionAccess(ctx, userId, collectionId)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, rpcerror.NotFound("collection", collectionId.String())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = search.CheckEmbeddingModel(service.SearchIndex, access.Collection.EmbeddingModel)
+	if err != nil {
+		return nil, rpcerror.New(codes.FailedPrecondition, rpcerror.ReasonModelMismatch, "collection_id", err.Error())
+	}
+
+	return access, nil
+}
+
+// checkActiveCollection ensures that the user can write to the collection and that
+// it is not archived, so that new documents can be indexed. It returns the id of
+// the owner.
+func (service *Service) checkActiveCollection(ctx context.Context, userId string, collectionId uuid.UUID) (string, error) {
+	access, err := service.getCollection(ctx, userId, collectionId)
+	if err != nil {
+		return "", err
+	}
+
+	if !access.CanWrite() {
+		return "", readOnlyError(collectionId)
+	}
+
+	if access.Collection.Archived {
+		return "", rpcerror.New(codes.FailedPrecondition, rpcerror.ReasonArchived, "collection_id",
+			fmt.Sprintf("collection is archived: %s", collectionId))
+	}
+
+	return access.Collection.UserId, nil
+}
+
+// documentAccess returns the id, owner, collection and index time of a document if
+// the user owns it or has access to its collection, and whether the user can change it.
+func (service *Service) documentAccess(ctx context.Context, userId string, docId uuid.UUID) (*datastore.Document, bool, error) {
+	doc, err := service.Database.GetDocumentOwner(ctx, docId)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, false, rpcerror.NotFound("document", docId.String())
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	if doc.UserId == userId {
+		return doc, true, nil
+	}
+
+	access, err := service.Database.GetCollectionAccess(ctx, userId, doc.CollectionId)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		// Don't reveal documents of other users
+		return nil, false, rpcerror.NotFound("document", docId.String())
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	return doc, access.CanWrite(), nil
+}
+
+// documentOwner returns the id of the owner of a document if the user can change
+// it, either as owner or with write access to its collection.
+func (service *Service) documentOwner(ctx context.Context, userId string, docId uuid.UUID) (string, error) {
+	doc, canWrite, err := service.documentAccess(ctx, userId, docId)
+	if err != nil {
+		return "", err
+	}
+
+	if !canWrite {
+		return "", readOnlyError(doc.CollectionId)
+	}
+
+	return doc.UserId, nil
+}
+
+// readOnlyError is returned if a user with read access tries to change a collection.
+func readOnlyError(collectionId uuid.UUID) error {
+	return rpcerror.New(codes.PermissionDenied, rpcerror.ReasonReadOnly, "collection_id",
+		fmt.Sprintf("write access required: %s", collectionId))
+}
@@ -0,0 +1,30 @@
+package documents
+
+import (
+	"context"
+	"github.com/google/uuid"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+)
+
+// indexingCosts returns the costs of indexing the documents by their id. Documents
+// without recorded usage are missing.
+func (service *Service) indexingCosts(ctx context.Context, ids ...uuid.UUID) (map[string]*pb.IndexingCost, error) {
+	costs := make(map[string]*pb.IndexingCost)
+	if len(ids) == 0 {
+		return costs, nil
+	}
+
+	usages, err := service.Database.UsageByDocument(ctx, ids...)
+	if err != nil {
+		return nil, err
+	}
+
+	for docId, usage := range usages {
+		costs[docId.String()] = &pb.IndexingCost{
+			Tokens: usage.InputTokens,
+			Costs:  usage.Costs,
+		}
+	}
+
+	return costs, nil
+}
@@ -1,13 +1,32 @@
 package documents
 
 import (
+	"cloud.google.com/go/storage"
 	"context"
+	"errors"
 	"github.com/google/uuid"
 	"github.com/pzierahn/chatbot_services/datastore"
 	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
+// deleteDocumentData removes the stored file and the search index entries of a document.
+// A file that was already deleted, e.g. by an earlier failed delete, is no error, so
+// that deletes can be retried.
+func (service *Service) deleteDocumentData(ctx context.Context, userId string, doc *datastore.Document) error {
+	if doc.Type == datastore.DocumentTypePDF {
+		obj := service.Storage.Object(doc.Source)
+		err := obj.Delete(ctx)
+		if err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+			return err
+		}
+	}
+
+	return service.SearchIndex.DeleteDocument(ctx, userId, doc.CollectionId.String(), doc.Id.String())
+}
+
 func (service *Service) Delete(ctx context.Context, req *pb.DocumentID) (*emptypb.Empty, error) {
 	userId, err := service.Auth.Verify(ctx)
 	if err != nil {
@@ -15,32 +34,133 @@ func (service *Service) Delete(ctx context.Context, req *pb.DocumentID) (*emptyp
 	}
 
 	docId, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, rpcerror.InvalidId("id", req.Id)
+	}
+
+	// Documents of shared collections can be deleted with write access
+	ownerId, err := service.documentOwner(ctx, userId, docId)
 	if err != nil {
 		return nil, err
 	}
 
-	doc, err := service.Database.GetDocument(ctx, userId, docId)
+	doc, err := service.Database.GetDocument(ctx, ownerId, docId)
 	if err != nil {
 		return nil, err
 	}
 
-	if doc.Type == datastore.DocumentTypePDF {
-		obj := service.Storage.Object(doc.Source)
-		err = obj.Delete(ctx)
+	err = service.deleteDocumentData(ctx, ownerId, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	err = service.Database.DeleteDocument(ctx, ownerId, docId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// DeleteMany deletes multiple documents and reports which documents could not be deleted.
+func (service *Service) DeleteMany(ctx context.Context, req *pb.DocumentIDs) (*pb.DeleteResults, error) {
+	userId, err := service.Auth.Verify(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := &pb.DeleteResults{
+		Failed: make(map[string]string),
+	}
+
+	var docIds []uuid.UUID
+	for _, id := range req.Ids {
+		docId, err := uuid.Parse(id)
 		if err != nil {
-			return nil, err
+			results.Failed[id] = "invalid document id"
+			continue
 		}
+
+		docIds = append(docIds, docId)
 	}
 
-	err = service.SearchIndex.DeleteDocument(ctx, userId, req.CollectionId, req.Id)
+	if len(docIds) == 0 {
+		return results, nil
+	}
+
+	// Only documents owned by the user are returned
+	docs, err := service.Database.GetDocumentMeta(ctx, userId, docIds...)
 	if err != nil {
 		return nil, err
 	}
 
-	err = service.Database.DeleteDocument(ctx, userId, docId)
+	owned := make(map[uuid.UUID]bool)
+	for _, doc := range docs {
+		owned[doc.Id] = true
+	}
+
+	// Other documents require write access to their shared collection
+	shared := make(map[string][]uuid.UUID)
+	for _, docId := range docIds {
+		if owned[docId] {
+			continue
+		}
+
+		ownerId, err := service.documentOwner(ctx, userId, docId)
+		if err != nil {
+			results.Failed[docId.String()] = status.Convert(err).Message()
+			continue
+		}
+
+		shared[ownerId] = append(shared[ownerId], docId)
+	}
+
+	service.deleteDocuments(ctx, userId, docs, results)
+
+	for ownerId, ids := range shared {
+		docs, err := service.Database.GetDocumentMeta(ctx, ownerId, ids...)
+		if err != nil {
+			return nil, err
+		}
+
+		service.deleteDocuments(ctx, ownerId, docs, results)
+	}
+
+	return results, nil
+}
+
+// deleteDocuments deletes documents of the owner and adds them to the results. The
+// database, the index and the bucket share no transaction. The file and the vectors
+// of a document are deleted before its entry, so that a failure leaves a document
+// that is reported as failed and can be deleted again, never an entry without data
+// that is reported as deleted.
+func (service *Service) deleteDocuments(ctx context.Context, ownerId string, docs []datastore.Document, results *pb.DeleteResults) {
+	var deleted []uuid.UUID
+	for idx := range docs {
+		doc := &docs[idx]
+
+		err := service.deleteDocumentData(ctx, ownerId, doc)
+		if err != nil {
+			results.Failed[doc.Id.String()] = err.Error()
+			continue
+		}
+
+		deleted = append(deleted, doc.Id)
+	}
+
+	if len(deleted) == 0 {
+		return
+	}
+
+	err := service.Database.DeleteDocuments(ctx, ownerId, deleted...)
 	if err != nil {
-		return nil, err
+		for _, docId := range deleted {
+			results.Failed[docId.String()] = err.Error()
+		}
+		return
 	}
 
-	return &emptypb.Empty{}, nil
+	for _, docId := range deleted {
+		results.Deleted = append(results.Deleted, docId.String())
+	}
 }
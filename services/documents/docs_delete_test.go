@@ -0,0 +1,109 @@
+package documents
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
+	"github.com/pzierahn/chatbot_services/search"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"os"
+	"testing"
+)
+
+// failingIndex fails to delete the vectors of the failing documents.
+type failingIndex struct {
+	search.Index
+	failing map[string]bool
+	deleted []string
+}
+
+func (index *failingIndex) DeleteDocument(_ context.Context, _, _, documentId string) error {
+	if index.failing[documentId] {
+		return errors.New("index unavailable")
+	}
+
+	index.deleted = append(index.deleted, documentId)
+	return nil
+}
+
+func TestDeleteMany(t *testing.T) {
+	uri := os.Getenv("CHATBOT_MONGODB_URI")
+	if uri == "" {
+		t.Skip("CHATBOT_MONGODB_URI not set")
+	}
+
+	ctx := context.Background()
+
+	db, err := datastore.NewFrom(ctx, uri, datastore.PoolConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	userId := "test-" + uuid.NewString()
+	collection := &datastore.Collection{
+		Id:     uuid.New(),
+		UserId: userId,
+		Name:   "delete",
+	}
+
+	err = db.InsertCollection(ctx, collection)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = db.DeleteCollection(ctx, userId, collection.Id) }()
+
+	var ids []string
+	for _, name := range []string{"deleted", "failing"} {
+		doc := &datastore.Document{
+			Id:           uuid.New(),
+			UserId:       userId,
+			CollectionId: collection.Id,
+			Name:         name,
+			Type:         datastore.DocumentTypeWeb,
+			Source:       "https://example.com/" + name,
+		}
+
+		err = db.InsertDocument(ctx, doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ids = append(ids, doc.Id.String())
+	}
+
+	index := &failingIndex{failing: map[string]bool{ids[1]: true}}
+	service := &Service{
+		Auth:        &testVerifier{userId: userId},
+		Database:    db,
+		SearchIndex: index,
+	}
+
+	results, err := service.DeleteMany(ctx, &pb.DocumentIDs{Ids: append(ids, "not-a-uuid")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results.Deleted) != 1 || results.Deleted[0] != ids[0] {
+		t.Fatalf("expected only the first document to be deleted, got %v", results.Deleted)
+	}
+	if results.Failed[ids[1]] == "" || results.Failed["not-a-uuid"] == "" {
+		t.Fatalf("expected the failing and the malformed document to be reported, got %v", results.Failed)
+	}
+
+	// The document whose vectors couldn't be deleted is kept for a retry
+	_, err = db.GetDocument(ctx, userId, uuid.MustParse(ids[1]))
+	if err != nil {
+		t.Fatalf("expected the failed document to be kept, got %v", err)
+	}
+
+	delete(index.failing, ids[1])
+	results, err = service.DeleteMany(ctx, &pb.DocumentIDs{Ids: ids[1:]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results.Deleted) != 1 || len(results.Failed) != 0 {
+		t.Fatalf("expected the retry to delete the document, got %v", results)
+	}
+}
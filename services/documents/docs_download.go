@@ -0,0 +1,105 @@
+package documents
+
+import (
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"google.golang.org/grpc/codes"
+	"io"
+)
+
+// downloadChunkSize is the maximum number of bytes sent per message
+const downloadChunkSize = 512 * 1024
+
+// Download streams the original file of a document.
+func (service *Service) Download(req *pb.DocumentID, stream pb.Document_DownloadServer) error {
+	ctx := stream.Context()
+
+	userId, err := service.Auth.Verify(ctx)
+	if err != nil {
+		return err
+	}
+
+	docId, err := uuid.Parse(req.Id)
+	if err != nil {
+		return rpcerror.InvalidId("id", req.Id)
+	}
+
+	// Documents of shared collections belong to the owner
+	owner, _, err := service.documentAccess(ctx, userId, docId)
+	if err != nil {
+		return err
+	}
+
+	docs, err := service.Database.GetDocumentMeta(ctx, owner.UserId, docId)
+	if err != nil {
+		return err
+	}
+
+	if len(docs) == 0 {
+		return rpcerror.NotFound("document", req.Id)
+	}
+
+	doc := docs[0]
+	if doc.Type != datastore.DocumentTypePDF {
+		return rpcerror.New(codes.FailedPrecondition, rpcerror.ReasonNoStoredData, "id",
+			fmt.Sprintf("document %s has no stored file", req.Id))
+	}
+
+	read, err := service.Storage.Object(doc.Source).NewReader(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = read.Close() }()
+
+	service.logDocument(userId, owner, datastore.AccessDownload)
+
+	contentType := read.Attrs.ContentType
+	if contentType == "" {
+		contentType = "application/pdf"
+	}
+
+	chunk := &pb.FileChunk{
+		Filename:    doc.Name,
+		ContentType: contentType,
+	}
+
+	return sendFile(read, chunk, stream)
+}
+
+// sendFile streams a file in messages of at most downloadChunkSize bytes. The first
+// message carries the file name and content type of the given chunk, empty files
+// are sent as a single message without data.
+func sendFile(read io.Reader, chunk *pb.FileChunk, stream pb.Document_DownloadServer) error {
+	buf := make([]byte, downloadChunkSize)
+	sent := false
+	for {
+		n, err := io.ReadFull(read, buf)
+		if n > 0 {
+			chunk.Data = buf[:n]
+
+			if err := stream.Send(chunk); err != nil {
+				return err
+			}
+
+			chunk = &pb.FileChunk{}
+			sent = true
+		}
+
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if !sent {
+		return stream.Send(chunk)
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package documents
+
+import (
+	"bytes"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"testing"
+)
+
+func TestSendFile(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), downloadChunkSize/5+1)
+
+	stream := &testFileStream{}
+	err := sendFile(bytes.NewReader(data), &pb.FileChunk{
+		Filename:    "report.pdf",
+		ContentType: "application/pdf",
+	}, stream)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(stream.chunks) != 3 {
+		t.Fatalf("expected 3 chunks, got %d", len(stream.chunks))
+	}
+
+	var received []byte
+	for idx, chunk := range stream.chunks {
+		if len(chunk.Data) > downloadChunkSize {
+			t.Errorf("chunk %d exceeds the chunk size: %d bytes", idx, len(chunk.Data))
+		}
+		if idx > 0 && (chunk.Filename != "" || chunk.ContentType != "") {
+			t.Errorf("expected only the first chunk to carry the metadata, got %q in chunk %d", chunk.Filename, idx)
+		}
+		received = append(received, chunk.Data...)
+	}
+
+	if first := stream.chunks[0]; first.Filename != "report.pdf" || first.ContentType != "application/pdf" {
+		t.Errorf("expected the metadata in the first chunk, got %+v", first)
+	}
+
+	if !bytes.Equal(received, data) {
+		t.Fatal("expected the chunks to add up to the file")
+	}
+}
+
+func TestSendFileEmpty(t *testing.T) {
+	stream := &testFileStream{}
+	err := sendFile(bytes.NewReader(nil), &pb.FileChunk{Filename: "empty.pdf"}, stream)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(stream.chunks) != 1 || stream.chunks[0].Filename != "empty.pdf" {
+		t.Fatalf("expected a single chunk with the metadata, got %v", stream.chunks)
+	}
+}
@@ -2,15 +2,35 @@ package documents
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
 	"github.com/pzierahn/chatbot_services/datastore"
+	"github.com/pzierahn/chatbot_services/llm"
 	"github.com/pzierahn/chatbot_services/search"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"google.golang.org/grpc/codes"
+	"log"
 	"time"
 )
 
-// addToSearchIndex adds the document content to the search index.
-func (service *Service) addToSearchIndex(ctx context.Context, doc *datastore.Document) error {
+// searchText returns the text of a chunk that is embedded, empty if the chunk
+// isn't embedded.
+func searchText(chunk *datastore.DocumentChunk) string {
+	if chunk.Boilerplate {
+		return ""
+	}
+
+	if chunk.SearchText != "" {
+		return chunk.SearchText
+	}
+
+	return chunk.Text
+}
+
+// addToSearchIndex adds the document content to the search index and records the
+// embedding usage for the user.
+func (service *Service) addToSearchIndex(ctx context.Context, userId string, doc *datastore.Document, progress search.Progress) error {
 	var vectors []*search.Fragment
 
 	for _, fragment := range doc.Content {
@@ -19,31 +39,42 @@ func (service *Service) addToSearchIndex(ctx context.Context, doc *datastore.Doc
 		}
 
 		// Empty fragments lead to errors in the search index
-		if fragment.Text == "" {
+		text := searchText(fragment)
+		if text == "" {
 			continue
 		}
 
 		vectors = append(vectors, &search.Fragment{
 			Id:           fragment.Id.String(),
-			Text:         fragment.Text,
+			Text:         text,
 			UserId:       doc.UserId,
 			DocumentId:   doc.Id.String(),
 			CollectionId: doc.CollectionId.String(),
 			Position:     fragment.Position,
+			Language:     fragment.Language,
+			Type:         fragment.Type,
 		})
 	}
 
-	usage, err := service.SearchIndex.Upsert(ctx, vectors)
+	usage, err := service.SearchIndex.Upsert(ctx, vectors, progress)
+	if errors.Is(err, llm.ErrInputTooLong) {
+		return rpcerror.New(codes.InvalidArgument, rpcerror.ReasonLimitExceeded, "document", err.Error())
+	}
 	if err != nil {
 		return err
 	}
 
-	_ = service.Database.InsertModelUsage(ctx, &datastore.ModelUsage{
+	if usage.Truncated > 0 {
+		log.Printf("truncated %d of %d chunks of %s to the maximum embedding input", usage.Truncated, len(vectors), doc.Id)
+	}
+
+	service.Database.RecordModelUsage(ctx, &datastore.ModelUsage{
 		Id:          uuid.New(),
-		UserId:      doc.UserId,
+		UserId:      userId,
 		Timestamp:   time.Now(),
 		ModelId:     usage.ModelId,
 		InputTokens: usage.Tokens,
+		DocumentId:  doc.Id,
 	})
 
 	return nil
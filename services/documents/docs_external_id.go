@@ -0,0 +1,54 @@
+package documents
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+)
+
+// MaxExternalIdLength is the maximum number of bytes of an external document id.
+const MaxExternalIdLength = 256
+
+// externalDocument returns the document of the collection with the external id of
+// the job, which is indexed again, or nil if the document has to be created.
+func (service *Service) externalDocument(ctx context.Context, ownerId string, collectionId uuid.UUID, req *pb.IndexJob) (*datastore.Document, error) {
+	if req.ExternalId == "" {
+		return nil, nil
+	}
+
+	if len(req.ExternalId) > MaxExternalIdLength {
+		return nil, rpcerror.New(codes.InvalidArgument, rpcerror.ReasonLimitExceeded, "external_id",
+			fmt.Sprintf("external_id too long: %d bytes exceeds the limit of %d", len(req.ExternalId), MaxExternalIdLength))
+	}
+
+	previous, err := service.Database.FindExternalDocument(ctx, ownerId, collectionId, req.ExternalId)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Id != "" && req.Id != previous.Id.String() {
+		return nil, rpcerror.New(codes.InvalidArgument, rpcerror.ReasonInvalidValue, "id",
+			fmt.Sprintf("external_id %s belongs to document %s", req.ExternalId, previous.Id))
+	}
+
+	return previous, nil
+}
+
+// chunkIds returns the ids of the chunks of a document.
+func chunkIds(document *datastore.Document) []string {
+	ids := make([]string, len(document.Content))
+	for idx, chunk := range document.Content {
+		ids[idx] = chunk.Id.String()
+	}
+
+	return ids
+}
@@ -0,0 +1,95 @@
+package documents
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
+	"github.com/pzierahn/chatbot_services/search"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExternalDocumentValidation(t *testing.T) {
+	service := &Service{}
+
+	previous, err := service.externalDocument(context.Background(), "user", uuid.New(), &pb.IndexJob{})
+	if err != nil || previous != nil {
+		t.Fatalf("jobs without external id must create documents, got %v, %v", previous, err)
+	}
+
+	_, err = service.externalDocument(context.Background(), "user", uuid.New(), &pb.IndexJob{
+		ExternalId: strings.Repeat("x", MaxExternalIdLength+1),
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for a long external id, got %v", err)
+	}
+}
+
+// testIndex records the deletes of chunks and fails the first ones.
+type testIndex struct {
+	search.Index
+	failures  int
+	documents []string
+	fragments [][]string
+}
+
+func (index *testIndex) DeleteDocument(_ context.Context, _, _, documentId string) error {
+	index.documents = append(index.documents, documentId)
+	return nil
+}
+
+func (index *testIndex) DeleteFragments(_ context.Context, _, _, _ string, ids ...string) error {
+	index.fragments = append(index.fragments, ids)
+	if index.failures > 0 {
+		index.failures--
+		return errors.New("unavailable")
+	}
+
+	return nil
+}
+
+func TestRemoveChunks(t *testing.T) {
+	index := &testIndex{}
+	service := &Service{SearchIndex: index}
+
+	doc := &datastore.Document{
+		Id:      uuid.New(),
+		Content: []*datastore.DocumentChunk{{Id: uuid.New()}},
+	}
+
+	// A new document that lost the race for its external id
+	service.removeChunks(context.Background(), doc, false)
+	if len(index.documents) != 1 || index.documents[0] != doc.Id.String() {
+		t.Fatalf("expected the vectors of the document to be deleted, got %v", index.documents)
+	}
+
+	// An update keeps the chunks of the stored version
+	service.removeChunks(context.Background(), doc, true)
+	if len(index.documents) != 1 || len(index.fragments) != 1 || index.fragments[0][0] != doc.Content[0].Id.String() {
+		t.Fatalf("expected only the new chunks to be deleted, got %v", index.fragments)
+	}
+}
+
+func TestRemoveStaleChunks(t *testing.T) {
+	delays := staleChunkRetryDelays
+	staleChunkRetryDelays = []time.Duration{0, 0}
+	t.Cleanup(func() { staleChunkRetryDelays = delays })
+
+	index := &testIndex{failures: 2}
+	service := &Service{SearchIndex: index}
+
+	previous := &datastore.Document{
+		Id:      uuid.New(),
+		Content: []*datastore.DocumentChunk{{Id: uuid.New()}, {Id: uuid.New()}},
+	}
+
+	service.removeStaleChunks(context.Background(), previous)
+	if len(index.fragments) != 3 || index.failures != 0 {
+		t.Fatalf("expected two retries, got %d attempts", len(index.fragments))
+	}
+}
@@ -0,0 +1,68 @@
+package documents
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Get returns the metadata of a single document.
+func (service *Service) Get(ctx context.Context, req *pb.DocumentID) (*pb.DocumentHeader, error) {
+	userId, err := service.Auth.Verify(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	docId, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, rpcerror.InvalidId("id", req.Id)
+	}
+
+	// Documents of shared collections belong to the owner
+	owner, _, err := service.documentAccess(ctx, userId, docId)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := service.Database.GetDocumentHeader(ctx, owner.UserId, docId)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, rpcerror.NotFound("document", req.Id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, err := documentMetadata(&doc.Document)
+	if err != nil {
+		return nil, err
+	}
+
+	header := &pb.DocumentHeader{
+		Id:           doc.Id.String(),
+		CollectionId: doc.CollectionId.String(),
+		Metadata:     metadata,
+		Pages:        doc.Pages,
+		Language:     doc.Language,
+		ExternalId:   doc.ExternalId,
+	}
+
+	if !doc.CreatedAt.IsZero() {
+		header.CreatedAt = timestamppb.New(doc.CreatedAt)
+	}
+
+	if !doc.IndexedAt.IsZero() {
+		header.IndexedAt = timestamppb.New(doc.IndexedAt)
+	}
+
+	costs, err := service.indexingCosts(ctx, doc.Id)
+	if err != nil {
+		return nil, err
+	}
+	header.IndexingCost = costs[doc.Id.String()]
+
+	return header, nil
+}
@@ -0,0 +1,68 @@
+package documents
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+func TestDocumentMetadata(t *testing.T) {
+	web, err := documentMetadata(&datastore.Document{
+		Type:   datastore.DocumentTypeWeb,
+		Name:   "Example",
+		Source: "https://example.com",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page := web.GetWeb(); page.GetTitle() != "Example" || page.GetUrl() != "https://example.com" {
+		t.Errorf("unexpected web metadata %v", web)
+	}
+
+	pdf, err := documentMetadata(&datastore.Document{
+		Type:   datastore.DocumentTypePDF,
+		Name:   "report.pdf",
+		Source: "documents/report.pdf",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if file := pdf.GetFile(); file.GetFilename() != "report.pdf" || file.GetPath() != "documents/report.pdf" {
+		t.Errorf("unexpected file metadata %v", pdf)
+	}
+
+	if _, err := documentMetadata(&datastore.Document{Type: "unknown"}); err == nil {
+		t.Error("expected an error for unknown document types")
+	}
+}
+
+func TestGet(t *testing.T) {
+	fixture := newGrantFixture(t)
+	service := fixture.service(fixture.owner)
+
+	header, err := service.Get(context.Background(), &pb.DocumentID{Id: fixture.doc.Id.String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if header.Id != fixture.doc.Id.String() || header.CollectionId != fixture.doc.CollectionId.String() {
+		t.Errorf("unexpected ids %s, %s", header.Id, header.CollectionId)
+	}
+	if header.Pages != 1 || header.Metadata.GetWeb().GetUrl() != fixture.doc.Source {
+		t.Errorf("unexpected header %v", header)
+	}
+
+	_, err = service.Get(context.Background(), &pb.DocumentID{Id: uuid.NewString()})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound for unknown documents, got %v", err)
+	}
+
+	_, err = service.Get(context.Background(), &pb.DocumentID{Id: "not-a-uuid"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for invalid ids, got %v", err)
+	}
+}
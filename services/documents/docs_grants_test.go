@@ -0,0 +1,227 @@
+package documents
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"os"
+	"testing"
+	"time"
+)
+
+type testVerifier struct {
+	userId string
+}
+
+func (verifier *testVerifier) Verify(context.Context) (string, error) {
+	return verifier.userId, nil
+}
+
+func (verifier *testVerifier) VerifyFunding(context.Context) (string, error) {
+	return verifier.userId, nil
+}
+
+// testFileStream collects the chunks of a download. Chunks are copied like gRPC
+// serializes them on Send, since their data may be reused afterward.
+type testFileStream struct {
+	grpc.ServerStream
+	chunks []*pb.FileChunk
+}
+
+func (stream *testFileStream) Context() context.Context {
+	return context.Background()
+}
+
+func (stream *testFileStream) Send(chunk *pb.FileChunk) error {
+	stream.chunks = append(stream.chunks, proto.Clone(chunk).(*pb.FileChunk))
+	return nil
+}
+
+// grantFixture is a web document in a collection of the owner, which is shared
+// with the grantee for reading.
+type grantFixture struct {
+	db       *datastore.Service
+	owner    string
+	grantee  string
+	stranger string
+	doc      *datastore.Document
+}
+
+func newGrantFixture(t *testing.T) *grantFixture {
+	uri := os.Getenv("CHATBOT_MONGODB_URI")
+	if uri == "" {
+		t.Skip("CHATBOT_MONGODB_URI not set")
+	}
+
+	ctx := context.Background()
+
+	db, err := datastore.NewFrom(ctx, uri, datastore.PoolConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(db.Close)
+
+	fixture := &grantFixture{
+		db:       db,
+		owner:    "test-" + uuid.NewString(),
+		grantee:  "test-" + uuid.NewString(),
+		stranger: "test-" + uuid.NewString(),
+	}
+
+	collection := &datastore.Collection{
+		Id:     uuid.New(),
+		UserId: fixture.owner,
+		Name:   "shared",
+	}
+
+	err = db.InsertCollection(ctx, collection)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.DeleteCollection(ctx, fixture.owner, collection.Id) })
+
+	fixture.doc = &datastore.Document{
+		Id:           uuid.New(),
+		UserId:       fixture.owner,
+		CollectionId: collection.Id,
+		Name:         "shared page",
+		Type:         datastore.DocumentTypeWeb,
+		Source:       "https://example.com",
+		Content: []*datastore.DocumentChunk{
+			{Id: uuid.New(), Text: "shared content", Position: 1},
+		},
+	}
+
+	err = db.InsertDocument(ctx, fixture.doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = db.StoreDocumentText(ctx, &datastore.DocumentText{
+		Id:           fixture.doc.Id,
+		UserId:       fixture.owner,
+		CollectionId: collection.Id,
+		Text:         "shared content",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = db.StoreGrant(ctx, &datastore.AccessGrant{
+		Id:           uuid.New(),
+		CollectionId: collection.Id,
+		OwnerId:      fixture.owner,
+		GranteeId:    fixture.grantee,
+		Role:         datastore.RoleRead,
+		CreatedAt:    time.Now(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return fixture
+}
+
+// service returns the documents service for the user.
+func (fixture *grantFixture) service(userId string) *Service {
+	return &Service{
+		Auth:     &testVerifier{userId: userId},
+		Database: fixture.db,
+	}
+}
+
+// users returns the expected error code of an RPC for each user.
+func (fixture *grantFixture) users(allowed codes.Code) map[string]codes.Code {
+	return map[string]codes.Code{
+		fixture.owner:    allowed,
+		fixture.grantee:  allowed,
+		fixture.stranger: codes.NotFound,
+	}
+}
+
+func TestGrantAccess(t *testing.T) {
+	fixture := newGrantFixture(t)
+	ctx := context.Background()
+	docId := &pb.DocumentID{Id: fixture.doc.Id.String()}
+
+	for userId, want := range fixture.users(codes.OK) {
+		service := fixture.service(userId)
+
+		_, err := service.Get(ctx, docId)
+		if status.Code(err) != want {
+			t.Errorf("Get by %s: expected %v, got %v", userId, want, err)
+		}
+
+		_, err = service.ListChunks(ctx, &pb.ChunkListRequest{DocumentId: docId.Id})
+		if status.Code(err) != want {
+			t.Errorf("ListChunks by %s: expected %v, got %v", userId, want, err)
+		}
+
+		stream := &testFileStream{}
+		err = service.DownloadText(docId, stream)
+		if status.Code(err) != want {
+			t.Errorf("DownloadText by %s: expected %v, got %v", userId, want, err)
+		}
+		if want == codes.OK && (len(stream.chunks) != 1 || string(stream.chunks[0].Data) != "shared content") {
+			t.Errorf("DownloadText by %s: unexpected chunks %v", userId, stream.chunks)
+		}
+	}
+
+	// Web documents have no stored file, only users with access learn that
+	for userId, want := range fixture.users(codes.FailedPrecondition) {
+		err := fixture.service(userId).Download(docId, &testFileStream{})
+		if status.Code(err) != want {
+			t.Errorf("Download by %s: expected %v, got %v", userId, want, err)
+		}
+	}
+}
+
+func TestGrantAccessChunks(t *testing.T) {
+	fixture := newGrantFixture(t)
+	ctx := context.Background()
+	chunkIds := &pb.ChunkIDs{Ids: []string{fixture.doc.Content[0].Id.String()}}
+
+	expected := map[string]int{
+		fixture.owner:    1,
+		fixture.grantee:  1,
+		fixture.stranger: 0,
+	}
+
+	for userId, want := range expected {
+		chunks, err := fixture.service(userId).GetChunks(ctx, chunkIds)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(chunks.Items) != want {
+			t.Errorf("GetChunks by %s: expected %d chunks, got %d", userId, want, len(chunks.Items))
+		}
+	}
+}
+
+func TestGrantAccessList(t *testing.T) {
+	fixture := newGrantFixture(t)
+	ctx := context.Background()
+	filter := &pb.DocumentFilter{CollectionId: fixture.doc.CollectionId.String()}
+
+	for _, userId := range []string{fixture.owner, fixture.grantee} {
+		list, err := fixture.service(userId).List(ctx, filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(list.Ids) != 1 || list.Ids[0] != fixture.doc.Id.String() {
+			t.Errorf("List by %s: expected the shared document, got %v", userId, list.Ids)
+		}
+	}
+
+	_, err := fixture.service(fixture.stranger).List(ctx, filter)
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("List by stranger: expected PermissionDenied, got %v", err)
+	}
+}
@@ -6,9 +6,15 @@ import (
 	"github.com/google/uuid"
 	"github.com/pzierahn/chatbot_services/datastore"
 	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
 	"github.com/pzierahn/chatbot_services/utils"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"io"
+	"log"
 	"strings"
+	"time"
 )
 
 func (service *Service) Index(req *pb.IndexJob, stream pb.Document_IndexServer) error {
@@ -25,23 +31,105 @@ func (service *Service) Index(req *pb.IndexJob, stream pb.Document_IndexServer)
 	} else {
 		documentId, err = uuid.Parse(req.Id)
 		if err != nil {
-			return err
+			return rpcerror.InvalidId("id", req.Id)
 		}
 	}
 
 	collectionId, err := uuid.Parse(req.CollectionId)
+	if err != nil {
+		return rpcerror.InvalidId("collection_id", req.CollectionId)
+	}
+
+	ownerId, err := service.checkActiveCollection(ctx, userId, collectionId)
+	if err != nil {
+		return err
+	}
+
+	if req.CallbackUrl != "" {
+		if service.WebhookSecret == "" {
+			return rpcerror.New(codes.FailedPrecondition, rpcerror.ReasonWebhooksDisabled, "callback_url",
+				"index callbacks are disabled on this server")
+		}
+
+		err = validateCallbackUrl(ctx, req.CallbackUrl)
+		if err != nil {
+			return err
+		}
+	}
+
+	previous, err := service.externalDocument(ctx, ownerId, collectionId, req)
 	if err != nil {
 		return err
 	}
 
+	// Documents belong to the owner of the collection
 	data := &datastore.Document{
 		Id:           documentId,
-		UserId:       userId,
+		UserId:       ownerId,
 		CollectionId: collectionId,
 		Name:         "",
 		Type:         "",
 		Source:       "",
+		CreatedAt:    time.Now(),
+		ExternalId:   req.ExternalId,
+	}
+
+	if previous != nil {
+		data.Id = previous.Id
+		data.CreatedAt = previous.CreatedAt
+	}
+
+	err = service.index(ctx, userId, req, data, previous, stream)
+
+	if req.CallbackUrl != "" {
+		event := &IndexEvent{
+			DocumentId:   data.Id.String(),
+			CollectionId: data.CollectionId.String(),
+			Status:       IndexStatusSuccess,
+			Pages:        countPages(data.Content),
+		}
+
+		if status.Code(err) == codes.Canceled {
+			event.Status = IndexStatusCanceled
+		} else if err != nil {
+			event.Status = IndexStatusFailed
+			event.Error = err.Error()
+		}
+
+		go service.notifyWebhook(req.CallbackUrl, event)
+	}
+
+	return err
+}
+
+// progressSender receives the progress of an indexing job.
+type progressSender interface {
+	Send(*pb.IndexProgress) error
+}
+
+// index extracts the document content and inserts it into the search index and
+// database. The embedding usage is attributed to the user, who may not own the document.
+// A previous version of the document is replaced once the new one is searchable.
+func (service *Service) index(ctx context.Context, userId string, req *pb.IndexJob, data *datastore.Document, previous *datastore.Document, stream progressSender) (err error) {
+	ctx, done, err := service.jobs.start(ctx, data.Id, userId, data.UserId)
+	if err != nil {
+		return err
 	}
+	defer done()
+
+	defer func() {
+		if err != nil && indexCanceled(ctx) {
+			err = service.cleanupCanceled(ctx, data, previous != nil, stream)
+		}
+	}()
+
+	_ = stream.Send(&pb.IndexProgress{
+		Status:     "Started",
+		DocumentId: data.Id.String(),
+		Updated:    previous != nil,
+	})
+
+	var text string
 
 	switch req.Document.Data.(type) {
 	case *pb.DocumentMetadata_Web:
@@ -53,7 +141,7 @@ func (service *Service) Index(req *pb.IndexJob, stream pb.Document_IndexServer)
 		data.Type = datastore.DocumentTypeWeb
 		data.Name = meta.Title
 		data.Source = meta.Url
-		data.Content, err = service.getWebChunks(ctx, meta)
+		text, data.Content, err = service.getWebChunks(ctx, meta)
 	case *pb.DocumentMetadata_File:
 		_ = stream.Send(&pb.IndexProgress{
 			Status: "Extracting PDF pages",
@@ -62,33 +150,111 @@ func (service *Service) Index(req *pb.IndexJob, stream pb.Document_IndexServer)
 		data.Type = datastore.DocumentTypePDF
 		data.Name = meta.Filename
 		data.Source = meta.Path
-		data.Content, err = service.getPDFChunks(ctx, meta)
+		text, data.Content, data.FileSize, err = service.getPDFChunks(ctx, meta, req.ContentChunks)
 	default:
-		return fmt.Errorf("unsupported metadata type")
+		return rpcerror.Missing("document")
+	}
+
+	if err != nil {
+		return err
 	}
 
+	if req.ContentChunks && data.Type == datastore.DocumentTypePDF {
+		data.Content = splitBlocks(data.Content)
+	}
+
+	// Reject large documents before any embeddings are paid for
+	err = service.checkContent(data.Content)
 	if err != nil {
 		return err
 	}
 
+	if req.StripBoilerplate && data.Type == datastore.DocumentTypePDF {
+		// Headers and footers are only part of the prose of the pages
+		var prose []*datastore.DocumentChunk
+		for _, chunk := range data.Content {
+			if chunk.Type == "" {
+				prose = append(prose, chunk)
+			}
+		}
+
+		stripped := stripBoilerplate(prose)
+		log.Printf("stripped %d boilerplate lines from %s", stripped, data.Id)
+
+		_ = stream.Send(&pb.IndexProgress{
+			Status:        fmt.Sprintf("Stripped %d boilerplate lines", stripped),
+			Progress:      1.0 / 3.0,
+			StrippedLines: uint32(stripped),
+		})
+	}
+
+	if indexCanceled(ctx) {
+		return errIndexCanceled
+	}
+
+	detectLanguages(data)
+
 	_ = stream.Send(&pb.IndexProgress{
 		Status:   "Inserting into search database",
 		Progress: 1.0 / 3.0,
 	})
-	err = service.addToSearchIndex(ctx, data)
+	err = service.addToSearchIndex(ctx, userId, data, func(processed, total int) {
+		_ = stream.Send(&pb.IndexProgress{
+			Status:         "Inserting into search database",
+			Progress:       (1.0 + float32(processed)/float32(total)) / 3.0,
+			ProcessedPages: uint32(processed),
+			TotalPages:     uint32(total),
+		})
+	})
 	if err != nil {
 		return err
 	}
 
+	// The last batch of embeddings may finish after the job was canceled
+	if indexCanceled(ctx) {
+		return errIndexCanceled
+	}
+
 	_ = stream.Send(&pb.IndexProgress{
 		Status:   "Inserting into database",
 		Progress: 2.0 / 3.0,
 	})
-	err = service.Database.InsertDocument(ctx, data)
+
+	// The embeddings are paid for, so the document is saved even if the stream
+	// is canceled, e.g. by the client disconnecting
+	persistCtx := context.WithoutCancel(ctx)
+
+	data.IndexedAt = time.Now()
+	if previous != nil {
+		err = service.Database.ReplaceDocument(persistCtx, data)
+	} else {
+		err = service.Database.InsertDocument(persistCtx, data)
+	}
+	if err != nil {
+		// The new chunks are in the search index, but the document isn't stored
+		service.removeChunks(persistCtx, data, previous != nil)
+
+		if mongo.IsDuplicateKeyError(err) && data.ExternalId != "" {
+			// Another job created the document with the external id in the meantime
+			return rpcerror.New(codes.AlreadyExists, rpcerror.ReasonAlreadyExists, "external_id",
+				fmt.Sprintf("document with external_id %s already exists", data.ExternalId))
+		}
+
+		return err
+	}
+
+	// Keep the extracted text, so that it doesn't have to be extracted again
+	err = service.Database.StoreDocumentText(persistCtx, documentText(data, text))
 	if err != nil {
 		return err
 	}
 
+	// The old chunks are removed after the new ones are stored, so that the
+	// document stays searchable while it's indexed again
+	if previous != nil {
+		service.removeStaleChunks(persistCtx, previous)
+	}
+
 	_ = stream.Send(&pb.IndexProgress{
 		Status:   "Success",
 		Progress: 1.0,
@@ -97,10 +263,65 @@ func (service *Service) Index(req *pb.IndexJob, stream pb.Document_IndexServer)
 	return nil
 }
 
-func (service *Service) getWebChunks(ctx context.Context, meta *pb.Webpage) ([]*datastore.DocumentChunk, error) {
+// staleChunkRetryDelays are the waits before retrying to remove the old chunks of
+// a document that was indexed again.
+var staleChunkRetryDelays = []time.Duration{time.Second, 4 * time.Second}
+
+// removeStaleChunks removes the old chunks of a document that was indexed again.
+// The document is already replaced, so failures are retried and finally logged
+// with the chunk ids instead of failing the job.
+func (service *Service) removeStaleChunks(ctx context.Context, previous *datastore.Document) {
+	ids := chunkIds(previous)
+
+	err := service.SearchIndex.DeleteFragments(ctx, previous.UserId, previous.CollectionId.String(), previous.Id.String(), ids...)
+	for _, delay := range staleChunkRetryDelays {
+		if err == nil {
+			return
+		}
+
+		time.Sleep(delay)
+		err = service.SearchIndex.DeleteFragments(ctx, previous.UserId, previous.CollectionId.String(), previous.Id.String(), ids...)
+	}
+
+	if err != nil {
+		log.Printf("failed to remove stale chunks %v of document %s: %v", ids, previous.Id, err)
+	}
+}
+
+// removeChunks removes the chunks of a job from the search index, e.g. after it
+// was canceled or the document couldn't be stored. Updates only remove the new
+// chunks, the old ones still belong to the stored document.
+func (service *Service) removeChunks(ctx context.Context, data *datastore.Document, update bool) {
+	ctx = context.WithoutCancel(ctx)
+
+	var err error
+	if update {
+		err = service.SearchIndex.DeleteFragments(ctx, data.UserId, data.CollectionId.String(), data.Id.String(), chunkIds(data)...)
+	} else {
+		err = service.SearchIndex.DeleteDocument(ctx, data.UserId, data.CollectionId.String(), data.Id.String())
+	}
+	if err != nil {
+		log.Printf("failed to remove chunks of document %s: %v", data.Id, err)
+	}
+}
+
+// cleanupCanceled removes the chunks of a canceled job from the search index and
+// returns the error that ends the stream.
+func (service *Service) cleanupCanceled(ctx context.Context, data *datastore.Document, update bool, stream progressSender) error {
+	service.removeChunks(ctx, data, update)
+
+	_ = stream.Send(&pb.IndexProgress{
+		Status:     "Canceled",
+		DocumentId: data.Id.String(),
+	})
+
+	return status.Error(codes.Canceled, "indexing canceled")
+}
+
+func (service *Service) getWebChunks(ctx context.Context, meta *pb.Webpage) (string, []*datastore.DocumentChunk, error) {
 	text, err := utils.Scrape(ctx, meta.Url)
 	if err != nil {
-		return nil, err
+		return "", nil, err
 	}
 
 	var inx uint32
@@ -121,36 +342,56 @@ func (service *Service) getWebChunks(ctx context.Context, meta *pb.Webpage) ([]*
 		inx++
 	}
 
-	return chunks, nil
+	return text, chunks, nil
 }
 
-func (service *Service) getPDFChunks(ctx context.Context, meta *pb.File) ([]*datastore.DocumentChunk, error) {
+// getPDFChunks extracts one chunk per page of a PDF and returns them with the size
+// of the file. With layout, the text keeps the columns of tables and the indentation
+// of code for splitBlocks.
+func (service *Service) getPDFChunks(ctx context.Context, meta *pb.File, layout bool) (string, []*datastore.DocumentChunk, int64, error) {
 
 	obj := service.Storage.Object(meta.Path)
+	attrs, err := obj.Attrs(ctx)
+	if err != nil {
+		return "", nil, 0, err
+	}
+
+	err = service.checkFileSize(uint64(attrs.Size))
+	if err != nil {
+		return "", nil, 0, err
+	}
+
 	read, err := obj.NewReader(ctx)
 	if err != nil {
-		return nil, err
+		return "", nil, 0, err
 	}
 	defer func() { _ = read.Close() }()
 
 	raw, err := io.ReadAll(read)
 	if err != nil {
-		return nil, err
+		return "", nil, 0, err
+	}
+
+	extract := utils.GetPagesFromPDFBytes
+	if layout {
+		extract = utils.GetLayoutPagesFromPDFBytes
 	}
 
-	pages, err := utils.GetPagesFromPDFBytes(ctx, raw)
+	pages, err := extract(ctx, raw)
 	if err != nil {
-		return nil, err
+		return "", nil, 0, err
 	}
 
 	chunks := make([]*datastore.DocumentChunk, len(pages))
+	texts := make([]string, len(pages))
 	for inx, page := range pages {
+		texts[inx] = strings.TrimSpace(page)
 		chunks[inx] = &datastore.DocumentChunk{
 			Id:       uuid.New(),
-			Text:     strings.TrimSpace(page),
+			Text:     texts[inx],
 			Position: uint32(inx),
 		}
 	}
 
-	return chunks, nil
+	return strings.Join(texts, "\n\n"), chunks, attrs.Size, nil
 }
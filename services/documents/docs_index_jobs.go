@@ -0,0 +1,119 @@
+package documents
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"sync"
+)
+
+// errIndexCanceled is the cause of the context of indexing jobs stopped by CancelIndex.
+var errIndexCanceled = errors.New("indexing canceled")
+
+// indexJob is a running indexing job.
+type indexJob struct {
+	userId  string // User who started the job
+	ownerId string // Owner of the collection
+	cancel  context.CancelCauseFunc
+}
+
+// indexJobs tracks the running indexing jobs by document id. Jobs are only known
+// to the instance that runs them.
+type indexJobs struct {
+	mu   sync.Mutex
+	jobs map[uuid.UUID]*indexJob
+}
+
+// start registers a job and returns its context, which is canceled with
+// errIndexCanceled by cancel. The returned function unregisters the job.
+func (registry *indexJobs) start(ctx context.Context, id uuid.UUID, userId, ownerId string) (context.Context, func(), error) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	if _, ok := registry.jobs[id]; ok {
+		return nil, nil, status.Errorf(codes.AlreadyExists, "document %s is already being indexed", id)
+	}
+
+	if registry.jobs == nil {
+		registry.jobs = make(map[uuid.UUID]*indexJob)
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	registry.jobs[id] = &indexJob{
+		userId:  userId,
+		ownerId: ownerId,
+		cancel:  cancel,
+	}
+
+	done := func() {
+		registry.mu.Lock()
+		defer registry.mu.Unlock()
+
+		delete(registry.jobs, id)
+		cancel(nil)
+	}
+
+	return ctx, done, nil
+}
+
+// cancel stops the job of a document. Only the user who started the job and the
+// owner of the collection can cancel it. It reports whether a job was canceled.
+func (registry *indexJobs) cancel(id uuid.UUID, userId string) bool {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	job, ok := registry.jobs[id]
+	if !ok || (job.userId != userId && job.ownerId != userId) {
+		return false
+	}
+
+	job.cancel(errIndexCanceled)
+
+	return true
+}
+
+// running reports whether the document is being indexed.
+func (registry *indexJobs) running(id uuid.UUID) bool {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	_, ok := registry.jobs[id]
+	return ok
+}
+
+// Indexing reports whether the document is being indexed by this instance. The
+// vectors of the document may already exist while it is not stored yet.
+func (service *Service) Indexing(documentId uuid.UUID) bool {
+	return service.jobs.running(documentId)
+}
+
+// indexCanceled reports whether the job of the context was stopped by CancelIndex.
+func indexCanceled(ctx context.Context) bool {
+	return errors.Is(context.Cause(ctx), errIndexCanceled)
+}
+
+// CancelIndex stops a running Index or Upload of a document. The job stops after
+// the embeddings in progress and removes the chunks that were already added to
+// the search index. The document is not stored.
+func (service *Service) CancelIndex(ctx context.Context, req *pb.DocumentID) (*emptypb.Empty, error) {
+	userId, err := service.Auth.Verify(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	docId, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, rpcerror.InvalidId("id", req.Id)
+	}
+
+	if !service.jobs.cancel(docId, userId) {
+		return nil, rpcerror.NotFound("indexing job", req.Id)
+	}
+
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,64 @@
+package documents
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+func TestIndexJobsCancel(t *testing.T) {
+	var registry indexJobs
+	id := uuid.New()
+
+	ctx, done, err := registry.start(context.Background(), id, "user", "owner")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !registry.running(id) {
+		t.Fatal("expected the job to be running")
+	}
+
+	// The same document can't be indexed twice at the same time
+	_, _, err = registry.start(context.Background(), id, "user", "owner")
+	if status.Code(err) != codes.AlreadyExists {
+		t.Fatalf("expected AlreadyExists, got %v", err)
+	}
+
+	if registry.cancel(id, "stranger") {
+		t.Fatal("other users must not cancel the job")
+	}
+	if indexCanceled(ctx) {
+		t.Fatal("job canceled by another user")
+	}
+
+	if !registry.cancel(id, "owner") {
+		t.Fatal("owner couldn't cancel the job")
+	}
+	if !indexCanceled(ctx) {
+		t.Fatal("job not canceled")
+	}
+
+	done()
+
+	if registry.running(id) {
+		t.Fatal("finished job reported as running")
+	}
+
+	if registry.cancel(id, "user") {
+		t.Fatal("finished jobs can't be canceled")
+	}
+
+	// Finished jobs are not reported as canceled
+	ctx, done, err = registry.start(context.Background(), id, "user", "owner")
+	if err != nil {
+		t.Fatal(err)
+	}
+	done()
+
+	if indexCanceled(ctx) {
+		t.Fatal("finished job reported as canceled")
+	}
+}
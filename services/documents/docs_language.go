@@ -0,0 +1,26 @@
+package documents
+
+import (
+	"github.com/pzierahn/chatbot_services/datastore"
+	"github.com/pzierahn/chatbot_services/search"
+)
+
+// detectLanguages sets the language of each chunk and the most frequent chunk
+// language as the language of the document.
+func detectLanguages(doc *datastore.Document) {
+	counts := make(map[string]int)
+
+	for _, chunk := range doc.Content {
+		chunk.Language = search.DetectLanguage(chunk.Text)
+		if chunk.Language != "" {
+			counts[chunk.Language]++
+		}
+	}
+
+	doc.Language = ""
+	for language, count := range counts {
+		if count > counts[doc.Language] || (count == counts[doc.Language] && language < doc.Language) {
+			doc.Language = language
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package documents
+
+import (
+	"fmt"
+	"github.com/pzierahn/chatbot_services/datastore"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"google.golang.org/grpc/codes"
+	"os"
+	"strconv"
+)
+
+const (
+	// DefaultMaxFileSize is the maximum size of a stored or uploaded file in bytes.
+	DefaultMaxFileSize = 32 << 20
+
+	// DefaultMaxPages is the maximum number of pages or chunks of a document.
+	DefaultMaxPages = 1000
+
+	// DefaultMaxTextBytes is the maximum size of the extracted text of a document.
+	DefaultMaxTextBytes = 8 << 20
+)
+
+// Limits restrict the size of indexed documents. They are checked before any
+// embeddings are created. Zero values use the defaults.
+type Limits struct {
+	MaxFileSize  uint64
+	MaxPages     int
+	MaxTextBytes int
+}
+
+// LimitsFromEnv reads the limits from CHATBOT_MAX_UPLOAD_SIZE, CHATBOT_MAX_PAGES
+// and CHATBOT_MAX_TEXT_BYTES.
+func LimitsFromEnv() Limits {
+	var limits Limits
+
+	if size, err := strconv.ParseUint(os.Getenv("CHATBOT_MAX_UPLOAD_SIZE"), 10, 64); err == nil {
+		limits.MaxFileSize = size
+	}
+
+	if pages, err := strconv.Atoi(os.Getenv("CHATBOT_MAX_PAGES")); err == nil {
+		limits.MaxPages = pages
+	}
+
+	if size, err := strconv.Atoi(os.Getenv("CHATBOT_MAX_TEXT_BYTES")); err == nil {
+		limits.MaxTextBytes = size
+	}
+
+	return limits
+}
+
+// limits returns the configured limits with defaults for unset values.
+func (service *Service) limits() Limits {
+	limits := service.Limits
+
+	if limits.MaxFileSize == 0 {
+		limits.MaxFileSize = DefaultMaxFileSize
+	}
+
+	if limits.MaxPages <= 0 {
+		limits.MaxPages = DefaultMaxPages
+	}
+
+	if limits.MaxTextBytes <= 0 {
+		limits.MaxTextBytes = DefaultMaxTextBytes
+	}
+
+	return limits
+}
+
+// checkFileSize returns an error if a file exceeds the size limit.
+func (service *Service) checkFileSize(size uint64) error {
+	limit := service.limits().MaxFileSize
+	if size > limit {
+		return rpcerror.New(codes.ResourceExhausted, rpcerror.ReasonLimitExceeded, "",
+			fmt.Sprintf("file too large: %d bytes exceeds the limit of %d bytes", size, limit),
+			"limit", "max_file_size")
+	}
+
+	return nil
+}
+
+// countPages returns the number of distinct positions of the chunks. The code
+// blocks and tables of a page keep its position.
+func countPages(content []*datastore.DocumentChunk) int {
+	positions := make(map[uint32]bool)
+	for _, chunk := range content {
+		positions[chunk.Position] = true
+	}
+
+	return len(positions)
+}
+
+// checkContent returns an error if the extracted content of a document exceeds the limits.
+func (service *Service) checkContent(content []*datastore.DocumentChunk) error {
+	limits := service.limits()
+
+	if pages := countPages(content); pages > limits.MaxPages {
+		return rpcerror.New(codes.ResourceExhausted, rpcerror.ReasonLimitExceeded, "",
+			fmt.Sprintf("document too large: %d pages exceeds the limit of %d pages", pages, limits.MaxPages),
+			"limit", "max_pages")
+	}
+
+	var size int
+	for _, chunk := range content {
+		size += len(chunk.Text)
+	}
+
+	if size > limits.MaxTextBytes {
+		return rpcerror.New(codes.ResourceExhausted, rpcerror.ReasonLimitExceeded, "",
+			fmt.Sprintf("document too large: %d bytes of text exceeds the limit of %d bytes", size, limits.MaxTextBytes),
+			"limit", "max_text_bytes")
+	}
+
+	return nil
+}
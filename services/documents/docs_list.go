@@ -2,12 +2,51 @@ package documents
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
 	"github.com/pzierahn/chatbot_services/datastore"
 	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// documentMetadata converts the document type and source to the proto metadata.
+func documentMetadata(doc *datastore.Document) (*pb.DocumentMetadata, error) {
+	switch doc.Type {
+	case datastore.DocumentTypeWeb:
+		return &pb.DocumentMetadata{
+			Data: &pb.DocumentMetadata_Web{
+				Web: &pb.Webpage{
+					Title: doc.Name,
+					Url:   doc.Source,
+				},
+			},
+		}, nil
+	case datastore.DocumentTypePDF:
+		return &pb.DocumentMetadata{
+			Data: &pb.DocumentMetadata_File{
+				File: &pb.File{
+					Filename: doc.Name,
+					Path:     doc.Source,
+				},
+			},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown document type: %s", doc.Type)
+	}
+}
+
+// documentOrders maps the sort keys of the filter to the datastore.
+var documentOrders = map[pb.DocumentOrder]string{
+	pb.DocumentOrder_DOCUMENT_ORDER_NAME:       datastore.OrderByName,
+	pb.DocumentOrder_DOCUMENT_ORDER_CREATED_AT: datastore.OrderByCreatedAt,
+	pb.DocumentOrder_DOCUMENT_ORDER_PAGES:      datastore.OrderByPages,
+	pb.DocumentOrder_DOCUMENT_ORDER_INDEXED_AT: datastore.OrderByIndexedAt,
+}
+
 func (service *Service) List(ctx context.Context, req *pb.DocumentFilter) (*pb.DocumentList, error) {
 
 	userId, err := service.Auth.Verify(ctx)
@@ -15,15 +54,33 @@ func (service *Service) List(ctx context.Context, req *pb.DocumentFilter) (*pb.D
 		return nil, err
 	}
 
+	orderBy, ok := documentOrders[req.OrderBy]
+	if !ok {
+		return nil, rpcerror.New(codes.InvalidArgument, rpcerror.ReasonInvalidValue, "order_by",
+			fmt.Sprintf("unknown order: %s", req.OrderBy))
+	}
+
 	filter := datastore.DocumentFilter{
-		UserId: userId,
-		Query:  req.Query,
+		UserId:     userId,
+		Query:      req.Query,
+		OrderBy:    orderBy,
+		Descending: req.Descending,
 	}
 
 	filter.CollectionId, err = uuid.Parse(req.CollectionId)
+	if err != nil {
+		return nil, rpcerror.InvalidId("collection_id", req.CollectionId)
+	}
+
+	// Documents of shared collections belong to the owner
+	access, err := service.Database.GetCollectionAccess(ctx, userId, filter.CollectionId)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, rpcerror.AccessDenied(req.CollectionId)
+	}
 	if err != nil {
 		return nil, err
 	}
+	filter.UserId = access.Collection.UserId
 
 	docs, err := service.Database.ListDocuments(ctx, filter)
 	if err != nil {
@@ -31,37 +88,34 @@ func (service *Service) List(ctx context.Context, req *pb.DocumentFilter) (*pb.D
 	}
 
 	result := &pb.DocumentList{
-		Items: make(map[string]*pb.DocumentMetadata),
+		Items:     make(map[string]*pb.DocumentMetadata),
+		CreatedAt: make(map[string]*timestamppb.Timestamp),
+		IndexedAt: make(map[string]*timestamppb.Timestamp),
 	}
 
-	for _, doc := range docs {
+	ids := make([]uuid.UUID, len(docs))
+	for idx := range docs {
+		metadata, err := documentMetadata(&docs[idx])
+		if err != nil {
+			return nil, err
+		}
 
-		var metadata *pb.DocumentMetadata
+		docId := docs[idx].Id.String()
+		result.Items[docId] = metadata
+		result.Ids = append(result.Ids, docId)
+		ids[idx] = docs[idx].Id
 
-		switch doc.Type {
-		case datastore.DocumentTypeWeb:
-			metadata = &pb.DocumentMetadata{
-				Data: &pb.DocumentMetadata_Web{
-					Web: &pb.Webpage{
-						Title: doc.Name,
-						Url:   doc.Source,
-					},
-				},
-			}
-		case datastore.DocumentTypePDF:
-			metadata = &pb.DocumentMetadata{
-				Data: &pb.DocumentMetadata_File{
-					File: &pb.File{
-						Filename: doc.Name,
-						Path:     doc.Source,
-					},
-				},
-			}
-		default:
-			return nil, fmt.Errorf("unknown document type: %s", doc.Type)
+		if !docs[idx].CreatedAt.IsZero() {
+			result.CreatedAt[docId] = timestamppb.New(docs[idx].CreatedAt)
+		}
+		if !docs[idx].IndexedAt.IsZero() {
+			result.IndexedAt[docId] = timestamppb.New(docs[idx].IndexedAt)
 		}
+	}
 
-		result.Items[doc.Id.String()] = metadata
+	result.IndexingCosts, err = service.indexingCosts(ctx, ids...)
+	if err != nil {
+		return nil, err
 	}
 
 	return result, nil
@@ -0,0 +1,88 @@
+package documents
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"strconv"
+)
+
+const (
+	defaultChunkPageSize = 20
+	maxChunkPageSize     = 100
+)
+
+// ListChunks returns the chunks of a document page by page, to inspect how a document was chunked.
+func (service *Service) ListChunks(ctx context.Context, req *pb.ChunkListRequest) (*pb.ChunkList, error) {
+	userId, err := service.Auth.Verify(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	docId, err := uuid.Parse(req.DocumentId)
+	if err != nil {
+		return nil, rpcerror.InvalidId("document_id", req.DocumentId)
+	}
+
+	pageSize := int(req.PageSize)
+	if pageSize == 0 {
+		pageSize = defaultChunkPageSize
+	}
+	pageSize = min(pageSize, maxChunkPageSize)
+
+	var offset int
+	if req.PageToken != "" {
+		offset, err = strconv.Atoi(req.PageToken)
+		if err != nil || offset < 0 {
+			return nil, rpcerror.New(codes.InvalidArgument, rpcerror.ReasonInvalidValue, "page_token", fmt.Sprintf("invalid page token: %s", req.PageToken))
+		}
+	}
+
+	// Documents of shared collections belong to the owner
+	owner, _, err := service.documentAccess(ctx, userId, docId)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := service.Database.GetDocumentHeader(ctx, owner.UserId, docId)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, rpcerror.NotFound("document", req.DocumentId)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	chunks, err := service.Database.ListChunks(ctx, owner.UserId, docId, offset, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	service.logDocument(userId, owner, datastore.AccessListChunks)
+
+	results := &pb.ChunkList{
+		Total: header.Chunks,
+	}
+
+	for _, chunk := range chunks {
+		results.Chunks = append(results.Chunks, &pb.Chunk{
+			Id:         chunk.Id.String(),
+			Text:       chunk.Text,
+			Postion:    chunk.Position,
+			DocumentId: req.DocumentId,
+			Language:   chunk.Language,
+			Type:       chunk.Type,
+		})
+	}
+
+	if next := offset + len(chunks); next < int(header.Chunks) && len(chunks) > 0 {
+		results.NextPageToken = strconv.Itoa(next)
+	}
+
+	return results, nil
+}
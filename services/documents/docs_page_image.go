@@ -0,0 +1,237 @@
+package documents
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"github.com/pzierahn/chatbot_services/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultPageDPI is the resolution of page images if the request doesn't set one.
+	DefaultPageDPI = 100
+
+	// MaxPageDPI limits the resolution, so that images stay below the message size limit.
+	MaxPageDPI = 200
+
+	// DefaultPageCacheSize is the number of bytes of rendered pages kept in memory.
+	DefaultPageCacheSize = 64 * 1024 * 1024
+
+	// MaxPageRenders limits the pages rendered at the same time by an instance,
+	// further requests wait for a free slot.
+	MaxPageRenders = 4
+
+	// PageRenderTimeout limits the time pdftoppm may take for a page.
+	PageRenderTimeout = 30 * time.Second
+)
+
+// renderSlots are the free slots to render pages, each running pdftoppm keeps
+// the PDF and the image in memory.
+var renderSlots = make(chan struct{}, MaxPageRenders)
+
+// acquireRender waits for a free render slot. The returned function releases it.
+func acquireRender(ctx context.Context) (func(), error) {
+	select {
+	case renderSlots <- struct{}{}:
+		return func() { <-renderSlots }, nil
+	case <-ctx.Done():
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+}
+
+var imageFormats = map[pb.ImageFormat]string{
+	pb.ImageFormat_IMAGE_FORMAT_PNG:  utils.ImagePNG,
+	pb.ImageFormat_IMAGE_FORMAT_JPEG: utils.ImageJPEG,
+}
+
+type pageKey struct {
+	documentId uuid.UUID
+	page       uint32
+	dpi        uint32
+	format     string
+
+	// indexedAt identifies the version of a document, so that the pages of a
+	// document indexed again under the same id are rendered again
+	indexedAt int64
+}
+
+type pageEntry struct {
+	key   pageKey
+	image []byte
+}
+
+// PageCache keeps rendered pages in memory. The least recently used pages are
+// evicted once the images exceed the size in bytes.
+type PageCache struct {
+	mu      sync.Mutex
+	size    int
+	used    int
+	entries map[pageKey]*list.Element
+	order   *list.List
+}
+
+// NewPageCache creates a cache for size bytes of images.
+func NewPageCache(size int) *PageCache {
+	return &PageCache{
+		size:    size,
+		entries: make(map[pageKey]*list.Element),
+		order:   list.New(),
+	}
+}
+
+func (cache *PageCache) get(key pageKey) ([]byte, bool) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	element, ok := cache.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	cache.order.MoveToBack(element)
+
+	return element.Value.(*pageEntry).image, true
+}
+
+func (cache *PageCache) put(key pageKey, image []byte) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if len(image) > cache.size {
+		return
+	}
+
+	if element, ok := cache.entries[key]; ok {
+		cache.used -= len(element.Value.(*pageEntry).image)
+		cache.order.Remove(element)
+	}
+
+	for cache.used+len(image) > cache.size {
+		oldest := cache.order.Front()
+		entry := cache.order.Remove(oldest).(*pageEntry)
+		cache.used -= len(entry.image)
+		delete(cache.entries, entry.key)
+	}
+
+	cache.entries[key] = cache.order.PushBack(&pageEntry{key: key, image: image})
+	cache.used += len(image)
+}
+
+// GetPageImage renders a page of a PDF document as an image, so that clients can
+// show the cited page. Documents of shared collections can be read by all members.
+func (service *Service) GetPageImage(ctx context.Context, req *pb.PageImageRequest) (*pb.PageImage, error) {
+	userId, err := service.Auth.Verify(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	docId, err := uuid.Parse(req.DocumentId)
+	if err != nil {
+		return nil, rpcerror.InvalidId("document_id", req.DocumentId)
+	}
+
+	format, ok := imageFormats[req.Format]
+	if !ok {
+		return nil, rpcerror.New(codes.InvalidArgument, rpcerror.ReasonInvalidValue, "format",
+			fmt.Sprintf("unsupported image format: %v", req.Format))
+	}
+
+	dpi := req.Dpi
+	if dpi == 0 {
+		dpi = DefaultPageDPI
+	}
+	if dpi > MaxPageDPI {
+		return nil, rpcerror.New(codes.InvalidArgument, rpcerror.ReasonLimitExceeded, "dpi",
+			fmt.Sprintf("dpi %d exceeds the limit of %d", dpi, MaxPageDPI))
+	}
+
+	owner, _, err := service.documentAccess(ctx, userId, docId)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pb.PageImage{
+		ContentType: "image/" + format,
+	}
+
+	key := pageKey{
+		documentId: docId,
+		page:       req.Page,
+		dpi:        dpi,
+		format:     format,
+		indexedAt:  owner.IndexedAt.UnixMilli(),
+	}
+	if service.PageCache != nil {
+		if image, ok := service.PageCache.get(key); ok {
+			service.logDocument(userId, owner, datastore.AccessPageImage)
+			response.Data = image
+			return response, nil
+		}
+	}
+
+	release, err := acquireRender(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	docs, err := service.Database.GetDocumentMeta(ctx, owner.UserId, docId)
+	if err != nil {
+		return nil, err
+	}
+	if len(docs) == 0 {
+		return nil, rpcerror.NotFound("document", req.DocumentId)
+	}
+
+	doc := docs[0]
+	if doc.Type != datastore.DocumentTypePDF {
+		return nil, rpcerror.New(codes.FailedPrecondition, rpcerror.ReasonInvalidValue, "document_id",
+			fmt.Sprintf("document %s is not a PDF", req.DocumentId))
+	}
+
+	read, err := service.Storage.Object(doc.Source).NewReader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = read.Close() }()
+
+	data, err := io.ReadAll(read)
+	if err != nil {
+		return nil, err
+	}
+
+	renderCtx, cancel := context.WithTimeout(ctx, PageRenderTimeout)
+	defer cancel()
+
+	image, err := utils.RenderPDFPage(renderCtx, data, int(req.Page)+1, int(dpi), format)
+	if ctx.Err() == nil && errors.Is(renderCtx.Err(), context.DeadlineExceeded) {
+		return nil, rpcerror.New(codes.DeadlineExceeded, rpcerror.ReasonLimitExceeded, "page",
+			fmt.Sprintf("rendering page %d took longer than %v", req.Page, PageRenderTimeout))
+	}
+	if errors.Is(err, utils.ErrPageNotFound) {
+		return nil, rpcerror.New(codes.InvalidArgument, rpcerror.ReasonInvalidValue, "page",
+			fmt.Sprintf("document has no page %d", req.Page))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if service.PageCache != nil {
+		service.PageCache.put(key, image)
+	}
+
+	service.logDocument(userId, owner, datastore.AccessPageImage)
+	response.Data = image
+
+	return response, nil
+}
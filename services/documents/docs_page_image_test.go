@@ -0,0 +1,86 @@
+package documents
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+	"time"
+)
+
+func TestPageCache(t *testing.T) {
+	cache := NewPageCache(10)
+	doc := uuid.New()
+
+	first := pageKey{documentId: doc, page: 0, dpi: 100, format: "png"}
+	second := pageKey{documentId: doc, page: 1, dpi: 100, format: "png"}
+	third := pageKey{documentId: doc, page: 2, dpi: 100, format: "png"}
+
+	cache.put(first, make([]byte, 4))
+	cache.put(second, make([]byte, 4))
+
+	// Reading the first page makes the second one the least recently used
+	if _, ok := cache.get(first); !ok {
+		t.Fatal("expected the first page to be cached")
+	}
+
+	cache.put(third, make([]byte, 4))
+
+	if _, ok := cache.get(second); ok {
+		t.Error("expected the second page to be evicted")
+	}
+	if _, ok := cache.get(first); !ok {
+		t.Error("expected the first page to be cached")
+	}
+	if cache.used != 8 {
+		t.Errorf("expected 8 bytes used, got %d", cache.used)
+	}
+
+	// Images larger than the cache are not cached
+	cache.put(second, make([]byte, 11))
+	if _, ok := cache.get(second); ok {
+		t.Error("expected the large image not to be cached")
+	}
+}
+
+func TestPageCacheVersion(t *testing.T) {
+	cache := NewPageCache(10)
+	key := pageKey{documentId: uuid.New(), page: 0, dpi: 100, format: "png", indexedAt: 1}
+	cache.put(key, make([]byte, 4))
+
+	// Indexing the document again changes its version
+	key.indexedAt = 2
+	if _, ok := cache.get(key); ok {
+		t.Fatal("expected the page of the previous version not to be returned")
+	}
+}
+
+func TestAcquireRender(t *testing.T) {
+	var releases []func()
+	for idx := 0; idx < MaxPageRenders; idx++ {
+		release, err := acquireRender(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		releases = append(releases, release)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := acquireRender(ctx)
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Fatalf("expected DeadlineExceeded without a free slot, got %v", err)
+	}
+
+	for _, release := range releases {
+		release()
+	}
+
+	release, err := acquireRender(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+}
@@ -2,11 +2,41 @@ package documents
 
 import (
 	"context"
+	"fmt"
 	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
 	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"path"
+	"strings"
 )
 
+// validateDocumentName checks the new name of a document and appends the
+// extension if the new name omits it.
+func validateDocumentName(name, ext string) (string, error) {
+	name = strings.TrimSpace(name)
+
+	if name == "" {
+		return "", rpcerror.Missing("name")
+	}
+
+	if strings.ContainsAny(name, "/\\") {
+		return "", rpcerror.New(codes.InvalidArgument, rpcerror.ReasonInvalidValue, "name", "document name must not contain slashes")
+	}
+
+	if name == "." || name == ".." {
+		return "", rpcerror.New(codes.InvalidArgument, rpcerror.ReasonInvalidValue, "name", fmt.Sprintf("invalid document name: %s", name))
+	}
+
+	if ext != "" && !strings.EqualFold(path.Ext(name), ext) {
+		name += ext
+	}
+
+	return name, nil
+}
+
 func (service *Service) Rename(ctx context.Context, req *pb.RenameDocument) (*emptypb.Empty, error) {
 	userId, err := service.Auth.Verify(ctx)
 	if err != nil {
@@ -14,11 +44,37 @@ func (service *Service) Rename(ctx context.Context, req *pb.RenameDocument) (*em
 	}
 
 	docId, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, rpcerror.InvalidId("id", req.Id)
+	}
+
+	// Documents of shared collections can be renamed with write access
+	ownerId, err := service.documentOwner(ctx, userId, docId)
+	if err != nil {
+		return nil, err
+	}
+
+	docs, err := service.Database.GetDocumentMeta(ctx, ownerId, docId)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(docs) == 0 {
+		return nil, rpcerror.NotFound("document", req.Id)
+	}
+
+	// Web documents are named by their title, which has no extension
+	var ext string
+	if docs[0].Type == datastore.DocumentTypePDF {
+		ext = path.Ext(docs[0].Name)
+	}
+
+	name, err := validateDocumentName(req.Name, ext)
 	if err != nil {
 		return nil, err
 	}
 
-	err = service.Database.RenameDocument(ctx, userId, docId, req.Name)
+	err = service.Database.RenameDocument(ctx, ownerId, docId, name)
 	if err != nil {
 		return nil, err
 	}
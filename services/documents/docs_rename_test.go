@@ -0,0 +1,33 @@
+package documents
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+func TestValidateDocumentName(t *testing.T) {
+	valid := []struct {
+		name, ext, want string
+	}{
+		{"report", ".pdf", "report.pdf"},
+		{"  report.pdf ", ".pdf", "report.pdf"},
+		{"report.PDF", ".pdf", "report.PDF"},
+		{"report.v2", ".pdf", "report.v2.pdf"},
+		{"notes", "", "notes"},
+	}
+
+	for _, tt := range valid {
+		name, err := validateDocumentName(tt.name, tt.ext)
+		if err != nil || name != tt.want {
+			t.Errorf("validateDocumentName(%q, %q) = %q, %v, want %q", tt.name, tt.ext, name, err, tt.want)
+		}
+	}
+
+	for _, name := range []string{"", "   ", "a/b", `a\b`, ".", ".."} {
+		_, err := validateDocumentName(name, ".pdf")
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("validateDocumentName(%q) = %v, want InvalidArgument", name, err)
+		}
+	}
+}
@@ -2,9 +2,14 @@ package documents
 
 import (
 	"context"
+	"errors"
 	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
 	"github.com/pzierahn/chatbot_services/search"
+	"github.com/pzierahn/chatbot_services/services/docfilter"
 	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"google.golang.org/grpc/codes"
 )
 
 type SearchQuery struct {
@@ -15,53 +20,210 @@ type SearchQuery struct {
 	Threshold  float32
 }
 
-func (service *Service) Search(ctx context.Context, query *pb.SearchQuery) (*pb.SearchResults, error) {
-
+// runSearch checks the query and the access of the user and searches the index
+// of the collection owner. It returns the results and the owner.
+func (service *Service) runSearch(ctx context.Context, query *pb.SearchQuery) (*search.Results, string, error) {
 	userId, err := service.Auth.Verify(ctx)
 	if err != nil {
-		return nil, err
+		return nil, "", err
+	}
+
+	collectionId, err := uuid.Parse(query.CollectionId)
+	if err != nil {
+		return nil, "", rpcerror.InvalidId("collection_id", query.CollectionId)
+	}
+
+	err = search.ValidateThreshold(query.Threshold)
+	if err != nil {
+		return nil, "", rpcerror.Invalid("threshold", err)
+	}
+
+	err = search.ValidateHnswEf(query.HnswEf)
+	if err != nil {
+		return nil, "", rpcerror.Invalid("hnsw_ef", err)
+	}
+
+	access, err := service.getCollection(ctx, userId, collectionId)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Shared collections are searched in the index of the owner
+	ownerId := access.Collection.UserId
+
+	documentIds, err := docfilter.Check(ctx, service.Database, "document_ids", ownerId, collectionId, query.DocumentIds)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Explained searches include the results below the threshold
+	threshold := query.Threshold
+	if query.Explain {
+		threshold = 0
 	}
 
 	searchResults, err := service.SearchIndex.Search(ctx, search.Query{
-		UserId:       userId,
-		CollectionId: query.CollectionId,
-		Query:        query.Text,
-		Limit:        query.Limit,
-		Threshold:    query.Threshold,
+		UserId:         ownerId,
+		CollectionId:   query.CollectionId,
+		Query:          query.Text,
+		Limit:          query.Limit,
+		Threshold:      threshold,
+		Language:       search.QueryLanguage(query.Language, query.Text),
+		EmbeddingModel: access.Collection.EmbeddingModel,
+		HnswEf:         query.HnswEf,
+		Grouping: search.Grouping{
+			PerDocument: query.GroupPerDocument,
+			Stitch:      query.Stitch,
+		},
+		DocumentIds: documentIds,
 	})
+	if errors.Is(err, search.ErrEmbeddingModelMismatch) {
+		return nil, "", rpcerror.New(codes.FailedPrecondition, rpcerror.ReasonModelMismatch, "collection_id", err.Error())
+	}
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	results := &pb.SearchResults{
-		DocumentNames: make(map[string]string),
+	service.AccessLog.Log(datastore.AccessLogEntry{
+		UserId:       userId,
+		OwnerId:      ownerId,
+		CollectionId: collectionId,
+		DocumentIds:  resultDocuments(searchResults.Results),
+		Action:       datastore.AccessSearch,
+	})
+
+	return searchResults, ownerId, nil
+}
+
+// resultDocuments returns the distinct documents of the search results.
+func resultDocuments(results []*search.Result) []uuid.UUID {
+	seen := make(map[string]bool)
+	docIds := make([]uuid.UUID, 0)
+	for _, result := range results {
+		if seen[result.DocumentId] {
+			continue
+		}
+		seen[result.DocumentId] = true
+
+		docId, err := uuid.Parse(result.DocumentId)
+		if err != nil {
+			continue
+		}
+		docIds = append(docIds, docId)
 	}
 
-	for _, vector := range searchResults.Results {
-		results.Chunks = append(results.Chunks, &pb.Chunk{
+	return docIds
+}
+
+// searchChunks converts search results with the given rank offset to chunks.
+func searchChunks(query *pb.SearchQuery, metric search.Metric, offset int, vectors []*search.Result) []*pb.Chunk {
+	chunks := make([]*pb.Chunk, len(vectors))
+
+	for idx, vector := range vectors {
+		snippet := search.FindSnippet(vector.Text, query.Text, int(query.SnippetLength))
+
+		chunk := &pb.Chunk{
 			Id:         vector.Id,
 			Text:       vector.Text,
 			Score:      vector.Score,
 			Postion:    vector.Position,
 			DocumentId: vector.DocumentId,
-		})
+			RawScore:   vector.RawScore,
+			Language:   vector.Language,
+			Type:       vector.Type,
+			Snippet: &pb.Snippet{
+				Text:  snippet.Text,
+				Start: uint32(snippet.Start),
+				End:   uint32(snippet.End),
+			},
+		}
+
+		if query.Explain {
+			chunk.Explanation = &pb.Explanation{
+				Rank:            uint32(offset + idx + 1),
+				Metric:          string(metric),
+				RawScore:        vector.RawScore,
+				Score:           vector.Score,
+				Threshold:       query.Threshold,
+				PassedThreshold: vector.Score >= query.Threshold,
+			}
+		}
 
-		results.DocumentNames[vector.DocumentId] = ""
+		chunks[idx] = chunk
+	}
+
+	return chunks
+}
+
+// searchGroups converts the groups of search results.
+func searchGroups(groups []*search.Group) []*pb.DocumentGroup {
+	var items []*pb.DocumentGroup
+	for _, group := range groups {
+		item := &pb.DocumentGroup{
+			DocumentId: group.DocumentId,
+			Score:      group.Score,
+		}
+
+		for _, result := range group.Results {
+			item.ChunkIds = append(item.ChunkIds, result.Id)
+		}
+
+		for _, passage := range group.Passages {
+			item.Passages = append(item.Passages, &pb.Passage{
+				ChunkIds:      passage.Ids,
+				Text:          passage.Text,
+				StartPosition: passage.Start,
+				EndPosition:   passage.End,
+				Score:         passage.Score,
+			})
+		}
+
+		items = append(items, item)
+	}
+
+	return items
+}
+
+// chunkDocumentNames returns the names of the documents of the chunks.
+func (service *Service) chunkDocumentNames(ctx context.Context, ownerId string, chunks []*pb.Chunk) (map[string]string, error) {
+	names := make(map[string]string)
+	for _, chunk := range chunks {
+		names[chunk.DocumentId] = ""
 	}
 
 	docIds := make([]uuid.UUID, 0)
-	for docId := range results.DocumentNames {
+	for docId := range names {
 		docIds = append(docIds, uuid.MustParse(docId))
 	}
 
-	docs, err := service.Database.GetDocumentMeta(ctx, userId, docIds...)
+	docs, err := service.Database.GetDocumentMeta(ctx, ownerId, docIds...)
 	if err != nil {
 		return nil, err
 	}
 
 	for _, doc := range docs {
-		results.DocumentNames[doc.Id.String()] = doc.Name
+		names[doc.Id.String()] = doc.Name
+	}
+
+	return names, nil
+}
+
+func (service *Service) Search(ctx context.Context, query *pb.SearchQuery) (*pb.SearchResults, error) {
+	searchResults, ownerId, err := service.runSearch(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	chunks := searchChunks(query, searchResults.Metric, 0, searchResults.Results)
+
+	names, err := service.chunkDocumentNames(ctx, ownerId, chunks)
+	if err != nil {
+		return nil, err
 	}
 
-	return results, nil
+	return &pb.SearchResults{
+		Chunks:        chunks,
+		DocumentNames: names,
+		Groups:        searchGroups(searchResults.Groups),
+	}, nil
 }
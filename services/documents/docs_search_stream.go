@@ -0,0 +1,65 @@
+package documents
+
+import (
+	"fmt"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	// searchStreamBatch is the number of chunks per streamed message.
+	searchStreamBatch = 50
+
+	// MaxSearchStreamLimit is the maximum number of results of a streamed search.
+	MaxSearchStreamLimit = 1000
+)
+
+// SearchStream searches like Search, but sends the results in batches, so that
+// clients can process the first results while the snippets and document names
+// of the following ones are prepared.
+func (service *Service) SearchStream(query *pb.SearchQuery, stream pb.Document_SearchStreamServer) error {
+	ctx := stream.Context()
+
+	if query.Limit == 0 {
+		return rpcerror.Missing("limit")
+	}
+
+	if query.Limit > MaxSearchStreamLimit {
+		return rpcerror.New(codes.InvalidArgument, rpcerror.ReasonLimitExceeded, "limit",
+			fmt.Sprintf("limit %d exceeds the limit of %d", query.Limit, MaxSearchStreamLimit))
+	}
+
+	searchResults, ownerId, err := service.runSearch(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	results := searchResults.Results
+	for offset := 0; offset < len(results); offset += searchStreamBatch {
+		batch := results[offset:min(offset+searchStreamBatch, len(results))]
+		chunks := searchChunks(query, searchResults.Metric, offset, batch)
+
+		names, err := service.chunkDocumentNames(ctx, ownerId, chunks)
+		if err != nil {
+			return err
+		}
+
+		batchResults := &pb.SearchResults{
+			Chunks:        chunks,
+			DocumentNames: names,
+		}
+
+		// Groups can span batches, so they are sent once all chunks are known
+		if offset+searchStreamBatch >= len(results) {
+			batchResults.Groups = searchGroups(searchResults.Groups)
+		}
+
+		err = stream.Send(batchResults)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,144 @@
+package documents
+
+import (
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"strings"
+)
+
+// locateChunk returns the span of a chunk in the text, searched from offset.
+// The prose of a page with code blocks or tables is interrupted by them, its
+// span reaches from the start of its first line to the end of its last line.
+func locateChunk(text, chunk string, offset int) (start, end int, ok bool) {
+	if idx := strings.Index(text[offset:], chunk); idx >= 0 {
+		start = offset + idx
+		return start, start + len(chunk), true
+	}
+
+	lineEnd := strings.IndexByte(chunk, '\n')
+	if lineEnd < 0 {
+		return 0, 0, false
+	}
+	first, last := chunk[:lineEnd], chunk[strings.LastIndexByte(chunk, '\n')+1:]
+
+	idx := strings.Index(text[offset:], first)
+	if idx < 0 {
+		return 0, 0, false
+	}
+	start = offset + idx
+
+	idx = strings.Index(text[start+len(first):], last)
+	if idx < 0 {
+		return 0, 0, false
+	}
+
+	return start, start + len(first) + idx + len(last), true
+}
+
+// documentText locates the chunks of a document in its extracted text. Chunks are
+// searched in order of their position, overlapping chunks are supported. The chunks
+// of a page, i.e. its prose, code blocks and tables, are searched from the start of
+// the page. Chunks that are not part of the text are omitted.
+func documentText(doc *datastore.Document, text string) *datastore.DocumentText {
+	result := &datastore.DocumentText{
+		Id:           doc.Id,
+		UserId:       doc.UserId,
+		CollectionId: doc.CollectionId,
+		Text:         text,
+	}
+
+	var offset, pageStart int
+	for idx, chunk := range doc.Content {
+		if idx == 0 || chunk.Position != doc.Content[idx-1].Position {
+			pageStart = offset
+		}
+
+		if chunk.Text == "" {
+			continue
+		}
+
+		start, end, ok := locateChunk(text, chunk.Text, pageStart)
+		if !ok {
+			continue
+		}
+
+		result.Chunks = append(result.Chunks, datastore.ChunkSpan{
+			Id:    chunk.Id,
+			Start: uint32(start),
+			End:   uint32(end),
+		})
+
+		offset = max(offset, start+1)
+	}
+
+	return result
+}
+
+// DownloadText streams the extracted text of a document as plain text.
+func (service *Service) DownloadText(req *pb.DocumentID, stream pb.Document_DownloadTextServer) error {
+	ctx := stream.Context()
+
+	userId, err := service.Auth.Verify(ctx)
+	if err != nil {
+		return err
+	}
+
+	docId, err := uuid.Parse(req.Id)
+	if err != nil {
+		return rpcerror.InvalidId("id", req.Id)
+	}
+
+	// Documents of shared collections belong to the owner
+	owner, _, err := service.documentAccess(ctx, userId, docId)
+	if err != nil {
+		return err
+	}
+
+	docs, err := service.Database.GetDocumentMeta(ctx, owner.UserId, docId)
+	if err != nil {
+		return err
+	}
+
+	if len(docs) == 0 {
+		return rpcerror.NotFound("document", req.Id)
+	}
+
+	text, err := service.Database.GetDocumentText(ctx, owner.UserId, docId)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return rpcerror.New(codes.FailedPrecondition, rpcerror.ReasonNoStoredData, "id",
+			fmt.Sprintf("document %s has no stored text, index it again", req.Id))
+	}
+	if err != nil {
+		return err
+	}
+
+	service.logDocument(userId, owner, datastore.AccessDownloadText)
+
+	filename := docs[0].Name
+	filename = strings.TrimSuffix(filename, ".pdf")
+
+	chunk := &pb.FileChunk{
+		Filename:    fmt.Sprintf("%s.txt", filename),
+		ContentType: "text/plain; charset=utf-8",
+	}
+
+	data := []byte(text.Text)
+	for start := 0; start < len(data) || start == 0; start += downloadChunkSize {
+		end := min(start+downloadChunkSize, len(data))
+		chunk.Data = data[start:end]
+
+		if err := stream.Send(chunk); err != nil {
+			return err
+		}
+
+		chunk = &pb.FileChunk{}
+	}
+
+	return nil
+}
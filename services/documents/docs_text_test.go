@@ -0,0 +1,75 @@
+package documents
+
+import (
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
+	"strings"
+	"testing"
+)
+
+func TestDocumentText(t *testing.T) {
+	text := "first chunk overlaps\nsecond chunk"
+	doc := &datastore.Document{
+		Id: uuid.New(),
+		Content: []*datastore.DocumentChunk{
+			{Id: uuid.New(), Text: "first chunk overlaps", Position: 0},
+			{Id: uuid.New(), Text: "overlaps\nsecond chunk", Position: 1},
+			{Id: uuid.New(), Text: "not in the text", Position: 2},
+		},
+	}
+
+	result := documentText(doc, text)
+	if len(result.Chunks) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(result.Chunks))
+	}
+
+	for idx, span := range result.Chunks {
+		if got := text[span.Start:span.End]; got != doc.Content[idx].Text {
+			t.Errorf("span %d: expected %q, got %q", idx, doc.Content[idx].Text, got)
+		}
+	}
+}
+
+func TestDocumentTextBlocks(t *testing.T) {
+	page := strings.Join([]string{
+		"The server reads its configuration from a file.",
+		"server:",
+		"  port: 8080",
+		"  host: localhost",
+		"  timeout: 30s",
+		"Restart the server after changes.",
+	}, "\n")
+	next := "The second page."
+	text := page + "\n\n" + next
+
+	doc := &datastore.Document{Id: uuid.New()}
+	doc.Content = splitBlocks([]*datastore.DocumentChunk{
+		{Id: uuid.New(), Text: page, Position: 0},
+		{Id: uuid.New(), Text: next, Position: 1},
+	})
+
+	if len(doc.Content) != 3 || countPages(doc.Content) != 2 {
+		t.Fatalf("expected 3 chunks of 2 pages, got %d chunks of %d pages", len(doc.Content), countPages(doc.Content))
+	}
+
+	result := documentText(doc, text)
+	if len(result.Chunks) != 3 {
+		t.Fatalf("expected a span for every chunk, got %d", len(result.Chunks))
+	}
+
+	// The prose spans the page around the code block
+	prose := result.Chunks[0]
+	if prose.Start != 0 || int(prose.End) != len(page) {
+		t.Errorf("expected the prose to span the page, got [%d, %d)", prose.Start, prose.End)
+	}
+
+	// The code block is located after the prose that precedes it
+	code := result.Chunks[1]
+	if got := text[code.Start:code.End]; got != doc.Content[1].Text {
+		t.Errorf("expected the code block, got %q", got)
+	}
+
+	if got := text[result.Chunks[2].Start:result.Chunks[2].End]; got != next {
+		t.Errorf("expected the second page, got %q", got)
+	}
+}
@@ -0,0 +1,153 @@
+package documents
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"google.golang.org/grpc/codes"
+	"io"
+	"time"
+)
+
+// Upload receives a PDF file, stores it and indexes it in a single call. The first
+// message contains the header and all following messages the file data. Incomplete
+// or oversized uploads are discarded without storing anything.
+func (service *Service) Upload(stream pb.Document_UploadServer) error {
+	ctx := stream.Context()
+
+	userId, err := service.Auth.Verify(ctx)
+	if err != nil {
+		return err
+	}
+
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	header := req.GetHeader()
+	if header == nil {
+		return rpcerror.New(codes.InvalidArgument, rpcerror.ReasonMissingField, "header", "first message must contain the upload header")
+	}
+
+	if header.Filename == "" {
+		return rpcerror.Missing("header.filename")
+	}
+
+	err = service.checkFileSize(header.Size)
+	if err != nil {
+		return err
+	}
+
+	documentId := uuid.New()
+	if header.Id != "" {
+		documentId, err = uuid.Parse(header.Id)
+		if err != nil {
+			return rpcerror.InvalidId("header.id", header.Id)
+		}
+	}
+
+	collectionId, err := uuid.Parse(header.CollectionId)
+	if err != nil {
+		return rpcerror.InvalidId("header.collection_id", header.CollectionId)
+	}
+
+	ownerId, err := service.checkActiveCollection(ctx, userId, collectionId)
+	if err != nil {
+		return err
+	}
+
+	_ = stream.Send(&pb.IndexProgress{
+		Status: "Uploading file",
+	})
+
+	path := fmt.Sprintf("documents/%s/%s/%s.pdf", ownerId, collectionId, documentId)
+	err = service.storeUpload(ctx, stream, path, header.Size)
+	if err != nil {
+		return err
+	}
+
+	job := &pb.IndexJob{
+		Id:               documentId.String(),
+		CollectionId:     collectionId.String(),
+		StripBoilerplate: header.StripBoilerplate,
+		ContentChunks:    header.ContentChunks,
+		Document: &pb.DocumentMetadata{
+			Data: &pb.DocumentMetadata_File{
+				File: &pb.File{
+					Path:     path,
+					Filename: header.Filename,
+				},
+			},
+		},
+	}
+
+	data := &datastore.Document{
+		Id:           documentId,
+		UserId:       ownerId,
+		CollectionId: collectionId,
+		CreatedAt:    time.Now(),
+	}
+
+	err = service.index(ctx, userId, job, data, nil, stream)
+	if err != nil {
+		// Don't keep files of documents that don't exist
+		_ = service.Storage.Object(path).Delete(context.Background())
+		return err
+	}
+
+	return nil
+}
+
+// storeUpload writes the received file data to the storage. The object is only
+// created if the upload is complete.
+func (service *Service) storeUpload(ctx context.Context, stream pb.Document_UploadServer, path string, size uint64) error {
+	// Canceling the context aborts the write
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	writer := service.Storage.Object(path).NewWriter(ctx)
+	writer.ContentType = "application/pdf"
+
+	var received uint64
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		chunk := req.GetChunk()
+		if req.GetHeader() != nil {
+			return rpcerror.New(codes.InvalidArgument, rpcerror.ReasonInvalidValue, "header", "header must only be sent once")
+		}
+
+		received += uint64(len(chunk))
+		err = service.checkFileSize(received)
+		if err != nil {
+			return err
+		}
+
+		_, err = writer.Write(chunk)
+		if err != nil {
+			return err
+		}
+	}
+
+	if received == 0 {
+		return rpcerror.New(codes.InvalidArgument, rpcerror.ReasonInvalidValue, "chunk", "file is empty")
+	}
+
+	if size > 0 && received != size {
+		return rpcerror.New(codes.DataLoss, rpcerror.ReasonIncompleteUpload, "size",
+			fmt.Sprintf("incomplete upload: received %d of %d bytes", received, size))
+	}
+
+	return writer.Close()
+}
@@ -0,0 +1,188 @@
+package documents
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/pzierahn/chatbot_services/services/rpcerror"
+	"log"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	IndexStatusSuccess  = "success"
+	IndexStatusFailed   = "failed"
+	IndexStatusCanceled = "canceled"
+)
+
+// SignatureHeader contains the hex encoded HMAC-SHA256 of the timestamp and
+// the webhook payload, joined by a dot.
+const SignatureHeader = "X-Chatbot-Signature-256"
+
+// TimestampHeader contains the unix time in seconds at which the webhook was
+// signed. Receivers should reject old timestamps to prevent replays.
+const TimestampHeader = "X-Chatbot-Timestamp"
+
+// IndexEvent is posted to the callback URL of an index job.
+type IndexEvent struct {
+	DocumentId   string `json:"document_id"`
+	CollectionId string `json:"collection_id"`
+	Status       string `json:"status"`
+	Pages        int    `json:"pages"`
+	Error        string `json:"error,omitempty"`
+}
+
+// validateCallbackUrl checks that the callback URL is an absolute http(s) URL
+// of a public host. Hosts resolving to internal addresses are rejected, so that
+// users can't make the server send requests into its own network.
+func validateCallbackUrl(ctx context.Context, callbackUrl string) error {
+	parsed, err := url.Parse(callbackUrl)
+	if err != nil {
+		return rpcerror.Invalid("callback_url", err)
+	}
+
+	if parsed.Scheme != "http" && parsed.Scheme != "https" || parsed.Hostname() == "" {
+		return rpcerror.Invalid("callback_url", fmt.Errorf("not an absolute http(s) url: %s", callbackUrl))
+	}
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", parsed.Hostname())
+	if err != nil {
+		return rpcerror.Invalid("callback_url", err)
+	}
+
+	for _, addr := range addrs {
+		if !publicAddr(addr) {
+			return rpcerror.Invalid("callback_url", fmt.Errorf("%s resolves to the internal address %s", parsed.Hostname(), addr))
+		}
+	}
+
+	return nil
+}
+
+// publicAddr reports whether the address is routable on the internet, i.e. not
+// a loopback, private, link-local (incl. cloud metadata), multicast or
+// unspecified address.
+func publicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+
+	return addr.IsValid() &&
+		!addr.IsLoopback() &&
+		!addr.IsPrivate() &&
+		!addr.IsLinkLocalUnicast() &&
+		!addr.IsLinkLocalMulticast() &&
+		!addr.IsInterfaceLocalMulticast() &&
+		!addr.IsMulticast() &&
+		!addr.IsUnspecified()
+}
+
+// checkDialAddr refuses connections to internal addresses. The host is resolved
+// again for the delivery, so a validated host could point to another address by now.
+func checkDialAddr(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+
+	if !publicAddr(addrPort.Addr()) {
+		return fmt.Errorf("refusing to connect to internal address %s", addrPort.Addr())
+	}
+
+	return nil
+}
+
+// webhookClient delivers webhooks to public addresses only. Redirects are not
+// followed, since they could lead to internal hosts as well.
+var webhookClient = &http.Client{
+	Transport: &http.Transport{
+		Proxy: nil,
+		DialContext: (&net.Dialer{
+			Timeout: 5 * time.Second,
+			Control: checkDialAddr,
+		}).DialContext,
+		TLSHandshakeTimeout: 5 * time.Second,
+	},
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// signPayload returns the hex encoded HMAC-SHA256 of the timestamp and payload.
+func signPayload(secret []byte, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// newEventRequest returns the request that posts the payload signed at the given time.
+func (service *Service) newEventRequest(ctx context.Context, callbackUrl string, payload []byte, now time.Time) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, callbackUrl, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, signPayload([]byte(service.WebhookSecret), timestamp, payload))
+
+	return req, nil
+}
+
+// postEvent sends the signed payload to the callback URL.
+func (service *Service) postEvent(ctx context.Context, callbackUrl string, payload []byte) error {
+	req, err := service.newEventRequest(ctx, callbackUrl, payload, time.Now())
+	if err != nil {
+		return err
+	}
+
+	resp, err := webhookClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// notifyWebhook delivers the event to the callback URL. Failed deliveries are retried up to three times.
+func (service *Service) notifyWebhook(callbackUrl string, event *IndexEvent) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("webhook: %v", err)
+		return
+	}
+
+	for attempt := 1; attempt <= 3; attempt++ {
+		// The index stream is already closed, so the delivery needs its own context
+		ctx, cnl := context.WithTimeout(context.Background(), 10*time.Second)
+		err = service.postEvent(ctx, callbackUrl, payload)
+		cnl()
+
+		if err == nil {
+			return
+		}
+
+		log.Printf("webhook: attempt %d for document %s failed: %v", attempt, event.DocumentId, err)
+
+		if attempt < 3 {
+			// Wait for a short time before retrying
+			time.Sleep(time.Duration(attempt) * 5 * time.Second)
+		}
+	}
+}
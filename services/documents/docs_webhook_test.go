@@ -0,0 +1,98 @@
+package documents
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+)
+
+func TestValidateCallbackUrl(t *testing.T) {
+	invalid := []string{
+		"ftp://93.184.215.14/hook",
+		"/relative/hook",
+		"http://127.0.0.1:8080/hook",
+		"http://localhost/hook",
+		"http://10.0.0.1/hook",
+		"http://192.168.1.10/hook",
+		"http://169.254.169.254/computeMetadata/v1/",
+		"http://[::1]/hook",
+		"http://[fd00::1]/hook",
+		"http://[::ffff:127.0.0.1]/hook",
+		"http://0.0.0.0/hook",
+	}
+
+	for _, callbackUrl := range invalid {
+		err := validateCallbackUrl(context.Background(), callbackUrl)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("validateCallbackUrl(%q) = %v, want InvalidArgument", callbackUrl, err)
+		}
+	}
+
+	if err := validateCallbackUrl(context.Background(), "https://93.184.215.14/hook"); err != nil {
+		t.Errorf("expected a public address to pass, got %v", err)
+	}
+}
+
+func TestCheckDialAddr(t *testing.T) {
+	if err := checkDialAddr("tcp", "10.1.2.3:443", nil); err == nil {
+		t.Error("expected private addresses to be refused")
+	}
+
+	if err := checkDialAddr("tcp", "93.184.215.14:443", nil); err != nil {
+		t.Errorf("expected public addresses to pass, got %v", err)
+	}
+
+	if publicAddr(netip.MustParseAddr("::ffff:169.254.169.254")) {
+		t.Error("expected mapped link-local addresses to be internal")
+	}
+}
+
+func TestWebhookClientRefusesLoopback(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	service := &Service{WebhookSecret: "secret"}
+	err := service.postEvent(context.Background(), server.URL, []byte("{}"))
+	if err == nil || called {
+		t.Fatalf("expected the delivery to a loopback address to fail, got %v", err)
+	}
+}
+
+func TestNewEventRequest(t *testing.T) {
+	service := &Service{WebhookSecret: "secret"}
+	payload := []byte(`{"document_id":"doc"}`)
+	now := time.Unix(1700000000, 0)
+
+	req, err := service.newEventRequest(context.Background(), "https://example.com/hook", payload, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := req.Header.Get(TimestampHeader); got != "1700000000" {
+		t.Fatalf("expected the timestamp header, got %q", got)
+	}
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte("1700000000." + string(payload)))
+	want := hex.EncodeToString(mac.Sum(nil))
+
+	if got := req.Header.Get(SignatureHeader); got != want {
+		t.Fatalf("expected the signature %s of timestamp and payload, got %s", want, got)
+	}
+
+	// Replays with another timestamp don't match the signature
+	if signPayload([]byte("secret"), "1700000001", payload) == want {
+		t.Fatal("expected the timestamp to be part of the signature")
+	}
+}
@@ -0,0 +1,19 @@
+package embeddings
+
+import (
+	"github.com/pzierahn/chatbot_services/datastore"
+	"github.com/pzierahn/chatbot_services/llm"
+	"github.com/pzierahn/chatbot_services/services/account"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+)
+
+// MaxInputs limits the number of texts embedded in a single request.
+const MaxInputs = 100
+
+// Service exposes the embedding engine used for indexing to external callers.
+type Service struct {
+	pb.UnimplementedEmbeddingServer
+	Auth     account.Verifier
+	Database *datastore.Service
+	Engine   llm.Embedding
+}
@@ -0,0 +1,82 @@
+package embeddings
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/pzierahn/chatbot_services/datastore"
+	"github.com/pzierahn/chatbot_services/llm"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+// Embed creates the embeddings for a batch of texts.
+func (service *Service) Embed(ctx context.Context, req *pb.EmbeddingRequest) (*pb.EmbeddingResponse, error) {
+	userId, err := service.Auth.VerifyFunding(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	//
+	// Integrity check
+	//
+
+	if len(req.Inputs) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "inputs missing")
+	}
+
+	if len(req.Inputs) > MaxInputs {
+		return nil, status.Errorf(codes.InvalidArgument, "too many inputs: %d > %d", len(req.Inputs), MaxInputs)
+	}
+
+	for idx, input := range req.Inputs {
+		if input == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "input %d is empty", idx)
+		}
+	}
+
+	embeddingType := req.Type
+	switch embeddingType {
+	case "":
+		embeddingType = llm.EmbeddingTypeDocument
+	case llm.EmbeddingTypeQuery, llm.EmbeddingTypeDocument:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "invalid embedding type: %s", req.Type)
+	}
+
+	//
+	// Create the embeddings
+	//
+
+	result, err := service.Engine.CreateEmbedding(ctx, &llm.EmbeddingRequest{
+		Inputs: req.Inputs,
+		UserId: userId,
+		Type:   embeddingType,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	service.Database.RecordModelUsage(ctx, &datastore.ModelUsage{
+		Id:          uuid.New(),
+		UserId:      userId,
+		Timestamp:   time.Now(),
+		ModelId:     service.Engine.GetModelId(),
+		InputTokens: result.Tokens,
+	})
+
+	response := &pb.EmbeddingResponse{
+		Model:     service.Engine.GetModelId(),
+		Dimension: uint32(service.Engine.GetEmbeddingDimension()),
+		Tokens:    result.Tokens,
+	}
+
+	for _, embedding := range result.Embeddings {
+		response.Vectors = append(response.Vectors, &pb.EmbeddingResponse_Vector{
+			Values: embedding,
+		})
+	}
+
+	return response, nil
+}
@@ -0,0 +1,302 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// MaxRequestSize limits the JSON body of a request like the default gRPC message limit.
+const MaxRequestSize = 4 << 20
+
+// allowHeaders are the request headers that browsers may send from other origins.
+const allowHeaders = "Authorization, Content-Type, Connect-Protocol-Version, Idempotency-Key"
+
+// skipHeaders are HTTP headers that aren't forwarded as gRPC metadata.
+var skipHeaders = map[string]bool{
+	"accept":                   true,
+	"accept-encoding":          true,
+	"accept-language":          true,
+	"connection":               true,
+	"content-length":           true,
+	"content-type":             true,
+	"cookie":                   true,
+	"host":                     true,
+	"origin":                   true,
+	"referer":                  true,
+	"te":                       true,
+	"user-agent":               true,
+	"transfer-encoding":        true,
+	"connect-protocol-version": true,
+}
+
+// Gateway serves the unary and server streaming RPCs of gRPC services to browsers
+// that can't speak gRPC. Requests follow the Connect protocol with JSON: a POST to
+// /<service>/<method> with the request message as body, or a GET with the message
+// in the "message" query parameter, e.g. for EventSource. Server streams are sent
+// as server-sent events. The headers of the request, like the authorization, are
+// forwarded as gRPC metadata.
+type Gateway struct {
+	conn    grpc.ClientConnInterface
+	methods map[string]*method
+
+	// origins allowed to call the gateway from a browser
+	origins map[string]bool
+}
+
+// method is a served RPC with the types of its messages.
+type method struct {
+	desc   protoreflect.MethodDescriptor
+	input  protoreflect.MessageType
+	output protoreflect.MessageType
+}
+
+// New returns a gateway for the services with the given full names, which are
+// called over the connection. Client streaming methods are not served. Browsers
+// send credentials, so the allowed origins must be listed explicitly.
+func New(conn grpc.ClientConnInterface, origins []string, services ...string) (*Gateway, error) {
+	gateway := &Gateway{
+		conn:    conn,
+		methods: make(map[string]*method),
+		origins: make(map[string]bool),
+	}
+
+	for _, origin := range origins {
+		origin = strings.TrimSpace(origin)
+		if origin == "*" {
+			return nil, errors.New("origin \"*\" is not allowed, list the origins explicitly")
+		}
+
+		if origin != "" {
+			gateway.origins[origin] = true
+		}
+	}
+
+	for _, name := range services {
+		desc, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(name))
+		if err != nil {
+			return nil, fmt.Errorf("service %s: %w", name, err)
+		}
+
+		service, ok := desc.(protoreflect.ServiceDescriptor)
+		if !ok {
+			return nil, fmt.Errorf("%s is not a service", name)
+		}
+
+		methods := service.Methods()
+		for idx := 0; idx < methods.Len(); idx++ {
+			desc := methods.Get(idx)
+			if desc.IsStreamingClient() {
+				continue
+			}
+
+			input, err := protoregistry.GlobalTypes.FindMessageByName(desc.Input().FullName())
+			if err != nil {
+				return nil, fmt.Errorf("method %s: %w", desc.FullName(), err)
+			}
+
+			output, err := protoregistry.GlobalTypes.FindMessageByName(desc.Output().FullName())
+			if err != nil {
+				return nil, fmt.Errorf("method %s: %w", desc.FullName(), err)
+			}
+
+			gateway.methods[fmt.Sprintf("/%s/%s", name, desc.Name())] = &method{
+				desc:   desc,
+				input:  input,
+				output: output,
+			}
+		}
+	}
+
+	return gateway, nil
+}
+
+func (gateway *Gateway) ServeHTTP(writer http.ResponseWriter, req *http.Request) {
+	if !gateway.allowOrigin(writer, req) {
+		http.Error(writer, "origin not allowed", http.StatusForbidden)
+		return
+	}
+
+	if req.Method == http.MethodOptions {
+		writer.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	method, ok := gateway.methods[req.URL.Path]
+	if !ok {
+		writeError(writer, status.Errorf(codes.Unimplemented, "unknown method: %s", req.URL.Path))
+		return
+	}
+
+	input, err := gateway.readInput(req, method)
+	if err != nil {
+		writeError(writer, err)
+		return
+	}
+
+	ctx := metadata.NewOutgoingContext(req.Context(), forwardHeaders(req.Header))
+
+	if method.desc.IsStreamingServer() {
+		gateway.serveStream(ctx, writer, req.URL.Path, method, input)
+		return
+	}
+
+	output := method.output.New().Interface()
+	err = gateway.conn.Invoke(ctx, req.URL.Path, input, output)
+	if err != nil {
+		writeError(writer, err)
+		return
+	}
+
+	data, err := protojson.Marshal(output)
+	if err != nil {
+		writeError(writer, err)
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	_, _ = writer.Write(data)
+}
+
+// readInput decodes the request message from the body of a POST or the message
+// query parameter of a GET request.
+func (gateway *Gateway) readInput(req *http.Request, method *method) (proto.Message, error) {
+	var data []byte
+
+	switch req.Method {
+	case http.MethodGet:
+		data = []byte(req.URL.Query().Get("message"))
+	case http.MethodPost:
+		body, err := io.ReadAll(http.MaxBytesReader(nil, req.Body, MaxRequestSize))
+		if err != nil {
+			return nil, status.Errorf(codes.ResourceExhausted, "request too large: %v", err)
+		}
+		data = body
+	default:
+		return nil, status.Errorf(codes.Unimplemented, "method %s not allowed", req.Method)
+	}
+
+	input := method.input.New().Interface()
+	if len(data) == 0 {
+		return input, nil
+	}
+
+	err := protojson.Unmarshal(data, input)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid request: %v", err)
+	}
+
+	return input, nil
+}
+
+// serveStream sends the messages of a server stream as server-sent events. The
+// stream ends with an "end" event or an "error" event with the status.
+func (gateway *Gateway) serveStream(ctx context.Context, writer http.ResponseWriter, path string, method *method, input proto.Message) {
+	flusher, ok := writer.(http.Flusher)
+	if !ok {
+		writeError(writer, status.Error(codes.Internal, "streaming not supported"))
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := gateway.conn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true}, path)
+	if err == nil {
+		err = stream.SendMsg(input)
+	}
+	if err == nil {
+		err = stream.CloseSend()
+	}
+	if err != nil {
+		writeError(writer, err)
+		return
+	}
+
+	writer.Header().Set("Content-Type", "text/event-stream")
+	writer.Header().Set("Cache-Control", "no-cache")
+	writer.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		output := method.output.New().Interface()
+
+		err = stream.RecvMsg(output)
+		if errors.Is(err, io.EOF) {
+			writeEvent(writer, "end", []byte("{}"))
+			flusher.Flush()
+			return
+		}
+		if err != nil {
+			data, _ := json.Marshal(errorBody(err))
+			writeEvent(writer, "error", data)
+			flusher.Flush()
+			return
+		}
+
+		data, err := protojson.Marshal(output)
+		if err != nil {
+			return
+		}
+
+		writeEvent(writer, "", data)
+		flusher.Flush()
+	}
+}
+
+// allowOrigin sets the CORS headers of browser requests from allowed origins.
+// Requests without origin, e.g. of servers, are always allowed.
+func (gateway *Gateway) allowOrigin(writer http.ResponseWriter, req *http.Request) bool {
+	origin := req.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	if !gateway.origins[origin] {
+		return false
+	}
+
+	header := writer.Header()
+	header.Set("Access-Control-Allow-Origin", origin)
+	header.Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+	header.Set("Access-Control-Allow-Headers", allowHeaders)
+	header.Set("Access-Control-Allow-Credentials", "true")
+	header.Add("Vary", "Origin")
+
+	return true
+}
+
+// forwardHeaders converts the request headers to gRPC metadata.
+func forwardHeaders(header http.Header) metadata.MD {
+	md := metadata.MD{}
+
+	for key, values := range header {
+		key = strings.ToLower(key)
+		if skipHeaders[key] || strings.HasPrefix(key, "grpc-") || strings.HasPrefix(key, "access-control-") {
+			continue
+		}
+
+		md.Append(key, values...)
+	}
+
+	return md
+}
+
+func writeEvent(writer io.Writer, event string, data []byte) {
+	if event != "" {
+		_, _ = fmt.Fprintf(writer, "event: %s\n", event)
+	}
+	_, _ = fmt.Fprintf(writer, "data: %s\n\n", data)
+}
@@ -0,0 +1,70 @@
+package gateway
+
+import (
+	"encoding/json"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"strings"
+)
+
+// httpStatus maps the gRPC codes to HTTP status codes like the Connect protocol.
+var httpStatus = map[codes.Code]int{
+	codes.Canceled:           499,
+	codes.Unknown:            http.StatusInternalServerError,
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.FailedPrecondition: http.StatusBadRequest,
+	codes.Aborted:            http.StatusConflict,
+	codes.OutOfRange:         http.StatusBadRequest,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Internal:           http.StatusInternalServerError,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+	codes.DataLoss:           http.StatusInternalServerError,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+}
+
+// connectError is the JSON body of an error in the Connect protocol.
+type connectError struct {
+	Code    string `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+// errorCode returns the Connect name of a code, e.g. invalid_argument.
+func errorCode(code codes.Code) string {
+	var name strings.Builder
+	for idx, char := range code.String() {
+		if idx > 0 && char >= 'A' && char <= 'Z' {
+			name.WriteByte('_')
+		}
+		name.WriteRune(char)
+	}
+
+	return strings.ToLower(name.String())
+}
+
+func errorBody(err error) connectError {
+	st := status.Convert(err)
+
+	return connectError{
+		Code:    errorCode(st.Code()),
+		Message: st.Message(),
+	}
+}
+
+func writeError(writer http.ResponseWriter, err error) {
+	code, ok := httpStatus[status.Code(err)]
+	if !ok {
+		code = http.StatusInternalServerError
+	}
+
+	data, _ := json.Marshal(errorBody(err))
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(code)
+	_, _ = writer.Write(data)
+}
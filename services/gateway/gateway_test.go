@@ -0,0 +1,200 @@
+package gateway
+
+import (
+	"context"
+	pb "github.com/pzierahn/chatbot_services/services/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type fakeChat struct {
+	pb.UnimplementedChatServer
+}
+
+func (fakeChat) GetThread(ctx context.Context, req *pb.ThreadID) (*pb.Thread, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if len(md.Get("authorization")) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "missing authorization")
+	}
+
+	if req.Id == "missing" {
+		return nil, status.Error(codes.NotFound, "thread not found")
+	}
+
+	return &pb.Thread{Id: req.Id}, nil
+}
+
+type fakeDocuments struct {
+	pb.UnimplementedDocumentServer
+}
+
+func (fakeDocuments) Index(_ *pb.IndexJob, stream pb.Document_IndexServer) error {
+	for _, progress := range []float32{0.5, 1} {
+		err := stream.Send(&pb.IndexProgress{Status: "embedding", Progress: progress})
+		if err != nil {
+			return err
+		}
+	}
+
+	return status.Error(codes.Internal, "index failed")
+}
+
+func newGateway(t *testing.T) http.Handler {
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	pb.RegisterChatServer(server, fakeChat{})
+	pb.RegisterDocumentServer(server, fakeDocuments{})
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	gateway, err := New(conn, []string{"https://app.example.com"},
+		pb.Chat_ServiceDesc.ServiceName, pb.Document_ServiceDesc.ServiceName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return gateway
+}
+
+func serve(handler http.Handler, req *http.Request) (int, string) {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	body, _ := io.ReadAll(recorder.Result().Body)
+	return recorder.Code, string(body)
+}
+
+func TestGatewayUnary(t *testing.T) {
+	gateway := newGateway(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/chatbot.chat.v1.Chat/GetThread", strings.NewReader(`{"id":"thread-1"}`))
+	req.Header.Set("Authorization", "Bearer token")
+	code, body := serve(gateway, req)
+	if code != http.StatusOK || !strings.Contains(body, `"thread-1"`) {
+		t.Fatalf("expected the thread, got %d %s", code, body)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/chatbot.chat.v1.Chat/GetThread", strings.NewReader(`{"id":"thread-1"}`))
+	code, body = serve(gateway, req)
+	if code != http.StatusUnauthorized || !strings.Contains(body, `"unauthenticated"`) {
+		t.Fatalf("expected unauthenticated without the header, got %d %s", code, body)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/chatbot.chat.v1.Chat/GetThread", strings.NewReader(`{"id":"missing"}`))
+	req.Header.Set("Authorization", "Bearer token")
+	code, body = serve(gateway, req)
+	if code != http.StatusNotFound || !strings.Contains(body, `"not_found"`) {
+		t.Fatalf("expected not found, got %d %s", code, body)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/chatbot.chat.v1.Chat/GetThread", strings.NewReader(`{"id":`))
+	code, _ = serve(gateway, req)
+	if code != http.StatusBadRequest {
+		t.Fatalf("expected bad request for invalid JSON, got %d", code)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/chatbot.documents.v1.Document/Upload", nil)
+	code, _ = serve(gateway, req)
+	if code != http.StatusNotImplemented {
+		t.Fatalf("expected client streams to be unimplemented, got %d", code)
+	}
+}
+
+func TestGatewayStream(t *testing.T) {
+	gateway := newGateway(t)
+
+	req := httptest.NewRequest(http.MethodGet, `/chatbot.documents.v1.Document/Index?message={"id":"doc-1"}`, nil)
+	code, body := serve(gateway, req)
+	if code != http.StatusOK {
+		t.Fatalf("expected ok, got %d %s", code, body)
+	}
+
+	expected := "data: {\"status\":\"embedding\",\"progress\":0.5}\n\n" +
+		"data: {\"status\":\"embedding\",\"progress\":1}\n\n" +
+		"event: error\ndata: {\"code\":\"internal\",\"message\":\"index failed\"}\n\n"
+	if strings.ReplaceAll(body, " ", "") != strings.ReplaceAll(expected, " ", "") {
+		t.Fatalf("unexpected events:\n%s", body)
+	}
+}
+
+func TestGatewayOrigins(t *testing.T) {
+	gateway := newGateway(t)
+
+	req := httptest.NewRequest(http.MethodOptions, "/chatbot.chat.v1.Chat/GetThread", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	code, _ := serve(gateway, req)
+	if code != http.StatusNoContent {
+		t.Fatalf("expected preflight of allowed origin to pass, got %d", code)
+	}
+
+	req = httptest.NewRequest(http.MethodOptions, "/chatbot.chat.v1.Chat/GetThread", nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+	code, _ = serve(gateway, req)
+	if code != http.StatusForbidden {
+		t.Fatalf("expected unknown origin to be rejected, got %d", code)
+	}
+}
+
+func TestGatewayCORSHeaders(t *testing.T) {
+	gateway := newGateway(t)
+
+	req := httptest.NewRequest(http.MethodOptions, "/chatbot.chat.v1.Chat/GetThread", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Access-Control-Request-Headers", "authorization, content-type")
+
+	recorder := httptest.NewRecorder()
+	gateway.ServeHTTP(recorder, req)
+	header := recorder.Header()
+
+	if got := header.Get("Access-Control-Allow-Origin"); got != "https://app.example.com" {
+		t.Errorf("expected the origin to be echoed, got %q", got)
+	}
+
+	allowed := header.Get("Access-Control-Allow-Headers")
+	if allowed == "*" || !strings.Contains(allowed, "Authorization") || !strings.Contains(allowed, "Content-Type") {
+		t.Errorf("expected the allowed headers to be listed, got %q", allowed)
+	}
+
+	if got := header.Get("Access-Control-Allow-Credentials"); got != "true" {
+		t.Errorf("expected credentials to be allowed, got %q", got)
+	}
+
+	// Requests without origin, e.g. of servers, get no CORS headers
+	req = httptest.NewRequest(http.MethodOptions, "/chatbot.chat.v1.Chat/GetThread", nil)
+	recorder = httptest.NewRecorder()
+	gateway.ServeHTTP(recorder, req)
+	if got := recorder.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("expected no CORS headers without origin, got %q", got)
+	}
+}
+
+func TestGatewayWildcardOrigin(t *testing.T) {
+	_, err := New(nil, []string{"https://app.example.com", " * "}, pb.Chat_ServiceDesc.ServiceName)
+	if err == nil {
+		t.Fatal("expected the wildcard origin to be rejected")
+	}
+
+	_, err = New(nil, []string{""}, "chatbot.chat.v1.Unknown")
+	if err == nil {
+		t.Fatal("expected unknown services to be rejected")
+	}
+}
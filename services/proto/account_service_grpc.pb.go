@@ -20,9 +20,10 @@ import (
 const _ = grpc.SupportPackageIsVersion8
 
 const (
-	Account_GetUsage_FullMethodName    = "/chatbot.account.v1.Account/GetUsage"
-	Account_GetPayments_FullMethodName = "/chatbot.account.v1.Account/GetPayments"
-	Account_GetOverview_FullMethodName = "/chatbot.account.v1.Account/GetOverview"
+	Account_GetUsage_FullMethodName       = "/chatbot.account.v1.Account/GetUsage"
+	Account_GetPayments_FullMethodName    = "/chatbot.account.v1.Account/GetPayments"
+	Account_GetOverview_FullMethodName    = "/chatbot.account.v1.Account/GetOverview"
+	Account_GetUsageReport_FullMethodName = "/chatbot.account.v1.Account/GetUsageReport"
 )
 
 // AccountClient is the client API for Account service.
@@ -32,6 +33,7 @@ type AccountClient interface {
 	GetUsage(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Usage, error)
 	GetPayments(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Payments, error)
 	GetOverview(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Overview, error)
+	GetUsageReport(ctx context.Context, in *UsageReportRequest, opts ...grpc.CallOption) (*UsageReport, error)
 }
 
 type accountClient struct {
@@ -72,6 +74,16 @@ func (c *accountClient) GetOverview(ctx context.Context, in *emptypb.Empty, opts
 	return out, nil
 }
 
+func (c *accountClient) GetUsageReport(ctx context.Context, in *UsageReportRequest, opts ...grpc.CallOption) (*UsageReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UsageReport)
+	err := c.cc.Invoke(ctx, Account_GetUsageReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AccountServer is the server API for Account service.
 // All implementations must embed UnimplementedAccountServer
 // for forward compatibility
@@ -79,6 +91,7 @@ type AccountServer interface {
 	GetUsage(context.Context, *emptypb.Empty) (*Usage, error)
 	GetPayments(context.Context, *emptypb.Empty) (*Payments, error)
 	GetOverview(context.Context, *emptypb.Empty) (*Overview, error)
+	GetUsageReport(context.Context, *UsageReportRequest) (*UsageReport, error)
 	mustEmbedUnimplementedAccountServer()
 }
 
@@ -95,6 +108,9 @@ func (UnimplementedAccountServer) GetPayments(context.Context, *emptypb.Empty) (
 func (UnimplementedAccountServer) GetOverview(context.Context, *emptypb.Empty) (*Overview, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetOverview not implemented")
 }
+func (UnimplementedAccountServer) GetUsageReport(context.Context, *UsageReportRequest) (*UsageReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUsageReport not implemented")
+}
 func (UnimplementedAccountServer) mustEmbedUnimplementedAccountServer() {}
 
 // UnsafeAccountServer may be embedded to opt out of forward compatibility for this service.
@@ -162,6 +178,24 @@ func _Account_GetOverview_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Account_GetUsageReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UsageReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).GetUsageReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_GetUsageReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).GetUsageReport(ctx, req.(*UsageReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Account_ServiceDesc is the grpc.ServiceDesc for Account service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -181,6 +215,10 @@ var Account_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetOverview",
 			Handler:    _Account_GetOverview_Handler,
 		},
+		{
+			MethodName: "GetUsageReport",
+			Handler:    _Account_GetUsageReport_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "account_service.proto",
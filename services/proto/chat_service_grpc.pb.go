@@ -21,11 +21,17 @@ const _ = grpc.SupportPackageIsVersion8
 
 const (
 	Chat_PostMessage_FullMethodName             = "/chatbot.chat.v1.Chat/PostMessage"
+	Chat_CreateThread_FullMethodName            = "/chatbot.chat.v1.Chat/CreateThread"
+	Chat_SubmitFeedback_FullMethodName          = "/chatbot.chat.v1.Chat/SubmitFeedback"
+	Chat_AppendMessages_FullMethodName          = "/chatbot.chat.v1.Chat/AppendMessages"
+	Chat_Regenerate_FullMethodName              = "/chatbot.chat.v1.Chat/Regenerate"
 	Chat_GetThread_FullMethodName               = "/chatbot.chat.v1.Chat/GetThread"
 	Chat_ListThreadIDs_FullMethodName           = "/chatbot.chat.v1.Chat/ListThreadIDs"
 	Chat_DeleteThread_FullMethodName            = "/chatbot.chat.v1.Chat/DeleteThread"
 	Chat_DeleteMessageFromThread_FullMethodName = "/chatbot.chat.v1.Chat/DeleteMessageFromThread"
 	Chat_Completion_FullMethodName              = "/chatbot.chat.v1.Chat/Completion"
+	Chat_ExportThread_FullMethodName            = "/chatbot.chat.v1.Chat/ExportThread"
+	Chat_GetTranscript_FullMethodName           = "/chatbot.chat.v1.Chat/GetTranscript"
 )
 
 // ChatClient is the client API for Chat service.
@@ -33,11 +39,17 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ChatClient interface {
 	PostMessage(ctx context.Context, in *Prompt, opts ...grpc.CallOption) (*Message, error)
+	CreateThread(ctx context.Context, in *NewThread, opts ...grpc.CallOption) (*ThreadID, error)
+	SubmitFeedback(ctx context.Context, in *Feedback, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	AppendMessages(ctx context.Context, in *AppendRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Regenerate(ctx context.Context, in *RegenerateRequest, opts ...grpc.CallOption) (*Message, error)
 	GetThread(ctx context.Context, in *ThreadID, opts ...grpc.CallOption) (*Thread, error)
 	ListThreadIDs(ctx context.Context, in *CollectionId, opts ...grpc.CallOption) (*ThreadIDs, error)
 	DeleteThread(ctx context.Context, in *ThreadID, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	DeleteMessageFromThread(ctx context.Context, in *MessageIndex, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	Completion(ctx context.Context, in *CompletionRequest, opts ...grpc.CallOption) (*CompletionResponse, error)
+	ExportThread(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (*ThreadExport, error)
+	GetTranscript(ctx context.Context, in *TranscriptRequest, opts ...grpc.CallOption) (*Transcript, error)
 }
 
 type chatClient struct {
@@ -58,6 +70,46 @@ func (c *chatClient) PostMessage(ctx context.Context, in *Prompt, opts ...grpc.C
 	return out, nil
 }
 
+func (c *chatClient) CreateThread(ctx context.Context, in *NewThread, opts ...grpc.CallOption) (*ThreadID, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ThreadID)
+	err := c.cc.Invoke(ctx, Chat_CreateThread_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatClient) SubmitFeedback(ctx context.Context, in *Feedback, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Chat_SubmitFeedback_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatClient) AppendMessages(ctx context.Context, in *AppendRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Chat_AppendMessages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatClient) Regenerate(ctx context.Context, in *RegenerateRequest, opts ...grpc.CallOption) (*Message, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Message)
+	err := c.cc.Invoke(ctx, Chat_Regenerate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *chatClient) GetThread(ctx context.Context, in *ThreadID, opts ...grpc.CallOption) (*Thread, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Thread)
@@ -108,16 +160,42 @@ func (c *chatClient) Completion(ctx context.Context, in *CompletionRequest, opts
 	return out, nil
 }
 
+func (c *chatClient) ExportThread(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (*ThreadExport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ThreadExport)
+	err := c.cc.Invoke(ctx, Chat_ExportThread_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatClient) GetTranscript(ctx context.Context, in *TranscriptRequest, opts ...grpc.CallOption) (*Transcript, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Transcript)
+	err := c.cc.Invoke(ctx, Chat_GetTranscript_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ChatServer is the server API for Chat service.
 // All implementations must embed UnimplementedChatServer
 // for forward compatibility
 type ChatServer interface {
 	PostMessage(context.Context, *Prompt) (*Message, error)
+	CreateThread(context.Context, *NewThread) (*ThreadID, error)
+	SubmitFeedback(context.Context, *Feedback) (*emptypb.Empty, error)
+	AppendMessages(context.Context, *AppendRequest) (*emptypb.Empty, error)
+	Regenerate(context.Context, *RegenerateRequest) (*Message, error)
 	GetThread(context.Context, *ThreadID) (*Thread, error)
 	ListThreadIDs(context.Context, *CollectionId) (*ThreadIDs, error)
 	DeleteThread(context.Context, *ThreadID) (*emptypb.Empty, error)
 	DeleteMessageFromThread(context.Context, *MessageIndex) (*emptypb.Empty, error)
 	Completion(context.Context, *CompletionRequest) (*CompletionResponse, error)
+	ExportThread(context.Context, *ExportRequest) (*ThreadExport, error)
+	GetTranscript(context.Context, *TranscriptRequest) (*Transcript, error)
 	mustEmbedUnimplementedChatServer()
 }
 
@@ -128,6 +206,18 @@ type UnimplementedChatServer struct {
 func (UnimplementedChatServer) PostMessage(context.Context, *Prompt) (*Message, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PostMessage not implemented")
 }
+func (UnimplementedChatServer) CreateThread(context.Context, *NewThread) (*ThreadID, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateThread not implemented")
+}
+func (UnimplementedChatServer) SubmitFeedback(context.Context, *Feedback) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitFeedback not implemented")
+}
+func (UnimplementedChatServer) AppendMessages(context.Context, *AppendRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AppendMessages not implemented")
+}
+func (UnimplementedChatServer) Regenerate(context.Context, *RegenerateRequest) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Regenerate not implemented")
+}
 func (UnimplementedChatServer) GetThread(context.Context, *ThreadID) (*Thread, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetThread not implemented")
 }
@@ -143,6 +233,12 @@ func (UnimplementedChatServer) DeleteMessageFromThread(context.Context, *Message
 func (UnimplementedChatServer) Completion(context.Context, *CompletionRequest) (*CompletionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Completion not implemented")
 }
+func (UnimplementedChatServer) ExportThread(context.Context, *ExportRequest) (*ThreadExport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportThread not implemented")
+}
+func (UnimplementedChatServer) GetTranscript(context.Context, *TranscriptRequest) (*Transcript, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTranscript not implemented")
+}
 func (UnimplementedChatServer) mustEmbedUnimplementedChatServer() {}
 
 // UnsafeChatServer may be embedded to opt out of forward compatibility for this service.
@@ -174,6 +270,78 @@ func _Chat_PostMessage_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Chat_CreateThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewThread)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServer).CreateThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Chat_CreateThread_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServer).CreateThread(ctx, req.(*NewThread))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chat_SubmitFeedback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Feedback)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServer).SubmitFeedback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Chat_SubmitFeedback_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServer).SubmitFeedback(ctx, req.(*Feedback))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chat_AppendMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServer).AppendMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Chat_AppendMessages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServer).AppendMessages(ctx, req.(*AppendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chat_Regenerate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegenerateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServer).Regenerate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Chat_Regenerate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServer).Regenerate(ctx, req.(*RegenerateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Chat_GetThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ThreadID)
 	if err := dec(in); err != nil {
@@ -264,6 +432,42 @@ func _Chat_Completion_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Chat_ExportThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServer).ExportThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Chat_ExportThread_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServer).ExportThread(ctx, req.(*ExportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chat_GetTranscript_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TranscriptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServer).GetTranscript(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Chat_GetTranscript_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServer).GetTranscript(ctx, req.(*TranscriptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Chat_ServiceDesc is the grpc.ServiceDesc for Chat service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -275,6 +479,22 @@ var Chat_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PostMessage",
 			Handler:    _Chat_PostMessage_Handler,
 		},
+		{
+			MethodName: "CreateThread",
+			Handler:    _Chat_CreateThread_Handler,
+		},
+		{
+			MethodName: "SubmitFeedback",
+			Handler:    _Chat_SubmitFeedback_Handler,
+		},
+		{
+			MethodName: "AppendMessages",
+			Handler:    _Chat_AppendMessages_Handler,
+		},
+		{
+			MethodName: "Regenerate",
+			Handler:    _Chat_Regenerate_Handler,
+		},
 		{
 			MethodName: "GetThread",
 			Handler:    _Chat_GetThread_Handler,
@@ -295,6 +515,14 @@ var Chat_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Completion",
 			Handler:    _Chat_Completion_Handler,
 		},
+		{
+			MethodName: "ExportThread",
+			Handler:    _Chat_ExportThread_Handler,
+		},
+		{
+			MethodName: "GetTranscript",
+			Handler:    _Chat_GetTranscript_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "chat_service.proto",
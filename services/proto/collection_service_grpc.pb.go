@@ -20,20 +20,37 @@ import (
 const _ = grpc.SupportPackageIsVersion8
 
 const (
-	Collections_List_FullMethodName   = "/chatbot.collections.v1.Collections/List"
-	Collections_Insert_FullMethodName = "/chatbot.collections.v1.Collections/Insert"
-	Collections_Update_FullMethodName = "/chatbot.collections.v1.Collections/Update"
-	Collections_Delete_FullMethodName = "/chatbot.collections.v1.Collections/Delete"
+	Collections_List_FullMethodName               = "/chatbot.collections.v1.Collections/List"
+	Collections_Insert_FullMethodName             = "/chatbot.collections.v1.Collections/Insert"
+	Collections_Update_FullMethodName             = "/chatbot.collections.v1.Collections/Update"
+	Collections_Delete_FullMethodName             = "/chatbot.collections.v1.Collections/Delete"
+	Collections_Archive_FullMethodName            = "/chatbot.collections.v1.Collections/Archive"
+	Collections_Unarchive_FullMethodName          = "/chatbot.collections.v1.Collections/Unarchive"
+	Collections_Grant_FullMethodName              = "/chatbot.collections.v1.Collections/Grant"
+	Collections_Revoke_FullMethodName             = "/chatbot.collections.v1.Collections/Revoke"
+	Collections_ListGrants_FullMethodName         = "/chatbot.collections.v1.Collections/ListGrants"
+	Collections_GetCollectionStats_FullMethodName = "/chatbot.collections.v1.Collections/GetCollectionStats"
+	Collections_SetDefault_FullMethodName         = "/chatbot.collections.v1.Collections/SetDefault"
 )
 
 // CollectionsClient is the client API for Collections service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type CollectionsClient interface {
-	List(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CollectionList, error)
+	List(ctx context.Context, in *CollectionFilter, opts ...grpc.CallOption) (*CollectionList, error)
 	Insert(ctx context.Context, in *Collection, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	Update(ctx context.Context, in *Collection, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	Delete(ctx context.Context, in *Collection, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Archive(ctx context.Context, in *Collection, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Unarchive(ctx context.Context, in *Collection, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Grant(ctx context.Context, in *AccessGrant, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Revoke(ctx context.Context, in *AccessGrant, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListGrants(ctx context.Context, in *Collection, opts ...grpc.CallOption) (*AccessGrants, error)
+	// Statistics of a collection owned by the user, only the id must be set
+	GetCollectionStats(ctx context.Context, in *Collection, opts ...grpc.CallOption) (*CollectionStats, error)
+	// Makes a collection owned by the user the default of prompts without a collection
+	// id, only the id must be set. An empty id unsets the default
+	SetDefault(ctx context.Context, in *Collection, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type collectionsClient struct {
@@ -44,7 +61,7 @@ func NewCollectionsClient(cc grpc.ClientConnInterface) CollectionsClient {
 	return &collectionsClient{cc}
 }
 
-func (c *collectionsClient) List(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CollectionList, error) {
+func (c *collectionsClient) List(ctx context.Context, in *CollectionFilter, opts ...grpc.CallOption) (*CollectionList, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CollectionList)
 	err := c.cc.Invoke(ctx, Collections_List_FullMethodName, in, out, cOpts...)
@@ -84,14 +101,94 @@ func (c *collectionsClient) Delete(ctx context.Context, in *Collection, opts ...
 	return out, nil
 }
 
+func (c *collectionsClient) Archive(ctx context.Context, in *Collection, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Collections_Archive_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionsClient) Unarchive(ctx context.Context, in *Collection, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Collections_Unarchive_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionsClient) Grant(ctx context.Context, in *AccessGrant, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Collections_Grant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionsClient) Revoke(ctx context.Context, in *AccessGrant, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Collections_Revoke_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionsClient) ListGrants(ctx context.Context, in *Collection, opts ...grpc.CallOption) (*AccessGrants, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccessGrants)
+	err := c.cc.Invoke(ctx, Collections_ListGrants_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionsClient) GetCollectionStats(ctx context.Context, in *Collection, opts ...grpc.CallOption) (*CollectionStats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CollectionStats)
+	err := c.cc.Invoke(ctx, Collections_GetCollectionStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionsClient) SetDefault(ctx context.Context, in *Collection, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Collections_SetDefault_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CollectionsServer is the server API for Collections service.
 // All implementations must embed UnimplementedCollectionsServer
 // for forward compatibility
 type CollectionsServer interface {
-	List(context.Context, *emptypb.Empty) (*CollectionList, error)
+	List(context.Context, *CollectionFilter) (*CollectionList, error)
 	Insert(context.Context, *Collection) (*emptypb.Empty, error)
 	Update(context.Context, *Collection) (*emptypb.Empty, error)
 	Delete(context.Context, *Collection) (*emptypb.Empty, error)
+	Archive(context.Context, *Collection) (*emptypb.Empty, error)
+	Unarchive(context.Context, *Collection) (*emptypb.Empty, error)
+	Grant(context.Context, *AccessGrant) (*emptypb.Empty, error)
+	Revoke(context.Context, *AccessGrant) (*emptypb.Empty, error)
+	ListGrants(context.Context, *Collection) (*AccessGrants, error)
+	// Statistics of a collection owned by the user, only the id must be set
+	GetCollectionStats(context.Context, *Collection) (*CollectionStats, error)
+	// Makes a collection owned by the user the default of prompts without a collection
+	// id, only the id must be set. An empty id unsets the default
+	SetDefault(context.Context, *Collection) (*emptypb.Empty, error)
 	mustEmbedUnimplementedCollectionsServer()
 }
 
@@ -99,7 +196,7 @@ type CollectionsServer interface {
 type UnimplementedCollectionsServer struct {
 }
 
-func (UnimplementedCollectionsServer) List(context.Context, *emptypb.Empty) (*CollectionList, error) {
+func (UnimplementedCollectionsServer) List(context.Context, *CollectionFilter) (*CollectionList, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
 }
 func (UnimplementedCollectionsServer) Insert(context.Context, *Collection) (*emptypb.Empty, error) {
@@ -111,6 +208,27 @@ func (UnimplementedCollectionsServer) Update(context.Context, *Collection) (*emp
 func (UnimplementedCollectionsServer) Delete(context.Context, *Collection) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
+func (UnimplementedCollectionsServer) Archive(context.Context, *Collection) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Archive not implemented")
+}
+func (UnimplementedCollectionsServer) Unarchive(context.Context, *Collection) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unarchive not implemented")
+}
+func (UnimplementedCollectionsServer) Grant(context.Context, *AccessGrant) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Grant not implemented")
+}
+func (UnimplementedCollectionsServer) Revoke(context.Context, *AccessGrant) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Revoke not implemented")
+}
+func (UnimplementedCollectionsServer) ListGrants(context.Context, *Collection) (*AccessGrants, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGrants not implemented")
+}
+func (UnimplementedCollectionsServer) GetCollectionStats(context.Context, *Collection) (*CollectionStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCollectionStats not implemented")
+}
+func (UnimplementedCollectionsServer) SetDefault(context.Context, *Collection) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetDefault not implemented")
+}
 func (UnimplementedCollectionsServer) mustEmbedUnimplementedCollectionsServer() {}
 
 // UnsafeCollectionsServer may be embedded to opt out of forward compatibility for this service.
@@ -125,7 +243,7 @@ func RegisterCollectionsServer(s grpc.ServiceRegistrar, srv CollectionsServer) {
 }
 
 func _Collections_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+	in := new(CollectionFilter)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -137,7 +255,7 @@ func _Collections_List_Handler(srv interface{}, ctx context.Context, dec func(in
 		FullMethod: Collections_List_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CollectionsServer).List(ctx, req.(*emptypb.Empty))
+		return srv.(CollectionsServer).List(ctx, req.(*CollectionFilter))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -196,6 +314,132 @@ func _Collections_Delete_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Collections_Archive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Collection)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionsServer).Archive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Collections_Archive_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionsServer).Archive(ctx, req.(*Collection))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Collections_Unarchive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Collection)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionsServer).Unarchive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Collections_Unarchive_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionsServer).Unarchive(ctx, req.(*Collection))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Collections_Grant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccessGrant)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionsServer).Grant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Collections_Grant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionsServer).Grant(ctx, req.(*AccessGrant))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Collections_Revoke_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccessGrant)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionsServer).Revoke(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Collections_Revoke_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionsServer).Revoke(ctx, req.(*AccessGrant))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Collections_ListGrants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Collection)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionsServer).ListGrants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Collections_ListGrants_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionsServer).ListGrants(ctx, req.(*Collection))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Collections_GetCollectionStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Collection)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionsServer).GetCollectionStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Collections_GetCollectionStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionsServer).GetCollectionStats(ctx, req.(*Collection))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Collections_SetDefault_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Collection)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionsServer).SetDefault(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Collections_SetDefault_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionsServer).SetDefault(ctx, req.(*Collection))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Collections_ServiceDesc is the grpc.ServiceDesc for Collections service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -219,6 +463,34 @@ var Collections_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Delete",
 			Handler:    _Collections_Delete_Handler,
 		},
+		{
+			MethodName: "Archive",
+			Handler:    _Collections_Archive_Handler,
+		},
+		{
+			MethodName: "Unarchive",
+			Handler:    _Collections_Unarchive_Handler,
+		},
+		{
+			MethodName: "Grant",
+			Handler:    _Collections_Grant_Handler,
+		},
+		{
+			MethodName: "Revoke",
+			Handler:    _Collections_Revoke_Handler,
+		},
+		{
+			MethodName: "ListGrants",
+			Handler:    _Collections_ListGrants_Handler,
+		},
+		{
+			MethodName: "GetCollectionStats",
+			Handler:    _Collections_GetCollectionStats_Handler,
+		},
+		{
+			MethodName: "SetDefault",
+			Handler:    _Collections_SetDefault_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "collection_service.proto",
@@ -0,0 +1,317 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             v5.27.3
+// source: diagnostics_service.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	Diagnostics_PingProviders_FullMethodName      = "/chatbot.diagnostics.v1.Diagnostics/PingProviders"
+	Diagnostics_PurgeOrphans_FullMethodName       = "/chatbot.diagnostics.v1.Diagnostics/PurgeOrphans"
+	Diagnostics_GetVectorIndex_FullMethodName     = "/chatbot.diagnostics.v1.Diagnostics/GetVectorIndex"
+	Diagnostics_RebuildVectorIndex_FullMethodName = "/chatbot.diagnostics.v1.Diagnostics/RebuildVectorIndex"
+	Diagnostics_GetReindexStatus_FullMethodName   = "/chatbot.diagnostics.v1.Diagnostics/GetReindexStatus"
+	Diagnostics_WarmupCollection_FullMethodName   = "/chatbot.diagnostics.v1.Diagnostics/WarmupCollection"
+)
+
+// DiagnosticsClient is the client API for Diagnostics service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type DiagnosticsClient interface {
+	// Sends a minimal request to every configured provider, only available to admins
+	PingProviders(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ProviderReport, error)
+	// Finds vectors and stored texts of documents that don't exist anymore, only available to admins
+	PurgeOrphans(ctx context.Context, in *PurgeRequest, opts ...grpc.CallOption) (*PurgeReport, error)
+	// Returns the state of the vector index, only available to admins
+	GetVectorIndex(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*VectorIndexStatus, error)
+	// Changes the parameters of the vector index, e.g. after bulk indexing, only available to admins
+	RebuildVectorIndex(ctx context.Context, in *VectorIndexParams, opts ...grpc.CallOption) (*VectorIndexStatus, error)
+	// Returns the progress of the latest reembedding of all documents by the
+	// migration, only available to admins
+	GetReindexStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ReindexStatus, error)
+	// Runs representative searches on a collection to fill the search cache and load
+	// the vector index, e.g. after bulk indexing, only available to admins
+	WarmupCollection(ctx context.Context, in *WarmupRequest, opts ...grpc.CallOption) (*WarmupReport, error)
+}
+
+type diagnosticsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDiagnosticsClient(cc grpc.ClientConnInterface) DiagnosticsClient {
+	return &diagnosticsClient{cc}
+}
+
+func (c *diagnosticsClient) PingProviders(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ProviderReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProviderReport)
+	err := c.cc.Invoke(ctx, Diagnostics_PingProviders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *diagnosticsClient) PurgeOrphans(ctx context.Context, in *PurgeRequest, opts ...grpc.CallOption) (*PurgeReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PurgeReport)
+	err := c.cc.Invoke(ctx, Diagnostics_PurgeOrphans_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *diagnosticsClient) GetVectorIndex(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*VectorIndexStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VectorIndexStatus)
+	err := c.cc.Invoke(ctx, Diagnostics_GetVectorIndex_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *diagnosticsClient) RebuildVectorIndex(ctx context.Context, in *VectorIndexParams, opts ...grpc.CallOption) (*VectorIndexStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VectorIndexStatus)
+	err := c.cc.Invoke(ctx, Diagnostics_RebuildVectorIndex_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *diagnosticsClient) GetReindexStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ReindexStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReindexStatus)
+	err := c.cc.Invoke(ctx, Diagnostics_GetReindexStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *diagnosticsClient) WarmupCollection(ctx context.Context, in *WarmupRequest, opts ...grpc.CallOption) (*WarmupReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WarmupReport)
+	err := c.cc.Invoke(ctx, Diagnostics_WarmupCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DiagnosticsServer is the server API for Diagnostics service.
+// All implementations must embed UnimplementedDiagnosticsServer
+// for forward compatibility
+type DiagnosticsServer interface {
+	// Sends a minimal request to every configured provider, only available to admins
+	PingProviders(context.Context, *emptypb.Empty) (*ProviderReport, error)
+	// Finds vectors and stored texts of documents that don't exist anymore, only available to admins
+	PurgeOrphans(context.Context, *PurgeRequest) (*PurgeReport, error)
+	// Returns the state of the vector index, only available to admins
+	GetVectorIndex(context.Context, *emptypb.Empty) (*VectorIndexStatus, error)
+	// Changes the parameters of the vector index, e.g. after bulk indexing, only available to admins
+	RebuildVectorIndex(context.Context, *VectorIndexParams) (*VectorIndexStatus, error)
+	// Returns the progress of the latest reembedding of all documents by the
+	// migration, only available to admins
+	GetReindexStatus(context.Context, *emptypb.Empty) (*ReindexStatus, error)
+	// Runs representative searches on a collection to fill the search cache and load
+	// the vector index, e.g. after bulk indexing, only available to admins
+	WarmupCollection(context.Context, *WarmupRequest) (*WarmupReport, error)
+	mustEmbedUnimplementedDiagnosticsServer()
+}
+
+// UnimplementedDiagnosticsServer must be embedded to have forward compatible implementations.
+type UnimplementedDiagnosticsServer struct {
+}
+
+func (UnimplementedDiagnosticsServer) PingProviders(context.Context, *emptypb.Empty) (*ProviderReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PingProviders not implemented")
+}
+func (UnimplementedDiagnosticsServer) PurgeOrphans(context.Context, *PurgeRequest) (*PurgeReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeOrphans not implemented")
+}
+func (UnimplementedDiagnosticsServer) GetVectorIndex(context.Context, *emptypb.Empty) (*VectorIndexStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVectorIndex not implemented")
+}
+func (UnimplementedDiagnosticsServer) RebuildVectorIndex(context.Context, *VectorIndexParams) (*VectorIndexStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RebuildVectorIndex not implemented")
+}
+func (UnimplementedDiagnosticsServer) GetReindexStatus(context.Context, *emptypb.Empty) (*ReindexStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReindexStatus not implemented")
+}
+func (UnimplementedDiagnosticsServer) WarmupCollection(context.Context, *WarmupRequest) (*WarmupReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WarmupCollection not implemented")
+}
+func (UnimplementedDiagnosticsServer) mustEmbedUnimplementedDiagnosticsServer() {}
+
+// UnsafeDiagnosticsServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DiagnosticsServer will
+// result in compilation errors.
+type UnsafeDiagnosticsServer interface {
+	mustEmbedUnimplementedDiagnosticsServer()
+}
+
+func RegisterDiagnosticsServer(s grpc.ServiceRegistrar, srv DiagnosticsServer) {
+	s.RegisterService(&Diagnostics_ServiceDesc, srv)
+}
+
+func _Diagnostics_PingProviders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiagnosticsServer).PingProviders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Diagnostics_PingProviders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiagnosticsServer).PingProviders(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Diagnostics_PurgeOrphans_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiagnosticsServer).PurgeOrphans(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Diagnostics_PurgeOrphans_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiagnosticsServer).PurgeOrphans(ctx, req.(*PurgeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Diagnostics_GetVectorIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiagnosticsServer).GetVectorIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Diagnostics_GetVectorIndex_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiagnosticsServer).GetVectorIndex(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Diagnostics_RebuildVectorIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VectorIndexParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiagnosticsServer).RebuildVectorIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Diagnostics_RebuildVectorIndex_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiagnosticsServer).RebuildVectorIndex(ctx, req.(*VectorIndexParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Diagnostics_GetReindexStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiagnosticsServer).GetReindexStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Diagnostics_GetReindexStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiagnosticsServer).GetReindexStatus(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Diagnostics_WarmupCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WarmupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiagnosticsServer).WarmupCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Diagnostics_WarmupCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiagnosticsServer).WarmupCollection(ctx, req.(*WarmupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Diagnostics_ServiceDesc is the grpc.ServiceDesc for Diagnostics service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Diagnostics_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "chatbot.diagnostics.v1.Diagnostics",
+	HandlerType: (*DiagnosticsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PingProviders",
+			Handler:    _Diagnostics_PingProviders_Handler,
+		},
+		{
+			MethodName: "PurgeOrphans",
+			Handler:    _Diagnostics_PurgeOrphans_Handler,
+		},
+		{
+			MethodName: "GetVectorIndex",
+			Handler:    _Diagnostics_GetVectorIndex_Handler,
+		},
+		{
+			MethodName: "RebuildVectorIndex",
+			Handler:    _Diagnostics_RebuildVectorIndex_Handler,
+		},
+		{
+			MethodName: "GetReindexStatus",
+			Handler:    _Diagnostics_GetReindexStatus_Handler,
+		},
+		{
+			MethodName: "WarmupCollection",
+			Handler:    _Diagnostics_WarmupCollection_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "diagnostics_service.proto",
+}
@@ -20,11 +20,22 @@ import (
 const _ = grpc.SupportPackageIsVersion8
 
 const (
-	Document_List_FullMethodName   = "/chatbot.documents.v1.Document/List"
-	Document_Rename_FullMethodName = "/chatbot.documents.v1.Document/Rename"
-	Document_Delete_FullMethodName = "/chatbot.documents.v1.Document/Delete"
-	Document_Index_FullMethodName  = "/chatbot.documents.v1.Document/Index"
-	Document_Search_FullMethodName = "/chatbot.documents.v1.Document/Search"
+	Document_List_FullMethodName         = "/chatbot.documents.v1.Document/List"
+	Document_Get_FullMethodName          = "/chatbot.documents.v1.Document/Get"
+	Document_Rename_FullMethodName       = "/chatbot.documents.v1.Document/Rename"
+	Document_Delete_FullMethodName       = "/chatbot.documents.v1.Document/Delete"
+	Document_DeleteMany_FullMethodName   = "/chatbot.documents.v1.Document/DeleteMany"
+	Document_Index_FullMethodName        = "/chatbot.documents.v1.Document/Index"
+	Document_CancelIndex_FullMethodName  = "/chatbot.documents.v1.Document/CancelIndex"
+	Document_Search_FullMethodName       = "/chatbot.documents.v1.Document/Search"
+	Document_SearchStream_FullMethodName = "/chatbot.documents.v1.Document/SearchStream"
+	Document_Download_FullMethodName     = "/chatbot.documents.v1.Document/Download"
+	Document_GetChunks_FullMethodName    = "/chatbot.documents.v1.Document/GetChunks"
+	Document_Upload_FullMethodName       = "/chatbot.documents.v1.Document/Upload"
+	Document_ListChunks_FullMethodName   = "/chatbot.documents.v1.Document/ListChunks"
+	Document_DownloadText_FullMethodName = "/chatbot.documents.v1.Document/DownloadText"
+	Document_GetPageImage_FullMethodName = "/chatbot.documents.v1.Document/GetPageImage"
+	Document_GetAccessLog_FullMethodName = "/chatbot.documents.v1.Document/GetAccessLog"
 )
 
 // DocumentClient is the client API for Document service.
@@ -32,10 +43,26 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type DocumentClient interface {
 	List(ctx context.Context, in *DocumentFilter, opts ...grpc.CallOption) (*DocumentList, error)
+	Get(ctx context.Context, in *DocumentID, opts ...grpc.CallOption) (*DocumentHeader, error)
 	Rename(ctx context.Context, in *RenameDocument, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	Delete(ctx context.Context, in *DocumentID, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	DeleteMany(ctx context.Context, in *DocumentIDs, opts ...grpc.CallOption) (*DeleteResults, error)
 	Index(ctx context.Context, in *IndexJob, opts ...grpc.CallOption) (Document_IndexClient, error)
+	// Stops a running Index or Upload of a document, the stream ends with CANCELLED
+	CancelIndex(ctx context.Context, in *DocumentID, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	Search(ctx context.Context, in *SearchQuery, opts ...grpc.CallOption) (*SearchResults, error)
+	// Search for large limits, the results are sent in batches ordered by score.
+	// Each batch contains the names of its documents
+	SearchStream(ctx context.Context, in *SearchQuery, opts ...grpc.CallOption) (Document_SearchStreamClient, error)
+	Download(ctx context.Context, in *DocumentID, opts ...grpc.CallOption) (Document_DownloadClient, error)
+	GetChunks(ctx context.Context, in *ChunkIDs, opts ...grpc.CallOption) (*Chunks, error)
+	Upload(ctx context.Context, opts ...grpc.CallOption) (Document_UploadClient, error)
+	ListChunks(ctx context.Context, in *ChunkListRequest, opts ...grpc.CallOption) (*ChunkList, error)
+	DownloadText(ctx context.Context, in *DocumentID, opts ...grpc.CallOption) (Document_DownloadTextClient, error)
+	GetPageImage(ctx context.Context, in *PageImageRequest, opts ...grpc.CallOption) (*PageImage, error)
+	// Returns which documents of a collection were read, newest first. Only the
+	// owner of the collection can read its access log
+	GetAccessLog(ctx context.Context, in *AccessLogRequest, opts ...grpc.CallOption) (*AccessLog, error)
 }
 
 type documentClient struct {
@@ -56,6 +83,16 @@ func (c *documentClient) List(ctx context.Context, in *DocumentFilter, opts ...g
 	return out, nil
 }
 
+func (c *documentClient) Get(ctx context.Context, in *DocumentID, opts ...grpc.CallOption) (*DocumentHeader, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DocumentHeader)
+	err := c.cc.Invoke(ctx, Document_Get_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *documentClient) Rename(ctx context.Context, in *RenameDocument, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -76,6 +113,16 @@ func (c *documentClient) Delete(ctx context.Context, in *DocumentID, opts ...grp
 	return out, nil
 }
 
+func (c *documentClient) DeleteMany(ctx context.Context, in *DocumentIDs, opts ...grpc.CallOption) (*DeleteResults, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteResults)
+	err := c.cc.Invoke(ctx, Document_DeleteMany_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *documentClient) Index(ctx context.Context, in *IndexJob, opts ...grpc.CallOption) (Document_IndexClient, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	stream, err := c.cc.NewStream(ctx, &Document_ServiceDesc.Streams[0], Document_Index_FullMethodName, cOpts...)
@@ -109,6 +156,16 @@ func (x *documentIndexClient) Recv() (*IndexProgress, error) {
 	return m, nil
 }
 
+func (c *documentClient) CancelIndex(ctx context.Context, in *DocumentID, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Document_CancelIndex_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *documentClient) Search(ctx context.Context, in *SearchQuery, opts ...grpc.CallOption) (*SearchResults, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(SearchResults)
@@ -119,15 +176,202 @@ func (c *documentClient) Search(ctx context.Context, in *SearchQuery, opts ...gr
 	return out, nil
 }
 
+func (c *documentClient) SearchStream(ctx context.Context, in *SearchQuery, opts ...grpc.CallOption) (Document_SearchStreamClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Document_ServiceDesc.Streams[1], Document_SearchStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &documentSearchStreamClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Document_SearchStreamClient interface {
+	Recv() (*SearchResults, error)
+	grpc.ClientStream
+}
+
+type documentSearchStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *documentSearchStreamClient) Recv() (*SearchResults, error) {
+	m := new(SearchResults)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *documentClient) Download(ctx context.Context, in *DocumentID, opts ...grpc.CallOption) (Document_DownloadClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Document_ServiceDesc.Streams[2], Document_Download_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &documentDownloadClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Document_DownloadClient interface {
+	Recv() (*FileChunk, error)
+	grpc.ClientStream
+}
+
+type documentDownloadClient struct {
+	grpc.ClientStream
+}
+
+func (x *documentDownloadClient) Recv() (*FileChunk, error) {
+	m := new(FileChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *documentClient) GetChunks(ctx context.Context, in *ChunkIDs, opts ...grpc.CallOption) (*Chunks, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Chunks)
+	err := c.cc.Invoke(ctx, Document_GetChunks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *documentClient) Upload(ctx context.Context, opts ...grpc.CallOption) (Document_UploadClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Document_ServiceDesc.Streams[3], Document_Upload_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &documentUploadClient{ClientStream: stream}
+	return x, nil
+}
+
+type Document_UploadClient interface {
+	Send(*UploadRequest) error
+	Recv() (*IndexProgress, error)
+	grpc.ClientStream
+}
+
+type documentUploadClient struct {
+	grpc.ClientStream
+}
+
+func (x *documentUploadClient) Send(m *UploadRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *documentUploadClient) Recv() (*IndexProgress, error) {
+	m := new(IndexProgress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *documentClient) ListChunks(ctx context.Context, in *ChunkListRequest, opts ...grpc.CallOption) (*ChunkList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChunkList)
+	err := c.cc.Invoke(ctx, Document_ListChunks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *documentClient) DownloadText(ctx context.Context, in *DocumentID, opts ...grpc.CallOption) (Document_DownloadTextClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Document_ServiceDesc.Streams[4], Document_DownloadText_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &documentDownloadTextClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Document_DownloadTextClient interface {
+	Recv() (*FileChunk, error)
+	grpc.ClientStream
+}
+
+type documentDownloadTextClient struct {
+	grpc.ClientStream
+}
+
+func (x *documentDownloadTextClient) Recv() (*FileChunk, error) {
+	m := new(FileChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *documentClient) GetPageImage(ctx context.Context, in *PageImageRequest, opts ...grpc.CallOption) (*PageImage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PageImage)
+	err := c.cc.Invoke(ctx, Document_GetPageImage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *documentClient) GetAccessLog(ctx context.Context, in *AccessLogRequest, opts ...grpc.CallOption) (*AccessLog, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccessLog)
+	err := c.cc.Invoke(ctx, Document_GetAccessLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DocumentServer is the server API for Document service.
 // All implementations must embed UnimplementedDocumentServer
 // for forward compatibility
 type DocumentServer interface {
 	List(context.Context, *DocumentFilter) (*DocumentList, error)
+	Get(context.Context, *DocumentID) (*DocumentHeader, error)
 	Rename(context.Context, *RenameDocument) (*emptypb.Empty, error)
 	Delete(context.Context, *DocumentID) (*emptypb.Empty, error)
+	DeleteMany(context.Context, *DocumentIDs) (*DeleteResults, error)
 	Index(*IndexJob, Document_IndexServer) error
+	// Stops a running Index or Upload of a document, the stream ends with CANCELLED
+	CancelIndex(context.Context, *DocumentID) (*emptypb.Empty, error)
 	Search(context.Context, *SearchQuery) (*SearchResults, error)
+	// Search for large limits, the results are sent in batches ordered by score.
+	// Each batch contains the names of its documents
+	SearchStream(*SearchQuery, Document_SearchStreamServer) error
+	Download(*DocumentID, Document_DownloadServer) error
+	GetChunks(context.Context, *ChunkIDs) (*Chunks, error)
+	Upload(Document_UploadServer) error
+	ListChunks(context.Context, *ChunkListRequest) (*ChunkList, error)
+	DownloadText(*DocumentID, Document_DownloadTextServer) error
+	GetPageImage(context.Context, *PageImageRequest) (*PageImage, error)
+	// Returns which documents of a collection were read, newest first. Only the
+	// owner of the collection can read its access log
+	GetAccessLog(context.Context, *AccessLogRequest) (*AccessLog, error)
 	mustEmbedUnimplementedDocumentServer()
 }
 
@@ -138,18 +382,51 @@ type UnimplementedDocumentServer struct {
 func (UnimplementedDocumentServer) List(context.Context, *DocumentFilter) (*DocumentList, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
 }
+func (UnimplementedDocumentServer) Get(context.Context, *DocumentID) (*DocumentHeader, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
 func (UnimplementedDocumentServer) Rename(context.Context, *RenameDocument) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Rename not implemented")
 }
 func (UnimplementedDocumentServer) Delete(context.Context, *DocumentID) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
+func (UnimplementedDocumentServer) DeleteMany(context.Context, *DocumentIDs) (*DeleteResults, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteMany not implemented")
+}
 func (UnimplementedDocumentServer) Index(*IndexJob, Document_IndexServer) error {
 	return status.Errorf(codes.Unimplemented, "method Index not implemented")
 }
+func (UnimplementedDocumentServer) CancelIndex(context.Context, *DocumentID) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelIndex not implemented")
+}
 func (UnimplementedDocumentServer) Search(context.Context, *SearchQuery) (*SearchResults, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
 }
+func (UnimplementedDocumentServer) SearchStream(*SearchQuery, Document_SearchStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method SearchStream not implemented")
+}
+func (UnimplementedDocumentServer) Download(*DocumentID, Document_DownloadServer) error {
+	return status.Errorf(codes.Unimplemented, "method Download not implemented")
+}
+func (UnimplementedDocumentServer) GetChunks(context.Context, *ChunkIDs) (*Chunks, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChunks not implemented")
+}
+func (UnimplementedDocumentServer) Upload(Document_UploadServer) error {
+	return status.Errorf(codes.Unimplemented, "method Upload not implemented")
+}
+func (UnimplementedDocumentServer) ListChunks(context.Context, *ChunkListRequest) (*ChunkList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListChunks not implemented")
+}
+func (UnimplementedDocumentServer) DownloadText(*DocumentID, Document_DownloadTextServer) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadText not implemented")
+}
+func (UnimplementedDocumentServer) GetPageImage(context.Context, *PageImageRequest) (*PageImage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPageImage not implemented")
+}
+func (UnimplementedDocumentServer) GetAccessLog(context.Context, *AccessLogRequest) (*AccessLog, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccessLog not implemented")
+}
 func (UnimplementedDocumentServer) mustEmbedUnimplementedDocumentServer() {}
 
 // UnsafeDocumentServer may be embedded to opt out of forward compatibility for this service.
@@ -181,6 +458,24 @@ func _Document_List_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Document_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DocumentID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Document_Get_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentServer).Get(ctx, req.(*DocumentID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Document_Rename_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RenameDocument)
 	if err := dec(in); err != nil {
@@ -217,6 +512,24 @@ func _Document_Delete_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Document_DeleteMany_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DocumentIDs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentServer).DeleteMany(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Document_DeleteMany_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentServer).DeleteMany(ctx, req.(*DocumentIDs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Document_Index_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(IndexJob)
 	if err := stream.RecvMsg(m); err != nil {
@@ -238,6 +551,24 @@ func (x *documentIndexServer) Send(m *IndexProgress) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Document_CancelIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DocumentID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentServer).CancelIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Document_CancelIndex_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentServer).CancelIndex(ctx, req.(*DocumentID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Document_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SearchQuery)
 	if err := dec(in); err != nil {
@@ -256,6 +587,167 @@ func _Document_Search_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Document_SearchStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SearchQuery)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DocumentServer).SearchStream(m, &documentSearchStreamServer{ServerStream: stream})
+}
+
+type Document_SearchStreamServer interface {
+	Send(*SearchResults) error
+	grpc.ServerStream
+}
+
+type documentSearchStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *documentSearchStreamServer) Send(m *SearchResults) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Document_Download_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DocumentID)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DocumentServer).Download(m, &documentDownloadServer{ServerStream: stream})
+}
+
+type Document_DownloadServer interface {
+	Send(*FileChunk) error
+	grpc.ServerStream
+}
+
+type documentDownloadServer struct {
+	grpc.ServerStream
+}
+
+func (x *documentDownloadServer) Send(m *FileChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Document_GetChunks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChunkIDs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentServer).GetChunks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Document_GetChunks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentServer).GetChunks(ctx, req.(*ChunkIDs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Document_Upload_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DocumentServer).Upload(&documentUploadServer{ServerStream: stream})
+}
+
+type Document_UploadServer interface {
+	Send(*IndexProgress) error
+	Recv() (*UploadRequest, error)
+	grpc.ServerStream
+}
+
+type documentUploadServer struct {
+	grpc.ServerStream
+}
+
+func (x *documentUploadServer) Send(m *IndexProgress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *documentUploadServer) Recv() (*UploadRequest, error) {
+	m := new(UploadRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Document_ListChunks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChunkListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentServer).ListChunks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Document_ListChunks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentServer).ListChunks(ctx, req.(*ChunkListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Document_DownloadText_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DocumentID)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DocumentServer).DownloadText(m, &documentDownloadTextServer{ServerStream: stream})
+}
+
+type Document_DownloadTextServer interface {
+	Send(*FileChunk) error
+	grpc.ServerStream
+}
+
+type documentDownloadTextServer struct {
+	grpc.ServerStream
+}
+
+func (x *documentDownloadTextServer) Send(m *FileChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Document_GetPageImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PageImageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentServer).GetPageImage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Document_GetPageImage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentServer).GetPageImage(ctx, req.(*PageImageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Document_GetAccessLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccessLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentServer).GetAccessLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Document_GetAccessLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentServer).GetAccessLog(ctx, req.(*AccessLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Document_ServiceDesc is the grpc.ServiceDesc for Document service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -267,6 +759,10 @@ var Document_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "List",
 			Handler:    _Document_List_Handler,
 		},
+		{
+			MethodName: "Get",
+			Handler:    _Document_Get_Handler,
+		},
 		{
 			MethodName: "Rename",
 			Handler:    _Document_Rename_Handler,
@@ -275,10 +771,34 @@ var Document_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Delete",
 			Handler:    _Document_Delete_Handler,
 		},
+		{
+			MethodName: "DeleteMany",
+			Handler:    _Document_DeleteMany_Handler,
+		},
+		{
+			MethodName: "CancelIndex",
+			Handler:    _Document_CancelIndex_Handler,
+		},
 		{
 			MethodName: "Search",
 			Handler:    _Document_Search_Handler,
 		},
+		{
+			MethodName: "GetChunks",
+			Handler:    _Document_GetChunks_Handler,
+		},
+		{
+			MethodName: "ListChunks",
+			Handler:    _Document_ListChunks_Handler,
+		},
+		{
+			MethodName: "GetPageImage",
+			Handler:    _Document_GetPageImage_Handler,
+		},
+		{
+			MethodName: "GetAccessLog",
+			Handler:    _Document_GetAccessLog_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -286,6 +806,27 @@ var Document_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Document_Index_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "SearchStream",
+			Handler:       _Document_SearchStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Download",
+			Handler:       _Document_Download_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Upload",
+			Handler:       _Document_Upload_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DownloadText",
+			Handler:       _Document_DownloadText_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "document_service.proto",
 }
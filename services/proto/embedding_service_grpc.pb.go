@@ -0,0 +1,110 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             v5.27.3
+// source: embedding_service.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	Embedding_Embed_FullMethodName = "/chatbot.embeddings.v1.Embedding/Embed"
+)
+
+// EmbeddingClient is the client API for Embedding service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type EmbeddingClient interface {
+	Embed(ctx context.Context, in *EmbeddingRequest, opts ...grpc.CallOption) (*EmbeddingResponse, error)
+}
+
+type embeddingClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEmbeddingClient(cc grpc.ClientConnInterface) EmbeddingClient {
+	return &embeddingClient{cc}
+}
+
+func (c *embeddingClient) Embed(ctx context.Context, in *EmbeddingRequest, opts ...grpc.CallOption) (*EmbeddingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmbeddingResponse)
+	err := c.cc.Invoke(ctx, Embedding_Embed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EmbeddingServer is the server API for Embedding service.
+// All implementations must embed UnimplementedEmbeddingServer
+// for forward compatibility
+type EmbeddingServer interface {
+	Embed(context.Context, *EmbeddingRequest) (*EmbeddingResponse, error)
+	mustEmbedUnimplementedEmbeddingServer()
+}
+
+// UnimplementedEmbeddingServer must be embedded to have forward compatible implementations.
+type UnimplementedEmbeddingServer struct {
+}
+
+func (UnimplementedEmbeddingServer) Embed(context.Context, *EmbeddingRequest) (*EmbeddingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Embed not implemented")
+}
+func (UnimplementedEmbeddingServer) mustEmbedUnimplementedEmbeddingServer() {}
+
+// UnsafeEmbeddingServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EmbeddingServer will
+// result in compilation errors.
+type UnsafeEmbeddingServer interface {
+	mustEmbedUnimplementedEmbeddingServer()
+}
+
+func RegisterEmbeddingServer(s grpc.ServiceRegistrar, srv EmbeddingServer) {
+	s.RegisterService(&Embedding_ServiceDesc, srv)
+}
+
+func _Embedding_Embed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmbeddingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmbeddingServer).Embed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Embedding_Embed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmbeddingServer).Embed(ctx, req.(*EmbeddingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Embedding_ServiceDesc is the grpc.ServiceDesc for Embedding service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Embedding_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "chatbot.embeddings.v1.Embedding",
+	HandlerType: (*EmbeddingServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Embed",
+			Handler:    _Embedding_Embed_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "embedding_service.proto",
+}
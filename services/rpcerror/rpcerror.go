@@ -0,0 +1,104 @@
+// Package rpcerror creates gRPC errors with ErrorInfo details, so that clients can
+// distinguish errors by their reason instead of parsing the message.
+package rpcerror
+
+import (
+	"fmt"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// Domain of all error reasons.
+const Domain = "chatbot"
+
+// Reasons of the errors, stable identifiers for clients.
+const (
+	ReasonInvalidId           = "INVALID_ID"
+	ReasonMissingField        = "MISSING_FIELD"
+	ReasonInvalidValue        = "INVALID_VALUE"
+	ReasonNotFound            = "NOT_FOUND"
+	ReasonModelNotFound       = "MODEL_NOT_FOUND"
+	ReasonNoFunding           = "NO_FUNDING"
+	ReasonSafetyBlocked       = "SAFETY_BLOCKED"
+	ReasonArchived            = "COLLECTION_ARCHIVED"
+	ReasonModelMismatch       = "EMBEDDING_MODEL_MISMATCH"
+	ReasonLimitExceeded       = "LIMIT_EXCEEDED"
+	ReasonTooManyRequests     = "TOO_MANY_REQUESTS"
+	ReasonReadOnly            = "READ_ONLY_ACCESS"
+	ReasonModelNotAllowed     = "MODEL_NOT_ALLOWED"
+	ReasonThreadTooLong       = "THREAD_TOO_LONG"
+	ReasonContextTooLong      = "CONTEXT_TOO_LONG"
+	ReasonWebhooksDisabled    = "WEBHOOKS_DISABLED"
+	ReasonAccessDenied        = "ACCESS_DENIED"
+	ReasonIdempotencyMismatch = "IDEMPOTENCY_KEY_REUSED"
+	ReasonInvalidOutput       = "INVALID_MODEL_OUTPUT"
+	ReasonNoStoredData        = "NO_STORED_DATA"
+	ReasonIncompleteUpload    = "INCOMPLETE_UPLOAD"
+	ReasonAlreadyExists       = "ALREADY_EXISTS"
+)
+
+// New returns an error with an ErrorInfo detail. The field names the request field
+// that caused the error and is added as metadata and, for invalid arguments, as a
+// BadRequest field violation. Additional metadata is given as key value pairs.
+func New(code codes.Code, reason, field, message string, metadata ...string) error {
+	info := &errdetails.ErrorInfo{
+		Reason:   reason,
+		Domain:   Domain,
+		Metadata: make(map[string]string),
+	}
+
+	if field != "" {
+		info.Metadata["field"] = field
+	}
+
+	for idx := 0; idx+1 < len(metadata); idx += 2 {
+		info.Metadata[metadata[idx]] = metadata[idx+1]
+	}
+
+	st := status.New(code, message)
+	details := []protoadapt.MessageV1{info}
+	if code == codes.InvalidArgument && field != "" {
+		details = append(details, &errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{{
+				Field:       field,
+				Description: message,
+			}},
+		})
+	}
+
+	detailed, err := st.WithDetails(details...)
+	if err != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
+
+// InvalidId returns an InvalidArgument error for a malformed id.
+func InvalidId(field, value string) error {
+	return New(codes.InvalidArgument, ReasonInvalidId, field, fmt.Sprintf("invalid %s: %s", field, value))
+}
+
+// Missing returns an InvalidArgument error for a required field that is not set.
+func Missing(field string) error {
+	return New(codes.InvalidArgument, ReasonMissingField, field, fmt.Sprintf("%s missing", field))
+}
+
+// Invalid returns an InvalidArgument error for a field with an invalid value.
+func Invalid(field string, err error) error {
+	return New(codes.InvalidArgument, ReasonInvalidValue, field, fmt.Sprintf("invalid %s: %v", field, err))
+}
+
+// NotFound returns a NotFound error for a resource that doesn't exist or isn't owned by the user.
+func NotFound(resource, id string) error {
+	return New(codes.NotFound, ReasonNotFound, "", fmt.Sprintf("%s not found: %s", resource, id), "resource", resource)
+}
+
+// AccessDenied returns a PermissionDenied error for a collection that is neither
+// owned by nor shared with the user, e.g. after the grant was revoked.
+func AccessDenied(collectionId string) error {
+	return New(codes.PermissionDenied, ReasonAccessDenied, "collection_id",
+		fmt.Sprintf("no access to collection: %s", collectionId))
+}
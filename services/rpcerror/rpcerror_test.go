@@ -0,0 +1,50 @@
+package rpcerror
+
+import (
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+func TestInvalidId(t *testing.T) {
+	st := status.Convert(InvalidId("collection_id", "abc"))
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", st.Code())
+	}
+
+	var info *errdetails.ErrorInfo
+	var violations *errdetails.BadRequest
+	for _, detail := range st.Details() {
+		switch detail := detail.(type) {
+		case *errdetails.ErrorInfo:
+			info = detail
+		case *errdetails.BadRequest:
+			violations = detail
+		}
+	}
+
+	if info == nil || info.Reason != ReasonInvalidId || info.Domain != Domain || info.Metadata["field"] != "collection_id" {
+		t.Fatalf("unexpected error info: %v", info)
+	}
+
+	if violations == nil || len(violations.FieldViolations) != 1 || violations.FieldViolations[0].Field != "collection_id" {
+		t.Fatalf("unexpected field violations: %v", violations)
+	}
+}
+
+func TestNotFoundMetadata(t *testing.T) {
+	st := status.Convert(NotFound("thread", "123"))
+	if st.Code() != codes.NotFound {
+		t.Fatalf("expected NotFound, got %v", st.Code())
+	}
+
+	if len(st.Details()) != 1 {
+		t.Fatalf("expected only error info, got %v", st.Details())
+	}
+
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	if !ok || info.Reason != ReasonNotFound || info.Metadata["resource"] != "thread" {
+		t.Fatalf("unexpected error info: %v", st.Details()[0])
+	}
+}
@@ -28,7 +28,19 @@ func GetPDFPages(ctx context.Context, filename string) (result []string, err err
 
 // GetPagesFromPDFBytes extracts the text from a PDF file and returns its contents as a slice of strings.
 func GetPagesFromPDFBytes(ctx context.Context, data []byte) (result []string, err error) {
-	cmd := exec.CommandContext(ctx, "pdftotext", "-", "-")
+	return pdfBytesToText(ctx, data)
+}
+
+// GetLayoutPagesFromPDFBytes extracts the text like GetPagesFromPDFBytes, but keeps
+// the physical layout, so that the columns of tables and the indentation of code
+// are preserved.
+func GetLayoutPagesFromPDFBytes(ctx context.Context, data []byte) (result []string, err error) {
+	return pdfBytesToText(ctx, data, "-layout")
+}
+
+func pdfBytesToText(ctx context.Context, data []byte, flags ...string) ([]string, error) {
+	args := append(flags, "-", "-")
+	cmd := exec.CommandContext(ctx, "pdftotext", args...)
 	cmd.Stdin = bytes.NewReader(data)
 
 	pages, err := cmd.CombinedOutput()
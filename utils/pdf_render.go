@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// Image formats of rendered PDF pages
+const (
+	ImagePNG  = "png"
+	ImageJPEG = "jpeg"
+)
+
+// ErrPageNotFound is returned if the PDF has no page with the requested number.
+var ErrPageNotFound = errors.New("page not found")
+
+// RenderPDFPage renders a single page of a PDF file as an image. The first page is 1.
+func RenderPDFPage(ctx context.Context, data []byte, page, dpi int, format string) ([]byte, error) {
+	if format != ImagePNG && format != ImageJPEG {
+		return nil, fmt.Errorf("unsupported image format: %s", format)
+	}
+
+	cmd := exec.CommandContext(ctx, "pdftoppm",
+		"-f", strconv.Itoa(page),
+		"-l", strconv.Itoa(page),
+		"-r", strconv.Itoa(dpi),
+		"-"+format,
+		"-singlefile",
+		"-",
+	)
+	cmd.Stdin = bytes.NewReader(data)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	image, err := cmd.Output()
+	if strings.Contains(stderr.String(), "Wrong page range") {
+		return nil, ErrPageNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("pdftoppm: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	if len(image) == 0 {
+		return nil, ErrPageNotFound
+	}
+
+	return image, nil
+}